@@ -16,6 +16,7 @@ import (
 	"github.com/kobsio/kobs/pkg/plugins"
 	"github.com/kobsio/kobs/pkg/plugins/plugin"
 	"github.com/kobsio/kobs/pkg/utils/config"
+	"github.com/kobsio/kobs/pkg/utils/tunnel"
 
 	"go.uber.org/zap"
 )
@@ -30,6 +31,7 @@ type Cmd struct {
 		Metrics    metrics.Config    `json:"metrics" embed:"" prefix:"metrics." envprefix:"METRICS_"`
 		Kubernetes kubernetes.Config `json:"kubernetes" embed:"" prefix:"kubernetes." envprefix:"KUBERNETES_"`
 		API        api.Config        `json:"api" embed:"" prefix:"api." envprefix:"API_"`
+		Tunnel     tunnel.Config     `json:"tunnel" embed:"" prefix:"tunnel." envprefix:"TUNNEL_"`
 		Plugins    []plugin.Instance `json:"plugins" kong:"-"`
 	} `json:"cluster" embed:"" prefix:"cluster." envprefix:"KOBS_CLUSTER_"`
 }
@@ -65,25 +67,74 @@ func (r *Cmd) Run(plugins []plugins.Plugin) error {
 		defer debugServer.Stop()
 	}
 
-	kubernetesClient, err := kubernetes.NewClient(cfg.Cluster.Kubernetes)
+	contexts, err := cfg.Cluster.Kubernetes.Provider.GetContexts()
 	if err != nil {
-		log.Error(context.Background(), "Could not create Kubernetes client", zap.Error(err))
+		log.Error(context.Background(), "Invalid Kubernetes provider configuration", zap.Error(err))
 		return err
 	}
 
-	pluginsClient, err := clusterPlugins.NewClient(plugins, cfg.Cluster.Plugins, kubernetesClient)
-	if err != nil {
-		log.Error(context.Background(), "Could not create plugins client", zap.Error(err))
-		return err
+	// When the user configured multiple contexts for the Kubeconfig provider, we create a Kubernetes client and a plugins
+	// client for each context and serve them as separate clusters. Otherwise we serve a single cluster, which is
+	// represented by a context without a name.
+	names := contexts
+	if len(names) == 0 {
+		names = []string{""}
+	}
+
+	var apiContexts []api.Context
+
+	for _, name := range names {
+		kubernetesConfig := cfg.Cluster.Kubernetes
+		instances := cfg.Cluster.Plugins
+		if name != "" {
+			kubernetesConfig.Provider = kubernetesConfig.Provider.WithContext(name)
+			instances = clusterPlugins.FilterInstancesByContext(name, instances)
+		}
+
+		kubernetesClient, err := kubernetes.NewClient(kubernetesConfig)
+		if err != nil {
+			log.Error(context.Background(), "Could not create Kubernetes client", zap.Error(err), zap.String("context", name))
+			return err
+		}
+
+		pluginsClient, err := clusterPlugins.NewClient(plugins, instances, kubernetesClient)
+		if err != nil {
+			log.Error(context.Background(), "Could not create plugins client", zap.Error(err), zap.String("context", name))
+			return err
+		}
+
+		apiContexts = append(apiContexts, api.Context{Name: name, KubernetesClient: kubernetesClient, PluginsClient: pluginsClient})
 	}
 
-	apiServer, err := api.New(cfg.Cluster.API, kubernetesClient, pluginsClient)
+	var apiServer api.Server
+	if len(contexts) > 0 {
+		apiServer, err = api.NewWithContexts(cfg.Cluster.API, apiContexts)
+	} else {
+		apiServer, err = api.New(cfg.Cluster.API, apiContexts[0].KubernetesClient, apiContexts[0].PluginsClient)
+	}
 	if err != nil {
 		log.Error(context.Background(), "Could not create client server", zap.Error(err))
 		return err
 	}
 	go apiServer.Start()
 
+	// When the user configured one or more tunnel addresses, we open a tunnel to each address and serve the cluster
+	// API on the tunnels. This is required for clusters, which can not be reached by the hub or watcher. When multiple
+	// contexts are configured, each context opens its own tunnel, where the name of the context is used as the name of
+	// the cluster.
+	if len(cfg.Cluster.Tunnel.Addresses) > 0 {
+		for _, apiContext := range apiContexts {
+			tunnelConfig := cfg.Cluster.Tunnel
+			if apiContext.Name != "" {
+				tunnelConfig.Name = apiContext.Name
+			}
+
+			tunnelClient := tunnel.NewClient(tunnelConfig, cfg.Cluster.API.Token, api.NewRouter(cfg.Cluster.API, apiContext.KubernetesClient, apiContext.PluginsClient))
+			go tunnelClient.Start()
+			defer tunnelClient.Stop()
+		}
+	}
+
 	// All components should be terminated gracefully. For that we are listen for the SIGINT and SIGTERM signals and try
 	// to gracefully shutdown the started kobs components. This ensures that established connections or tasks are not
 	// interrupted.
@@ -95,6 +146,9 @@ func (r *Cmd) Run(plugins []plugins.Plugin) error {
 	log.Info(context.Background(), "Shutdown kobs client...")
 
 	apiServer.Stop()
+	for _, apiContext := range apiContexts {
+		apiContext.KubernetesClient.Stop()
+	}
 
 	log.Info(context.Background(), "Shutdown is done")
 
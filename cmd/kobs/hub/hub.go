@@ -6,12 +6,16 @@ import (
 	"os/signal"
 	"syscall"
 
+	clusterAPI "github.com/kobsio/kobs/pkg/cluster/api"
 	"github.com/kobsio/kobs/pkg/hub/api"
 	"github.com/kobsio/kobs/pkg/hub/app"
 	"github.com/kobsio/kobs/pkg/hub/auth"
 	"github.com/kobsio/kobs/pkg/hub/clusters"
 	"github.com/kobsio/kobs/pkg/hub/db"
 	hubPlugins "github.com/kobsio/kobs/pkg/hub/plugins"
+	"github.com/kobsio/kobs/pkg/hub/reload"
+	"github.com/kobsio/kobs/pkg/hub/reports"
+	"github.com/kobsio/kobs/pkg/hub/scorecards"
 	"github.com/kobsio/kobs/pkg/instrument/debug"
 	"github.com/kobsio/kobs/pkg/instrument/log"
 	"github.com/kobsio/kobs/pkg/instrument/metrics"
@@ -35,6 +39,8 @@ type Cmd struct {
 		API      api.Config        `json:"api" embed:"" prefix:"api." envprefix:"API_"`
 		Auth     auth.Config       `json:"auth" embed:"" prefix:"auth." envprefix:"AUTH_"`
 		App      app.Config        `json:"app" embed:"" prefix:"app." envprefix:"APP_"`
+		Reports  reports.Config    `json:"reports" embed:"" prefix:"reports." envprefix:"REPORTS_"`
+		Reload   reload.Config     `json:"reload" embed:"" prefix:"reload." envprefix:"RELOAD_"`
 		Clusters clusters.Config   `json:"clusters" kong:"-"`
 		Plugins  []plugin.Instance `json:"plugins" kong:"-"`
 	} `json:"hub" embed:"" prefix:"hub." envprefix:"KOBS_HUB_"`
@@ -71,12 +77,6 @@ func (r *Cmd) Run(plugins []plugins.Plugin) error {
 		defer debugServer.Stop()
 	}
 
-	clustersClient, err := clusters.NewClient(cfg.Hub.Clusters)
-	if err != nil {
-		log.Error(context.Background(), "Could not create clusters client", zap.Error(err))
-		return err
-	}
-
 	dbClient, err := db.NewClient(cfg.Hub.Database)
 	if err != nil {
 		log.Error(context.Background(), "Could not create database client", zap.Error(err))
@@ -89,6 +89,13 @@ func (r *Cmd) Run(plugins []plugins.Plugin) error {
 		return err
 	}
 
+	clustersClient, err := clusters.NewClient(cfg.Hub.Clusters, clusterAPI.NewAgentlessHandler(plugins), dbClient)
+	if err != nil {
+		log.Error(context.Background(), "Could not create clusters client", zap.Error(err))
+		return err
+	}
+	go clustersClient.Watch()
+
 	pluginsClient, err := hubPlugins.NewClient(plugins, cfg.Hub.Plugins, clustersClient, dbClient)
 	if err != nil {
 		log.Error(context.Background(), "Could not create plugins client", zap.Error(err))
@@ -115,6 +122,69 @@ func (r *Cmd) Run(plugins []plugins.Plugin) error {
 	}
 	go appServer.Start()
 
+	reportsClient, err := reports.NewClient(cfg.Hub.Reports, pluginsClient.Mount(), dbClient)
+	if err != nil {
+		log.Error(context.Background(), "Could not create reports client", zap.Error(err))
+		return err
+	}
+	reportsClient.Start()
+
+	scorecardsClient, err := scorecards.NewClient(cfg.Hub.App.Settings.Scorecards, pluginsClient.Mount(), dbClient)
+	if err != nil {
+		log.Error(context.Background(), "Could not create scorecards client", zap.Error(err))
+		return err
+	}
+	scorecardsClient.Start()
+
+	// The settings and the plugin instances can be changed without restarting the hub. When the configuration file is
+	// changed, the new configuration is validated first and only when it is valid the settings and plugin instances are
+	// replaced. All other configuration options still require a restart of the hub.
+	reloadClient, err := reload.NewClient(cfg.Hub.Reload, r.Config, func(ctx context.Context) error {
+		newCfg, err := config.Load(r.Config, *r)
+		if err != nil {
+			return err
+		}
+
+		if err := newCfg.Hub.App.Settings.Validate(); err != nil {
+			return err
+		}
+
+		newScorecardsClient, err := scorecards.NewClient(newCfg.Hub.App.Settings.Scorecards, pluginsClient.Mount(), dbClient)
+		if err != nil {
+			return err
+		}
+
+		commitAPI, err := apiServer.Reload(newCfg.Hub.App.Settings)
+		if err != nil {
+			return err
+		}
+
+		// The plugin instances are prepared in the last step, because they are also saved in the database, so that they
+		// are only saved when all other changes could be prepared.
+		commitPlugins, err := pluginsClient.Reload(ctx, newCfg.Hub.Plugins)
+		if err != nil {
+			return err
+		}
+
+		// All changes could be prepared, so that we can apply them now. Applying the changes can not fail, so that the
+		// hub is never left in a partly reloaded state. The old scorecards client is stopped in a new goroutine, because
+		// stopping the client waits until a running evaluation is finished.
+		commitPlugins()
+		commitAPI()
+		authClient.SetSettings(newCfg.Hub.App.Settings)
+
+		go scorecardsClient.Stop()
+		scorecardsClient = newScorecardsClient
+		scorecardsClient.Start()
+
+		return nil
+	})
+	if err != nil {
+		log.Error(context.Background(), "Could not create reload client", zap.Error(err))
+		return err
+	}
+	reloadClient.Start()
+
 	// All components should be terminated gracefully. For that we are listen for the SIGINT and SIGTERM signals and try
 	// to gracefully shutdown the started kobs components. This ensures that established connections or tasks are not
 	// interrupted.
@@ -125,6 +195,9 @@ func (r *Cmd) Run(plugins []plugins.Plugin) error {
 	<-done
 	log.Info(context.Background(), "Shutdown kobs hub...")
 
+	reloadClient.Stop()
+	scorecardsClient.Stop()
+	reportsClient.Stop()
 	appServer.Stop()
 	apiServer.Stop()
 
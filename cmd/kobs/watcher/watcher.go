@@ -2,10 +2,13 @@ package watcher
 
 import (
 	"context"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	clusterAPI "github.com/kobsio/kobs/pkg/cluster/api"
 	"github.com/kobsio/kobs/pkg/hub/clusters"
 	"github.com/kobsio/kobs/pkg/hub/db"
 	"github.com/kobsio/kobs/pkg/hub/watcher"
@@ -15,6 +18,7 @@ import (
 	"github.com/kobsio/kobs/pkg/instrument/tracer"
 	"github.com/kobsio/kobs/pkg/plugins"
 	"github.com/kobsio/kobs/pkg/utils/config"
+	"github.com/kobsio/kobs/pkg/utils/tunnel"
 
 	"go.uber.org/zap"
 )
@@ -23,12 +27,15 @@ type Cmd struct {
 	Config string `env:"KOBS_CONFIG" default:"config.yaml" help:"The path to the configuration file for the watcher."`
 
 	Watcher struct {
-		Debug    debug.Config    `json:"debug" embed:"" prefix:"debug." envprefix:"DEBUG_"`
-		Log      log.Config      `json:"log" embed:"" prefix:"log." envprefix:"LOG_"`
-		Tracer   tracer.Config   `json:"tracer" embed:"" prefix:"tracer." envprefix:"TRACER_"`
-		Metrics  metrics.Config  `json:"metrics" embed:"" prefix:"metrics." envprefix:"METRICS_"`
-		Database db.Config       `json:"database" embed:"" prefix:"database." envprefix:"DATABASE_"`
-		Watcher  watcher.Config  `json:"watcher" embed:"" prefix:"watcher." envprefix:"WATCHER_"`
+		Debug    debug.Config   `json:"debug" embed:"" prefix:"debug." envprefix:"DEBUG_"`
+		Log      log.Config     `json:"log" embed:"" prefix:"log." envprefix:"LOG_"`
+		Tracer   tracer.Config  `json:"tracer" embed:"" prefix:"tracer." envprefix:"TRACER_"`
+		Metrics  metrics.Config `json:"metrics" embed:"" prefix:"metrics." envprefix:"METRICS_"`
+		Database db.Config      `json:"database" embed:"" prefix:"database." envprefix:"DATABASE_"`
+		Watcher  watcher.Config `json:"watcher" embed:"" prefix:"watcher." envprefix:"WATCHER_"`
+		Tunnel   struct {
+			Address string `json:"address" env:"ADDRESS" default:"" help:"The address where the watcher should listen for tunnels opened by clusters. If not set, clusters can not open a tunnel to the watcher."`
+		} `json:"tunnel" embed:"" prefix:"tunnel." envprefix:"TUNNEL_"`
 		Clusters clusters.Config `json:"clusters" kong:"-"`
 	} `json:"watcher" embed:"" prefix:"watcher." envprefix:"KOBS_WATCHER_"`
 }
@@ -64,15 +71,30 @@ func (r *Cmd) Run(plugins []plugins.Plugin) error {
 		defer debugServer.Stop()
 	}
 
-	clustersClient, err := clusters.NewClient(cfg.Watcher.Clusters)
+	dbClient, err := db.NewClient(cfg.Watcher.Database)
+	if err != nil {
+		log.Error(context.Background(), "Could not create database client", zap.Error(err))
+	}
+
+	clustersClient, err := clusters.NewClient(cfg.Watcher.Clusters, clusterAPI.NewAgentlessHandler(plugins), dbClient)
 	if err != nil {
 		log.Error(context.Background(), "Could not create clusters client", zap.Error(err))
 		return err
 	}
 
-	dbClient, err := db.NewClient(cfg.Watcher.Database)
-	if err != nil {
-		log.Error(context.Background(), "Could not create database client", zap.Error(err))
+	// Clusters which are connected via a tunnel can not be reached by the watcher, so that they must also open a tunnel
+	// to the watcher. For that we start a server, which only serves the tunnel endpoint.
+	if cfg.Watcher.Tunnel.Address != "" {
+		tunnelMux := http.NewServeMux()
+		tunnelMux.HandleFunc(tunnel.Path, clustersClient.ServeTunnel)
+		tunnelServer := &http.Server{Addr: cfg.Watcher.Tunnel.Address, Handler: tunnelMux, ReadHeaderTimeout: 3 * time.Second}
+
+		go func() {
+			if err := tunnelServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Error(context.Background(), "Tunnel server died unexpected", zap.Error(err))
+			}
+		}()
+		defer tunnelServer.Close()
 	}
 
 	watcherClient, err := watcher.NewClient(cfg.Watcher.Watcher, clustersClient, dbClient)
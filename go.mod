@@ -27,11 +27,14 @@ require (
 	github.com/go-chi/chi/v5 v5.0.12
 	github.com/go-chi/cors v1.2.1
 	github.com/go-chi/render v1.0.3
+	github.com/go-pdf/fpdf v0.9.0
 	github.com/go-sql-driver/mysql v1.8.1
 	github.com/golang-jwt/jwt/v4 v4.5.0
 	github.com/golang/mock v1.6.0
+	github.com/google/cel-go v0.17.7
 	github.com/google/go-github v17.0.0+incompatible
 	github.com/gorilla/websocket v1.5.1
+	github.com/hashicorp/yamux v0.1.2
 	github.com/kiali/kiali v1.82.0
 	github.com/lib/pq v1.10.9
 	github.com/minio/minio-go/v7 v7.0.69
@@ -39,8 +42,10 @@ require (
 	github.com/mmcdole/gofeed v1.3.0
 	github.com/opsgenie/opsgenie-go-sdk-v2 v1.2.22
 	github.com/orlangure/gnomock v0.30.0
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/prometheus/client_golang v1.19.0
 	github.com/prometheus/common v0.51.1
+	github.com/robfig/cron/v3 v3.0.1
 	github.com/signalsciences/go-sigsci v0.1.20
 	github.com/stretchr/testify v1.9.0
 	github.com/vmware-tanzu/velero v1.11.1
@@ -54,12 +59,14 @@ require (
 	go.opentelemetry.io/otel/trace v1.24.0
 	go.uber.org/zap v1.27.0
 	golang.org/x/crypto v0.24.0
+	golang.org/x/image v0.18.0
 	golang.org/x/oauth2 v0.18.0
 	google.golang.org/api v0.172.0
 	k8s.io/api v0.29.3
 	k8s.io/apiextensions-apiserver v0.29.3
 	k8s.io/apimachinery v0.29.3
 	k8s.io/client-go v0.29.3
+	k8s.io/metrics v0.29.3
 	sigs.k8s.io/controller-runtime v0.17.2
 	sigs.k8s.io/yaml v1.4.0
 )
@@ -80,6 +87,7 @@ require (
 	github.com/ajg/form v1.5.1 // indirect
 	github.com/andybalholm/brotli v1.1.0 // indirect
 	github.com/andybalholm/cascadia v1.3.1 // indirect
+	github.com/antlr/antlr4/runtime/Go/antlr/v4 v4.0.0-20230305170008-8188dc5388df // indirect
 	github.com/apache/arrow/go/v14 v14.0.2 // indirect
 	github.com/aws/aws-sdk-go v1.44.332 // indirect
 	github.com/beorn7/perks v1.0.1 // indirect
@@ -153,7 +161,6 @@ require (
 	github.com/pierrec/lz4/v4 v4.1.21 // indirect
 	github.com/pkg/browser v0.0.0-20240102092130-5ac0b6a4141c // indirect
 	github.com/pkg/errors v0.9.1 // indirect
-	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/prometheus/client_model v0.6.0 // indirect
 	github.com/prometheus/procfs v0.12.0 // indirect
 	github.com/rs/xid v1.5.0 // indirect
@@ -163,6 +170,7 @@ require (
 	github.com/sirupsen/logrus v1.9.3 // indirect
 	github.com/spf13/cobra v1.7.0 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
+	github.com/stoewer/go-strcase v1.2.0 // indirect
 	github.com/stretchr/objx v0.5.2 // indirect
 	github.com/trivago/tgo v1.0.7 // indirect
 	github.com/vjeantet/grok v1.0.0 // indirect
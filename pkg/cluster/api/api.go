@@ -2,7 +2,9 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/kobsio/kobs/pkg/cluster/api/applications"
@@ -14,6 +16,8 @@ import (
 	"github.com/kobsio/kobs/pkg/cluster/plugins"
 	"github.com/kobsio/kobs/pkg/instrument"
 	"github.com/kobsio/kobs/pkg/instrument/log"
+	kobsPlugins "github.com/kobsio/kobs/pkg/plugins"
+	"github.com/kobsio/kobs/pkg/plugins/plugin"
 	"github.com/kobsio/kobs/pkg/utils/middleware/recoverer"
 	"github.com/kobsio/kobs/pkg/utils/middleware/tokenauth"
 
@@ -29,6 +33,14 @@ type Config struct {
 	Token   string `json:"token" env:"TOKEN" default:"" help:"The token which is used to protect the cluster API."`
 }
 
+// Context is a single context from a Kubeconfig file, which is served as a separate cluster, when the cluster is
+// configured with multiple contexts. Each context has its own Kubernetes client and plugin instances.
+type Context struct {
+	Name             string
+	KubernetesClient kubernetes.Client
+	PluginsClient    plugins.Client
+}
+
 // Server is the interface of a client service, which provides the options to start and stop the underlying http
 // server.
 type Server interface {
@@ -71,6 +83,30 @@ func (s *server) Stop() {
 // our defined middlewares like request id, metrics, auth or loggin. This also makes it easier to analyze the logs in a
 // Kubernetes cluster where the health check is called every x seconds, because we generate less logs.
 func New(config Config, kubernetesClient kubernetes.Client, pluginsClient plugins.Client) (Server, error) {
+	return &server{
+		server: &http.Server{
+			Addr:              config.Address,
+			Handler:           NewRouter(config, kubernetesClient, pluginsClient),
+			ReadHeaderTimeout: 3 * time.Second,
+		},
+	}, nil
+}
+
+// NewWithContexts returns a new client server, which serves the cluster API for each of the provided contexts. See
+// NewContextsRouter for the routes of the server.
+func NewWithContexts(config Config, contexts []Context) (Server, error) {
+	return &server{
+		server: &http.Server{
+			Addr:              config.Address,
+			Handler:           NewContextsRouter(config, contexts),
+			ReadHeaderTimeout: 3 * time.Second,
+		},
+	}, nil
+}
+
+// NewRouter returns the router for the cluster API. The router is used by the cluster server, but it can also be used
+// by the hub to serve the cluster API in-process for agentless clusters, where no kobs cluster is running.
+func NewRouter(config Config, kubernetesClient kubernetes.Client, pluginsClient plugins.Client) chi.Router {
 	router := chi.NewRouter()
 	router.Use(recoverer.Handler)
 	router.Use(middleware.Compress(5))
@@ -97,11 +133,39 @@ func New(config Config, kubernetesClient kubernetes.Client, pluginsClient plugin
 		r.Mount("/plugins", pluginsClient.Mount())
 	})
 
-	return &server{
-		server: &http.Server{
-			Addr:              config.Address,
-			Handler:           router,
-			ReadHeaderTimeout: 3 * time.Second,
-		},
-	}, nil
+	return router
+}
+
+// NewContextsRouter returns the router for a cluster, which serves multiple contexts from a Kubeconfig file. The cluster
+// API for each context is served under the "/contexts/<name>" prefix, so that the address of a context in the hub
+// configuration is "http://<address>/contexts/<name>" and the hub handles each context as a separate cluster. The name
+// of a context is escaped, because it can contain a "/", e.g. for the ARN of an EKS cluster, so that the address for
+// the context "arn:aws:eks:eu-central-1:123456789012:cluster/dev" is
+// "http://<address>/contexts/arn:aws:eks:eu-central-1:123456789012:cluster%2Fdev".
+func NewContextsRouter(config Config, contexts []Context) chi.Router {
+	router := chi.NewRouter()
+
+	router.Get("/api/health", func(w http.ResponseWriter, r *http.Request) {
+		render.JSON(w, r, nil)
+	})
+
+	for _, c := range contexts {
+		router.Mount(fmt.Sprintf("/contexts/%s", url.PathEscape(c.Name)), NewRouter(config, c.KubernetesClient, c.PluginsClient))
+	}
+
+	return router
+}
+
+// NewAgentlessHandler returns a function, which creates the cluster API for an agentless cluster. The returned function
+// is used by the hub and watcher to create the cluster API for each agentless cluster, with the plugin instances of the
+// cluster and a Kubernetes client, which is talking directly to the Kubernetes API server of the cluster.
+func NewAgentlessHandler(availablePlugins []kobsPlugins.Plugin) func(instances []plugin.Instance, kubernetesClient kubernetes.Client) (http.Handler, error) {
+	return func(instances []plugin.Instance, kubernetesClient kubernetes.Client) (http.Handler, error) {
+		pluginsClient, err := plugins.NewClient(availablePlugins, instances, kubernetesClient)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewRouter(Config{}, kubernetesClient, pluginsClient), nil
+	}
 }
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes"
+	"github.com/kobsio/kobs/pkg/cluster/plugins"
+	"github.com/kobsio/kobs/pkg/utils"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewContextsRouter(t *testing.T) {
+	newContext := func(t *testing.T, name string) Context {
+		ctrl := gomock.NewController(t)
+		kubernetesClient := kubernetes.NewMockClient(ctrl)
+
+		pluginsRouter := chi.NewRouter()
+		pluginsRouter.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			render.JSON(w, r, []string{name})
+		})
+
+		pluginsClient := plugins.NewMockClient(ctrl)
+		pluginsClient.EXPECT().Mount().Return(pluginsRouter)
+
+		return Context{Name: name, KubernetesClient: kubernetesClient, PluginsClient: pluginsClient}
+	}
+
+	router := NewContextsRouter(Config{Token: "token"}, []Context{newContext(t, "kind-dev"), newContext(t, "kind-stage"), newContext(t, "arn:aws:eks:eu-central-1:123456789012:cluster/dev")})
+
+	for _, tt := range []struct {
+		name         string
+		url          string
+		token        string
+		expectedCode int
+		expectedBody string
+	}{
+		{name: "should return health", url: "/api/health", expectedCode: http.StatusOK, expectedBody: `null`},
+		{name: "should return plugins of first context", url: "/contexts/kind-dev/api/plugins", token: "token", expectedCode: http.StatusOK, expectedBody: `["kind-dev"]`},
+		{name: "should return plugins of second context", url: "/contexts/kind-stage/api/plugins", token: "token", expectedCode: http.StatusOK, expectedBody: `["kind-stage"]`},
+		{name: "should return plugins of context with slash", url: "/contexts/arn:aws:eks:eu-central-1:123456789012:cluster%2Fdev/api/plugins", token: "token", expectedCode: http.StatusOK, expectedBody: `["arn:aws:eks:eu-central-1:123456789012:cluster/dev"]`},
+		{name: "should fail for unescaped context with slash", url: "/contexts/arn:aws:eks:eu-central-1:123456789012:cluster/dev/api/plugins", token: "token", expectedCode: http.StatusNotFound},
+		{name: "should fail without token", url: "/contexts/kind-dev/api/plugins", expectedCode: http.StatusUnauthorized},
+		{name: "should fail for unknown context", url: "/contexts/kind-prod/api/plugins", token: "token", expectedCode: http.StatusNotFound},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, tt.url, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			utils.AssertStatusEq(t, w, tt.expectedCode)
+			if tt.expectedBody != "" {
+				utils.AssertJSONEq(t, w, tt.expectedBody)
+			}
+		})
+	}
+}
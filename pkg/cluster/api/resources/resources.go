@@ -2,14 +2,18 @@ package resources
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/kobsio/kobs/pkg/cluster/kubernetes"
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/redact"
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/related"
 	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/terminal"
 	"github.com/kobsio/kobs/pkg/instrument/log"
+	"github.com/kobsio/kobs/pkg/utils"
 	"github.com/kobsio/kobs/pkg/utils/middleware/errresponse"
 
 	"github.com/go-chi/chi/v5"
@@ -24,6 +28,10 @@ import (
 
 var (
 	pingPeriod = 30 * time.Second
+
+	// errRedacted is returned when a user tries to create, apply or patch a resource with the redacted values, which
+	// were returned by kobs. Otherwise the placeholders would overwrite the real values of the resource.
+	errRedacted = errors.New("resource contains redacted values")
 )
 
 type Router struct {
@@ -33,7 +41,9 @@ type Router struct {
 }
 
 // getResources returns a list of resources for the given clusters and namespaces. The result can limited by the
-// paramName and param query parameter.
+// paramName and param query parameter or by the labelSelector and fieldSelector query parameters. The limit and
+// continue query parameters can be used to get the resources page by page. When the cache is enabled, lists are returned
+// from the cache, which can be bypassed by setting the bypassCache query parameter to "true".
 func (router *Router) getResources(w http.ResponseWriter, r *http.Request) {
 	namespace := r.URL.Query().Get("namespace")
 	name := r.URL.Query().Get("name")
@@ -41,6 +51,11 @@ func (router *Router) getResources(w http.ResponseWriter, r *http.Request) {
 	path := r.URL.Query().Get("path")
 	paramName := r.URL.Query().Get("paramName")
 	param := r.URL.Query().Get("param")
+	labelSelector := r.URL.Query().Get("labelSelector")
+	fieldSelector := r.URL.Query().Get("fieldSelector")
+	limit := r.URL.Query().Get("limit")
+	continueToken := r.URL.Query().Get("continue")
+	bypassCache := r.URL.Query().Get("bypassCache")
 
 	ctx, span := router.tracer.Start(r.Context(), "getResources")
 	defer span.End()
@@ -50,9 +65,33 @@ func (router *Router) getResources(w http.ResponseWriter, r *http.Request) {
 	span.SetAttributes(attribute.Key("path").String(path))
 	span.SetAttributes(attribute.Key("paramName").String(paramName))
 	span.SetAttributes(attribute.Key("param").String(param))
-	log.Debug(ctx, "Get resources", zap.String("namespace", namespace), zap.String("name", name), zap.String("resource", resource), zap.String("path", path), zap.String("paramName", paramName), zap.String("param", param))
+	span.SetAttributes(attribute.Key("labelSelector").String(labelSelector))
+	span.SetAttributes(attribute.Key("fieldSelector").String(fieldSelector))
+	span.SetAttributes(attribute.Key("limit").String(limit))
+	span.SetAttributes(attribute.Key("continue").String(continueToken))
+	span.SetAttributes(attribute.Key("bypassCache").String(bypassCache))
+	log.Debug(ctx, "Get resources", zap.String("namespace", namespace), zap.String("name", name), zap.String("resource", resource), zap.String("path", path), zap.String("paramName", paramName), zap.String("param", param), zap.String("labelSelector", labelSelector), zap.String("fieldSelector", fieldSelector), zap.String("limit", limit), zap.String("continue", continueToken), zap.String("bypassCache", bypassCache))
+
+	var parsedLimit int64
+	if limit != "" {
+		var err error
+		parsedLimit, err = strconv.ParseInt(limit, 10, 64)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.Error(ctx, "Failed to parse 'limit' parameter", zap.Error(err))
+			errresponse.Render(w, r, http.StatusBadRequest, "Failed to parse 'limit' parameter")
+			return
+		}
+	}
 
-	list, err := router.kubernetesClient.GetResources(ctx, namespace, name, path, resource, paramName, param)
+	list, err := router.kubernetesClient.GetResources(ctx, namespace, name, path, resource, paramName, param, kubernetes.ListOptions{
+		LabelSelector: labelSelector,
+		FieldSelector: fieldSelector,
+		Limit:         parsedLimit,
+		Continue:      continueToken,
+		BypassCache:   bypassCache == "true",
+	})
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
@@ -61,6 +100,18 @@ func (router *Router) getResources(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The values of Secrets and ConfigMaps are redacted before they are returned, so that a user needs the "reveal"
+	// verb to see them. The values are not redacted in the Kubernetes client, because they are also used internally,
+	// e.g. by the Helm plugin to decode the releases.
+	list, err = router.kubernetesClient.Redactor().Redact(path, resource, list)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		log.Error(ctx, "Failed to redact resources", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to redact resources")
+		return
+	}
+
 	var resources map[string]any
 	err = json.Unmarshal(list, &resources)
 	if err != nil {
@@ -147,6 +198,14 @@ func (router *Router) patchResource(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if redact.IsRedacted(body) {
+		span.RecordError(errRedacted)
+		span.SetStatus(codes.Error, errRedacted.Error())
+		log.Warn(ctx, "Patch contains redacted values")
+		errresponse.Render(w, r, http.StatusBadRequest, "The patch contains redacted values")
+		return
+	}
+
 	err = router.kubernetesClient.PatchResource(ctx, namespace, name, path, resource, subResource, body)
 	if err != nil {
 		span.RecordError(err)
@@ -184,6 +243,14 @@ func (router *Router) createResource(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if redact.IsRedacted(body) {
+		span.RecordError(errRedacted)
+		span.SetStatus(codes.Error, errRedacted.Error())
+		log.Warn(ctx, "Resource contains redacted values")
+		errresponse.Render(w, r, http.StatusBadRequest, "The resource contains redacted values")
+		return
+	}
+
 	err = router.kubernetesClient.CreateResource(ctx, namespace, name, path, resource, body)
 	if err != nil {
 		span.RecordError(err)
@@ -196,6 +263,391 @@ func (router *Router) createResource(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, nil)
 }
 
+// applyResource handles server-side apply operations for resources. The resource can be identified by the given
+// namespace, name, resource and path, where the name is optional and taken from the manifest if it is not provided.
+// The manifest must be provided in the request body in JSON or YAML format.
+//
+// When the user sets the "dryRun" parameter to "true" nothing is persisted. Instead we return the object as it would be
+// persisted by the API server together with a diff against the live object. The "force" parameter can be used to
+// take the ownership of fields which are managed by another field manager.
+func (router *Router) applyResource(w http.ResponseWriter, r *http.Request) {
+	namespace := r.URL.Query().Get("namespace")
+	name := r.URL.Query().Get("name")
+	resource := r.URL.Query().Get("resource")
+	path := r.URL.Query().Get("path")
+	force := r.URL.Query().Get("force")
+	dryRun := r.URL.Query().Get("dryRun")
+
+	ctx, span := router.tracer.Start(r.Context(), "applyResource")
+	defer span.End()
+	span.SetAttributes(attribute.Key("namespace").String(namespace))
+	span.SetAttributes(attribute.Key("name").String(name))
+	span.SetAttributes(attribute.Key("resource").String(resource))
+	span.SetAttributes(attribute.Key("path").String(path))
+	span.SetAttributes(attribute.Key("force").String(force))
+	span.SetAttributes(attribute.Key("dryRun").String(dryRun))
+	log.Debug(ctx, "Apply resource", zap.String("namespace", namespace), zap.String("name", name), zap.String("resource", resource), zap.String("path", path), zap.String("force", force), zap.String("dryRun", dryRun))
+
+	parsedForce, _ := strconv.ParseBool(force)
+	parsedDryRun, _ := strconv.ParseBool(dryRun)
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		log.Error(ctx, "Failed to decode request body", zap.Error(err))
+		errresponse.Render(w, r, http.StatusBadRequest, "Failed to decode request body")
+		return
+	}
+
+	if redact.IsRedacted(body) {
+		span.RecordError(errRedacted)
+		span.SetStatus(codes.Error, errRedacted.Error())
+		log.Warn(ctx, "Resource contains redacted values")
+		errresponse.Render(w, r, http.StatusBadRequest, "The resource contains redacted values")
+		return
+	}
+
+	result, err := router.kubernetesClient.ApplyResource(ctx, namespace, name, path, resource, body, parsedForce, parsedDryRun)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		log.Error(ctx, "Failed to apply resource", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to apply resource")
+		return
+	}
+
+	// The returned object and the diff contain the values of the applied resource and for a dry-run also the values of
+	// the live resource, so that they must be redacted in the same way as the resources returned by getResources.
+	if metadata, ok := result.Object["metadata"].(map[string]any); ok {
+		if objName, ok := metadata["name"].(string); ok {
+			name = objName
+		}
+	}
+	redactor := router.kubernetesClient.Redactor()
+	redactor.RedactChanges(path, resource, name, result.Diff)
+	redactor.RedactObject(path, resource, result.Object)
+
+	render.JSON(w, r, result)
+}
+
+// getRelatedResources returns the resource identified by the namespace, name and resource together with all related
+// resources, e.g. the ReplicaSets and Pods for a Deployment. Each resource also contains the Events for the resource.
+func (router *Router) getRelatedResources(w http.ResponseWriter, r *http.Request) {
+	namespace := r.URL.Query().Get("namespace")
+	name := r.URL.Query().Get("name")
+	resource := r.URL.Query().Get("resource")
+
+	ctx, span := router.tracer.Start(r.Context(), "getRelatedResources")
+	defer span.End()
+	span.SetAttributes(attribute.Key("namespace").String(namespace))
+	span.SetAttributes(attribute.Key("name").String(name))
+	span.SetAttributes(attribute.Key("resource").String(resource))
+	log.Debug(ctx, "Get related resources", zap.String("namespace", namespace), zap.String("name", name), zap.String("resource", resource))
+
+	if !utils.Contains(related.Resources, resource) {
+		log.Warn(ctx, "Resource is not supported", zap.String("resource", resource))
+		errresponse.Render(w, r, http.StatusBadRequest, "Resource is not supported")
+		return
+	}
+
+	relatedResource, err := router.kubernetesClient.GetRelatedResources(ctx, namespace, name, resource)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		log.Error(ctx, "Failed to get related resources", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get related resources")
+		return
+	}
+
+	render.JSON(w, r, relatedResource)
+}
+
+// rolloutRestart restarts all Pods of a Deployment, StatefulSet or DaemonSet. The resource is identified by the given
+// namespace, name and resource.
+func (router *Router) rolloutRestart(w http.ResponseWriter, r *http.Request) {
+	namespace := r.URL.Query().Get("namespace")
+	name := r.URL.Query().Get("name")
+	resource := r.URL.Query().Get("resource")
+
+	ctx, span := router.tracer.Start(r.Context(), "rolloutRestart")
+	defer span.End()
+	span.SetAttributes(attribute.Key("namespace").String(namespace))
+	span.SetAttributes(attribute.Key("name").String(name))
+	span.SetAttributes(attribute.Key("resource").String(resource))
+	log.Debug(ctx, "Restart resource", zap.String("namespace", namespace), zap.String("name", name), zap.String("resource", resource))
+
+	err := router.kubernetesClient.RolloutRestart(ctx, namespace, name, resource)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		log.Error(ctx, "Failed to restart resource", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to restart resource")
+		return
+	}
+
+	render.JSON(w, r, nil)
+}
+
+// rolloutScale sets the number of replicas for a Deployment or StatefulSet. The number of replicas must be provided
+// via the "replicas" query parameter.
+func (router *Router) rolloutScale(w http.ResponseWriter, r *http.Request) {
+	namespace := r.URL.Query().Get("namespace")
+	name := r.URL.Query().Get("name")
+	resource := r.URL.Query().Get("resource")
+	replicas := r.URL.Query().Get("replicas")
+
+	ctx, span := router.tracer.Start(r.Context(), "rolloutScale")
+	defer span.End()
+	span.SetAttributes(attribute.Key("namespace").String(namespace))
+	span.SetAttributes(attribute.Key("name").String(name))
+	span.SetAttributes(attribute.Key("resource").String(resource))
+	span.SetAttributes(attribute.Key("replicas").String(replicas))
+	log.Debug(ctx, "Scale resource", zap.String("namespace", namespace), zap.String("name", name), zap.String("resource", resource), zap.String("replicas", replicas))
+
+	parsedReplicas, err := strconv.ParseInt(replicas, 10, 32)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		log.Error(ctx, "Failed to parse 'replicas' parameter", zap.Error(err))
+		errresponse.Render(w, r, http.StatusBadRequest, "Failed to parse 'replicas' parameter")
+		return
+	}
+
+	err = router.kubernetesClient.RolloutScale(ctx, namespace, name, resource, int32(parsedReplicas))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		log.Error(ctx, "Failed to scale resource", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to scale resource")
+		return
+	}
+
+	render.JSON(w, r, nil)
+}
+
+// rolloutPause pauses the rollout of a Deployment.
+func (router *Router) rolloutPause(w http.ResponseWriter, r *http.Request) {
+	router.setRolloutPaused(w, r, true)
+}
+
+// rolloutResume resumes the rollout of a paused Deployment.
+func (router *Router) rolloutResume(w http.ResponseWriter, r *http.Request) {
+	router.setRolloutPaused(w, r, false)
+}
+
+func (router *Router) setRolloutPaused(w http.ResponseWriter, r *http.Request, paused bool) {
+	namespace := r.URL.Query().Get("namespace")
+	name := r.URL.Query().Get("name")
+	resource := r.URL.Query().Get("resource")
+
+	ctx, span := router.tracer.Start(r.Context(), "setRolloutPaused")
+	defer span.End()
+	span.SetAttributes(attribute.Key("namespace").String(namespace))
+	span.SetAttributes(attribute.Key("name").String(name))
+	span.SetAttributes(attribute.Key("resource").String(resource))
+	span.SetAttributes(attribute.Key("paused").Bool(paused))
+	log.Debug(ctx, "Pause resource", zap.String("namespace", namespace), zap.String("name", name), zap.String("resource", resource), zap.Bool("paused", paused))
+
+	err := router.kubernetesClient.RolloutPause(ctx, namespace, name, resource, paused)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if paused {
+			log.Error(ctx, "Failed to pause resource", zap.Error(err))
+			errresponse.Render(w, r, http.StatusInternalServerError, "Failed to pause resource")
+			return
+		}
+		log.Error(ctx, "Failed to resume resource", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to resume resource")
+		return
+	}
+
+	render.JSON(w, r, nil)
+}
+
+// rolloutStatus returns the rollout status of a Deployment, StatefulSet or DaemonSet.
+func (router *Router) rolloutStatus(w http.ResponseWriter, r *http.Request) {
+	namespace := r.URL.Query().Get("namespace")
+	name := r.URL.Query().Get("name")
+	resource := r.URL.Query().Get("resource")
+
+	ctx, span := router.tracer.Start(r.Context(), "rolloutStatus")
+	defer span.End()
+	span.SetAttributes(attribute.Key("namespace").String(namespace))
+	span.SetAttributes(attribute.Key("name").String(name))
+	span.SetAttributes(attribute.Key("resource").String(resource))
+	log.Debug(ctx, "Get rollout status", zap.String("namespace", namespace), zap.String("name", name), zap.String("resource", resource))
+
+	status, err := router.kubernetesClient.RolloutStatus(ctx, namespace, name, resource)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		log.Error(ctx, "Failed to get rollout status", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get rollout status")
+		return
+	}
+
+	render.JSON(w, r, status)
+}
+
+// rolloutHistory returns the revision history of a Deployment, StatefulSet or DaemonSet.
+func (router *Router) rolloutHistory(w http.ResponseWriter, r *http.Request) {
+	namespace := r.URL.Query().Get("namespace")
+	name := r.URL.Query().Get("name")
+	resource := r.URL.Query().Get("resource")
+
+	ctx, span := router.tracer.Start(r.Context(), "rolloutHistory")
+	defer span.End()
+	span.SetAttributes(attribute.Key("namespace").String(namespace))
+	span.SetAttributes(attribute.Key("name").String(name))
+	span.SetAttributes(attribute.Key("resource").String(resource))
+	log.Debug(ctx, "Get rollout history", zap.String("namespace", namespace), zap.String("name", name), zap.String("resource", resource))
+
+	revisions, err := router.kubernetesClient.RolloutHistory(ctx, namespace, name, resource)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		log.Error(ctx, "Failed to get rollout history", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get rollout history")
+		return
+	}
+
+	render.JSON(w, r, revisions)
+}
+
+// rolloutUndo rolls back a Deployment, StatefulSet or DaemonSet to the revision provided via the "revision" query
+// parameter. If the revision is not set, the resource is rolled back to the previous revision.
+func (router *Router) rolloutUndo(w http.ResponseWriter, r *http.Request) {
+	namespace := r.URL.Query().Get("namespace")
+	name := r.URL.Query().Get("name")
+	resource := r.URL.Query().Get("resource")
+	revision := r.URL.Query().Get("revision")
+
+	ctx, span := router.tracer.Start(r.Context(), "rolloutUndo")
+	defer span.End()
+	span.SetAttributes(attribute.Key("namespace").String(namespace))
+	span.SetAttributes(attribute.Key("name").String(name))
+	span.SetAttributes(attribute.Key("resource").String(resource))
+	span.SetAttributes(attribute.Key("revision").String(revision))
+	log.Debug(ctx, "Undo rollout", zap.String("namespace", namespace), zap.String("name", name), zap.String("resource", resource), zap.String("revision", revision))
+
+	var parsedRevision int64
+	if revision != "" {
+		var err error
+		parsedRevision, err = strconv.ParseInt(revision, 10, 64)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.Error(ctx, "Failed to parse 'revision' parameter", zap.Error(err))
+			errresponse.Render(w, r, http.StatusBadRequest, "Failed to parse 'revision' parameter")
+			return
+		}
+	}
+
+	err := router.kubernetesClient.RolloutUndo(ctx, namespace, name, resource, parsedRevision)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		log.Error(ctx, "Failed to undo rollout", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to undo rollout")
+		return
+	}
+
+	render.JSON(w, r, nil)
+}
+
+// revealValue returns the value of a single key of a Secret or ConfigMap. The values of Secrets and of ConfigMaps which
+// are matching the configured patterns are redacted in the getResources handler, so that this handler is the only way
+// to get the real value. The hub checks the "reveal" verb for this handler and writes an audit log for each request.
+func (router *Router) revealValue(w http.ResponseWriter, r *http.Request) {
+	namespace := r.URL.Query().Get("namespace")
+	name := r.URL.Query().Get("name")
+	resource := r.URL.Query().Get("resource")
+	key := r.URL.Query().Get("key")
+
+	ctx, span := router.tracer.Start(r.Context(), "revealValue")
+	defer span.End()
+	span.SetAttributes(attribute.Key("namespace").String(namespace))
+	span.SetAttributes(attribute.Key("name").String(name))
+	span.SetAttributes(attribute.Key("resource").String(resource))
+	span.SetAttributes(attribute.Key("key").String(key))
+	log.Debug(ctx, "Reveal value", zap.String("namespace", namespace), zap.String("name", name), zap.String("resource", resource), zap.String("key", key))
+
+	if namespace == "" || name == "" || key == "" || (resource != "secrets" && resource != "configmaps") {
+		log.Warn(ctx, "Invalid parameters")
+		errresponse.Render(w, r, http.StatusBadRequest, "The parameters 'namespace', 'name' and 'key' are required and 'resource' must be 'secrets' or 'configmaps'")
+		return
+	}
+
+	value, err := router.kubernetesClient.GetResourceValue(ctx, namespace, name, resource, key)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		if errors.Is(err, kubernetes.ErrKeyNotFound) {
+			log.Warn(ctx, "Key not found", zap.Error(err))
+			errresponse.Render(w, r, http.StatusNotFound, "Key not found")
+			return
+		}
+
+		log.Error(ctx, "Failed to reveal value", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to reveal value")
+		return
+	}
+
+	data := struct {
+		Value string `json:"value"`
+	}{
+		value,
+	}
+
+	render.JSON(w, r, data)
+}
+
+// getPodsUsage returns the CPU and memory usage of all Pods in a namespace together with the requests and limits of
+// the containers. The Pods can be filtered via the "labelSelector" parameter.
+func (router *Router) getPodsUsage(w http.ResponseWriter, r *http.Request) {
+	namespace := r.URL.Query().Get("namespace")
+	labelSelector := r.URL.Query().Get("labelSelector")
+
+	ctx, span := router.tracer.Start(r.Context(), "getPodsUsage")
+	defer span.End()
+	span.SetAttributes(attribute.Key("namespace").String(namespace))
+	span.SetAttributes(attribute.Key("labelSelector").String(labelSelector))
+	log.Debug(ctx, "Get pods usage", zap.String("namespace", namespace), zap.String("labelSelector", labelSelector))
+
+	pods, err := router.kubernetesClient.GetPodsUsage(ctx, namespace, labelSelector)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		log.Error(ctx, "Failed to get pods usage", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get pods usage")
+		return
+	}
+
+	render.JSON(w, r, pods)
+}
+
+// getNodesUsage returns the CPU and memory usage of all Nodes together with the allocatable resources and the sum of
+// the requests and limits of all Pods on a Node.
+func (router *Router) getNodesUsage(w http.ResponseWriter, r *http.Request) {
+	ctx, span := router.tracer.Start(r.Context(), "getNodesUsage")
+	defer span.End()
+	log.Debug(ctx, "Get nodes usage")
+
+	nodes, err := router.kubernetesClient.GetNodesUsage(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		log.Error(ctx, "Failed to get nodes usage", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get nodes usage")
+		return
+	}
+
+	render.JSON(w, r, nodes)
+}
+
 // getLogs returns the logs for the container of a pod in a cluster and namespace. A user can also set the time since
 // when the logs should be returned.
 func (router *Router) getLogs(w http.ResponseWriter, r *http.Request) {
@@ -475,6 +927,26 @@ func (router *Router) getCRDs(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, crds)
 }
 
+// getInventory returns the inventory of the cluster, with the Kubernetes version, the versions of all Nodes, the
+// allocatable resources and the groups of the installed Custom Resource Definitions. It is used by the watcher to save
+// the inventory of all clusters in the database.
+func (router *Router) getInventory(w http.ResponseWriter, r *http.Request) {
+	ctx, span := router.tracer.Start(r.Context(), "getInventory")
+	defer span.End()
+	log.Debug(ctx, "Get inventory")
+
+	inventory, err := router.kubernetesClient.GetInventory(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		log.Error(ctx, "Failed to get inventory", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get inventory")
+		return
+	}
+
+	render.JSON(w, r, inventory)
+}
+
 func Mount(kubernetesClient kubernetes.Client) chi.Router {
 	router := Router{
 		chi.NewRouter(),
@@ -486,12 +958,25 @@ func Mount(kubernetesClient kubernetes.Client) chi.Router {
 	router.Delete("/", router.deleteResource)
 	router.Put("/", router.patchResource)
 	router.Post("/", router.createResource)
+	router.Put("/apply", router.applyResource)
+	router.Get("/related", router.getRelatedResources)
+	router.Post("/rollout/restart", router.rolloutRestart)
+	router.Post("/rollout/scale", router.rolloutScale)
+	router.Post("/rollout/pause", router.rolloutPause)
+	router.Post("/rollout/resume", router.rolloutResume)
+	router.Get("/rollout/status", router.rolloutStatus)
+	router.Get("/rollout/history", router.rolloutHistory)
+	router.Post("/rollout/undo", router.rolloutUndo)
+	router.Get("/reveal", router.revealValue)
+	router.Get("/usage/pods", router.getPodsUsage)
+	router.Get("/usage/nodes", router.getNodesUsage)
 	router.Get("/logs", router.getLogs)
 	router.HandleFunc("/terminal", router.getTerminal)
 	router.Get("/file", router.getFile)
 	router.Post("/file", router.postFile)
 	router.Get("/namespaces", router.getNamespaces)
 	router.Get("/crds", router.getCRDs)
+	router.Get("/inventory", router.getInventory)
 
 	return router
 }
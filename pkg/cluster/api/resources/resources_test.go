@@ -11,6 +11,12 @@ import (
 	"testing"
 
 	"github.com/kobsio/kobs/pkg/cluster/kubernetes"
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/diff"
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/inventory"
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/redact"
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/related"
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/rollout"
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/usage"
 	"github.com/kobsio/kobs/pkg/utils"
 
 	"github.com/go-chi/chi/v5"
@@ -26,6 +32,7 @@ func TestGetResources(t *testing.T) {
 	var newKubernetesClient = func(t *testing.T) *kubernetes.MockClient {
 		ctrl := gomock.NewController(t)
 		kubernetesClient := kubernetes.NewMockClient(ctrl)
+		kubernetesClient.EXPECT().Redactor().Return(nil).AnyTimes()
 		return kubernetesClient
 	}
 
@@ -46,7 +53,7 @@ func TestGetResources(t *testing.T) {
 
 		cluster := "cluster1"
 		kubernetesClient := newKubernetesClient(t)
-		kubernetesClient.EXPECT().GetResources(gomock.Any(), namespace, name, "", resource, "", "").Return(resourceBytes, nil)
+		kubernetesClient.EXPECT().GetResources(gomock.Any(), namespace, name, "", resource, "", "", kubernetes.ListOptions{}).Return(resourceBytes, nil)
 
 		router := Router{chi.NewRouter(), kubernetesClient, defaultTracer}
 		router.Get("/resources", router.getResources)
@@ -61,9 +68,82 @@ func TestGetResources(t *testing.T) {
 		utils.AssertJSONEq(t, w, string(resourceBytes))
 	})
 
+	t.Run("should pass list options", func(t *testing.T) {
+		kubernetesClient := newKubernetesClient(t)
+		kubernetesClient.EXPECT().GetResources(gomock.Any(), "default", "", "/api/v1", "pods", "", "", kubernetes.ListOptions{
+			LabelSelector: "app=nginx",
+			FieldSelector: "status.phase=Running",
+			Limit:         100,
+			Continue:      "token",
+			BypassCache:   true,
+		}).Return([]byte(`{"metadata":{"continue":"next"}}`), nil)
+
+		router := Router{chi.NewRouter(), kubernetesClient, defaultTracer}
+		router.Get("/resources", router.getResources)
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/resources?namespace=default&path=/api/v1&resource=pods&labelSelector=app%3Dnginx&fieldSelector=status.phase%3DRunning&limit=100&continue=token&bypassCache=true", nil)
+		w := httptest.NewRecorder()
+
+		router.getResources(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `{"metadata":{"continue":"next"}}`)
+	})
+
+	t.Run("should redact secrets", func(t *testing.T) {
+		kubernetesClient := newKubernetesClient(t)
+		kubernetesClient.EXPECT().GetResources(gomock.Any(), "default", "", "/api/v1", "secrets", "", "", kubernetes.ListOptions{}).Return([]byte(`{"items":[{"metadata":{"name":"db"},"data":{"password":"c2VjcmV0"}}]}`), nil)
+
+		router := Router{chi.NewRouter(), kubernetesClient, defaultTracer}
+		router.Get("/resources", router.getResources)
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/resources?namespace=default&path=/api/v1&resource=secrets", nil)
+		w := httptest.NewRecorder()
+
+		router.getResources(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `{"items":[{"metadata":{"name":"db","annotations":{"kobs.io/redacted":"true"}},"data":{"password":"W3JlZGFjdGVkXQ=="}}]}`)
+	})
+
+	t.Run("should not redact secrets when disabled", func(t *testing.T) {
+		redactor, _ := redact.New(redact.Config{Disabled: true})
+
+		ctrl := gomock.NewController(t)
+		kubernetesClient := kubernetes.NewMockClient(ctrl)
+		kubernetesClient.EXPECT().Redactor().Return(redactor)
+		kubernetesClient.EXPECT().GetResources(gomock.Any(), "default", "", "/api/v1", "secrets", "", "", kubernetes.ListOptions{}).Return([]byte(`{"items":[{"metadata":{"name":"db"},"data":{"password":"c2VjcmV0"}}]}`), nil)
+
+		router := Router{chi.NewRouter(), kubernetesClient, defaultTracer}
+		router.Get("/resources", router.getResources)
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/resources?namespace=default&path=/api/v1&resource=secrets", nil)
+		w := httptest.NewRecorder()
+
+		router.getResources(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `{"items":[{"metadata":{"name":"db"},"data":{"password":"c2VjcmV0"}}]}`)
+	})
+
+	t.Run("should handle invalid limit", func(t *testing.T) {
+		kubernetesClient := newKubernetesClient(t)
+
+		router := Router{chi.NewRouter(), kubernetesClient, defaultTracer}
+		router.Get("/resources", router.getResources)
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/resources?limit=abc", nil)
+		w := httptest.NewRecorder()
+
+		router.getResources(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusBadRequest)
+		utils.AssertJSONEq(t, w, `{"errors":["Failed to parse 'limit' parameter"]}`)
+	})
+
 	t.Run("should handle error from Kubernetes client", func(t *testing.T) {
 		kubernetesClient := newKubernetesClient(t)
-		kubernetesClient.EXPECT().GetResources(gomock.Any(), "", "", "", "", "", "").Return(nil, fmt.Errorf("unexpected error"))
+		kubernetesClient.EXPECT().GetResources(gomock.Any(), "", "", "", "", "", "", kubernetes.ListOptions{}).Return(nil, fmt.Errorf("unexpected error"))
 
 		router := Router{chi.NewRouter(), kubernetesClient, defaultTracer}
 		router.Get("/resources", router.getResources)
@@ -80,7 +160,7 @@ func TestGetResources(t *testing.T) {
 
 	t.Run("should handle unmarshal error", func(t *testing.T) {
 		kubernetesClient := newKubernetesClient(t)
-		kubernetesClient.EXPECT().GetResources(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte(`{"bad":"json}`), nil)
+		kubernetesClient.EXPECT().GetResources(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte(`{"bad":"json}`), nil)
 
 		router := Router{chi.NewRouter(), kubernetesClient, defaultTracer}
 		router.Get("/resources", router.getResources)
@@ -158,6 +238,22 @@ func TestPatchResource(t *testing.T) {
 		utils.AssertJSONEq(t, w, `{"errors":["Failed to decode request body"]}`)
 	})
 
+	t.Run("should reject patch with redacted values", func(t *testing.T) {
+		patch := `{"metadata":{"annotations":{"kobs.io/redacted":"true"}},"data":{"password":"W3JlZGFjdGVkXQ=="}}`
+
+		kubernetesClient := newKubernetesClient(t)
+		router := Router{chi.NewRouter(), kubernetesClient, defaultTracer}
+		router.Get("/resources", router.patchResource)
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/resources?namespace=garden&name=apple&path=/api/v1&resource=secrets", strings.NewReader(patch))
+		w := httptest.NewRecorder()
+
+		router.patchResource(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusBadRequest)
+		utils.AssertJSONEq(t, w, `{"errors":["The patch contains redacted values"]}`)
+	})
+
 	t.Run("should handle Kubernetes client error", func(t *testing.T) {
 		kubernetesClient := newKubernetesClient(t)
 		kubernetesClient.EXPECT().PatchResource(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("unexpected error"))
@@ -340,6 +436,505 @@ func TestCreateResource(t *testing.T) {
 	})
 }
 
+func TestApplyResource(t *testing.T) {
+	defaultTracer := otel.Tracer("fakeTracer")
+
+	var newKubernetesClient = func(t *testing.T) *kubernetes.MockClient {
+		ctrl := gomock.NewController(t)
+		kubernetesClient := kubernetes.NewMockClient(ctrl)
+		kubernetesClient.EXPECT().Redactor().Return(nil).AnyTimes()
+		return kubernetesClient
+	}
+
+	t.Run("should apply resource", func(t *testing.T) {
+		manifest := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: apple\ndata:\n  color: red\n"
+
+		kubernetesClient := newKubernetesClient(t)
+		kubernetesClient.EXPECT().ApplyResource(gomock.Any(), "garden", "apple", "/api/v1", "configmaps", []byte(manifest), false, false).Return(&kubernetes.ApplyResult{Object: map[string]any{"kind": "ConfigMap"}}, nil)
+
+		router := Router{chi.NewRouter(), kubernetesClient, defaultTracer}
+		router.Put("/resources/apply", router.applyResource)
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/resources/apply?namespace=garden&name=apple&path=/api/v1&resource=configmaps", strings.NewReader(manifest))
+		w := httptest.NewRecorder()
+
+		router.applyResource(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `{"object":{"kind":"ConfigMap"}}`)
+	})
+
+	t.Run("should return diff for dry run", func(t *testing.T) {
+		kubernetesClient := newKubernetesClient(t)
+		kubernetesClient.EXPECT().ApplyResource(gomock.Any(), "garden", "apple", "/api/v1", "configmaps", gomock.Any(), true, true).Return(&kubernetes.ApplyResult{
+			Object: map[string]any{"data": map[string]any{"color": "green"}},
+			Diff:   []diff.Change{{Path: "/data/color", Type: "replace", From: "red", To: "green"}},
+		}, nil)
+
+		router := Router{chi.NewRouter(), kubernetesClient, defaultTracer}
+		router.Put("/resources/apply", router.applyResource)
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/resources/apply?namespace=garden&name=apple&path=/api/v1&resource=configmaps&force=true&dryRun=true", strings.NewReader(`{"data":{"color":"green"}}`))
+		w := httptest.NewRecorder()
+
+		router.applyResource(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `{"object":{"data":{"color":"green"}},"diff":[{"path":"/data/color","type":"replace","from":"red","to":"green"}]}`)
+	})
+
+	t.Run("should redact secret and diff for dry run", func(t *testing.T) {
+		kubernetesClient := newKubernetesClient(t)
+		kubernetesClient.EXPECT().ApplyResource(gomock.Any(), "garden", "", "/api/v1", "secrets", gomock.Any(), false, true).Return(&kubernetes.ApplyResult{
+			Object: map[string]any{"metadata": map[string]any{"name": "apple"}, "data": map[string]any{"password": "bmV3"}},
+			Diff: []diff.Change{
+				{Path: "/data/password", Type: "replace", From: "b2xk", To: "bmV3"},
+				{Path: "/metadata/annotations/kubectl.kubernetes.io~1last-applied-configuration", Type: "remove", From: `{"data":{"password":"b2xk"}}`},
+				{Path: "/stringData", Type: "add", To: map[string]any{"username": "admin"}},
+			},
+		}, nil)
+
+		router := Router{chi.NewRouter(), kubernetesClient, defaultTracer}
+		router.Put("/resources/apply", router.applyResource)
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/resources/apply?namespace=garden&path=/api/v1&resource=secrets&dryRun=true", strings.NewReader(`{"metadata":{"name":"apple"},"data":{"password":"bmV3"}}`))
+		w := httptest.NewRecorder()
+
+		router.applyResource(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `{"object":{"metadata":{"name":"apple","annotations":{"kobs.io/redacted":"true"}},"data":{"password":"W3JlZGFjdGVkXQ=="}},"diff":[{"path":"/data/password","type":"replace","from":"W3JlZGFjdGVkXQ==","to":"W3JlZGFjdGVkXQ=="},{"path":"/metadata/annotations/kubectl.kubernetes.io~1last-applied-configuration","type":"remove"},{"path":"/stringData","type":"add","to":{"username":"[redacted]"}}]}`)
+	})
+
+	t.Run("should handle bad body", func(t *testing.T) {
+		kubernetesClient := newKubernetesClient(t)
+		router := Router{chi.NewRouter(), kubernetesClient, defaultTracer}
+		router.Put("/resources/apply", router.applyResource)
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/resources/apply", badReader{})
+		w := httptest.NewRecorder()
+
+		router.applyResource(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusBadRequest)
+		utils.AssertJSONEq(t, w, `{"errors":["Failed to decode request body"]}`)
+	})
+
+	t.Run("should reject resource with redacted values", func(t *testing.T) {
+		manifest := "apiVersion: v1\nkind: Secret\nmetadata:\n  name: apple\n  annotations:\n    kobs.io/redacted: \"true\"\ndata:\n  password: W3JlZGFjdGVkXQ==\n"
+
+		kubernetesClient := newKubernetesClient(t)
+		router := Router{chi.NewRouter(), kubernetesClient, defaultTracer}
+		router.Put("/resources/apply", router.applyResource)
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/resources/apply?namespace=garden&name=apple&path=/api/v1&resource=secrets", strings.NewReader(manifest))
+		w := httptest.NewRecorder()
+
+		router.applyResource(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusBadRequest)
+		utils.AssertJSONEq(t, w, `{"errors":["The resource contains redacted values"]}`)
+	})
+
+	t.Run("should handle Kubernetes client error", func(t *testing.T) {
+		kubernetesClient := newKubernetesClient(t)
+		kubernetesClient.EXPECT().ApplyResource(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("unexpected error"))
+		router := Router{chi.NewRouter(), kubernetesClient, defaultTracer}
+		router.Put("/resources/apply", router.applyResource)
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/resources/apply", strings.NewReader(""))
+		w := httptest.NewRecorder()
+
+		router.applyResource(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors":["Failed to apply resource"]}`)
+	})
+}
+
+func TestGetRelatedResources(t *testing.T) {
+	defaultTracer := otel.Tracer("fakeTracer")
+
+	var newKubernetesClient = func(t *testing.T) *kubernetes.MockClient {
+		ctrl := gomock.NewController(t)
+		kubernetesClient := kubernetes.NewMockClient(ctrl)
+		return kubernetesClient
+	}
+
+	t.Run("should return related resources", func(t *testing.T) {
+		kubernetesClient := newKubernetesClient(t)
+		kubernetesClient.EXPECT().GetRelatedResources(gomock.Any(), "default", "nginx", "deployments").Return(&related.Resource{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Namespace:  "default",
+			Name:       "nginx",
+			Related:    []related.Resource{{APIVersion: "apps/v1", Kind: "ReplicaSet", Namespace: "default", Name: "nginx-1"}},
+		}, nil)
+
+		router := Router{chi.NewRouter(), kubernetesClient, defaultTracer}
+		router.Get("/resources/related", router.getRelatedResources)
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/resources/related?namespace=default&name=nginx&resource=deployments", nil)
+		w := httptest.NewRecorder()
+
+		router.getRelatedResources(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `{"apiVersion":"apps/v1","kind":"Deployment","namespace":"default","name":"nginx","manifest":null,"related":[{"apiVersion":"apps/v1","kind":"ReplicaSet","namespace":"default","name":"nginx-1","manifest":null}]}`)
+	})
+
+	t.Run("should handle not supported resource", func(t *testing.T) {
+		kubernetesClient := newKubernetesClient(t)
+		router := Router{chi.NewRouter(), kubernetesClient, defaultTracer}
+		router.Get("/resources/related", router.getRelatedResources)
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/resources/related?namespace=default&name=nginx&resource=configmaps", nil)
+		w := httptest.NewRecorder()
+
+		router.getRelatedResources(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusBadRequest)
+		utils.AssertJSONEq(t, w, `{"errors":["Resource is not supported"]}`)
+	})
+
+	t.Run("should handle Kubernetes client error", func(t *testing.T) {
+		kubernetesClient := newKubernetesClient(t)
+		kubernetesClient.EXPECT().GetRelatedResources(gomock.Any(), "default", "nginx", "deployments").Return(nil, fmt.Errorf("unexpected error"))
+		router := Router{chi.NewRouter(), kubernetesClient, defaultTracer}
+		router.Get("/resources/related", router.getRelatedResources)
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/resources/related?namespace=default&name=nginx&resource=deployments", nil)
+		w := httptest.NewRecorder()
+
+		router.getRelatedResources(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors":["Failed to get related resources"]}`)
+	})
+}
+
+func TestRollout(t *testing.T) {
+	defaultTracer := otel.Tracer("fakeTracer")
+	query := "?namespace=default&name=nginx&resource=deployments"
+
+	var newRouter = func(t *testing.T) (*kubernetes.MockClient, Router) {
+		ctrl := gomock.NewController(t)
+		kubernetesClient := kubernetes.NewMockClient(ctrl)
+		router := Router{chi.NewRouter(), kubernetesClient, defaultTracer}
+		return kubernetesClient, router
+	}
+
+	for _, tt := range []struct {
+		name         string
+		url          string
+		prepare      func(kubernetesClient *kubernetes.MockClient)
+		handler      func(router Router) http.HandlerFunc
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "should restart resource",
+			url:  "/rollout/restart" + query,
+			prepare: func(c *kubernetes.MockClient) {
+				c.EXPECT().RolloutRestart(gomock.Any(), "default", "nginx", "deployments").Return(nil)
+			},
+			handler:      func(router Router) http.HandlerFunc { return router.rolloutRestart },
+			expectedCode: http.StatusOK,
+			expectedBody: `null`,
+		},
+		{
+			name: "should handle restart error",
+			url:  "/rollout/restart" + query,
+			prepare: func(c *kubernetes.MockClient) {
+				c.EXPECT().RolloutRestart(gomock.Any(), "default", "nginx", "deployments").Return(fmt.Errorf("unexpected error"))
+			},
+			handler:      func(router Router) http.HandlerFunc { return router.rolloutRestart },
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"errors":["Failed to restart resource"]}`,
+		},
+		{
+			name: "should scale resource",
+			url:  "/rollout/scale" + query + "&replicas=3",
+			prepare: func(c *kubernetes.MockClient) {
+				c.EXPECT().RolloutScale(gomock.Any(), "default", "nginx", "deployments", int32(3)).Return(nil)
+			},
+			handler:      func(router Router) http.HandlerFunc { return router.rolloutScale },
+			expectedCode: http.StatusOK,
+			expectedBody: `null`,
+		},
+		{
+			name:         "should handle invalid replicas",
+			url:          "/rollout/scale" + query + "&replicas=abc",
+			prepare:      func(c *kubernetes.MockClient) {},
+			handler:      func(router Router) http.HandlerFunc { return router.rolloutScale },
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"errors":["Failed to parse 'replicas' parameter"]}`,
+		},
+		{
+			name: "should handle scale error",
+			url:  "/rollout/scale" + query + "&replicas=3",
+			prepare: func(c *kubernetes.MockClient) {
+				c.EXPECT().RolloutScale(gomock.Any(), "default", "nginx", "deployments", int32(3)).Return(fmt.Errorf("unexpected error"))
+			},
+			handler:      func(router Router) http.HandlerFunc { return router.rolloutScale },
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"errors":["Failed to scale resource"]}`,
+		},
+		{
+			name: "should pause resource",
+			url:  "/rollout/pause" + query,
+			prepare: func(c *kubernetes.MockClient) {
+				c.EXPECT().RolloutPause(gomock.Any(), "default", "nginx", "deployments", true).Return(nil)
+			},
+			handler:      func(router Router) http.HandlerFunc { return router.rolloutPause },
+			expectedCode: http.StatusOK,
+			expectedBody: `null`,
+		},
+		{
+			name: "should handle resume error",
+			url:  "/rollout/resume" + query,
+			prepare: func(c *kubernetes.MockClient) {
+				c.EXPECT().RolloutPause(gomock.Any(), "default", "nginx", "deployments", false).Return(fmt.Errorf("unexpected error"))
+			},
+			handler:      func(router Router) http.HandlerFunc { return router.rolloutResume },
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"errors":["Failed to resume resource"]}`,
+		},
+		{
+			name: "should return rollout status",
+			url:  "/rollout/status" + query,
+			prepare: func(c *kubernetes.MockClient) {
+				c.EXPECT().RolloutStatus(gomock.Any(), "default", "nginx", "deployments").Return(&rollout.Status{Done: true, Message: "done"}, nil)
+			},
+			handler:      func(router Router) http.HandlerFunc { return router.rolloutStatus },
+			expectedCode: http.StatusOK,
+			expectedBody: `{"done":true,"message":"done"}`,
+		},
+		{
+			name: "should handle rollout status error",
+			url:  "/rollout/status" + query,
+			prepare: func(c *kubernetes.MockClient) {
+				c.EXPECT().RolloutStatus(gomock.Any(), "default", "nginx", "deployments").Return(nil, fmt.Errorf("unexpected error"))
+			},
+			handler:      func(router Router) http.HandlerFunc { return router.rolloutStatus },
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"errors":["Failed to get rollout status"]}`,
+		},
+		{
+			name: "should return rollout history",
+			url:  "/rollout/history" + query,
+			prepare: func(c *kubernetes.MockClient) {
+				c.EXPECT().RolloutHistory(gomock.Any(), "default", "nginx", "deployments").Return([]rollout.Revision{{Revision: 1, Name: "nginx-1"}}, nil)
+			},
+			handler:      func(router Router) http.HandlerFunc { return router.rolloutHistory },
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"revision":1,"name":"nginx-1","createdAt":null}]`,
+		},
+		{
+			name: "should handle rollout history error",
+			url:  "/rollout/history" + query,
+			prepare: func(c *kubernetes.MockClient) {
+				c.EXPECT().RolloutHistory(gomock.Any(), "default", "nginx", "deployments").Return(nil, fmt.Errorf("unexpected error"))
+			},
+			handler:      func(router Router) http.HandlerFunc { return router.rolloutHistory },
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"errors":["Failed to get rollout history"]}`,
+		},
+		{
+			name: "should undo rollout to previous revision",
+			url:  "/rollout/undo" + query,
+			prepare: func(c *kubernetes.MockClient) {
+				c.EXPECT().RolloutUndo(gomock.Any(), "default", "nginx", "deployments", int64(0)).Return(nil)
+			},
+			handler:      func(router Router) http.HandlerFunc { return router.rolloutUndo },
+			expectedCode: http.StatusOK,
+			expectedBody: `null`,
+		},
+		{
+			name: "should undo rollout to revision",
+			url:  "/rollout/undo" + query + "&revision=2",
+			prepare: func(c *kubernetes.MockClient) {
+				c.EXPECT().RolloutUndo(gomock.Any(), "default", "nginx", "deployments", int64(2)).Return(nil)
+			},
+			handler:      func(router Router) http.HandlerFunc { return router.rolloutUndo },
+			expectedCode: http.StatusOK,
+			expectedBody: `null`,
+		},
+		{
+			name:         "should handle invalid revision",
+			url:          "/rollout/undo" + query + "&revision=abc",
+			prepare:      func(c *kubernetes.MockClient) {},
+			handler:      func(router Router) http.HandlerFunc { return router.rolloutUndo },
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"errors":["Failed to parse 'revision' parameter"]}`,
+		},
+		{
+			name: "should handle undo error",
+			url:  "/rollout/undo" + query,
+			prepare: func(c *kubernetes.MockClient) {
+				c.EXPECT().RolloutUndo(gomock.Any(), "default", "nginx", "deployments", int64(0)).Return(fmt.Errorf("unexpected error"))
+			},
+			handler:      func(router Router) http.HandlerFunc { return router.rolloutUndo },
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"errors":["Failed to undo rollout"]}`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			kubernetesClient, router := newRouter(t)
+			tt.prepare(kubernetesClient)
+
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, tt.url, nil)
+			w := httptest.NewRecorder()
+
+			tt.handler(router)(w, req)
+
+			utils.AssertStatusEq(t, w, tt.expectedCode)
+			utils.AssertJSONEq(t, w, tt.expectedBody)
+		})
+	}
+}
+
+func TestRevealValue(t *testing.T) {
+	defaultTracer := otel.Tracer("fakeTracer")
+
+	var newRouter = func(t *testing.T) (*kubernetes.MockClient, Router) {
+		ctrl := gomock.NewController(t)
+		kubernetesClient := kubernetes.NewMockClient(ctrl)
+		router := Router{chi.NewRouter(), kubernetesClient, defaultTracer}
+		return kubernetesClient, router
+	}
+
+	for _, tt := range []struct {
+		name         string
+		url          string
+		prepare      func(kubernetesClient *kubernetes.MockClient)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "should fail for invalid parameters",
+			url:          "/reveal?namespace=default&name=db&resource=pods&key=password",
+			prepare:      func(c *kubernetes.MockClient) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"errors":["The parameters 'namespace', 'name' and 'key' are required and 'resource' must be 'secrets' or 'configmaps'"]}`,
+		},
+		{
+			name: "should fail for missing key",
+			url:  "/reveal?namespace=default&name=db&resource=secrets&key=password",
+			prepare: func(c *kubernetes.MockClient) {
+				c.EXPECT().GetResourceValue(gomock.Any(), "default", "db", "secrets", "password").Return("", kubernetes.ErrKeyNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"errors":["Key not found"]}`,
+		},
+		{
+			name: "should handle Kubernetes client error",
+			url:  "/reveal?namespace=default&name=db&resource=secrets&key=password",
+			prepare: func(c *kubernetes.MockClient) {
+				c.EXPECT().GetResourceValue(gomock.Any(), "default", "db", "secrets", "password").Return("", fmt.Errorf("unexpected error"))
+			},
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"errors":["Failed to reveal value"]}`,
+		},
+		{
+			name: "should return value",
+			url:  "/reveal?namespace=default&name=db&resource=secrets&key=password",
+			prepare: func(c *kubernetes.MockClient) {
+				c.EXPECT().GetResourceValue(gomock.Any(), "default", "db", "secrets", "password").Return("secret", nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"value":"secret"}`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			kubernetesClient, router := newRouter(t)
+			tt.prepare(kubernetesClient)
+
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+
+			router.revealValue(w, req)
+
+			utils.AssertStatusEq(t, w, tt.expectedCode)
+			utils.AssertJSONEq(t, w, tt.expectedBody)
+		})
+	}
+}
+
+func TestUsage(t *testing.T) {
+	defaultTracer := otel.Tracer("fakeTracer")
+
+	var newRouter = func(t *testing.T) (*kubernetes.MockClient, Router) {
+		ctrl := gomock.NewController(t)
+		kubernetesClient := kubernetes.NewMockClient(ctrl)
+		router := Router{chi.NewRouter(), kubernetesClient, defaultTracer}
+		return kubernetesClient, router
+	}
+
+	for _, tt := range []struct {
+		name         string
+		url          string
+		prepare      func(kubernetesClient *kubernetes.MockClient)
+		handler      func(router Router) http.HandlerFunc
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "should return pods usage",
+			url:  "/usage/pods?namespace=default&labelSelector=app%3Dnginx",
+			prepare: func(c *kubernetes.MockClient) {
+				c.EXPECT().GetPodsUsage(gomock.Any(), "default", "app=nginx").Return([]usage.Pod{{Namespace: "default", Name: "nginx", CPU: usage.Resource{Usage: 50, Requests: 100, RequestsUtilization: 50}}}, nil)
+			},
+			handler:      func(router Router) http.HandlerFunc { return router.getPodsUsage },
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"namespace":"default","name":"nginx","node":"","containers":null,"cpu":{"usage":50,"requests":100,"limits":0,"requestsUtilization":50,"limitsUtilization":0},"memory":{"usage":0,"requests":0,"limits":0,"requestsUtilization":0,"limitsUtilization":0}}]`,
+		},
+		{
+			name: "should handle pods usage error",
+			url:  "/usage/pods?namespace=default",
+			prepare: func(c *kubernetes.MockClient) {
+				c.EXPECT().GetPodsUsage(gomock.Any(), "default", "").Return(nil, fmt.Errorf("unexpected error"))
+			},
+			handler:      func(router Router) http.HandlerFunc { return router.getPodsUsage },
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"errors":["Failed to get pods usage"]}`,
+		},
+		{
+			name: "should return nodes usage",
+			url:  "/usage/nodes",
+			prepare: func(c *kubernetes.MockClient) {
+				c.EXPECT().GetNodesUsage(gomock.Any()).Return([]usage.Node{{Name: "node1", Pods: 1, CPU: usage.Resource{Usage: 500, Allocatable: 1000, AllocatableUtilization: 50}}}, nil)
+			},
+			handler:      func(router Router) http.HandlerFunc { return router.getNodesUsage },
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"name":"node1","pods":1,"cpu":{"usage":500,"requests":0,"limits":0,"allocatable":1000,"requestsUtilization":0,"limitsUtilization":0,"allocatableUtilization":50},"memory":{"usage":0,"requests":0,"limits":0,"requestsUtilization":0,"limitsUtilization":0}}]`,
+		},
+		{
+			name: "should handle nodes usage error",
+			url:  "/usage/nodes",
+			prepare: func(c *kubernetes.MockClient) {
+				c.EXPECT().GetNodesUsage(gomock.Any()).Return(nil, fmt.Errorf("unexpected error"))
+			},
+			handler:      func(router Router) http.HandlerFunc { return router.getNodesUsage },
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"errors":["Failed to get nodes usage"]}`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			kubernetesClient, router := newRouter(t)
+			tt.prepare(kubernetesClient)
+
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+
+			tt.handler(router)(w, req)
+
+			utils.AssertStatusEq(t, w, tt.expectedCode)
+			utils.AssertJSONEq(t, w, tt.expectedBody)
+		})
+	}
+}
+
 func TestGetFile(t *testing.T) {
 	defaultTracer := otel.Tracer("fakeTracer")
 
@@ -514,6 +1109,43 @@ func TestGetLogs(t *testing.T) {
 	})
 }
 
+func TestGetInventory(t *testing.T) {
+	defaultTracer := otel.Tracer("fakeTracer")
+
+	var newRouter = func(t *testing.T) (*kubernetes.MockClient, Router) {
+		ctrl := gomock.NewController(t)
+		kubernetesClient := kubernetes.NewMockClient(ctrl)
+		router := Router{chi.NewRouter(), kubernetesClient, defaultTracer}
+		return kubernetesClient, router
+	}
+
+	t.Run("should handle Kubernetes client error", func(t *testing.T) {
+		kubernetesClient, router := newRouter(t)
+		kubernetesClient.EXPECT().GetInventory(gomock.Any()).Return(nil, fmt.Errorf("unexpected error"))
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/inventory", nil)
+		w := httptest.NewRecorder()
+
+		router.getInventory(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors":["Failed to get inventory"]}`)
+	})
+
+	t.Run("should return inventory", func(t *testing.T) {
+		kubernetesClient, router := newRouter(t)
+		kubernetesClient.EXPECT().GetInventory(gomock.Any()).Return(&inventory.Inventory{KubernetesVersion: "v1.27.3", NodesCount: 1, Nodes: []inventory.Node{{Name: "node1", KubeletVersion: "v1.27.3"}}, Allocatable: inventory.Resources{CPU: 2000, Memory: 4096}, CRDGroups: []string{"kobs.io"}}, nil)
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/inventory", nil)
+		w := httptest.NewRecorder()
+
+		router.getInventory(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `{"cluster":"","kubernetesVersion":"v1.27.3","platform":"","kobsVersion":"","nodesCount":1,"nodes":[{"name":"node1","kubeletVersion":"v1.27.3","osImage":"","operatingSystem":"","architecture":"","kernelVersion":"","containerRuntimeVersion":"","allocatable":{"cpu":0,"memory":0}}],"allocatable":{"cpu":2000,"memory":4096},"crdGroups":["kobs.io"],"updatedAt":0}`)
+	})
+}
+
 func TestMount(t *testing.T) {
 	router := Mount(nil)
 	require.NotNil(t, router)
@@ -40,6 +40,7 @@ type ApplicationSpec struct {
 	Topology    Topology                `json:"topology,omitempty" bson:"topology"`
 	Insights    []Insight               `json:"insights,omitempty" bson:"insights"`
 	Dashboards  []dashboardv1.Reference `json:"dashboards,omitempty" bson:"dashboards"`
+	Resources   *Resources              `json:"resources,omitempty" bson:"resources"`
 }
 
 type Link struct {
@@ -59,6 +60,15 @@ type Dependency struct {
 	Description string `json:"description,omitempty" bson:"description"`
 }
 
+// Resources selects the Kubernetes resources which belong to an application. The kinds are the ids of the resources
+// (e.g. "deployments" or "vaultsecrets.ricoberger.de" for a Custom Resource) and the resources are selected via the
+// label selector in the given namespaces. If no namespaces are set, the namespace of the application is used.
+type Resources struct {
+	Kinds         []string `json:"kinds,omitempty" bson:"kinds"`
+	Namespaces    []string `json:"namespaces,omitempty" bson:"namespaces"`
+	LabelSelector string   `json:"labelSelector" bson:"labelSelector"`
+}
+
 type Insight struct {
 	Title    string             `json:"title" bson:"title"`
 	Type     string             `json:"type" bson:"type"`
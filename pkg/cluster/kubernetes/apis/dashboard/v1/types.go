@@ -39,6 +39,7 @@ type DashboardSpec struct {
 	Placeholders []Placeholder `json:"placeholders,omitempty" bson:"placeholders"`
 	Variables    []Variable    `json:"variables,omitempty" bson:"variables"`
 	Rows         []Row         `json:"rows" bson:"rows"`
+	Problems     []string      `json:"problems,omitempty" bson:"problems"`
 }
 
 type Placeholder struct {
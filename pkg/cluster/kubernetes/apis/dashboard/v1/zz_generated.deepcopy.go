@@ -92,6 +92,11 @@ func (in *DashboardSpec) DeepCopyInto(out *DashboardSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Problems != nil {
+		in, out := &in.Problems, &out.Problems
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
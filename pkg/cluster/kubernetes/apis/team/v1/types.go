@@ -39,9 +39,34 @@ type TeamSpec struct {
 	Logo        string                  `json:"logo,omitempty" bson:"logo"`
 	Permissions userv1.Permissions      `json:"permissions,omitempty" bson:"permissions"`
 	Dashboards  []dashboardv1.Reference `json:"dashboards,omitempty" bson:"dashboards"`
+	OnCall      *OnCall                 `json:"onCall,omitempty" bson:"onCall"`
 }
 
 type Link struct {
 	Title string `json:"title" bson:"title"`
 	Link  string `json:"link" bson:"link"`
 }
+
+// OnCall contains the on-call information of a team. The current on-call participants can be resolved via an Opsgenie
+// schedule, the Slack channel and escalation contacts are static information which are shown next to them.
+type OnCall struct {
+	Opsgenie     *Opsgenie    `json:"opsgenie,omitempty" bson:"opsgenie"`
+	SlackChannel string       `json:"slackChannel,omitempty" bson:"slackChannel"`
+	Escalation   []Escalation `json:"escalation,omitempty" bson:"escalation"`
+}
+
+// Opsgenie references a schedule in an Opsgenie plugin instance. If a rotation is set, only the participants of this
+// rotation are returned, otherwise all participants of the schedule are returned.
+type Opsgenie struct {
+	Cluster  string `json:"cluster,omitempty" bson:"cluster"`
+	Name     string `json:"name" bson:"name"`
+	Schedule string `json:"schedule" bson:"schedule"`
+	Rotation string `json:"rotation,omitempty" bson:"rotation"`
+}
+
+// Escalation is a contact of a team, which can be used when the on-call participants are not reachable.
+type Escalation struct {
+	Name  string `json:"name" bson:"name"`
+	Email string `json:"email,omitempty" bson:"email"`
+	Phone string `json:"phone,omitempty" bson:"phone"`
+}
@@ -69,6 +69,23 @@ type Resources struct {
 	Verbs      []string `json:"verbs" bson:"verbs"`
 }
 
+// The following verbs can be used in the resources permissions of a user, to allow the rollout operations for
+// Deployments, StatefulSets and DaemonSets. They are checked in addition to the default "get", "patch", "post" and
+// "delete" verbs, so that a user can be allowed to restart a Deployment without being allowed to edit it.
+const (
+	VerbRestart = "restart"
+	VerbScale   = "scale"
+	VerbPause   = "pause"
+	VerbResume  = "resume"
+	VerbStatus  = "status"
+	VerbHistory = "history"
+	VerbUndo    = "undo"
+)
+
+// VerbReveal is the verb, which is required to reveal the value of a single key of a Secret or ConfigMap. The values are
+// redacted for users, which only have the "get" verb for these resources.
+const VerbReveal = "reveal"
+
 type Navigation struct {
 	Name  string           `json:"name" bson:"name"`
 	Items []NavigationItem `json:"items" bson:"items"`
@@ -0,0 +1,232 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/kobsio/kobs/pkg/instrument/log"
+
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	toolsCache "k8s.io/client-go/tools/cache"
+)
+
+type Config struct {
+	Enabled      bool          `json:"enabled" env:"ENABLED" default:"false" help:"Enable the in-memory cache for list requests of the configured resources."`
+	Resources    []string      `json:"resources" env:"RESOURCES" default:"pods,deployments,services,events" help:"The resources which should be cached. Supported resources are \"pods\", \"services\", \"events\", \"configmaps\", \"endpoints\", \"nodes\", \"namespaces\", \"deployments\", \"replicasets\", \"statefulsets\", \"daemonsets\", \"jobs\", \"cronjobs\" and \"ingresses\"."`
+	ResyncPeriod time.Duration `json:"resyncPeriod" env:"RESYNC_PERIOD" default:"10m" help:"The interval in which the informers should resync the cached resources."`
+}
+
+// resource is a resource which can be cached. Besides the group, version and resource, which are used to create the
+// informer, it contains the Kubernetes API path, which is used to find the informer for a list request and the kind of
+// the list, which is returned for a list request.
+type resource struct {
+	gvr      schema.GroupVersionResource
+	path     string
+	listKind string
+}
+
+var resources = map[string]resource{
+	"pods":         {gvr: schema.GroupVersionResource{Version: "v1", Resource: "pods"}, path: "/api/v1", listKind: "PodList"},
+	"services":     {gvr: schema.GroupVersionResource{Version: "v1", Resource: "services"}, path: "/api/v1", listKind: "ServiceList"},
+	"events":       {gvr: schema.GroupVersionResource{Version: "v1", Resource: "events"}, path: "/api/v1", listKind: "EventList"},
+	"configmaps":   {gvr: schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}, path: "/api/v1", listKind: "ConfigMapList"},
+	"endpoints":    {gvr: schema.GroupVersionResource{Version: "v1", Resource: "endpoints"}, path: "/api/v1", listKind: "EndpointsList"},
+	"nodes":        {gvr: schema.GroupVersionResource{Version: "v1", Resource: "nodes"}, path: "/api/v1", listKind: "NodeList"},
+	"namespaces":   {gvr: schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}, path: "/api/v1", listKind: "NamespaceList"},
+	"deployments":  {gvr: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, path: "/apis/apps/v1", listKind: "DeploymentList"},
+	"replicasets":  {gvr: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "replicasets"}, path: "/apis/apps/v1", listKind: "ReplicaSetList"},
+	"statefulsets": {gvr: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "statefulsets"}, path: "/apis/apps/v1", listKind: "StatefulSetList"},
+	"daemonsets":   {gvr: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "daemonsets"}, path: "/apis/apps/v1", listKind: "DaemonSetList"},
+	"jobs":         {gvr: schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}, path: "/apis/batch/v1", listKind: "JobList"},
+	"cronjobs":     {gvr: schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "cronjobs"}, path: "/apis/batch/v1", listKind: "CronJobList"},
+	"ingresses":    {gvr: schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"}, path: "/apis/networking.k8s.io/v1", listKind: "IngressList"},
+}
+
+// Cache is the interface for the in-memory cache of Kubernetes resources. The cache can be used to answer list requests
+// for the configured resources from memory instead of making a request against the Kubernetes API server.
+type Cache interface {
+	List(path, resource, namespace, labelSelector, fieldSelector string) ([]byte, bool)
+}
+
+// Info is added to each list which is returned from the cache, so that a user knows how fresh the returned data is.
+// The "updatedAt" field contains the time (in seconds since epoch) until which the cached data is known to be in sync
+// with the Kubernetes API server.
+type Info struct {
+	Cached    bool  `json:"cached"`
+	UpdatedAt int64 `json:"updatedAt"`
+}
+
+// List is the format of a list of resources, which is returned by the cache. It has the same format as a list returned
+// by the Kubernetes API server, with an additional "cache" field to indicate how fresh the data is.
+type List struct {
+	APIVersion string                      `json:"apiVersion"`
+	Kind       string                      `json:"kind"`
+	Metadata   map[string]any              `json:"metadata"`
+	Items      []unstructured.Unstructured `json:"items"`
+	Cache      Info                        `json:"cache"`
+}
+
+// informer is the informer for a single resource. It tracks the time of the last successful list or watch request
+// against the Kubernetes API server and if the last request failed, so that we know how fresh the cached data is.
+type informer struct {
+	resource resource
+	informer toolsCache.SharedIndexInformer
+	syncedAt atomic.Int64
+	failing  atomic.Bool
+}
+
+// synced must be called after each successful list or watch request of the informer.
+func (i *informer) synced() {
+	i.syncedAt.Store(time.Now().Unix())
+	i.failing.Store(false)
+}
+
+// updatedAt returns the time until which the cached data is known to be in sync with the Kubernetes API server. As long
+// as the watch of the informer is running, the cached data is up to date, so that the current time is returned. When a
+// list or watch request failed, the time of the last successful request is returned.
+func (i *informer) updatedAt() int64 {
+	if i.failing.Load() {
+		return i.syncedAt.Load()
+	}
+	return time.Now().Unix()
+}
+
+type cache struct {
+	informers map[string]*informer
+}
+
+// List returns the list of resources from the cache. The second return value is false when the request can not be
+// answered from the cache, e.g. because the resource is not cached, the informer is not synced yet or the field
+// selector contains fields which are not supported by the cache. In this case the request must be sent to the
+// Kubernetes API server.
+func (c *cache) List(path, resource, namespace, labelSelector, fieldSelector string) ([]byte, bool) {
+	i, ok := c.informers[path+"/"+resource]
+	if !ok || !i.informer.HasSynced() {
+		return nil, false
+	}
+
+	labelsSelector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, false
+	}
+
+	fieldsSelector, err := fields.ParseSelector(fieldSelector)
+	if err != nil {
+		return nil, false
+	}
+
+	// We only support the "metadata.name" and "metadata.namespace" fields in a field selector, because all other
+	// fields are resource specific and are only evaluated by the Kubernetes API server.
+	for _, requirement := range fieldsSelector.Requirements() {
+		if requirement.Field != "metadata.name" && requirement.Field != "metadata.namespace" {
+			return nil, false
+		}
+	}
+
+	var objs []any
+	if namespace != "" {
+		objs, err = i.informer.GetIndexer().ByIndex(toolsCache.NamespaceIndex, namespace)
+		if err != nil {
+			return nil, false
+		}
+	} else {
+		objs = i.informer.GetIndexer().List()
+	}
+
+	items := make([]unstructured.Unstructured, 0, len(objs))
+	for _, obj := range objs {
+		item, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+
+		if !labelsSelector.Matches(labels.Set(item.GetLabels())) {
+			continue
+		}
+
+		if !fieldsSelector.Matches(fields.Set{"metadata.name": item.GetName(), "metadata.namespace": item.GetNamespace()}) {
+			continue
+		}
+
+		items = append(items, *item)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].GetNamespace() != items[j].GetNamespace() {
+			return items[i].GetNamespace() < items[j].GetNamespace()
+		}
+		return items[i].GetName() < items[j].GetName()
+	})
+
+	data, err := json.Marshal(List{
+		APIVersion: i.resource.gvr.GroupVersion().String(),
+		Kind:       i.resource.listKind,
+		Metadata:   map[string]any{"resourceVersion": i.informer.LastSyncResourceVersion()},
+		Items:      items,
+		Cache:      Info{Cached: true, UpdatedAt: i.updatedAt()},
+	})
+	if err != nil {
+		return nil, false
+	}
+
+	return data, true
+}
+
+// New creates a new cache for the configured resources. For each resource an informer is created and started. The
+// informers are running until the given context is canceled.
+func New(ctx context.Context, config Config, client dynamic.Interface) (Cache, error) {
+	c := &cache{informers: make(map[string]*informer)}
+
+	for _, name := range config.Resources {
+		r, ok := resources[name]
+		if !ok {
+			return nil, fmt.Errorf("resource %s can not be cached", name)
+		}
+
+		i := &informer{resource: r}
+		i.informer = toolsCache.NewSharedIndexInformer(&toolsCache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				list, err := client.Resource(r.gvr).List(ctx, options)
+				if err == nil {
+					i.synced()
+				}
+				return list, err
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				w, err := client.Resource(r.gvr).Watch(ctx, options)
+				if err == nil {
+					i.synced()
+				}
+				return w, err
+			},
+		}, &unstructured.Unstructured{}, config.ResyncPeriod, toolsCache.Indexers{toolsCache.NamespaceIndex: toolsCache.MetaNamespaceIndexFunc})
+
+		if err := i.informer.SetWatchErrorHandler(func(reflector *toolsCache.Reflector, err error) {
+			i.failing.Store(true)
+			toolsCache.DefaultWatchErrorHandler(reflector, err)
+		}); err != nil {
+			return nil, err
+		}
+
+		c.informers[r.path+"/"+r.gvr.Resource] = i
+	}
+
+	log.Info(ctx, "Start informers for cache", zap.Strings("resources", config.Resources))
+	for _, i := range c.informers {
+		go i.informer.Run(ctx.Done())
+	}
+
+	return c, nil
+}
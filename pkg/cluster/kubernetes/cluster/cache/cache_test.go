@@ -0,0 +1,165 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+func newPod(namespace, name string, labels map[string]string) *unstructured.Unstructured {
+	pod := &unstructured.Unstructured{}
+	pod.SetAPIVersion("v1")
+	pod.SetKind("Pod")
+	pod.SetNamespace(namespace)
+	pod.SetName(name)
+	pod.SetLabels(labels)
+	return pod
+}
+
+func newCache(t *testing.T, objects ...runtime.Object) Cache {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	client := dynamicFake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "pods"}:                       "PodList",
+		{Group: "apps", Version: "v1", Resource: "deployments"}: "DeploymentList",
+	}, objects...)
+
+	c, err := New(ctx, Config{Resources: []string{"pods", "deployments"}, ResyncPeriod: time.Minute}, client)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		_, ok := c.List("/api/v1", "pods", "", "", "")
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+
+	return c
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should fail for unsupported resource", func(t *testing.T) {
+		client := dynamicFake.NewSimpleDynamicClient(runtime.NewScheme())
+		_, err := New(context.Background(), Config{Resources: []string{"secrets"}}, client)
+		require.Error(t, err)
+	})
+}
+
+func TestList(t *testing.T) {
+	c := newCache(t,
+		newPod("kube-system", "coredns", map[string]string{"app": "coredns"}),
+		newPod("default", "nginx-2", map[string]string{"app": "nginx"}),
+		newPod("default", "nginx-1", map[string]string{"app": "nginx"}),
+		newPod("default", "redis", map[string]string{"app": "redis"}),
+	)
+
+	getNames := func(t *testing.T, data []byte) []string {
+		var list List
+		require.NoError(t, json.Unmarshal(data, &list))
+		require.Equal(t, "v1", list.APIVersion)
+		require.Equal(t, "PodList", list.Kind)
+		require.True(t, list.Cache.Cached)
+		require.NotZero(t, list.Cache.UpdatedAt)
+
+		var names []string
+		for _, item := range list.Items {
+			names = append(names, item.GetNamespace()+"/"+item.GetName())
+		}
+		return names
+	}
+
+	for _, tt := range []struct {
+		name          string
+		path          string
+		resource      string
+		namespace     string
+		labelSelector string
+		fieldSelector string
+		expectedOk    bool
+		expectedNames []string
+	}{
+		{name: "should return all pods", path: "/api/v1", resource: "pods", expectedOk: true, expectedNames: []string{"default/nginx-1", "default/nginx-2", "default/redis", "kube-system/coredns"}},
+		{name: "should return pods in namespace", path: "/api/v1", resource: "pods", namespace: "kube-system", expectedOk: true, expectedNames: []string{"kube-system/coredns"}},
+		{name: "should return pods for label selector", path: "/api/v1", resource: "pods", namespace: "default", labelSelector: "app=nginx", expectedOk: true, expectedNames: []string{"default/nginx-1", "default/nginx-2"}},
+		{name: "should return pods for field selector", path: "/api/v1", resource: "pods", fieldSelector: "metadata.name=redis", expectedOk: true, expectedNames: []string{"default/redis"}},
+		{name: "should not return resource which is not cached", path: "/api/v1", resource: "services", expectedOk: false},
+		{name: "should not return resource for wrong path", path: "/apis/apps/v1", resource: "pods", expectedOk: false},
+		{name: "should not return for unsupported field selector", path: "/api/v1", resource: "pods", fieldSelector: "status.phase=Running", expectedOk: false},
+		{name: "should not return for invalid label selector", path: "/api/v1", resource: "pods", labelSelector: "app in (", expectedOk: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			data, ok := c.List(tt.path, tt.resource, tt.namespace, tt.labelSelector, tt.fieldSelector)
+			require.Equal(t, tt.expectedOk, ok)
+			if tt.expectedOk {
+				require.Equal(t, tt.expectedNames, getNames(t, data))
+			}
+		})
+	}
+}
+
+func TestUpdatedAt(t *testing.T) {
+	t.Run("should return current time when informer is not failing", func(t *testing.T) {
+		i := &informer{}
+		i.syncedAt.Store(time.Now().Add(-time.Hour).Unix())
+		require.GreaterOrEqual(t, i.updatedAt(), time.Now().Add(-time.Minute).Unix())
+	})
+
+	t.Run("should return time of last successful sync when informer is failing", func(t *testing.T) {
+		syncedAt := time.Now().Add(-time.Hour).Unix()
+
+		i := &informer{}
+		i.syncedAt.Store(syncedAt)
+		i.failing.Store(true)
+		require.Equal(t, syncedAt, i.updatedAt())
+
+		i.synced()
+		require.False(t, i.failing.Load())
+	})
+
+	t.Run("should mark informer as failing when list fails", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+
+		client := dynamicFake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			{Version: "v1", Resource: "pods"}: "PodList",
+		})
+		client.PrependReactor("list", "pods", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+			return true, nil, fmt.Errorf("could not list pods")
+		})
+
+		c, err := New(ctx, Config{Resources: []string{"pods"}, ResyncPeriod: time.Minute}, client)
+		require.NoError(t, err)
+
+		i := c.(*cache).informers["/api/v1/pods"]
+		require.Eventually(t, i.failing.Load, 5*time.Second, 10*time.Millisecond)
+		require.Zero(t, i.syncedAt.Load())
+
+		_, ok := c.List("/api/v1", "pods", "", "", "")
+		require.False(t, ok)
+	})
+}
+
+func TestStop(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	client := dynamicFake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "pods"}: "PodList",
+	})
+
+	c, err := New(ctx, Config{Resources: []string{"pods"}, ResyncPeriod: time.Minute}, client)
+	require.NoError(t, err)
+
+	i := c.(*cache).informers["/api/v1/pods"]
+	require.Eventually(t, i.informer.HasSynced, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.Eventually(t, i.informer.IsStopped, 5*time.Second, 10*time.Millisecond)
+}
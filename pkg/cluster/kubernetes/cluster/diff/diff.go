@@ -0,0 +1,191 @@
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"sigs.k8s.io/yaml"
+)
+
+// Change is a single difference between two objects. The path is a JSON Pointer (RFC 6901) to the changed field, e.g.
+// "/spec/replicas". The type is "add" when the field only exists in the new object, "remove" when it only exists in
+// the old object and "replace" when the value of the field was changed.
+type Change struct {
+	Path string `json:"path"`
+	Type string `json:"type"`
+	From any    `json:"from,omitempty"`
+	To   any    `json:"to,omitempty"`
+}
+
+// Objects returns the list of changes which are required to get from the "from" object to the "to" object. Both objects
+// must be the result of a "json.Unmarshal" call into an "any" value, so that they only contain maps, slices and
+// primitive types. The changes are sorted by their path, so that the result is stable between calls.
+func Objects(from, to any) []Change {
+	var changes []Change
+	compare("", from, to, &changes)
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+
+	return changes
+}
+
+func compare(path string, from, to any, changes *[]Change) {
+	fromMap, fromIsMap := from.(map[string]any)
+	toMap, toIsMap := to.(map[string]any)
+	if fromIsMap && toIsMap {
+		keys := make(map[string]struct{})
+		for key := range fromMap {
+			keys[key] = struct{}{}
+		}
+		for key := range toMap {
+			keys[key] = struct{}{}
+		}
+
+		for key := range keys {
+			fromValue, fromOk := fromMap[key]
+			toValue, toOk := toMap[key]
+			keyPath := path + "/" + escape(key)
+
+			switch {
+			case fromOk && !toOk:
+				*changes = append(*changes, Change{Path: keyPath, Type: "remove", From: fromValue})
+			case !fromOk && toOk:
+				*changes = append(*changes, Change{Path: keyPath, Type: "add", To: toValue})
+			default:
+				compare(keyPath, fromValue, toValue, changes)
+			}
+		}
+
+		return
+	}
+
+	fromSlice, fromIsSlice := from.([]any)
+	toSlice, toIsSlice := to.([]any)
+	if fromIsSlice && toIsSlice {
+		for i := 0; i < len(fromSlice) || i < len(toSlice); i++ {
+			indexPath := fmt.Sprintf("%s/%d", path, i)
+
+			switch {
+			case i >= len(toSlice):
+				*changes = append(*changes, Change{Path: indexPath, Type: "remove", From: fromSlice[i]})
+			case i >= len(fromSlice):
+				*changes = append(*changes, Change{Path: indexPath, Type: "add", To: toSlice[i]})
+			default:
+				compare(indexPath, fromSlice[i], toSlice[i], changes)
+			}
+		}
+
+		return
+	}
+
+	if !reflect.DeepEqual(from, to) {
+		if path == "" {
+			path = "/"
+		}
+		*changes = append(*changes, Change{Path: path, Type: "replace", From: from, To: to})
+	}
+}
+
+// escape escapes a single reference token of a JSON Pointer as it is defined in RFC 6901.
+func escape(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// noisyMetadataFields are the fields in the metadata of a resource, which are set by the Kubernetes API server or are
+// specific for a single cluster. They are removed by the Clean function, because they would always differ.
+var noisyMetadataFields = []string{"managedFields", "resourceVersion", "uid", "generation", "creationTimestamp", "selfLink", "namespace"}
+
+// noisyAnnotations are the annotations, which are set by kubectl or a controller and which are removed by the Clean
+// function.
+var noisyAnnotations = []string{"kubectl.kubernetes.io/last-applied-configuration", "deployment.kubernetes.io/revision"}
+
+// Clean returns a copy of the given resource without the fields, which are not relevant when the manifests of the same
+// resource in different clusters or namespaces are compared. This includes the status, the managed fields and all
+// other metadata which is set by the Kubernetes API server, like the uid or the resource version. The given resource
+// is not modified.
+func Clean(obj map[string]any) map[string]any {
+	cleaned := make(map[string]any, len(obj))
+	for key, value := range obj {
+		if key != "status" {
+			cleaned[key] = value
+		}
+	}
+
+	metadata, ok := obj["metadata"].(map[string]any)
+	if !ok {
+		return cleaned
+	}
+
+	cleanedMetadata := make(map[string]any, len(metadata))
+	for key, value := range metadata {
+		cleanedMetadata[key] = value
+	}
+	for _, field := range noisyMetadataFields {
+		delete(cleanedMetadata, field)
+	}
+
+	if annotations, ok := metadata["annotations"].(map[string]any); ok {
+		cleanedAnnotations := make(map[string]any, len(annotations))
+		for key, value := range annotations {
+			cleanedAnnotations[key] = value
+		}
+		for _, annotation := range noisyAnnotations {
+			delete(cleanedAnnotations, annotation)
+		}
+
+		if len(cleanedAnnotations) == 0 {
+			delete(cleanedMetadata, "annotations")
+		} else {
+			cleanedMetadata["annotations"] = cleanedAnnotations
+		}
+	}
+
+	if ownerReferences, ok := metadata["ownerReferences"].([]any); ok {
+		cleanedOwnerReferences := make([]any, 0, len(ownerReferences))
+		for _, ownerReference := range ownerReferences {
+			if ref, ok := ownerReference.(map[string]any); ok {
+				cleanedRef := make(map[string]any, len(ref))
+				for key, value := range ref {
+					if key != "uid" {
+						cleanedRef[key] = value
+					}
+				}
+				cleanedOwnerReferences = append(cleanedOwnerReferences, cleanedRef)
+			} else {
+				cleanedOwnerReferences = append(cleanedOwnerReferences, ownerReference)
+			}
+		}
+		cleanedMetadata["ownerReferences"] = cleanedOwnerReferences
+	}
+
+	cleaned["metadata"] = cleanedMetadata
+	return cleaned
+}
+
+// Unified returns a unified diff between the YAML representation of the "from" and "to" object. The names are used in
+// the header of the diff to identify the objects, e.g. "staging/default". If both objects are equal an empty string is
+// returned.
+func Unified(fromName, toName string, from, to any) (string, error) {
+	fromYAML, err := yaml.Marshal(from)
+	if err != nil {
+		return "", err
+	}
+
+	toYAML, err := yaml.Marshal(to)
+	if err != nil {
+		return "", err
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(strings.TrimSuffix(string(fromYAML), "\n")),
+		B:        difflib.SplitLines(strings.TrimSuffix(string(toYAML), "\n")),
+		FromFile: fromName,
+		ToFile:   toName,
+		Context:  3,
+	})
+}
@@ -0,0 +1,107 @@
+package diff
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func unmarshal(t *testing.T, data string) any {
+	var obj any
+	err := json.Unmarshal([]byte(data), &obj)
+	require.NoError(t, err)
+	return obj
+}
+
+func TestObjects(t *testing.T) {
+	t.Run("should return no changes for equal objects", func(t *testing.T) {
+		obj := unmarshal(t, `{"metadata":{"name":"nginx"},"spec":{"replicas":1,"ports":[80,443]}}`)
+		require.Nil(t, Objects(obj, obj))
+	})
+
+	t.Run("should return added, removed and replaced fields", func(t *testing.T) {
+		from := unmarshal(t, `{"metadata":{"name":"nginx","labels":{"app":"nginx"}},"spec":{"replicas":1}}`)
+		to := unmarshal(t, `{"metadata":{"name":"nginx","annotations":{"kobs.io/owner":"team1"}},"spec":{"replicas":3}}`)
+
+		require.Equal(t, []Change{
+			{Path: "/metadata/annotations", Type: "add", To: map[string]any{"kobs.io/owner": "team1"}},
+			{Path: "/metadata/labels", Type: "remove", From: map[string]any{"app": "nginx"}},
+			{Path: "/spec/replicas", Type: "replace", From: float64(1), To: float64(3)},
+		}, Objects(from, to))
+	})
+
+	t.Run("should compare slices by index", func(t *testing.T) {
+		from := unmarshal(t, `{"ports":[80,443,8080]}`)
+		to := unmarshal(t, `{"ports":[80,8443]}`)
+
+		require.Equal(t, []Change{
+			{Path: "/ports/1", Type: "replace", From: float64(443), To: float64(8443)},
+			{Path: "/ports/2", Type: "remove", From: float64(8080)},
+		}, Objects(from, to))
+	})
+
+	t.Run("should escape keys", func(t *testing.T) {
+		from := unmarshal(t, `{"labels":{"app.kubernetes.io/name":"nginx"}}`)
+		to := unmarshal(t, `{"labels":{"app.kubernetes.io/name":"httpd"}}`)
+
+		require.Equal(t, []Change{
+			{Path: "/labels/app.kubernetes.io~1name", Type: "replace", From: "nginx", To: "httpd"},
+		}, Objects(from, to))
+	})
+
+	t.Run("should handle nil objects", func(t *testing.T) {
+		to := unmarshal(t, `{"kind":"Pod"}`)
+
+		require.Equal(t, []Change{
+			{Path: "/", Type: "replace", From: nil, To: map[string]any{"kind": "Pod"}},
+		}, Objects(nil, to))
+	})
+}
+
+func TestClean(t *testing.T) {
+	t.Run("should remove noise", func(t *testing.T) {
+		obj := unmarshal(t, `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"nginx","namespace":"default","uid":"123","resourceVersion":"1","generation":2,"creationTimestamp":"2023-01-01T00:00:00Z","managedFields":[],"labels":{"app":"nginx"},"annotations":{"deployment.kubernetes.io/revision":"2","kobs.io/owner":"team1"},"ownerReferences":[{"kind":"Foo","name":"foo","uid":"456"}]},"spec":{"replicas":1},"status":{"replicas":1}}`).(map[string]any)
+
+		require.Equal(t, map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]any{
+				"name":            "nginx",
+				"labels":          map[string]any{"app": "nginx"},
+				"annotations":     map[string]any{"kobs.io/owner": "team1"},
+				"ownerReferences": []any{map[string]any{"kind": "Foo", "name": "foo"}},
+			},
+			"spec": map[string]any{"replicas": float64(1)},
+		}, Clean(obj))
+		require.Contains(t, obj, "status")
+		require.Contains(t, obj["metadata"], "uid")
+	})
+
+	t.Run("should remove empty annotations", func(t *testing.T) {
+		obj := unmarshal(t, `{"metadata":{"name":"nginx","annotations":{"kubectl.kubernetes.io/last-applied-configuration":"{}"}}}`).(map[string]any)
+		require.Equal(t, map[string]any{"metadata": map[string]any{"name": "nginx"}}, Clean(obj))
+	})
+
+	t.Run("should handle object without metadata", func(t *testing.T) {
+		require.Equal(t, map[string]any{"kind": "Pod"}, Clean(map[string]any{"kind": "Pod", "status": map[string]any{}}))
+	})
+}
+
+func TestUnified(t *testing.T) {
+	t.Run("should return empty diff for equal objects", func(t *testing.T) {
+		obj := unmarshal(t, `{"spec":{"replicas":1}}`)
+		unified, err := Unified("staging", "production", obj, obj)
+		require.NoError(t, err)
+		require.Empty(t, unified)
+	})
+
+	t.Run("should return unified diff", func(t *testing.T) {
+		from := unmarshal(t, `{"metadata":{"name":"nginx"},"spec":{"replicas":1}}`)
+		to := unmarshal(t, `{"metadata":{"name":"nginx"},"spec":{"replicas":3}}`)
+
+		unified, err := Unified("staging", "production", from, to)
+		require.NoError(t, err)
+		require.Equal(t, "--- staging\n+++ production\n@@ -1,4 +1,4 @@\n metadata:\n   name: nginx\n spec:\n-  replicas: 1\n+  replicas: 3\n", unified)
+	})
+}
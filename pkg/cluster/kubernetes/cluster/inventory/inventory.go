@@ -0,0 +1,113 @@
+// Package inventory implements the collection of metadata for a Kubernetes cluster, like the Kubernetes version, the
+// versions of the Nodes and the installed Custom Resource Definitions. The inventory is collected by the watcher for
+// all clusters, so that platform teams can track the upgrade status of their clusters.
+package inventory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/kobsio/kobs/pkg/version"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Inventory contains the metadata of a cluster. The allocatable CPU is in millicores and the allocatable memory is in
+// bytes. The cluster and updatedAt fields are set by the watcher, when the inventory is saved in the database.
+type Inventory struct {
+	Cluster           string    `json:"cluster" bson:"_id"`
+	KubernetesVersion string    `json:"kubernetesVersion" bson:"kubernetesVersion"`
+	Platform          string    `json:"platform" bson:"platform"`
+	KobsVersion       string    `json:"kobsVersion" bson:"kobsVersion"`
+	NodesCount        int       `json:"nodesCount" bson:"nodesCount"`
+	Nodes             []Node    `json:"nodes" bson:"nodes"`
+	Allocatable       Resources `json:"allocatable" bson:"allocatable"`
+	CRDGroups         []string  `json:"crdGroups" bson:"crdGroups"`
+	UpdatedAt         int64     `json:"updatedAt" bson:"updatedAt"`
+}
+
+// Node contains the versions of the operating system, kernel, container runtime and kubelet of a single Node.
+type Node struct {
+	Name                    string    `json:"name" bson:"name"`
+	KubeletVersion          string    `json:"kubeletVersion" bson:"kubeletVersion"`
+	OSImage                 string    `json:"osImage" bson:"osImage"`
+	OperatingSystem         string    `json:"operatingSystem" bson:"operatingSystem"`
+	Architecture            string    `json:"architecture" bson:"architecture"`
+	KernelVersion           string    `json:"kernelVersion" bson:"kernelVersion"`
+	ContainerRuntimeVersion string    `json:"containerRuntimeVersion" bson:"containerRuntimeVersion"`
+	Allocatable             Resources `json:"allocatable" bson:"allocatable"`
+}
+
+// Resources are the allocatable CPU (in millicores) and memory (in bytes) of a Node or of all Nodes of a cluster.
+type Resources struct {
+	CPU    int64 `json:"cpu" bson:"cpu"`
+	Memory int64 `json:"memory" bson:"memory"`
+}
+
+// Get returns the inventory of the cluster. The Kubernetes version is retrieved via the discovery API and the versions of
+// the Nodes are retrieved from the node info of each Node. The groups of the installed Custom Resource Definitions must
+// be passed to the function, because they are already loaded by the Kubernetes client.
+func Get(ctx context.Context, clientset kubernetes.Interface, crdGroups []string) (*Inventory, error) {
+	serverVersion, err := clientset.Discovery().ServerVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	nodeList, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	inventory := &Inventory{
+		KubernetesVersion: serverVersion.GitVersion,
+		Platform:          serverVersion.Platform,
+		KobsVersion:       version.Version,
+		NodesCount:        len(nodeList.Items),
+		Nodes:             []Node{},
+		CRDGroups:         uniqueSortedGroups(crdGroups),
+	}
+
+	for _, node := range nodeList.Items {
+		allocatable := Resources{
+			CPU:    node.Status.Allocatable.Cpu().MilliValue(),
+			Memory: node.Status.Allocatable.Memory().Value(),
+		}
+
+		inventory.Allocatable.CPU += allocatable.CPU
+		inventory.Allocatable.Memory += allocatable.Memory
+
+		inventory.Nodes = append(inventory.Nodes, Node{
+			Name:                    node.Name,
+			KubeletVersion:          node.Status.NodeInfo.KubeletVersion,
+			OSImage:                 node.Status.NodeInfo.OSImage,
+			OperatingSystem:         node.Status.NodeInfo.OperatingSystem,
+			Architecture:            node.Status.NodeInfo.Architecture,
+			KernelVersion:           node.Status.NodeInfo.KernelVersion,
+			ContainerRuntimeVersion: node.Status.NodeInfo.ContainerRuntimeVersion,
+			Allocatable:             allocatable,
+		})
+	}
+
+	sort.Slice(inventory.Nodes, func(i, j int) bool {
+		return inventory.Nodes[i].Name < inventory.Nodes[j].Name
+	})
+
+	return inventory, nil
+}
+
+// uniqueSortedGroups returns the provided groups without duplicates in alphabetical order.
+func uniqueSortedGroups(groups []string) []string {
+	uniqueGroups := []string{}
+	seen := make(map[string]bool)
+
+	for _, group := range groups {
+		if group != "" && !seen[group] {
+			seen[group] = true
+			uniqueGroups = append(uniqueGroups, group)
+		}
+	}
+
+	sort.Strings(uniqueGroups)
+	return uniqueGroups
+}
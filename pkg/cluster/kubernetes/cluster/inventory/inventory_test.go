@@ -0,0 +1,68 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kobsio/kobs/pkg/version"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachineryVersion "k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newNode(name, kubeletVersion, cpu, memory string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+			},
+			NodeInfo: corev1.NodeSystemInfo{
+				KubeletVersion:          kubeletVersion,
+				OSImage:                 "Ubuntu 22.04.3 LTS",
+				OperatingSystem:         "linux",
+				Architecture:            "amd64",
+				KernelVersion:           "5.15.0-1051-azure",
+				ContainerRuntimeVersion: "containerd://1.7.5",
+			},
+		},
+	}
+}
+
+func TestGet(t *testing.T) {
+	originalVersion := version.Version
+	t.Cleanup(func() { version.Version = originalVersion })
+	version.Version = "v0.12.0"
+
+	clientset := fake.NewSimpleClientset(
+		newNode("node2", "v1.27.3", "1900m", "4Gi"),
+		newNode("node1", "v1.26.6", "3", "8Gi"),
+	)
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &apimachineryVersion.Info{GitVersion: "v1.27.3", Platform: "linux/amd64"}
+
+	inventory, err := Get(context.Background(), clientset, []string{"kobs.io", "", "cert-manager.io", "kobs.io"})
+	require.NoError(t, err)
+	require.Equal(t, "v1.27.3", inventory.KubernetesVersion)
+	require.Equal(t, "linux/amd64", inventory.Platform)
+	require.Equal(t, "v0.12.0", inventory.KobsVersion)
+	require.Equal(t, 2, inventory.NodesCount)
+	require.Equal(t, Resources{CPU: 4900, Memory: 12 * 1024 * 1024 * 1024}, inventory.Allocatable)
+	require.Equal(t, []string{"cert-manager.io", "kobs.io"}, inventory.CRDGroups)
+	require.Equal(t, Node{
+		Name:                    "node1",
+		KubeletVersion:          "v1.26.6",
+		OSImage:                 "Ubuntu 22.04.3 LTS",
+		OperatingSystem:         "linux",
+		Architecture:            "amd64",
+		KernelVersion:           "5.15.0-1051-azure",
+		ContainerRuntimeVersion: "containerd://1.7.5",
+		Allocatable:             Resources{CPU: 3000, Memory: 8 * 1024 * 1024 * 1024},
+	}, inventory.Nodes[0])
+	require.Equal(t, "node2", inventory.Nodes[1].Name)
+}
@@ -0,0 +1,19 @@
+package owner
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// IsOwnedBy returns true when one of the given owner references points to the object with the given uid. It is used to
+// find the objects which are controlled by another object, e.g. the ReplicaSets of a Deployment, because the label
+// selector of the owner can also match objects of other owners.
+func IsOwnedBy(ownerReferences []metav1.OwnerReference, uid types.UID) bool {
+	for _, ownerReference := range ownerReferences {
+		if ownerReference.UID == uid {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,16 @@
+package owner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIsOwnedBy(t *testing.T) {
+	ownerReferences := []metav1.OwnerReference{{UID: "uid1"}, {UID: "uid2"}}
+
+	require.True(t, IsOwnedBy(ownerReferences, "uid2"))
+	require.False(t, IsOwnedBy(ownerReferences, "uid3"))
+	require.False(t, IsOwnedBy(nil, "uid1"))
+}
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/kobsio/kobs/pkg/instrument/log"
 
@@ -13,11 +14,50 @@ import (
 type Config struct {
 	Type       string `json:"type" env:"TYPE" enum:"incluster,kubeconfig" default:"incluster" help:"The provider which should be used for the Kubernetes cluster. Must be \"incluster\" or \"kubeconfig\"."`
 	Kubeconfig struct {
-		Path    string `json:"path" env:"PATH" default:"" help:"The path to the Kubeconfig file, which should be used when the provider is \"kubeconfig\"."`
-		Context string `json:"context" env:"CONTEXT" default:"" help:"The context, which should be used from the Kubeconfig file, when the provider is \"kubeconfig\""`
+		Path     string   `json:"path" env:"PATH" default:"" help:"The path to the Kubeconfig file, which should be used when the provider is \"kubeconfig\"."`
+		Context  string   `json:"context" env:"CONTEXT" default:"" help:"The context, which should be used from the Kubeconfig file, when the provider is \"kubeconfig\""`
+		Contexts []string `json:"contexts" env:"CONTEXTS" help:"A list of contexts from the Kubeconfig file, which should be served as separate clusters, when the provider is \"kubeconfig\". The name of each cluster is the name of the context."`
 	} `json:"kubeconfig" embed:"" prefix:"kubeconfig." envprefix:"KUBECONFIG_"`
 }
 
+// GetContexts returns the names of all contexts, which should be served as separate clusters. If the "contexts" field is
+// not set, the returned list is empty and the cluster is served with the "context" field or the in-cluster
+// configuration.
+func (c Config) GetContexts() ([]string, error) {
+	if len(c.Kubeconfig.Contexts) == 0 {
+		return nil, nil
+	}
+
+	if c.Type != "kubeconfig" {
+		return nil, fmt.Errorf("contexts are only supported for the \"kubeconfig\" provider")
+	}
+
+	if c.Kubeconfig.Context != "" {
+		return nil, fmt.Errorf("context and contexts can not be used together")
+	}
+
+	seen := make(map[string]bool)
+	for _, name := range c.Kubeconfig.Contexts {
+		if name == "" {
+			return nil, fmt.Errorf("context name can not be empty")
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("context %s is configured multiple times", name)
+		}
+		seen[name] = true
+	}
+
+	return c.Kubeconfig.Contexts, nil
+}
+
+// WithContext returns a copy of the provider configuration, which only uses the given context from the Kubeconfig
+// file. It is used to create a separate Kubernetes client for each of the configured contexts.
+func (c Config) WithContext(name string) Config {
+	c.Kubeconfig.Context = name
+	c.Kubeconfig.Contexts = nil
+	return c
+}
+
 func NewRestConfig(config Config) (*rest.Config, error) {
 	log.Debug(context.Background(), "Create rest config", zap.String("provider", config.Type))
 
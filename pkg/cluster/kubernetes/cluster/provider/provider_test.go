@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetContexts(t *testing.T) {
+	newConfig := func(providerType, context string, contexts []string) Config {
+		config := Config{Type: providerType}
+		config.Kubeconfig.Context = context
+		config.Kubeconfig.Contexts = contexts
+		return config
+	}
+
+	for _, tt := range []struct {
+		name             string
+		config           Config
+		expectedContexts []string
+		expectedErr      string
+	}{
+		{name: "should return no contexts", config: newConfig("incluster", "", nil)},
+		{name: "should return contexts", config: newConfig("kubeconfig", "", []string{"kind-dev", "kind-stage"}), expectedContexts: []string{"kind-dev", "kind-stage"}},
+		{name: "should fail for incluster provider", config: newConfig("incluster", "", []string{"kind-dev"}), expectedErr: "contexts are only supported for the \"kubeconfig\" provider"},
+		{name: "should fail when context and contexts are set", config: newConfig("kubeconfig", "kind-dev", []string{"kind-dev"}), expectedErr: "context and contexts can not be used together"},
+		{name: "should fail for empty context", config: newConfig("kubeconfig", "", []string{""}), expectedErr: "context name can not be empty"},
+		{name: "should fail for duplicated context", config: newConfig("kubeconfig", "", []string{"kind-dev", "kind-dev"}), expectedErr: "context kind-dev is configured multiple times"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			contexts, err := tt.config.GetContexts()
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedContexts, contexts)
+			}
+		})
+	}
+}
+
+func TestWithContext(t *testing.T) {
+	config := Config{Type: "kubeconfig"}
+	config.Kubeconfig.Path = "/kubeconfig"
+	config.Kubeconfig.Contexts = []string{"kind-dev", "kind-stage"}
+
+	contextConfig := config.WithContext("kind-stage")
+	require.Equal(t, "kubeconfig", contextConfig.Type)
+	require.Equal(t, "/kubeconfig", contextConfig.Kubeconfig.Path)
+	require.Equal(t, "kind-stage", contextConfig.Kubeconfig.Context)
+	require.Nil(t, contextConfig.Kubeconfig.Contexts)
+	require.Equal(t, []string{"kind-dev", "kind-stage"}, config.Kubeconfig.Contexts)
+}
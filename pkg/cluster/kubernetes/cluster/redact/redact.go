@@ -0,0 +1,341 @@
+package redact
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/diff"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Placeholder is the value, which is used instead of the redacted values. For the data of Secrets and the binary
+	// data of ConfigMaps the placeholder is base64 encoded, so that clients which decode the values can still show the
+	// placeholder.
+	Placeholder = "[redacted]"
+
+	// Annotation is added to all resources where at least one value was redacted, so that clients know that the
+	// resource can not be applied as it is.
+	Annotation = "kobs.io/redacted"
+
+	lastAppliedConfigurationAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+// Config is the configuration for the redaction of Secrets and ConfigMaps. By default the values of all Secrets are
+// redacted. The values of ConfigMaps are only redacted when the name of the ConfigMap or the key is matching one of the
+// configured regular expressions.
+type Config struct {
+	Disabled       bool     `json:"disabled" env:"DISABLED" default:"false" help:"Disable the redaction of Secret values."`
+	ConfigMapNames []string `json:"configMapNames" name:"configmap-names" env:"CONFIGMAP_NAMES" help:"A list of regular expressions. The values of all ConfigMaps with a name matching one of the expressions are redacted."`
+	ConfigMapKeys  []string `json:"configMapKeys" name:"configmap-keys" env:"CONFIGMAP_KEYS" help:"A list of regular expressions. The values of all ConfigMap keys matching one of the expressions are redacted."`
+}
+
+// Redactor redacts the values of Secrets and ConfigMaps. A nil Redactor uses the default configuration, so that the
+// values of Secrets are always redacted.
+type Redactor struct {
+	disabled       bool
+	configMapNames []*regexp.Regexp
+	configMapKeys  []*regexp.Regexp
+}
+
+// Redact redacts the values in the provided JSON manifest, which can be a single resource or a list of resources as it
+// is returned by the Kubernetes API. Only Secrets and ConfigMaps from the core API group are redacted, for all other
+// resources the manifest is returned without modifications.
+func (r *Redactor) Redact(path, resource string, manifest []byte) ([]byte, error) {
+	if !r.enabled(path, resource) {
+		return manifest, nil
+	}
+
+	var obj map[string]any
+	if err := json.Unmarshal(manifest, &obj); err != nil {
+		return nil, err
+	}
+
+	redacted := false
+
+	if items, ok := obj["items"].([]any); ok {
+		for _, item := range items {
+			if itemObj, ok := item.(map[string]any); ok && r.redactObject(resource, itemObj) {
+				redacted = true
+			}
+		}
+	} else {
+		redacted = r.redactObject(resource, obj)
+	}
+
+	if !redacted {
+		return manifest, nil
+	}
+
+	return json.Marshal(obj)
+}
+
+// RedactObject redacts the values of a single resource, which was already decoded. The object is modified in place. It
+// returns true when at least one value was redacted.
+func (r *Redactor) RedactObject(path, resource string, obj map[string]any) bool {
+	if !r.enabled(path, resource) || obj == nil {
+		return false
+	}
+
+	return r.redactObject(resource, obj)
+}
+
+// RedactChanges redacts the values of the provided changes between two versions of the resource with the provided
+// name, e.g. the changes of a dry-run apply. The changes are modified in place. Because a change can contain a single
+// value, a map of values or a complete field like the metadata of the resource, each value is placed at its path in an
+// otherwise empty resource, which is then redacted like a normal resource.
+func (r *Redactor) RedactChanges(path, resource, name string, changes []diff.Change) {
+	if !r.enabled(path, resource) {
+		return
+	}
+
+	for i := range changes {
+		tokens := splitPointer(changes[i].Path)
+		if len(tokens) == 0 {
+			continue
+		}
+
+		changes[i].From = r.redactChangeValue(resource, name, tokens, changes[i].From)
+		changes[i].To = r.redactChangeValue(resource, name, tokens, changes[i].To)
+	}
+}
+
+// redactChangeValue returns the redacted value of a change at the path, which is defined by the provided tokens.
+func (r *Redactor) redactChangeValue(resource, name string, tokens []string, value any) any {
+	if value == nil {
+		return nil
+	}
+
+	// The value is copied, so that we do not modify the objects from which the changes were created.
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	var copied any
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil
+	}
+
+	obj := make(map[string]any)
+	current := obj
+	for _, token := range tokens[:len(tokens)-1] {
+		next := make(map[string]any)
+		current[token] = next
+		current = next
+	}
+	current[tokens[len(tokens)-1]] = copied
+
+	metadata, ok := obj["metadata"].(map[string]any)
+	if !ok {
+		metadata = make(map[string]any)
+		obj["metadata"] = metadata
+	}
+	if _, ok := metadata["name"]; !ok {
+		metadata["name"] = name
+	}
+
+	r.redactData(resource, obj)
+
+	// The last applied configuration contains all values of the resource, so that it is always removed from the
+	// changes, even when no other value of the change was redacted.
+	if annotations, ok := metadata["annotations"].(map[string]any); ok {
+		delete(annotations, lastAppliedConfigurationAnnotation)
+	}
+
+	var result any = obj
+	for _, token := range tokens {
+		m, ok := result.(map[string]any)
+		if !ok {
+			return nil
+		}
+		result = m[token]
+	}
+
+	return result
+}
+
+// enabled returns true when the values of the provided resource must be redacted. Only Secrets and ConfigMaps from the
+// core API group are redacted. The values of ConfigMaps are only redacted when at least one pattern is configured.
+func (r *Redactor) enabled(path, resource string) bool {
+	if path != "/api/v1" || (resource != "secrets" && resource != "configmaps") {
+		return false
+	}
+
+	if resource == "secrets" && r != nil && r.disabled {
+		return false
+	}
+
+	if resource == "configmaps" && (r == nil || (len(r.configMapNames) == 0 && len(r.configMapKeys) == 0)) {
+		return false
+	}
+
+	return true
+}
+
+// redactObject redacts the values of a single Secret or ConfigMap and marks the object as redacted. It returns true when
+// at least one value was redacted.
+func (r *Redactor) redactObject(resource string, obj map[string]any) bool {
+	redacted := r.redactData(resource, obj)
+
+	if redacted {
+		metadata, ok := obj["metadata"].(map[string]any)
+		if !ok {
+			metadata = make(map[string]any)
+			obj["metadata"] = metadata
+		}
+
+		annotations, ok := metadata["annotations"].(map[string]any)
+		if !ok {
+			annotations = make(map[string]any)
+			metadata["annotations"] = annotations
+		}
+
+		// The last applied configuration contains all values of the resource, so that we have to remove it.
+		delete(annotations, lastAppliedConfigurationAnnotation)
+		annotations[Annotation] = "true"
+	}
+
+	return redacted
+}
+
+// redactData redacts the values of a single Secret or ConfigMap, without marking the object as redacted. It returns
+// true when at least one value was redacted.
+func (r *Redactor) redactData(resource string, obj map[string]any) bool {
+	redacted := false
+
+	if resource == "secrets" {
+		for _, field := range []string{"data", "stringData"} {
+			if redactValues(obj, field, func(key string) bool { return true }, field == "data") {
+				redacted = true
+			}
+		}
+	} else {
+		name := ""
+		if metadata, ok := obj["metadata"].(map[string]any); ok {
+			name, _ = metadata["name"].(string)
+		}
+
+		matchName := r.matchConfigMapName(name)
+		matchKey := func(key string) bool { return matchName || r.matchConfigMapKey(key) }
+
+		if redactValues(obj, "data", matchKey, false) {
+			redacted = true
+		}
+		if redactValues(obj, "binaryData", matchKey, true) {
+			redacted = true
+		}
+	}
+
+	return redacted
+}
+
+func (r *Redactor) matchConfigMapName(name string) bool {
+	if r == nil {
+		return false
+	}
+
+	for _, re := range r.configMapNames {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Redactor) matchConfigMapKey(key string) bool {
+	if r == nil {
+		return false
+	}
+
+	for _, re := range r.configMapKeys {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactValues replaces all values in the given field of the object, for which the match function returns true, with
+// the placeholder. It returns true when at least one value was redacted.
+func redactValues(obj map[string]any, field string, match func(key string) bool, encoded bool) bool {
+	values, ok := obj[field].(map[string]any)
+	if !ok {
+		return false
+	}
+
+	placeholder := Placeholder
+	if encoded {
+		placeholder = base64.StdEncoding.EncodeToString([]byte(Placeholder))
+	}
+
+	redacted := false
+	for key := range values {
+		if match(key) {
+			values[key] = placeholder
+			redacted = true
+		}
+	}
+
+	return redacted
+}
+
+// splitPointer splits the provided JSON Pointer (RFC 6901) into its unescaped reference tokens.
+func splitPointer(pointer string) []string {
+	if pointer == "" || pointer == "/" {
+		return nil
+	}
+
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+
+	return tokens
+}
+
+// IsRedacted returns true when the provided JSON or YAML manifest contains the redaction annotation. Such manifests
+// contain the placeholder instead of the real values, so that they must not be applied.
+func IsRedacted(manifest []byte) bool {
+	var obj struct {
+		Metadata struct {
+			Annotations map[string]string `json:"annotations"`
+		} `json:"metadata"`
+	}
+
+	if err := yaml.Unmarshal(manifest, &obj); err != nil {
+		return false
+	}
+
+	_, ok := obj.Metadata.Annotations[Annotation]
+	return ok
+}
+
+// New returns a new Redactor for the provided configuration. An error is returned when one of the regular expressions
+// is invalid.
+func New(config Config) (*Redactor, error) {
+	redactor := &Redactor{disabled: config.Disabled}
+
+	for _, expr := range config.ConfigMapNames {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ConfigMap name expression %q: %w", expr, err)
+		}
+		redactor.configMapNames = append(redactor.configMapNames, re)
+	}
+
+	for _, expr := range config.ConfigMapKeys {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ConfigMap key expression %q: %w", expr, err)
+		}
+		redactor.configMapKeys = append(redactor.configMapKeys, re)
+	}
+
+	return redactor, nil
+}
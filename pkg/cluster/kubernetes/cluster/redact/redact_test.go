@@ -0,0 +1,151 @@
+package redact
+
+import (
+	"testing"
+
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/diff"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedact(t *testing.T) {
+	encodedPlaceholder := "W3JlZGFjdGVkXQ=="
+
+	for _, tt := range []struct {
+		name             string
+		config           Config
+		path             string
+		resource         string
+		manifest         string
+		expectedManifest string
+	}{
+		{
+			name:             "should not redact other resources",
+			path:             "/apis/apps/v1",
+			resource:         "deployments",
+			manifest:         `{"data":{"password":"c2VjcmV0"}}`,
+			expectedManifest: `{"data":{"password":"c2VjcmV0"}}`,
+		},
+		{
+			name:             "should redact secret",
+			path:             "/api/v1",
+			resource:         "secrets",
+			manifest:         `{"metadata":{"name":"db","annotations":{"kubectl.kubernetes.io/last-applied-configuration":"{}"}},"data":{"password":"c2VjcmV0"}}`,
+			expectedManifest: `{"metadata":{"name":"db","annotations":{"kobs.io/redacted":"true"}},"data":{"password":"` + encodedPlaceholder + `"}}`,
+		},
+		{
+			name:             "should redact list of secrets",
+			path:             "/api/v1",
+			resource:         "secrets",
+			manifest:         `{"items":[{"metadata":{"name":"db"},"data":{"password":"c2VjcmV0"}},{"metadata":{"name":"empty"}}]}`,
+			expectedManifest: `{"items":[{"metadata":{"name":"db","annotations":{"kobs.io/redacted":"true"}},"data":{"password":"` + encodedPlaceholder + `"}},{"metadata":{"name":"empty"}}]}`,
+		},
+		{
+			name:             "should not redact secret when disabled",
+			config:           Config{Disabled: true},
+			path:             "/api/v1",
+			resource:         "secrets",
+			manifest:         `{"data":{"password":"c2VjcmV0"}}`,
+			expectedManifest: `{"data":{"password":"c2VjcmV0"}}`,
+		},
+		{
+			name:             "should not redact config map without patterns",
+			path:             "/api/v1",
+			resource:         "configmaps",
+			manifest:         `{"data":{"password":"secret"}}`,
+			expectedManifest: `{"data":{"password":"secret"}}`,
+		},
+		{
+			name:             "should redact config map keys",
+			config:           Config{ConfigMapKeys: []string{"(?i)password|token"}},
+			path:             "/api/v1",
+			resource:         "configmaps",
+			manifest:         `{"metadata":{"name":"app"},"data":{"PASSWORD":"secret","host":"localhost"}}`,
+			expectedManifest: `{"metadata":{"name":"app","annotations":{"kobs.io/redacted":"true"}},"data":{"PASSWORD":"[redacted]","host":"localhost"}}`,
+		},
+		{
+			name:             "should redact config map by name",
+			config:           Config{ConfigMapNames: []string{"-credentials$"}},
+			path:             "/api/v1",
+			resource:         "configmaps",
+			manifest:         `{"items":[{"metadata":{"name":"app-credentials"},"data":{"host":"localhost"},"binaryData":{"cert":"Y2VydA=="}},{"metadata":{"name":"app"},"data":{"host":"localhost"}}]}`,
+			expectedManifest: `{"items":[{"metadata":{"name":"app-credentials","annotations":{"kobs.io/redacted":"true"}},"data":{"host":"[redacted]"},"binaryData":{"cert":"` + encodedPlaceholder + `"}},{"metadata":{"name":"app"},"data":{"host":"localhost"}}]}`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			redactor, err := New(tt.config)
+			require.NoError(t, err)
+
+			manifest, err := redactor.Redact(tt.path, tt.resource, []byte(tt.manifest))
+			require.NoError(t, err)
+			require.JSONEq(t, tt.expectedManifest, string(manifest))
+		})
+	}
+
+	t.Run("should redact secrets with nil redactor", func(t *testing.T) {
+		var redactor *Redactor
+		manifest, err := redactor.Redact("/api/v1", "secrets", []byte(`{"data":{"password":"c2VjcmV0"}}`))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"metadata":{"annotations":{"kobs.io/redacted":"true"}},"data":{"password":"`+encodedPlaceholder+`"}}`, string(manifest))
+	})
+
+	t.Run("should return error for invalid manifest", func(t *testing.T) {
+		redactor, _ := New(Config{})
+		_, err := redactor.Redact("/api/v1", "secrets", []byte(`{"data"`))
+		require.Error(t, err)
+	})
+}
+
+func TestRedactChanges(t *testing.T) {
+	t.Run("should redact changes of config map with matching name", func(t *testing.T) {
+		redactor, err := New(Config{ConfigMapNames: []string{"^credentials$"}})
+		require.NoError(t, err)
+
+		changes := []diff.Change{
+			{Path: "/data/token", Type: "replace", From: "old", To: "new"},
+			{Path: "/metadata/labels/app", Type: "add", To: "kobs"},
+		}
+		redactor.RedactChanges("/api/v1", "configmaps", "credentials", changes)
+		require.Equal(t, []diff.Change{
+			{Path: "/data/token", Type: "replace", From: Placeholder, To: Placeholder},
+			{Path: "/metadata/labels/app", Type: "add", To: "kobs"},
+		}, changes)
+	})
+
+	t.Run("should not redact changes of config map with other name", func(t *testing.T) {
+		redactor, err := New(Config{ConfigMapNames: []string{"^credentials$"}})
+		require.NoError(t, err)
+
+		changes := []diff.Change{{Path: "/data/token", Type: "replace", From: "old", To: "new"}}
+		redactor.RedactChanges("/api/v1", "configmaps", "app", changes)
+		require.Equal(t, []diff.Change{{Path: "/data/token", Type: "replace", From: "old", To: "new"}}, changes)
+	})
+
+	t.Run("should redact complete data of new secret", func(t *testing.T) {
+		var redactor *Redactor
+
+		changes := []diff.Change{{Path: "/data", Type: "add", To: map[string]any{"password": "c2VjcmV0"}}}
+		redactor.RedactChanges("/api/v1", "secrets", "db", changes)
+		require.Equal(t, []diff.Change{{Path: "/data", Type: "add", To: map[string]any{"password": "W3JlZGFjdGVkXQ=="}}}, changes)
+	})
+}
+
+func TestIsRedacted(t *testing.T) {
+	require.True(t, IsRedacted([]byte(`{"metadata":{"annotations":{"kobs.io/redacted":"true"}}}`)))
+	require.True(t, IsRedacted([]byte("metadata:\n  annotations:\n    kobs.io/redacted: \"true\"\n")))
+	require.False(t, IsRedacted([]byte(`{"metadata":{"name":"db"}}`)))
+	require.False(t, IsRedacted([]byte(`invalid: [`)))
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(Config{ConfigMapNames: []string{"("}})
+	require.Error(t, err)
+
+	_, err = New(Config{ConfigMapKeys: []string{"("}})
+	require.Error(t, err)
+
+	redactor, err := New(Config{ConfigMapNames: []string{"credentials"}, ConfigMapKeys: []string{"password"}})
+	require.NoError(t, err)
+	require.Len(t, redactor.configMapNames, 1)
+	require.Len(t, redactor.configMapKeys, 1)
+}
@@ -0,0 +1,355 @@
+package related
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/owner"
+	"github.com/kobsio/kobs/pkg/utils"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	kubernetesErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Resource is a single Kubernetes object together with the Events for this object and all the objects which are related
+// to it. For example the related objects of a Deployment are its ReplicaSets and the related objects of a ReplicaSet
+// are its Pods.
+type Resource struct {
+	APIVersion string         `json:"apiVersion"`
+	Kind       string         `json:"kind"`
+	Namespace  string         `json:"namespace"`
+	Name       string         `json:"name"`
+	Manifest   any            `json:"manifest"`
+	Events     []corev1.Event `json:"events,omitempty"`
+	Related    []Resource     `json:"related,omitempty"`
+}
+
+// Resources is the list of resources for which the related objects can be returned.
+var Resources = []string{"deployments", "statefulsets", "daemonsets", "replicasets", "pods", "services", "endpoints", "ingresses"}
+
+// walker is used to walk from an object to all its related objects. It contains all Events of the namespace, so that we
+// only have to list the Events once and can then attach them to each object via the uid of the involved object.
+type walker struct {
+	clientset kubernetes.Interface
+	namespace string
+	events    map[types.UID][]corev1.Event
+}
+
+// Get returns the object with the given name together with all its related objects. The related objects are found by
+// walking the owner references and label selectors of the objects:
+//   - Deployment  --> ReplicaSet --> Pod
+//   - StatefulSet --> Pod
+//   - DaemonSet   --> Pod
+//   - Service     --> Endpoints  --> Pod
+//   - Ingress     --> Service    --> Endpoints --> Pod
+func Get(ctx context.Context, clientset kubernetes.Interface, namespace, name, resource string) (*Resource, error) {
+	eventList, err := clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	w := walker{
+		clientset: clientset,
+		namespace: namespace,
+		events:    make(map[types.UID][]corev1.Event),
+	}
+
+	for _, event := range eventList.Items {
+		w.events[event.InvolvedObject.UID] = append(w.events[event.InvolvedObject.UID], event)
+	}
+
+	for uid := range w.events {
+		sort.SliceStable(w.events[uid], func(i, j int) bool {
+			return w.events[uid][i].LastTimestamp.Before(&w.events[uid][j].LastTimestamp)
+		})
+	}
+
+	switch resource {
+	case "deployments":
+		return w.deployment(ctx, name)
+	case "statefulsets":
+		return w.statefulSet(ctx, name)
+	case "daemonsets":
+		return w.daemonSet(ctx, name)
+	case "replicasets":
+		return w.replicaSet(ctx, name)
+	case "pods":
+		return w.pod(ctx, name)
+	case "services":
+		return w.service(ctx, name)
+	case "endpoints":
+		return w.endpoints(ctx, name, nil)
+	case "ingresses":
+		return w.ingress(ctx, name)
+	default:
+		return nil, fmt.Errorf("resource %s is not supported", resource)
+	}
+}
+
+func (w *walker) newResource(apiVersion, kind string, obj metav1.Object, manifest any, related []Resource) *Resource {
+	return &Resource{
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		Manifest:   manifest,
+		Events:     w.events[obj.GetUID()],
+		Related:    related,
+	}
+}
+
+// ownedPods returns all Pods which are matching the given selector and which are owned by the object with the given
+// uid.
+func (w *walker) ownedPods(ctx context.Context, selector *metav1.LabelSelector, uid types.UID) ([]Resource, error) {
+	pods, err := w.listPods(ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var related []Resource
+	for i := range pods {
+		if owner.IsOwnedBy(pods[i].OwnerReferences, uid) {
+			related = append(related, *w.newResource("v1", "Pod", &pods[i], pods[i], nil))
+		}
+	}
+
+	return related, nil
+}
+
+func (w *walker) listPods(ctx context.Context, selector *metav1.LabelSelector) ([]corev1.Pod, error) {
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	podList, err := w.clientset.CoreV1().Pods(w.namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	return podList.Items, nil
+}
+
+func (w *walker) deployment(ctx context.Context, name string) (*Resource, error) {
+	deployment, err := w.clientset.AppsV1().Deployments(w.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	labelSelector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	replicaSetList, err := w.clientset.AppsV1().ReplicaSets(w.namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	// All Pods of the Deployment are matching the selector of the Deployment, so that we only have to list them once
+	// and can then assign them to the ReplicaSets via the owner references.
+	pods, err := w.listPods(ctx, deployment.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var related []Resource
+	for i := range replicaSetList.Items {
+		if owner.IsOwnedBy(replicaSetList.Items[i].OwnerReferences, deployment.UID) {
+			related = append(related, *w.replicaSetWithPods(&replicaSetList.Items[i], pods))
+		}
+	}
+
+	return w.newResource("apps/v1", "Deployment", deployment, deployment, related), nil
+}
+
+func (w *walker) statefulSet(ctx context.Context, name string) (*Resource, error) {
+	statefulSet, err := w.clientset.AppsV1().StatefulSets(w.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	related, err := w.ownedPods(ctx, statefulSet.Spec.Selector, statefulSet.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.newResource("apps/v1", "StatefulSet", statefulSet, statefulSet, related), nil
+}
+
+func (w *walker) daemonSet(ctx context.Context, name string) (*Resource, error) {
+	daemonSet, err := w.clientset.AppsV1().DaemonSets(w.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	related, err := w.ownedPods(ctx, daemonSet.Spec.Selector, daemonSet.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.newResource("apps/v1", "DaemonSet", daemonSet, daemonSet, related), nil
+}
+
+func (w *walker) replicaSet(ctx context.Context, name string) (*Resource, error) {
+	replicaSet, err := w.clientset.AppsV1().ReplicaSets(w.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := w.listPods(ctx, replicaSet.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.replicaSetWithPods(replicaSet, pods), nil
+}
+
+func (w *walker) replicaSetWithPods(replicaSet *appsv1.ReplicaSet, pods []corev1.Pod) *Resource {
+	var related []Resource
+	for i := range pods {
+		if owner.IsOwnedBy(pods[i].OwnerReferences, replicaSet.UID) {
+			related = append(related, *w.newResource("v1", "Pod", &pods[i], pods[i], nil))
+		}
+	}
+
+	return w.newResource("apps/v1", "ReplicaSet", replicaSet, replicaSet, related)
+}
+
+func (w *walker) pod(ctx context.Context, name string) (*Resource, error) {
+	pod, err := w.clientset.CoreV1().Pods(w.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return w.newResource("v1", "Pod", pod, pod, nil), nil
+}
+
+func (w *walker) service(ctx context.Context, name string) (*Resource, error) {
+	service, err := w.clientset.CoreV1().Services(w.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Services without a selector do not have to have an Endpoints object, so that a missing Endpoints object is not
+	// handled as error.
+	var related []Resource
+	endpoints, err := w.endpoints(ctx, name, service.Spec.Selector)
+	if err != nil {
+		if !kubernetesErrors.IsNotFound(err) {
+			return nil, err
+		}
+	} else {
+		related = append(related, *endpoints)
+	}
+
+	return w.newResource("v1", "Service", service, service, related), nil
+}
+
+// endpoints returns the Endpoints object with the given name and the Pods which are referenced by it. When the
+// selector of the Service for the Endpoints object is provided, the Pods are listed via the selector. Otherwise each
+// Pod is requested by its name, because the Endpoints object can be managed manually and then we do not know the labels
+// of the Pods.
+func (w *walker) endpoints(ctx context.Context, name string, selector map[string]string) (*Resource, error) {
+	endpoints, err := w.clientset.CoreV1().Endpoints(w.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var podNames []string
+	for _, subset := range endpoints.Subsets {
+		for _, address := range append(subset.Addresses, subset.NotReadyAddresses...) {
+			if address.TargetRef != nil && address.TargetRef.Kind == "Pod" {
+				podNames = utils.AppendIfStringIsMissing(podNames, address.TargetRef.Name)
+			}
+		}
+	}
+
+	// The Endpoints object can reference a Pod which was already deleted, so that Pods which are not found are skipped.
+	var related []Resource
+	if len(podNames) > 0 {
+		if len(selector) > 0 {
+			pods, err := w.listPods(ctx, &metav1.LabelSelector{MatchLabels: selector})
+			if err != nil {
+				return nil, err
+			}
+
+			for i := range pods {
+				if utils.Contains(podNames, pods[i].Name) {
+					related = append(related, *w.newResource("v1", "Pod", &pods[i], pods[i], nil))
+				}
+			}
+		} else {
+			for _, podName := range podNames {
+				pod, err := w.clientset.CoreV1().Pods(w.namespace).Get(ctx, podName, metav1.GetOptions{})
+				if err != nil {
+					if kubernetesErrors.IsNotFound(err) {
+						continue
+					}
+					return nil, err
+				}
+
+				related = append(related, *w.newResource("v1", "Pod", pod, pod, nil))
+			}
+		}
+
+		sort.SliceStable(related, func(i, j int) bool {
+			return related[i].Name < related[j].Name
+		})
+	}
+
+	return w.newResource("v1", "Endpoints", endpoints, endpoints, related), nil
+}
+
+func (w *walker) ingress(ctx context.Context, name string) (*Resource, error) {
+	ingress, err := w.clientset.NetworkingV1().Ingresses(w.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var related []Resource
+	for _, serviceName := range ingressServices(ingress) {
+		service, err := w.service(ctx, serviceName)
+		if err != nil {
+			if kubernetesErrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		related = append(related, *service)
+	}
+
+	return w.newResource("networking.k8s.io/v1", "Ingress", ingress, ingress, related), nil
+}
+
+// ingressServices returns the names of all Services which are used as backend in the given Ingress. The names are
+// sorted and each name is only returned once.
+func ingressServices(ingress *networkingv1.Ingress) []string {
+	var serviceNames []string
+
+	if ingress.Spec.DefaultBackend != nil && ingress.Spec.DefaultBackend.Service != nil {
+		serviceNames = utils.AppendIfStringIsMissing(serviceNames, ingress.Spec.DefaultBackend.Service.Name)
+	}
+
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service != nil {
+				serviceNames = utils.AppendIfStringIsMissing(serviceNames, path.Backend.Service.Name)
+			}
+		}
+	}
+
+	sort.Strings(serviceNames)
+	return serviceNames
+}
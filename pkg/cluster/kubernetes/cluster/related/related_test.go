@@ -0,0 +1,130 @@
+package related
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func objectMeta(name, uid, ownerUID string) metav1.ObjectMeta {
+	meta := metav1.ObjectMeta{Namespace: "default", Name: name, UID: types.UID("uid-" + uid), Labels: map[string]string{"app": "nginx"}}
+	if ownerUID != "" {
+		meta.OwnerReferences = []metav1.OwnerReference{{UID: types.UID("uid-" + ownerUID)}}
+	}
+	return meta
+}
+
+func newClientset() *fake.Clientset {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}}
+
+	return fake.NewSimpleClientset(
+		&appsv1.Deployment{ObjectMeta: objectMeta("nginx", "deploy", ""), Spec: appsv1.DeploymentSpec{Selector: selector}},
+		&appsv1.ReplicaSet{ObjectMeta: objectMeta("nginx-1", "rs1", "deploy"), Spec: appsv1.ReplicaSetSpec{Selector: selector}},
+		&appsv1.ReplicaSet{ObjectMeta: objectMeta("nginx-2", "rs2", "deploy"), Spec: appsv1.ReplicaSetSpec{Selector: selector}},
+		&appsv1.ReplicaSet{ObjectMeta: objectMeta("other", "rs3", "other"), Spec: appsv1.ReplicaSetSpec{Selector: selector}},
+		&corev1.Pod{ObjectMeta: objectMeta("nginx-1-a", "pod1", "rs1")},
+		&corev1.Pod{ObjectMeta: objectMeta("nginx-2-a", "pod2", "rs2")},
+		&corev1.Pod{ObjectMeta: objectMeta("nginx-2-b", "pod3", "rs2")},
+		&corev1.Service{ObjectMeta: objectMeta("nginx", "svc", ""), Spec: corev1.ServiceSpec{Selector: map[string]string{"app": "nginx"}}},
+		&corev1.Endpoints{ObjectMeta: objectMeta("nginx", "ep", ""), Subsets: []corev1.EndpointSubset{{
+			Addresses:         []corev1.EndpointAddress{{TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "nginx-2-a"}}},
+			NotReadyAddresses: []corev1.EndpointAddress{{TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "nginx-2-b"}}, {TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "deleted"}}},
+		}}},
+		&networkingv1.Ingress{ObjectMeta: objectMeta("nginx", "ing", ""), Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{
+			{IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{Paths: []networkingv1.HTTPIngressPath{
+				{Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "nginx"}}},
+				{Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "missing"}}},
+			}}}},
+		}}},
+		&corev1.Event{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "event1"}, InvolvedObject: corev1.ObjectReference{UID: "uid-pod1"}, Reason: "BackOff"},
+	)
+}
+
+// names returns the names of the given resource and all its related resources in the format "Kind/Name", so that the
+// tree of related resources can be compared easily in the tests.
+func names(resource Resource) []string {
+	result := []string{resource.Kind + "/" + resource.Name}
+	for _, related := range resource.Related {
+		for _, name := range names(related) {
+			result = append(result, "  "+name)
+		}
+	}
+	return result
+}
+
+func TestGet(t *testing.T) {
+	t.Run("should return related resources for deployment", func(t *testing.T) {
+		resource, err := Get(context.Background(), newClientset(), "default", "nginx", "deployments")
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"Deployment/nginx",
+			"  ReplicaSet/nginx-1",
+			"    Pod/nginx-1-a",
+			"  ReplicaSet/nginx-2",
+			"    Pod/nginx-2-a",
+			"    Pod/nginx-2-b",
+		}, names(*resource))
+		require.Equal(t, "BackOff", resource.Related[0].Related[0].Events[0].Reason)
+		require.Empty(t, resource.Related[1].Related[0].Events)
+	})
+
+	t.Run("should return related resources for ingress", func(t *testing.T) {
+		clientset := newClientset()
+		resource, err := Get(context.Background(), clientset, "default", "nginx", "ingresses")
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"Ingress/nginx",
+			"  Service/nginx",
+			"    Endpoints/nginx",
+			"      Pod/nginx-2-a",
+			"      Pod/nginx-2-b",
+		}, names(*resource))
+
+		var podSelectors []string
+		for _, action := range clientset.Actions() {
+			if listAction, ok := action.(k8stesting.ListAction); ok && action.GetResource().Resource == "pods" {
+				podSelectors = append(podSelectors, listAction.GetListRestrictions().Labels.String())
+			}
+		}
+		require.Equal(t, []string{"app=nginx"}, podSelectors)
+	})
+
+	t.Run("should return related resources for endpoints", func(t *testing.T) {
+		clientset := newClientset()
+		resource, err := Get(context.Background(), clientset, "default", "nginx", "endpoints")
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"Endpoints/nginx",
+			"  Pod/nginx-2-a",
+			"  Pod/nginx-2-b",
+		}, names(*resource))
+
+		for _, action := range clientset.Actions() {
+			require.False(t, action.GetVerb() == "list" && action.GetResource().Resource == "pods")
+		}
+	})
+
+	t.Run("should return related resources for replicaset", func(t *testing.T) {
+		resource, err := Get(context.Background(), newClientset(), "default", "nginx-1", "replicasets")
+		require.NoError(t, err)
+		require.Equal(t, []string{"ReplicaSet/nginx-1", "  Pod/nginx-1-a"}, names(*resource))
+	})
+
+	t.Run("should return error for not existing resource", func(t *testing.T) {
+		_, err := Get(context.Background(), newClientset(), "default", "missing", "deployments")
+		require.Error(t, err)
+	})
+
+	t.Run("should return error for not supported resource", func(t *testing.T) {
+		_, err := Get(context.Background(), newClientset(), "default", "nginx", "configmaps")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,422 @@
+package rollout
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/owner"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// RestartedAtAnnotation is the annotation which is set in the Pod template of a resource to restart it. It is the
+	// same annotation which is used by "kubectl rollout restart".
+	RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+	// RevisionAnnotation is the annotation which is set by the Deployment controller on each ReplicaSet to track the
+	// revision of the Deployment.
+	RevisionAnnotation = "deployment.kubernetes.io/revision"
+	// ChangeCauseAnnotation is the annotation which can be used to record the cause of a change in the revision history.
+	ChangeCauseAnnotation = "kubernetes.io/change-cause"
+)
+
+// Status is the rollout status of a Deployment, StatefulSet or DaemonSet. The status is "done" when the rollout was
+// completed. The message contains a human readable description of the current status, which is the same as the one
+// returned by "kubectl rollout status".
+type Status struct {
+	Done    bool   `json:"done"`
+	Message string `json:"message"`
+}
+
+// Revision is a single revision in the rollout history of a Deployment, StatefulSet or DaemonSet. For a Deployment the
+// name is the name of the ReplicaSet and for a StatefulSet and DaemonSet the name is the name of the
+// ControllerRevision.
+type Revision struct {
+	Revision    int64       `json:"revision"`
+	Name        string      `json:"name"`
+	ChangeCause string      `json:"changeCause,omitempty"`
+	CreatedAt   metav1.Time `json:"createdAt"`
+}
+
+// Restart restarts all Pods of a Deployment, StatefulSet or DaemonSet, by setting the restartedAt annotation in the Pod
+// template of the resource.
+func Restart(ctx context.Context, clientset kubernetes.Interface, namespace, name, resource string) error {
+	patch := []byte(fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{"%s":"%s"}}}}}`, RestartedAtAnnotation, time.Now().Format(time.RFC3339)))
+
+	switch resource {
+	case "deployments":
+		deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if deployment.Spec.Paused {
+			return fmt.Errorf("can not restart paused deployment, resume it first")
+		}
+
+		_, err = clientset.AppsV1().Deployments(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		return err
+	case "statefulsets":
+		_, err := clientset.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		return err
+	case "daemonsets":
+		_, err := clientset.AppsV1().DaemonSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		return err
+	default:
+		return fmt.Errorf("resource %s is not supported", resource)
+	}
+}
+
+// Scale sets the number of replicas for a Deployment or StatefulSet.
+func Scale(ctx context.Context, clientset kubernetes.Interface, namespace, name, resource string, replicas int32) error {
+	if replicas < 0 {
+		return fmt.Errorf("replicas must be greater than or equal to 0")
+	}
+
+	patch := []byte(fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas))
+
+	switch resource {
+	case "deployments":
+		_, err := clientset.AppsV1().Deployments(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+		return err
+	case "statefulsets":
+		_, err := clientset.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+		return err
+	default:
+		return fmt.Errorf("resource %s is not supported", resource)
+	}
+}
+
+// Pause pauses or resumes the rollout of a Deployment. Pausing is only supported for Deployments, because StatefulSets
+// and DaemonSets do not have a paused field.
+func Pause(ctx context.Context, clientset kubernetes.Interface, namespace, name, resource string, paused bool) error {
+	if resource != "deployments" {
+		return fmt.Errorf("resource %s is not supported", resource)
+	}
+
+	patch := []byte(fmt.Sprintf(`{"spec":{"paused":%t}}`, paused))
+
+	_, err := clientset.AppsV1().Deployments(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// GetStatus returns the rollout status of a Deployment, StatefulSet or DaemonSet. The status is computed in the same way
+// as it is done by "kubectl rollout status".
+func GetStatus(ctx context.Context, clientset kubernetes.Interface, namespace, name, resource string) (*Status, error) {
+	switch resource {
+	case "deployments":
+		deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return deploymentStatus(deployment), nil
+	case "statefulsets":
+		statefulSet, err := clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return statefulSetStatus(statefulSet), nil
+	case "daemonsets":
+		daemonSet, err := clientset.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return daemonSetStatus(daemonSet), nil
+	default:
+		return nil, fmt.Errorf("resource %s is not supported", resource)
+	}
+}
+
+func deploymentStatus(deployment *appsv1.Deployment) *Status {
+	if deployment.Generation > deployment.Status.ObservedGeneration {
+		return &Status{Message: "Waiting for deployment spec update to be observed..."}
+	}
+
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+			return &Status{Message: fmt.Sprintf("deployment %q exceeded its progress deadline", deployment.Name)}
+		}
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	if deployment.Status.UpdatedReplicas < replicas {
+		return &Status{Message: fmt.Sprintf("Waiting for deployment %q rollout to finish: %d out of %d new replicas have been updated...", deployment.Name, deployment.Status.UpdatedReplicas, replicas)}
+	}
+	if deployment.Status.Replicas > deployment.Status.UpdatedReplicas {
+		return &Status{Message: fmt.Sprintf("Waiting for deployment %q rollout to finish: %d old replicas are pending termination...", deployment.Name, deployment.Status.Replicas-deployment.Status.UpdatedReplicas)}
+	}
+	if deployment.Status.AvailableReplicas < deployment.Status.UpdatedReplicas {
+		return &Status{Message: fmt.Sprintf("Waiting for deployment %q rollout to finish: %d of %d updated replicas are available...", deployment.Name, deployment.Status.AvailableReplicas, deployment.Status.UpdatedReplicas)}
+	}
+
+	return &Status{Done: true, Message: fmt.Sprintf("deployment %q successfully rolled out", deployment.Name)}
+}
+
+func statefulSetStatus(statefulSet *appsv1.StatefulSet) *Status {
+	if statefulSet.Spec.UpdateStrategy.Type != appsv1.RollingUpdateStatefulSetStrategyType {
+		return &Status{Done: true, Message: fmt.Sprintf("rollout status is only available for %s strategy type", appsv1.RollingUpdateStatefulSetStrategyType)}
+	}
+	if statefulSet.Status.ObservedGeneration == 0 || statefulSet.Generation > statefulSet.Status.ObservedGeneration {
+		return &Status{Message: "Waiting for statefulset spec update to be observed..."}
+	}
+
+	replicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+
+	if statefulSet.Status.ReadyReplicas < replicas {
+		return &Status{Message: fmt.Sprintf("Waiting for %d pods to be ready...", replicas-statefulSet.Status.ReadyReplicas)}
+	}
+
+	if statefulSet.Spec.UpdateStrategy.RollingUpdate != nil && statefulSet.Spec.UpdateStrategy.RollingUpdate.Partition != nil {
+		partition := *statefulSet.Spec.UpdateStrategy.RollingUpdate.Partition
+		if statefulSet.Status.UpdatedReplicas < replicas-partition {
+			return &Status{Message: fmt.Sprintf("Waiting for partitioned roll out to finish: %d out of %d new pods have been updated...", statefulSet.Status.UpdatedReplicas, replicas-partition)}
+		}
+		return &Status{Done: true, Message: fmt.Sprintf("partitioned roll out complete: %d new pods have been updated...", statefulSet.Status.UpdatedReplicas)}
+	}
+
+	if statefulSet.Status.UpdateRevision != statefulSet.Status.CurrentRevision {
+		return &Status{Message: fmt.Sprintf("waiting for statefulset rolling update to complete %d pods at revision %s...", statefulSet.Status.UpdatedReplicas, statefulSet.Status.UpdateRevision)}
+	}
+
+	return &Status{Done: true, Message: fmt.Sprintf("statefulset rolling update complete %d pods at revision %s...", statefulSet.Status.CurrentReplicas, statefulSet.Status.CurrentRevision)}
+}
+
+func daemonSetStatus(daemonSet *appsv1.DaemonSet) *Status {
+	if daemonSet.Spec.UpdateStrategy.Type != appsv1.RollingUpdateDaemonSetStrategyType {
+		return &Status{Done: true, Message: fmt.Sprintf("rollout status is only available for %s strategy type", appsv1.RollingUpdateDaemonSetStrategyType)}
+	}
+	if daemonSet.Generation > daemonSet.Status.ObservedGeneration {
+		return &Status{Message: "Waiting for daemon set spec update to be observed..."}
+	}
+
+	if daemonSet.Status.UpdatedNumberScheduled < daemonSet.Status.DesiredNumberScheduled {
+		return &Status{Message: fmt.Sprintf("Waiting for daemon set %q rollout to finish: %d out of %d new pods have been updated...", daemonSet.Name, daemonSet.Status.UpdatedNumberScheduled, daemonSet.Status.DesiredNumberScheduled)}
+	}
+	if daemonSet.Status.NumberAvailable < daemonSet.Status.DesiredNumberScheduled {
+		return &Status{Message: fmt.Sprintf("Waiting for daemon set %q rollout to finish: %d of %d updated pods are available...", daemonSet.Name, daemonSet.Status.NumberAvailable, daemonSet.Status.DesiredNumberScheduled)}
+	}
+
+	return &Status{Done: true, Message: fmt.Sprintf("daemon set %q successfully rolled out", daemonSet.Name)}
+}
+
+// GetHistory returns the revision history of a Deployment, StatefulSet or DaemonSet. For Deployments the history is
+// built from the ReplicaSets of the Deployment and for StatefulSets and DaemonSets from the ControllerRevisions. The
+// revisions are sorted in ascending order.
+func GetHistory(ctx context.Context, clientset kubernetes.Interface, namespace, name, resource string) ([]Revision, error) {
+	switch resource {
+	case "deployments":
+		_, replicaSets, err := deploymentHistory(ctx, clientset, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+
+		var revisions []Revision
+		for _, replicaSet := range replicaSets {
+			revisions = append(revisions, Revision{
+				Revision:    replicaSetRevision(replicaSet),
+				Name:        replicaSet.Name,
+				ChangeCause: replicaSet.Annotations[ChangeCauseAnnotation],
+				CreatedAt:   replicaSet.CreationTimestamp,
+			})
+		}
+		return revisions, nil
+	case "statefulsets", "daemonsets":
+		controllerRevisions, err := controllerRevisionHistory(ctx, clientset, namespace, name, resource)
+		if err != nil {
+			return nil, err
+		}
+
+		var revisions []Revision
+		for _, controllerRevision := range controllerRevisions {
+			revisions = append(revisions, Revision{
+				Revision:    controllerRevision.Revision,
+				Name:        controllerRevision.Name,
+				ChangeCause: controllerRevision.Annotations[ChangeCauseAnnotation],
+				CreatedAt:   controllerRevision.CreationTimestamp,
+			})
+		}
+		return revisions, nil
+	default:
+		return nil, fmt.Errorf("resource %s is not supported", resource)
+	}
+}
+
+// Undo rolls back a Deployment, StatefulSet or DaemonSet to the given revision. If the revision is 0 the resource is
+// rolled back to the previous revision.
+func Undo(ctx context.Context, clientset kubernetes.Interface, namespace, name, resource string, revision int64) error {
+	switch resource {
+	case "deployments":
+		deployment, replicaSets, err := deploymentHistory(ctx, clientset, namespace, name)
+		if err != nil {
+			return err
+		}
+		if deployment.Spec.Paused {
+			return fmt.Errorf("can not rollback paused deployment, resume it first")
+		}
+
+		var revisions []int64
+		for _, replicaSet := range replicaSets {
+			revisions = append(revisions, replicaSetRevision(replicaSet))
+		}
+
+		index, err := findRevision(revisions, revision)
+		if err != nil {
+			return err
+		}
+
+		// The Pod template of the ReplicaSet contains the "pod-template-hash" label, which is added by the Deployment
+		// controller. We have to remove it, before we can use the template in the Deployment.
+		template := replicaSets[index].Spec.Template.DeepCopy()
+		delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+
+		patch, err := json.Marshal([]map[string]any{{"op": "replace", "path": "/spec/template", "value": template}})
+		if err != nil {
+			return err
+		}
+
+		_, err = clientset.AppsV1().Deployments(namespace).Patch(ctx, name, types.JSONPatchType, patch, metav1.PatchOptions{})
+		return err
+	case "statefulsets", "daemonsets":
+		controllerRevisions, err := controllerRevisionHistory(ctx, clientset, namespace, name, resource)
+		if err != nil {
+			return err
+		}
+
+		var revisions []int64
+		for _, controllerRevision := range controllerRevisions {
+			revisions = append(revisions, controllerRevision.Revision)
+		}
+
+		index, err := findRevision(revisions, revision)
+		if err != nil {
+			return err
+		}
+
+		// The data of a ControllerRevision is a strategic merge patch, which can be applied to the StatefulSet or
+		// DaemonSet to restore the Pod template of the revision.
+		if resource == "statefulsets" {
+			_, err = clientset.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, controllerRevisions[index].Data.Raw, metav1.PatchOptions{})
+			return err
+		}
+
+		_, err = clientset.AppsV1().DaemonSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, controllerRevisions[index].Data.Raw, metav1.PatchOptions{})
+		return err
+	default:
+		return fmt.Errorf("resource %s is not supported", resource)
+	}
+}
+
+// findRevision returns the index of the given revision in the sorted list of revisions. If the revision is 0 the index
+// of the previous revision is returned.
+func findRevision(revisions []int64, revision int64) (int, error) {
+	if revision == 0 {
+		if len(revisions) < 2 {
+			return 0, fmt.Errorf("no previous revision found")
+		}
+		return len(revisions) - 2, nil
+	}
+
+	for i, r := range revisions {
+		if r == revision {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("revision %d not found", revision)
+}
+
+// deploymentHistory returns the Deployment and all ReplicaSets which are owned by the Deployment. The ReplicaSets are
+// sorted by their revision.
+func deploymentHistory(ctx context.Context, clientset kubernetes.Interface, namespace, name string) (*appsv1.Deployment, []appsv1.ReplicaSet, error) {
+	deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	labelSelector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	replicaSetList, err := clientset.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector.String()})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var replicaSets []appsv1.ReplicaSet
+	for _, replicaSet := range replicaSetList.Items {
+		if owner.IsOwnedBy(replicaSet.OwnerReferences, deployment.UID) {
+			replicaSets = append(replicaSets, replicaSet)
+		}
+	}
+
+	sort.Slice(replicaSets, func(i, j int) bool {
+		return replicaSetRevision(replicaSets[i]) < replicaSetRevision(replicaSets[j])
+	})
+
+	return deployment, replicaSets, nil
+}
+
+// controllerRevisionHistory returns all ControllerRevisions which are owned by the StatefulSet or DaemonSet. The
+// ControllerRevisions are sorted by their revision.
+func controllerRevisionHistory(ctx context.Context, clientset kubernetes.Interface, namespace, name, resource string) ([]appsv1.ControllerRevision, error) {
+	var uid types.UID
+	var selector *metav1.LabelSelector
+
+	if resource == "statefulsets" {
+		statefulSet, err := clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		uid = statefulSet.UID
+		selector = statefulSet.Spec.Selector
+	} else {
+		daemonSet, err := clientset.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		uid = daemonSet.UID
+		selector = daemonSet.Spec.Selector
+	}
+
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	controllerRevisionList, err := clientset.AppsV1().ControllerRevisions(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	var controllerRevisions []appsv1.ControllerRevision
+	for _, controllerRevision := range controllerRevisionList.Items {
+		if owner.IsOwnedBy(controllerRevision.OwnerReferences, uid) {
+			controllerRevisions = append(controllerRevisions, controllerRevision)
+		}
+	}
+
+	sort.Slice(controllerRevisions, func(i, j int) bool {
+		return controllerRevisions[i].Revision < controllerRevisions[j].Revision
+	})
+
+	return controllerRevisions, nil
+}
+
+func replicaSetRevision(replicaSet appsv1.ReplicaSet) int64 {
+	revision, _ := strconv.ParseInt(replicaSet.Annotations[RevisionAnnotation], 10, 64)
+	return revision
+}
@@ -0,0 +1,247 @@
+package rollout
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func podTemplate(image string) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "nginx", appsv1.DefaultDeploymentUniqueLabelKey: image}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx", Image: image}}},
+	}
+}
+
+func newClientset(paused bool) *fake.Clientset {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}}
+	owner := []metav1.OwnerReference{{UID: "deploy"}}
+
+	return fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx", UID: "deploy"},
+			Spec:       appsv1.DeploymentSpec{Replicas: int32Ptr(1), Selector: selector, Template: podTemplate("nginx:3"), Paused: paused},
+		},
+		&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx-1", Labels: selector.MatchLabels, OwnerReferences: owner, Annotations: map[string]string{RevisionAnnotation: "1"}},
+			Spec:       appsv1.ReplicaSetSpec{Selector: selector, Template: podTemplate("nginx:1")},
+		},
+		&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx-3", Labels: selector.MatchLabels, OwnerReferences: owner, Annotations: map[string]string{RevisionAnnotation: "3"}},
+			Spec:       appsv1.ReplicaSetSpec{Selector: selector, Template: podTemplate("nginx:3")},
+		},
+		&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx-2", Labels: selector.MatchLabels, OwnerReferences: owner, Annotations: map[string]string{RevisionAnnotation: "2", ChangeCauseAnnotation: "update image"}},
+			Spec:       appsv1.ReplicaSetSpec{Selector: selector, Template: podTemplate("nginx:2")},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx", UID: "sts"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: int32Ptr(1), Selector: selector, Template: podTemplate("nginx:2")},
+		},
+		&appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx-a", Labels: selector.MatchLabels, OwnerReferences: []metav1.OwnerReference{{UID: "sts"}}},
+			Revision:   1,
+			Data:       runtime.RawExtension{Raw: []byte(`{"spec":{"template":{"spec":{"containers":[{"name":"nginx","image":"nginx:1"}]}}}}`)},
+		},
+		&appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx-b", Labels: selector.MatchLabels, OwnerReferences: []metav1.OwnerReference{{UID: "sts"}}},
+			Revision:   2,
+			Data:       runtime.RawExtension{Raw: []byte(`{"spec":{"template":{"spec":{"containers":[{"name":"nginx","image":"nginx:2"}]}}}}`)},
+		},
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx", UID: "ds"},
+			Spec:       appsv1.DaemonSetSpec{Selector: selector, Template: podTemplate("nginx:1")},
+		},
+	)
+}
+
+func TestRestart(t *testing.T) {
+	t.Run("should restart deployment", func(t *testing.T) {
+		clientset := newClientset(false)
+		err := Restart(context.Background(), clientset, "default", "nginx", "deployments")
+		require.NoError(t, err)
+
+		deployment, _ := clientset.AppsV1().Deployments("default").Get(context.Background(), "nginx", metav1.GetOptions{})
+		require.NotEmpty(t, deployment.Spec.Template.Annotations[RestartedAtAnnotation])
+	})
+
+	t.Run("should fail for paused deployment", func(t *testing.T) {
+		err := Restart(context.Background(), newClientset(true), "default", "nginx", "deployments")
+		require.Error(t, err)
+	})
+
+	t.Run("should restart daemonset", func(t *testing.T) {
+		clientset := newClientset(false)
+		err := Restart(context.Background(), clientset, "default", "nginx", "daemonsets")
+		require.NoError(t, err)
+
+		daemonSet, _ := clientset.AppsV1().DaemonSets("default").Get(context.Background(), "nginx", metav1.GetOptions{})
+		require.NotEmpty(t, daemonSet.Spec.Template.Annotations[RestartedAtAnnotation])
+	})
+
+	t.Run("should fail for not supported resource", func(t *testing.T) {
+		err := Restart(context.Background(), newClientset(false), "default", "nginx", "pods")
+		require.Error(t, err)
+	})
+}
+
+func TestScale(t *testing.T) {
+	t.Run("should scale statefulset", func(t *testing.T) {
+		clientset := newClientset(false)
+		err := Scale(context.Background(), clientset, "default", "nginx", "statefulsets", 3)
+		require.NoError(t, err)
+
+		statefulSet, _ := clientset.AppsV1().StatefulSets("default").Get(context.Background(), "nginx", metav1.GetOptions{})
+		require.Equal(t, int32(3), *statefulSet.Spec.Replicas)
+	})
+
+	t.Run("should fail for negative replicas", func(t *testing.T) {
+		err := Scale(context.Background(), newClientset(false), "default", "nginx", "deployments", -1)
+		require.Error(t, err)
+	})
+
+	t.Run("should fail for daemonset", func(t *testing.T) {
+		err := Scale(context.Background(), newClientset(false), "default", "nginx", "daemonsets", 1)
+		require.Error(t, err)
+	})
+}
+
+func TestPause(t *testing.T) {
+	t.Run("should pause and resume deployment", func(t *testing.T) {
+		clientset := newClientset(false)
+
+		err := Pause(context.Background(), clientset, "default", "nginx", "deployments", true)
+		require.NoError(t, err)
+		deployment, _ := clientset.AppsV1().Deployments("default").Get(context.Background(), "nginx", metav1.GetOptions{})
+		require.True(t, deployment.Spec.Paused)
+
+		err = Pause(context.Background(), clientset, "default", "nginx", "deployments", false)
+		require.NoError(t, err)
+		deployment, _ = clientset.AppsV1().Deployments("default").Get(context.Background(), "nginx", metav1.GetOptions{})
+		require.False(t, deployment.Spec.Paused)
+	})
+
+	t.Run("should fail for statefulset", func(t *testing.T) {
+		err := Pause(context.Background(), newClientset(false), "default", "nginx", "statefulsets", true)
+		require.Error(t, err)
+	})
+}
+
+func TestGetStatus(t *testing.T) {
+	t.Run("should return status for deployment", func(t *testing.T) {
+		status, err := GetStatus(context.Background(), newClientset(false), "default", "nginx", "deployments")
+		require.NoError(t, err)
+		require.Equal(t, &Status{Done: false, Message: `Waiting for deployment "nginx" rollout to finish: 0 out of 1 new replicas have been updated...`}, status)
+	})
+
+	t.Run("should return status for completed deployment", func(t *testing.T) {
+		status := deploymentStatus(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx", Generation: 2},
+			Spec:       appsv1.DeploymentSpec{Replicas: int32Ptr(2)},
+			Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+		})
+		require.Equal(t, &Status{Done: true, Message: `deployment "nginx" successfully rolled out`}, status)
+	})
+
+	t.Run("should return status for statefulset", func(t *testing.T) {
+		status := statefulSetStatus(&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx", Generation: 1},
+			Spec:       appsv1.StatefulSetSpec{Replicas: int32Ptr(2), UpdateStrategy: appsv1.StatefulSetUpdateStrategy{Type: appsv1.RollingUpdateStatefulSetStrategyType}},
+			Status:     appsv1.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 2, UpdatedReplicas: 1, CurrentRevision: "a", UpdateRevision: "b"},
+		})
+		require.Equal(t, &Status{Done: false, Message: "waiting for statefulset rolling update to complete 1 pods at revision b..."}, status)
+	})
+
+	t.Run("should return status for daemonset", func(t *testing.T) {
+		status := daemonSetStatus(&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx", Generation: 1},
+			Spec:       appsv1.DaemonSetSpec{UpdateStrategy: appsv1.DaemonSetUpdateStrategy{Type: appsv1.RollingUpdateDaemonSetStrategyType}},
+			Status:     appsv1.DaemonSetStatus{ObservedGeneration: 1, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 3},
+		})
+		require.Equal(t, &Status{Done: true, Message: `daemon set "nginx" successfully rolled out`}, status)
+	})
+
+	t.Run("should fail for not existing resource", func(t *testing.T) {
+		_, err := GetStatus(context.Background(), newClientset(false), "default", "missing", "statefulsets")
+		require.Error(t, err)
+	})
+}
+
+func TestGetHistory(t *testing.T) {
+	t.Run("should return history for deployment", func(t *testing.T) {
+		revisions, err := GetHistory(context.Background(), newClientset(false), "default", "nginx", "deployments")
+		require.NoError(t, err)
+		require.Equal(t, []Revision{
+			{Revision: 1, Name: "nginx-1"},
+			{Revision: 2, Name: "nginx-2", ChangeCause: "update image"},
+			{Revision: 3, Name: "nginx-3"},
+		}, revisions)
+	})
+
+	t.Run("should return history for statefulset", func(t *testing.T) {
+		revisions, err := GetHistory(context.Background(), newClientset(false), "default", "nginx", "statefulsets")
+		require.NoError(t, err)
+		require.Equal(t, []Revision{{Revision: 1, Name: "nginx-a"}, {Revision: 2, Name: "nginx-b"}}, revisions)
+	})
+
+	t.Run("should return empty history for daemonset", func(t *testing.T) {
+		revisions, err := GetHistory(context.Background(), newClientset(false), "default", "nginx", "daemonsets")
+		require.NoError(t, err)
+		require.Empty(t, revisions)
+	})
+}
+
+func TestUndo(t *testing.T) {
+	t.Run("should rollback deployment to previous revision", func(t *testing.T) {
+		clientset := newClientset(false)
+		err := Undo(context.Background(), clientset, "default", "nginx", "deployments", 0)
+		require.NoError(t, err)
+
+		deployment, _ := clientset.AppsV1().Deployments("default").Get(context.Background(), "nginx", metav1.GetOptions{})
+		require.Equal(t, "nginx:2", deployment.Spec.Template.Spec.Containers[0].Image)
+		require.Equal(t, map[string]string{"app": "nginx"}, deployment.Spec.Template.Labels)
+	})
+
+	t.Run("should rollback deployment to revision", func(t *testing.T) {
+		clientset := newClientset(false)
+		err := Undo(context.Background(), clientset, "default", "nginx", "deployments", 1)
+		require.NoError(t, err)
+
+		deployment, _ := clientset.AppsV1().Deployments("default").Get(context.Background(), "nginx", metav1.GetOptions{})
+		require.Equal(t, "nginx:1", deployment.Spec.Template.Spec.Containers[0].Image)
+	})
+
+	t.Run("should fail for paused deployment", func(t *testing.T) {
+		err := Undo(context.Background(), newClientset(true), "default", "nginx", "deployments", 0)
+		require.Error(t, err)
+	})
+
+	t.Run("should fail for not existing revision", func(t *testing.T) {
+		err := Undo(context.Background(), newClientset(false), "default", "nginx", "deployments", 10)
+		require.Error(t, err)
+	})
+
+	t.Run("should rollback statefulset to previous revision", func(t *testing.T) {
+		clientset := newClientset(false)
+		err := Undo(context.Background(), clientset, "default", "nginx", "statefulsets", 0)
+		require.NoError(t, err)
+
+		statefulSet, _ := clientset.AppsV1().StatefulSets("default").Get(context.Background(), "nginx", metav1.GetOptions{})
+		require.Equal(t, "nginx:1", statefulSet.Spec.Template.Spec.Containers[0].Image)
+	})
+
+	t.Run("should fail for daemonset without previous revision", func(t *testing.T) {
+		err := Undo(context.Background(), newClientset(false), "default", "nginx", "daemonsets", 0)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,266 @@
+package usage
+
+import (
+	"context"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsVersioned "k8s.io/metrics/pkg/client/clientset/versioned"
+)
+
+// Resource is the usage of a single resource (CPU or memory) together with the requested and limited amount of the
+// resource. The CPU values are in millicores and the memory values are in bytes. For nodes the allocatable and capacity
+// fields are also set.
+//
+// The requests and limits utilization is the usage in percent of the requests and limits. If no requests or limits
+// are set, the utilization is 0. A utilization above 100 for the requests means that the workload uses more than it
+// requested, which indicates that the workload is under-provisioned. A low utilization means that the workload is
+// over-provisioned.
+//
+// For containers and Pods the limits are unbounded, when a container has no limit for the resource. In this case the
+// limits and the limits utilization are 0, because the usage of the container is only bounded by the Node.
+type Resource struct {
+	Usage                  int64   `json:"usage"`
+	Requests               int64   `json:"requests"`
+	Limits                 int64   `json:"limits"`
+	LimitsUnbounded        bool    `json:"limitsUnbounded,omitempty"`
+	Allocatable            int64   `json:"allocatable,omitempty"`
+	Capacity               int64   `json:"capacity,omitempty"`
+	RequestsUtilization    float64 `json:"requestsUtilization"`
+	LimitsUtilization      float64 `json:"limitsUtilization"`
+	AllocatableUtilization float64 `json:"allocatableUtilization,omitempty"`
+}
+
+// Container is the CPU and memory usage of a single container of a Pod.
+type Container struct {
+	Name   string   `json:"name"`
+	CPU    Resource `json:"cpu"`
+	Memory Resource `json:"memory"`
+}
+
+// Pod is the CPU and memory usage of a Pod. The usage, requests and limits of the Pod are the sum of the values of all
+// containers. If one of the containers has no limit, the limits of the Pod are unbounded.
+type Pod struct {
+	Namespace  string      `json:"namespace"`
+	Name       string      `json:"name"`
+	Node       string      `json:"node"`
+	Containers []Container `json:"containers"`
+	CPU        Resource    `json:"cpu"`
+	Memory     Resource    `json:"memory"`
+}
+
+// Node is the CPU and memory usage of a Node. The requests and limits of a Node are the sum of the requests and limits
+// of all Pods, which are running on the Node. Containers without a limit are not included in the limits of the Node, so
+// that the limits can be used to see how much the Node is overcommitted.
+type Node struct {
+	Name   string   `json:"name"`
+	Pods   int64    `json:"pods"`
+	CPU    Resource `json:"cpu"`
+	Memory Resource `json:"memory"`
+}
+
+// GetPods returns the usage of all Pods in the given namespace, which are matching the given label selector. If the
+// namespace is empty the Pods from all namespaces are returned. Only Pods which have metrics are returned, so that
+// Pods which are not running (e.g. because they are completed) are not included in the result.
+func GetPods(ctx context.Context, clientset kubernetes.Interface, metricsClientset metricsVersioned.Interface, namespace, labelSelector string) ([]Pod, error) {
+	podMetricsList, err := metricsClientset.MetricsV1beta1().PodMetricses(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, err
+	}
+
+	podList, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, err
+	}
+
+	podsByName := make(map[string]corev1.Pod, len(podList.Items))
+	for _, pod := range podList.Items {
+		podsByName[pod.Namespace+"/"+pod.Name] = pod
+	}
+
+	pods := make([]Pod, 0, len(podMetricsList.Items))
+	for _, podMetrics := range podMetricsList.Items {
+		pod, ok := podsByName[podMetrics.Namespace+"/"+podMetrics.Name]
+		if !ok {
+			continue
+		}
+
+		pods = append(pods, newPod(pod, podMetrics))
+	}
+
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+
+	return pods, nil
+}
+
+// GetNodes returns the usage of all Nodes. The requests and limits of a Node are calculated from all Pods which are
+// scheduled on the Node and which are not completed.
+func GetNodes(ctx context.Context, clientset kubernetes.Interface, metricsClientset metricsVersioned.Interface) ([]Node, error) {
+	nodeMetricsList, err := metricsClientset.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	nodeList, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	podList, err := clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	nodeMetricsByName := make(map[string]metricsv1beta1.NodeMetrics, len(nodeMetricsList.Items))
+	for _, nodeMetrics := range nodeMetricsList.Items {
+		nodeMetricsByName[nodeMetrics.Name] = nodeMetrics
+	}
+
+	podsByNode := make(map[string][]corev1.Pod)
+	for _, pod := range podList.Items {
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		podsByNode[pod.Spec.NodeName] = append(podsByNode[pod.Spec.NodeName], pod)
+	}
+
+	nodes := make([]Node, 0, len(nodeList.Items))
+	for _, node := range nodeList.Items {
+		nodeMetrics, ok := nodeMetricsByName[node.Name]
+		if !ok {
+			continue
+		}
+
+		nodes = append(nodes, newNode(node, nodeMetrics, podsByNode[node.Name]))
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+
+	return nodes, nil
+}
+
+// newPod joins the metrics of a Pod with the requests and limits of the containers of the Pod.
+func newPod(pod corev1.Pod, podMetrics metricsv1beta1.PodMetrics) Pod {
+	usageByContainer := make(map[string]corev1.ResourceList, len(podMetrics.Containers))
+	for _, containerMetrics := range podMetrics.Containers {
+		usageByContainer[containerMetrics.Name] = containerMetrics.Usage
+	}
+
+	result := Pod{
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+		Node:      pod.Spec.NodeName,
+	}
+
+	for _, container := range pod.Spec.Containers {
+		usage := usageByContainer[container.Name]
+
+		c := Container{
+			Name:   container.Name,
+			CPU:    newResource(milliValue(usage, corev1.ResourceCPU), milliValue(container.Resources.Requests, corev1.ResourceCPU), milliValue(container.Resources.Limits, corev1.ResourceCPU)),
+			Memory: newResource(value(usage, corev1.ResourceMemory), value(container.Resources.Requests, corev1.ResourceMemory), value(container.Resources.Limits, corev1.ResourceMemory)),
+		}
+
+		if _, ok := container.Resources.Limits[corev1.ResourceCPU]; !ok {
+			c.CPU.LimitsUnbounded = true
+		}
+		if _, ok := container.Resources.Limits[corev1.ResourceMemory]; !ok {
+			c.Memory.LimitsUnbounded = true
+		}
+
+		result.Containers = append(result.Containers, c)
+		result.CPU = addResource(result.CPU, c.CPU)
+		result.Memory = addResource(result.Memory, c.Memory)
+	}
+
+	return result
+}
+
+// newNode joins the metrics of a Node with the allocatable resources of the Node and the requests and limits of all
+// Pods on the Node.
+func newNode(node corev1.Node, nodeMetrics metricsv1beta1.NodeMetrics, pods []corev1.Pod) Node {
+	var cpuRequests, cpuLimits, memoryRequests, memoryLimits int64
+	for _, pod := range pods {
+		for _, container := range pod.Spec.Containers {
+			cpuRequests += milliValue(container.Resources.Requests, corev1.ResourceCPU)
+			cpuLimits += milliValue(container.Resources.Limits, corev1.ResourceCPU)
+			memoryRequests += value(container.Resources.Requests, corev1.ResourceMemory)
+			memoryLimits += value(container.Resources.Limits, corev1.ResourceMemory)
+		}
+	}
+
+	cpu := newResource(milliValue(nodeMetrics.Usage, corev1.ResourceCPU), cpuRequests, cpuLimits)
+	cpu.Allocatable = milliValue(node.Status.Allocatable, corev1.ResourceCPU)
+	cpu.Capacity = milliValue(node.Status.Capacity, corev1.ResourceCPU)
+	cpu.AllocatableUtilization = utilization(cpu.Usage, cpu.Allocatable)
+
+	memory := newResource(value(nodeMetrics.Usage, corev1.ResourceMemory), memoryRequests, memoryLimits)
+	memory.Allocatable = value(node.Status.Allocatable, corev1.ResourceMemory)
+	memory.Capacity = value(node.Status.Capacity, corev1.ResourceMemory)
+	memory.AllocatableUtilization = utilization(memory.Usage, memory.Allocatable)
+
+	return Node{
+		Name:   node.Name,
+		Pods:   int64(len(pods)),
+		CPU:    cpu,
+		Memory: memory,
+	}
+}
+
+func newResource(usage, requests, limits int64) Resource {
+	return Resource{
+		Usage:               usage,
+		Requests:            requests,
+		Limits:              limits,
+		RequestsUtilization: utilization(usage, requests),
+		LimitsUtilization:   utilization(usage, limits),
+	}
+}
+
+// addResource returns the sum of the provided resources. If the limits of one of the resources are unbounded, the limits
+// of the sum are also unbounded.
+func addResource(a, b Resource) Resource {
+	if a.LimitsUnbounded || b.LimitsUnbounded {
+		result := newResource(a.Usage+b.Usage, a.Requests+b.Requests, 0)
+		result.LimitsUnbounded = true
+		return result
+	}
+
+	return newResource(a.Usage+b.Usage, a.Requests+b.Requests, a.Limits+b.Limits)
+}
+
+// utilization returns the usage in percent of the given total. If the total is 0, the utilization is also 0.
+func utilization(usage, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return float64(usage) / float64(total) * 100
+}
+
+func milliValue(resources corev1.ResourceList, name corev1.ResourceName) int64 {
+	if quantity, ok := resources[name]; ok {
+		return quantity.MilliValue()
+	}
+
+	return 0
+}
+
+func value(resources corev1.ResourceList, name corev1.ResourceName) int64 {
+	if quantity, ok := resources[name]; ok {
+		return quantity.Value()
+	}
+
+	return 0
+}
@@ -0,0 +1,138 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsFake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+func resources(cpu, memory string) corev1.ResourceList {
+	return corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu), corev1.ResourceMemory: resource.MustParse(memory)}
+}
+
+func newPodObject(namespace, name, node string, phase corev1.PodPhase, requests, limits corev1.ResourceList) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: corev1.PodSpec{
+			NodeName: node,
+			Containers: []corev1.Container{
+				{Name: "app", Resources: corev1.ResourceRequirements{Requests: requests, Limits: limits}},
+				{Name: "sidecar"},
+			},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+// newMetricsClientset returns a fake metrics clientset, which returns the given metrics for list requests. We have to
+// use reactors, because the object tracker of the fake clientset can not map the metrics to the correct resources.
+func newMetricsClientset(podMetrics []metricsv1beta1.PodMetrics, nodeMetrics []metricsv1beta1.NodeMetrics, err error) *metricsFake.Clientset {
+	metricsClientset := metricsFake.NewSimpleClientset()
+	metricsClientset.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &metricsv1beta1.PodMetricsList{Items: podMetrics}, err
+	})
+	metricsClientset.PrependReactor("list", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &metricsv1beta1.NodeMetricsList{Items: nodeMetrics}, err
+	})
+	return metricsClientset
+}
+
+func TestGetPods(t *testing.T) {
+	limitedPod := newPodObject("default", "nginx-3", "node1", corev1.PodRunning, resources("100m", "128Mi"), resources("200m", "256Mi"))
+	limitedPod.Spec.Containers[1].Resources.Limits = resources("100m", "64Mi")
+
+	clientset := fake.NewSimpleClientset(
+		newPodObject("default", "nginx-2", "node1", corev1.PodRunning, resources("100m", "128Mi"), resources("200m", "256Mi")),
+		newPodObject("default", "nginx-1", "node1", corev1.PodRunning, resources("100m", "128Mi"), nil),
+		limitedPod,
+	)
+
+	t.Run("should return error", func(t *testing.T) {
+		_, err := GetPods(context.Background(), clientset, newMetricsClientset(nil, nil, fmt.Errorf("metrics api not available")), "default", "")
+		require.Error(t, err)
+	})
+
+	t.Run("should return pods", func(t *testing.T) {
+		metricsClientset := newMetricsClientset([]metricsv1beta1.PodMetrics{
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx-2"}, Containers: []metricsv1beta1.ContainerMetrics{{Name: "app", Usage: resources("50m", "64Mi")}, {Name: "sidecar", Usage: resources("10m", "16Mi")}}},
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx-1"}, Containers: []metricsv1beta1.ContainerMetrics{{Name: "app", Usage: resources("150m", "128Mi")}}},
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx-3"}, Containers: []metricsv1beta1.ContainerMetrics{{Name: "app", Usage: resources("50m", "64Mi")}, {Name: "sidecar", Usage: resources("10m", "16Mi")}}},
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "deleted"}, Containers: []metricsv1beta1.ContainerMetrics{{Name: "app", Usage: resources("150m", "128Mi")}}},
+		}, nil, nil)
+
+		pods, err := GetPods(context.Background(), clientset, metricsClientset, "default", "")
+		require.NoError(t, err)
+		require.Len(t, pods, 3)
+
+		require.Equal(t, "nginx-1", pods[0].Name)
+		require.Equal(t, Resource{Usage: 150, Requests: 100, LimitsUnbounded: true, RequestsUtilization: 150}, pods[0].Containers[0].CPU)
+		require.Equal(t, Resource{LimitsUnbounded: true}, pods[0].Containers[1].CPU)
+
+		require.Equal(t, "nginx-2", pods[1].Name)
+		require.Equal(t, "node1", pods[1].Node)
+		require.Equal(t, Resource{Usage: 50, Requests: 100, Limits: 200, RequestsUtilization: 50, LimitsUtilization: 25}, pods[1].Containers[0].CPU)
+		require.Equal(t, Resource{Usage: 60, Requests: 100, LimitsUnbounded: true, RequestsUtilization: 60}, pods[1].CPU)
+		require.Equal(t, Resource{Usage: 80 * 1024 * 1024, Requests: 128 * 1024 * 1024, LimitsUnbounded: true, RequestsUtilization: 62.5}, pods[1].Memory)
+
+		require.Equal(t, "nginx-3", pods[2].Name)
+		require.Equal(t, Resource{Usage: 60, Requests: 100, Limits: 300, RequestsUtilization: 60, LimitsUtilization: 20}, pods[2].CPU)
+		require.Equal(t, Resource{Usage: 80 * 1024 * 1024, Requests: 128 * 1024 * 1024, Limits: 320 * 1024 * 1024, RequestsUtilization: 62.5, LimitsUtilization: 25}, pods[2].Memory)
+	})
+}
+
+func TestGetNodes(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node2"}, Status: corev1.NodeStatus{Allocatable: resources("2", "4Gi"), Capacity: resources("2", "4Gi")}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}, Status: corev1.NodeStatus{Allocatable: resources("3", "6Gi"), Capacity: resources("4", "8Gi")}},
+		newPodObject("default", "nginx-1", "node1", corev1.PodRunning, resources("500m", "1Gi"), resources("1", "2Gi")),
+		newPodObject("kube-system", "coredns", "node1", corev1.PodRunning, resources("250m", "512Mi"), nil),
+		newPodObject("default", "job", "node1", corev1.PodSucceeded, resources("1", "1Gi"), nil),
+		newPodObject("default", "pending", "", corev1.PodPending, resources("1", "1Gi"), nil),
+	)
+
+	t.Run("should return error", func(t *testing.T) {
+		_, err := GetNodes(context.Background(), clientset, newMetricsClientset(nil, nil, fmt.Errorf("metrics api not available")))
+		require.Error(t, err)
+	})
+
+	t.Run("should return nodes", func(t *testing.T) {
+		metricsClientset := newMetricsClientset(nil, []metricsv1beta1.NodeMetrics{
+			{ObjectMeta: metav1.ObjectMeta{Name: "node1"}, Usage: resources("1500m", "3Gi")},
+			{ObjectMeta: metav1.ObjectMeta{Name: "node2"}, Usage: resources("500m", "1Gi")},
+		}, nil)
+
+		nodes, err := GetNodes(context.Background(), clientset, metricsClientset)
+		require.NoError(t, err)
+		require.Len(t, nodes, 2)
+
+		require.Equal(t, Node{
+			Name: "node1",
+			Pods: 2,
+			CPU:  Resource{Usage: 1500, Requests: 750, Limits: 1000, Allocatable: 3000, Capacity: 4000, RequestsUtilization: 200, LimitsUtilization: 150, AllocatableUtilization: 50},
+			Memory: Resource{
+				Usage:                  3 * 1024 * 1024 * 1024,
+				Requests:               1536 * 1024 * 1024,
+				Limits:                 2 * 1024 * 1024 * 1024,
+				Allocatable:            6 * 1024 * 1024 * 1024,
+				Capacity:               8 * 1024 * 1024 * 1024,
+				RequestsUtilization:    200,
+				LimitsUtilization:      150,
+				AllocatableUtilization: 50,
+			},
+		}, nodes[0])
+
+		require.Equal(t, "node2", nodes[1].Name)
+		require.Equal(t, int64(0), nodes[1].Pods)
+		require.Equal(t, float64(25), nodes[1].CPU.AllocatableUtilization)
+	})
+}
@@ -10,6 +10,7 @@ package kubernetes
 import (
 	"bufio"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"mime/multipart"
@@ -17,6 +18,7 @@ import (
 	"net/url"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	applicationv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/application/v1"
@@ -27,10 +29,17 @@ import (
 	dashboardClientsetVersioned "github.com/kobsio/kobs/pkg/cluster/kubernetes/clients/dashboard/clientset/versioned"
 	teamClientsetVersioned "github.com/kobsio/kobs/pkg/cluster/kubernetes/clients/team/clientset/versioned"
 	userClientsetVersioned "github.com/kobsio/kobs/pkg/cluster/kubernetes/clients/user/clientset/versioned"
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/cache"
 	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/copy"
 	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/defaults"
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/diff"
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/inventory"
 	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/provider"
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/redact"
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/related"
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/rollout"
 	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/terminal"
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/usage"
 	"github.com/kobsio/kobs/pkg/instrument/log"
 
 	"github.com/gorilla/websocket"
@@ -41,28 +50,51 @@ import (
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kubernetesErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/remotecommand"
+	metricsVersioned "k8s.io/metrics/pkg/client/clientset/versioned"
 	controllerRuntimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	// ErrKeyNotFound is returned by GetResourceValue, when the Secret or ConfigMap doesn't contain the requested key.
+	ErrKeyNotFound = fmt.Errorf("key not found")
 )
 
 type Config struct {
 	Provider provider.Config `json:"provider" embed:"" prefix:"provider." envprefix:"PROVIDER_"`
+	Cache    cache.Config    `json:"cache" embed:"" prefix:"cache." envprefix:"CACHE_"`
+	Redact   redact.Config   `json:"redact" embed:"" prefix:"redact." envprefix:"REDACT_"`
 }
 
 // Client is the interface to interact with an Kubernetes cluster.
 type Client interface {
 	GetClient(ctx context.Context, schema *runtime.Scheme) (controllerRuntimeClient.Client, error)
 	GetNamespaces(ctx context.Context) ([]string, error)
-	GetResources(ctx context.Context, namespace, name, path, resource, paramName, param string) ([]byte, error)
+	GetResources(ctx context.Context, namespace, name, path, resource, paramName, param string, options ListOptions) ([]byte, error)
+	GetResourceValue(ctx context.Context, namespace, name, resource, key string) (string, error)
+	Redactor() *redact.Redactor
 	DeleteResource(ctx context.Context, namespace, name, path, resource string, body []byte) error
 	PatchResource(ctx context.Context, namespace, name, path, resource, subResource string, body []byte) error
 	CreateResource(ctx context.Context, namespace, name, path, resource string, body []byte) error
+	ApplyResource(ctx context.Context, namespace, name, path, resource string, body []byte, force, dryRun bool) (*ApplyResult, error)
+	GetRelatedResources(ctx context.Context, namespace, name, resource string) (*related.Resource, error)
+	RolloutRestart(ctx context.Context, namespace, name, resource string) error
+	RolloutScale(ctx context.Context, namespace, name, resource string, replicas int32) error
+	RolloutPause(ctx context.Context, namespace, name, resource string, paused bool) error
+	RolloutStatus(ctx context.Context, namespace, name, resource string) (*rollout.Status, error)
+	RolloutHistory(ctx context.Context, namespace, name, resource string) ([]rollout.Revision, error)
+	RolloutUndo(ctx context.Context, namespace, name, resource string, revision int64) error
+	GetPodsUsage(ctx context.Context, namespace, labelSelector string) ([]usage.Pod, error)
+	GetNodesUsage(ctx context.Context) ([]usage.Node, error)
 	GetLogs(ctx context.Context, namespace, name, container, regex string, since, tail int64, previous bool) (string, error)
 	StreamLogs(ctx context.Context, conn *websocket.Conn, namespace, name, container string, since, tail int64, follow bool) error
 	GetTerminal(ctx context.Context, conn *websocket.Conn, namespace, name, container, shell string) error
@@ -77,6 +109,8 @@ type Client interface {
 	GetUsers(ctx context.Context, cluster, namespace string) ([]userv1.UserSpec, error)
 	GetUser(ctx context.Context, cluster, namespace, name string) (*userv1.UserSpec, error)
 	GetCRDs(ctx context.Context) ([]CRD, error)
+	GetInventory(ctx context.Context) (*inventory.Inventory, error)
+	Stop()
 }
 
 // client implements the Client interface. It contains all required fields and methods to interact with an Kubernetes
@@ -86,6 +120,7 @@ type Client interface {
 //   - teamClientsetVersioned.Interface        --> *teamClientsetVersioned.Clientset
 //   - dashboardClientsetVersioned.Interface   --> *dashboardClientsetVersioned.Clientset
 //   - userClientsetVersioned.Interface        --> *userClientsetVersioned.Clientset
+//   - metricsVersioned.Interface              --> *metricsVersioned.Clientset
 type client struct {
 	restConfig           *rest.Config
 	clientset            kubernetes.Interface
@@ -93,9 +128,35 @@ type client struct {
 	teamClientset        teamClientsetVersioned.Interface
 	dashboardClientset   dashboardClientsetVersioned.Interface
 	userClientset        userClientsetVersioned.Interface
+	metricsClientset     metricsVersioned.Interface
+	cache                cache.Cache
+	cacheCancel          context.CancelFunc
+	redactor             *redact.Redactor
 	tracer               trace.Tracer
 }
 
+// ListOptions can be used to limit the list of resources returned by GetResources. The label and field selector are
+// evaluated by the Kubernetes API server. When a limit is set and there are more resources, the metadata of the returned
+// list contains a continue token, which can be passed to the next call to get the next page of resources.
+type ListOptions struct {
+	LabelSelector string
+	FieldSelector string
+	Limit         int64
+	Continue      string
+	BypassCache   bool
+}
+
+// FieldManager is the name of the field manager, which is used for all server-side apply requests made by kobs.
+const FieldManager = "kobs"
+
+// ApplyResult is the result of a server-side apply request. It contains the object as it was (or in case of a dry run
+// would be) persisted by the Kubernetes API server. For dry runs it also contains the changes compared to the live
+// object, so that a user can review the changes before they are applied.
+type ApplyResult struct {
+	Object map[string]any `json:"object"`
+	Diff   []diff.Change  `json:"diff,omitempty"`
+}
+
 // CRD is the format of a Custom Resource Definition. Each CRD must contain a path and resource, which are used for the
 // API request to retrieve all CRs for a CRD. It also must contain a title (kind), an optional description, the scope of
 // the CRs (namespaced vs. cluster) and an optional list of columns with the fields, which should be shown in the
@@ -159,8 +220,8 @@ func (c *client) GetNamespaces(ctx context.Context) ([]string, error) {
 
 // GetResources returns a list for the given resource in the given namespace. The resource is identified by the
 // Kubernetes API path and the resource. The name is optional and can be used to get a single resource, instead of a
-// list of resources.
-func (c *client) GetResources(ctx context.Context, namespace, name, path, resource, paramName, param string) ([]byte, error) {
+// list of resources. The list options are only used when no name is provided.
+func (c *client) GetResources(ctx context.Context, namespace, name, path, resource, paramName, param string, options ListOptions) ([]byte, error) {
 	ctx, span := c.tracer.Start(ctx, "cluster.GetResources")
 	span.SetAttributes(attribute.Key("namespace").String(namespace))
 	span.SetAttributes(attribute.Key("name").String(name))
@@ -168,6 +229,11 @@ func (c *client) GetResources(ctx context.Context, namespace, name, path, resour
 	span.SetAttributes(attribute.Key("resource").String(resource))
 	span.SetAttributes(attribute.Key("paramName").String(paramName))
 	span.SetAttributes(attribute.Key("param").String(param))
+	span.SetAttributes(attribute.Key("labelSelector").String(options.LabelSelector))
+	span.SetAttributes(attribute.Key("fieldSelector").String(options.FieldSelector))
+	span.SetAttributes(attribute.Key("limit").Int64(options.Limit))
+	span.SetAttributes(attribute.Key("continue").String(options.Continue))
+	span.SetAttributes(attribute.Key("bypassCache").Bool(options.BypassCache))
 	defer span.End()
 
 	if name != "" {
@@ -194,7 +260,26 @@ func (c *client) GetResources(ctx context.Context, namespace, name, path, resour
 		return res, nil
 	}
 
-	res, err := c.clientset.CoreV1().RESTClient().Get().AbsPath(path).Namespace(namespace).Resource(resource).Param(paramName, param).DoRaw(ctx)
+	if res, ok := c.getResourcesFromCache(namespace, path, resource, paramName, param, options); ok {
+		span.SetAttributes(attribute.Key("cached").Bool(true))
+		return res, nil
+	}
+
+	req := c.clientset.CoreV1().RESTClient().Get().AbsPath(path).Namespace(namespace).Resource(resource).Param(paramName, param)
+	if options.LabelSelector != "" {
+		req = req.Param("labelSelector", options.LabelSelector)
+	}
+	if options.FieldSelector != "" {
+		req = req.Param("fieldSelector", options.FieldSelector)
+	}
+	if options.Limit > 0 {
+		req = req.Param("limit", strconv.FormatInt(options.Limit, 10))
+	}
+	if options.Continue != "" {
+		req = req.Param("continue", options.Continue)
+	}
+
+	res, err := req.DoRaw(ctx)
 	if err != nil {
 		log.Error(ctx, "Could not get resources", zap.Error(err), zap.String("namespace", namespace), zap.String("path", path), zap.String("resource", resource))
 		span.RecordError(err)
@@ -205,6 +290,115 @@ func (c *client) GetResources(ctx context.Context, namespace, name, path, resour
 	return res, nil
 }
 
+// GetResourceValue returns the value of a single key of a Secret or ConfigMap. It is used to reveal a single value on
+// demand, because the values are redacted by the cluster API. The value of Secrets is returned decoded.
+func (c *client) GetResourceValue(ctx context.Context, namespace, name, resource, key string) (string, error) {
+	ctx, span := c.tracer.Start(ctx, "cluster.GetResourceValue")
+	span.SetAttributes(attribute.Key("namespace").String(namespace))
+	span.SetAttributes(attribute.Key("name").String(name))
+	span.SetAttributes(attribute.Key("resource").String(resource))
+	span.SetAttributes(attribute.Key("key").String(key))
+	defer span.End()
+
+	var value string
+	var found bool
+
+	switch resource {
+	case "secrets":
+		secret, err := c.clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			log.Error(ctx, "Could not get secret", zap.Error(err), zap.String("namespace", namespace), zap.String("name", name))
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return "", err
+		}
+
+		var data []byte
+		data, found = secret.Data[key]
+		value = string(data)
+	case "configmaps":
+		configMap, err := c.clientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			log.Error(ctx, "Could not get config map", zap.Error(err), zap.String("namespace", namespace), zap.String("name", name))
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return "", err
+		}
+
+		value, found = configMap.Data[key]
+		if !found {
+			var data []byte
+			data, found = configMap.BinaryData[key]
+			value = base64.StdEncoding.EncodeToString(data)
+		}
+	default:
+		err := fmt.Errorf("resource %s is not supported", resource)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", err
+	}
+
+	if !found {
+		span.RecordError(ErrKeyNotFound)
+		span.SetStatus(codes.Error, ErrKeyNotFound.Error())
+		return "", ErrKeyNotFound
+	}
+
+	return value, nil
+}
+
+// Redactor returns the redactor for the configured redaction of Secrets and ConfigMaps. The client itself always
+// returns the unredacted resources, so that they can be used internally. The redactor must be used before the resources
+// are returned to a user.
+func (c *client) Redactor() *redact.Redactor {
+	return c.redactor
+}
+
+// Stop stops the informers of the cache. It must be called when the client isn't used anymore, e.g. when a cluster is
+// removed from the hub.
+func (c *client) Stop() {
+	if c.cacheCancel != nil {
+		c.cacheCancel()
+	}
+}
+
+// getResourcesFromCache returns the list of resources from the cache, when the cache is enabled and the request can be
+// answered from the cache. Requests with a limit or continue token are always sent to the Kubernetes API server, because
+// the cache does not support pagination. The "paramName" and "param" values are only supported when they are used to
+// set a label or field selector.
+func (c *client) getResourcesFromCache(namespace, path, resource, paramName, param string, options ListOptions) ([]byte, bool) {
+	if c.cache == nil || options.BypassCache || options.Limit > 0 || options.Continue != "" {
+		return nil, false
+	}
+
+	labelSelector := options.LabelSelector
+	fieldSelector := options.FieldSelector
+
+	if paramName != "" && param != "" {
+		switch paramName {
+		case "labelSelector":
+			labelSelector = joinSelectors(labelSelector, param)
+		case "fieldSelector":
+			fieldSelector = joinSelectors(fieldSelector, param)
+		default:
+			return nil, false
+		}
+	}
+
+	return c.cache.List(path, resource, namespace, labelSelector, fieldSelector)
+}
+
+func joinSelectors(selectors ...string) string {
+	var nonEmptySelectors []string
+	for _, selector := range selectors {
+		if selector != "" {
+			nonEmptySelectors = append(nonEmptySelectors, selector)
+		}
+	}
+
+	return strings.Join(nonEmptySelectors, ",")
+}
+
 // DeleteResource can be used to delete the given resource. The resource is identified by the Kubernetes API path and
 // the name of the resource.
 func (c *client) DeleteResource(ctx context.Context, namespace, name, path, resource string, body []byte) error {
@@ -280,6 +474,293 @@ func (c *client) CreateResource(ctx context.Context, namespace, name, path, reso
 	return nil
 }
 
+// ApplyResource applies the given manifest via server-side apply, using kobs as field manager. The manifest can be
+// provided in JSON or YAML format. If no name is provided, the name is taken from the metadata of the manifest. When
+// "force" is set, conflicts with other field managers are resolved in favour of kobs.
+//
+// When "dryRun" is set, the request is sent with "dryRun=All", so that nothing is persisted. In this case the returned
+// result also contains the changes between the live object and the object the API server would persist. Managed
+// fields are removed before the objects are compared, because they would always differ.
+func (c *client) ApplyResource(ctx context.Context, namespace, name, path, resource string, body []byte, force, dryRun bool) (*ApplyResult, error) {
+	ctx, span := c.tracer.Start(ctx, "cluster.ApplyResource")
+	span.SetAttributes(attribute.Key("namespace").String(namespace))
+	span.SetAttributes(attribute.Key("name").String(name))
+	span.SetAttributes(attribute.Key("path").String(path))
+	span.SetAttributes(attribute.Key("resource").String(resource))
+	span.SetAttributes(attribute.Key("force").Bool(force))
+	span.SetAttributes(attribute.Key("dryRun").Bool(dryRun))
+	defer span.End()
+
+	manifest, err := yaml.YAMLToJSON(body)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if name == "" {
+		var obj struct {
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+		}
+		if err := json.Unmarshal(manifest, &obj); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		name = obj.Metadata.Name
+	}
+
+	if name == "" {
+		err := fmt.Errorf("name is required for server-side apply")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	req := c.clientset.CoreV1().RESTClient().Patch(types.ApplyPatchType).AbsPath(path).Namespace(namespace).Resource(resource).Name(name).Param("fieldManager", FieldManager)
+	if force {
+		req = req.Param("force", "true")
+	}
+	if dryRun {
+		req = req.Param("dryRun", "All")
+	}
+
+	res, err := req.Body(manifest).DoRaw(ctx)
+	if err != nil {
+		log.Error(ctx, "Could not apply resource", zap.Error(err), zap.String("namespace", namespace), zap.String("name", name), zap.String("path", path), zap.String("resource", resource), zap.Bool("dryRun", dryRun))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	var result ApplyResult
+	if err := json.Unmarshal(res, &result.Object); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if !dryRun {
+		return &result, nil
+	}
+
+	live := make(map[string]any)
+	liveRes, err := c.clientset.CoreV1().RESTClient().Get().AbsPath(path).Namespace(namespace).Resource(resource).Name(name).DoRaw(ctx)
+	if err != nil {
+		if !kubernetesErrors.IsNotFound(err) {
+			log.Error(ctx, "Could not get live resource", zap.Error(err), zap.String("namespace", namespace), zap.String("name", name), zap.String("path", path), zap.String("resource", resource))
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+	} else if err := json.Unmarshal(liveRes, &live); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	result.Diff = diff.Objects(withoutManagedFields(live), withoutManagedFields(result.Object))
+	return &result, nil
+}
+
+// withoutManagedFields returns a shallow copy of the given object without the "metadata.managedFields" field.
+func withoutManagedFields(obj map[string]any) map[string]any {
+	copied := make(map[string]any, len(obj))
+	for key, value := range obj {
+		copied[key] = value
+	}
+
+	if metadata, ok := obj["metadata"].(map[string]any); ok {
+		copiedMetadata := make(map[string]any, len(metadata))
+		for key, value := range metadata {
+			if key != "managedFields" {
+				copiedMetadata[key] = value
+			}
+		}
+		copied["metadata"] = copiedMetadata
+	}
+
+	return copied
+}
+
+// GetRelatedResources returns the resource with the given name together with all related resources (e.g. the
+// ReplicaSets and Pods of a Deployment) and the Events for each of these resources.
+func (c *client) GetRelatedResources(ctx context.Context, namespace, name, resource string) (*related.Resource, error) {
+	ctx, span := c.tracer.Start(ctx, "cluster.GetRelatedResources")
+	span.SetAttributes(attribute.Key("namespace").String(namespace))
+	span.SetAttributes(attribute.Key("name").String(name))
+	span.SetAttributes(attribute.Key("resource").String(resource))
+	defer span.End()
+
+	relatedResource, err := related.Get(ctx, c.clientset, namespace, name, resource)
+	if err != nil {
+		log.Error(ctx, "Could not get related resources", zap.Error(err), zap.String("namespace", namespace), zap.String("name", name), zap.String("resource", resource))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return relatedResource, nil
+}
+
+// RolloutRestart restarts all Pods of the given Deployment, StatefulSet or DaemonSet.
+func (c *client) RolloutRestart(ctx context.Context, namespace, name, resource string) error {
+	ctx, span := c.tracer.Start(ctx, "cluster.RolloutRestart")
+	span.SetAttributes(attribute.Key("namespace").String(namespace))
+	span.SetAttributes(attribute.Key("name").String(name))
+	span.SetAttributes(attribute.Key("resource").String(resource))
+	defer span.End()
+
+	err := rollout.Restart(ctx, c.clientset, namespace, name, resource)
+	if err != nil {
+		log.Error(ctx, "Could not restart resource", zap.Error(err), zap.String("namespace", namespace), zap.String("name", name), zap.String("resource", resource))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// RolloutScale sets the number of replicas for the given Deployment or StatefulSet.
+func (c *client) RolloutScale(ctx context.Context, namespace, name, resource string, replicas int32) error {
+	ctx, span := c.tracer.Start(ctx, "cluster.RolloutScale")
+	span.SetAttributes(attribute.Key("namespace").String(namespace))
+	span.SetAttributes(attribute.Key("name").String(name))
+	span.SetAttributes(attribute.Key("resource").String(resource))
+	span.SetAttributes(attribute.Key("replicas").Int(int(replicas)))
+	defer span.End()
+
+	err := rollout.Scale(ctx, c.clientset, namespace, name, resource, replicas)
+	if err != nil {
+		log.Error(ctx, "Could not scale resource", zap.Error(err), zap.String("namespace", namespace), zap.String("name", name), zap.String("resource", resource), zap.Int32("replicas", replicas))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// RolloutPause pauses or resumes the rollout of the given Deployment.
+func (c *client) RolloutPause(ctx context.Context, namespace, name, resource string, paused bool) error {
+	ctx, span := c.tracer.Start(ctx, "cluster.RolloutPause")
+	span.SetAttributes(attribute.Key("namespace").String(namespace))
+	span.SetAttributes(attribute.Key("name").String(name))
+	span.SetAttributes(attribute.Key("resource").String(resource))
+	span.SetAttributes(attribute.Key("paused").Bool(paused))
+	defer span.End()
+
+	err := rollout.Pause(ctx, c.clientset, namespace, name, resource, paused)
+	if err != nil {
+		log.Error(ctx, "Could not pause resource", zap.Error(err), zap.String("namespace", namespace), zap.String("name", name), zap.String("resource", resource), zap.Bool("paused", paused))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// RolloutStatus returns the rollout status of the given Deployment, StatefulSet or DaemonSet.
+func (c *client) RolloutStatus(ctx context.Context, namespace, name, resource string) (*rollout.Status, error) {
+	ctx, span := c.tracer.Start(ctx, "cluster.RolloutStatus")
+	span.SetAttributes(attribute.Key("namespace").String(namespace))
+	span.SetAttributes(attribute.Key("name").String(name))
+	span.SetAttributes(attribute.Key("resource").String(resource))
+	defer span.End()
+
+	status, err := rollout.GetStatus(ctx, c.clientset, namespace, name, resource)
+	if err != nil {
+		log.Error(ctx, "Could not get rollout status", zap.Error(err), zap.String("namespace", namespace), zap.String("name", name), zap.String("resource", resource))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// RolloutHistory returns the revision history of the given Deployment, StatefulSet or DaemonSet.
+func (c *client) RolloutHistory(ctx context.Context, namespace, name, resource string) ([]rollout.Revision, error) {
+	ctx, span := c.tracer.Start(ctx, "cluster.RolloutHistory")
+	span.SetAttributes(attribute.Key("namespace").String(namespace))
+	span.SetAttributes(attribute.Key("name").String(name))
+	span.SetAttributes(attribute.Key("resource").String(resource))
+	defer span.End()
+
+	revisions, err := rollout.GetHistory(ctx, c.clientset, namespace, name, resource)
+	if err != nil {
+		log.Error(ctx, "Could not get rollout history", zap.Error(err), zap.String("namespace", namespace), zap.String("name", name), zap.String("resource", resource))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// RolloutUndo rolls back the given Deployment, StatefulSet or DaemonSet to the given revision. When the revision is 0
+// the resource is rolled back to the previous revision.
+func (c *client) RolloutUndo(ctx context.Context, namespace, name, resource string, revision int64) error {
+	ctx, span := c.tracer.Start(ctx, "cluster.RolloutUndo")
+	span.SetAttributes(attribute.Key("namespace").String(namespace))
+	span.SetAttributes(attribute.Key("name").String(name))
+	span.SetAttributes(attribute.Key("resource").String(resource))
+	span.SetAttributes(attribute.Key("revision").Int64(revision))
+	defer span.End()
+
+	err := rollout.Undo(ctx, c.clientset, namespace, name, resource, revision)
+	if err != nil {
+		log.Error(ctx, "Could not undo rollout", zap.Error(err), zap.String("namespace", namespace), zap.String("name", name), zap.String("resource", resource), zap.Int64("revision", revision))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// GetPodsUsage returns the CPU and memory usage of all Pods in the given namespace, which are matching the given label
+// selector. The usage is retrieved from the metrics API (metrics.k8s.io) and joined with the requests and limits of
+// the containers.
+func (c *client) GetPodsUsage(ctx context.Context, namespace, labelSelector string) ([]usage.Pod, error) {
+	ctx, span := c.tracer.Start(ctx, "cluster.GetPodsUsage")
+	span.SetAttributes(attribute.Key("namespace").String(namespace))
+	span.SetAttributes(attribute.Key("labelSelector").String(labelSelector))
+	defer span.End()
+
+	pods, err := usage.GetPods(ctx, c.clientset, c.metricsClientset, namespace, labelSelector)
+	if err != nil {
+		log.Error(ctx, "Could not get pods usage", zap.Error(err), zap.String("namespace", namespace), zap.String("labelSelector", labelSelector))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return pods, nil
+}
+
+// GetNodesUsage returns the CPU and memory usage of all Nodes. The usage is retrieved from the metrics API
+// (metrics.k8s.io) and joined with the allocatable resources of the Nodes and the requests and limits of all Pods on
+// the Nodes.
+func (c *client) GetNodesUsage(ctx context.Context) ([]usage.Node, error) {
+	ctx, span := c.tracer.Start(ctx, "cluster.GetNodesUsage")
+	defer span.End()
+
+	nodes, err := usage.GetNodes(ctx, c.clientset, c.metricsClientset)
+	if err != nil {
+		log.Error(ctx, "Could not get nodes usage", zap.Error(err))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return nodes, nil
+}
+
 // GetLogs returns the logs for a Container. The Container is identified by the namespace and pod name and the container
 // name. Is is also possible to set the time since when the logs should be received and with the previous flag the logs
 // for the last container can be received.
@@ -707,6 +1188,37 @@ func (c *client) GetCRDs(ctx context.Context) ([]CRD, error) {
 	return crds, nil
 }
 
+// GetInventory returns the inventory of the cluster, which contains the Kubernetes version, the versions of all Nodes,
+// the allocatable resources and the groups of all installed Custom Resource Definitions.
+func (c *client) GetInventory(ctx context.Context) (*inventory.Inventory, error) {
+	ctx, span := c.tracer.Start(ctx, "cluster.GetInventory")
+	defer span.End()
+
+	crds, err := c.GetCRDs(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	var crdGroups []string
+	for _, crd := range crds {
+		if _, group, ok := strings.Cut(crd.ID, "."); ok {
+			crdGroups = append(crdGroups, group)
+		}
+	}
+
+	inv, err := inventory.Get(ctx, c.clientset, crdGroups)
+	if err != nil {
+		log.Error(ctx, "Could not get inventory", zap.Error(err))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return inv, nil
+}
+
 // NewClient returns a new client to interact with a Kubernetes cluster. Each cluster must have a unique name and the
 // actual Kubernetes clients to make requests against the Kubernetes API server. When a client was successfully created
 // we call the loadCRDs function to get all CRDs in the Kubernetes cluster.
@@ -747,6 +1259,39 @@ func NewClient(config Config) (Client, error) {
 		return nil, err
 	}
 
+	metricsClientset, err := metricsVersioned.NewForConfig(restConfig)
+	if err != nil {
+		log.Error(context.Background(), "Could not create metrics clientset", zap.Error(err))
+		return nil, err
+	}
+
+	redactor, err := redact.New(config.Redact)
+	if err != nil {
+		log.Error(context.Background(), "Could not create redactor", zap.Error(err))
+		return nil, err
+	}
+
+	var resourcesCache cache.Cache
+	var cacheCancel context.CancelFunc
+	if config.Cache.Enabled {
+		dynamicClient, err := dynamic.NewForConfig(restConfig)
+		if err != nil {
+			log.Error(context.Background(), "Could not create dynamic client", zap.Error(err))
+			return nil, err
+		}
+
+		// The informers of the cache are running until the Stop method of the client is called.
+		var cacheCtx context.Context
+		cacheCtx, cacheCancel = context.WithCancel(context.Background())
+
+		resourcesCache, err = cache.New(cacheCtx, config.Cache, dynamicClient)
+		if err != nil {
+			cacheCancel()
+			log.Error(context.Background(), "Could not create cache", zap.Error(err))
+			return nil, err
+		}
+	}
+
 	return &client{
 		restConfig:           restConfig,
 		clientset:            clientset,
@@ -754,6 +1299,10 @@ func NewClient(config Config) (Client, error) {
 		teamClientset:        teamClientset,
 		dashboardClientset:   dashboardClientset,
 		userClientset:        userClientset,
+		metricsClientset:     metricsClientset,
+		cache:                resourcesCache,
+		cacheCancel:          cacheCancel,
+		redactor:             redactor,
 		tracer:               otel.Tracer("cluster"),
 	}, nil
 }
@@ -16,6 +16,11 @@ import (
 	v10 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
 	v11 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/team/v1"
 	v12 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/user/v1"
+	inventory "github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/inventory"
+	redact "github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/redact"
+	related "github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/related"
+	rollout "github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/rollout"
+	usage "github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/usage"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	controllerRuntimeClient "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -43,6 +48,21 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// ApplyResource mocks base method.
+func (m *MockClient) ApplyResource(ctx context.Context, namespace, name, path, resource string, body []byte, force, dryRun bool) (*ApplyResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyResource", ctx, namespace, name, path, resource, body, force, dryRun)
+	ret0, _ := ret[0].(*ApplyResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyResource indicates an expected call of ApplyResource.
+func (mr *MockClientMockRecorder) ApplyResource(ctx, namespace, name, path, resource, body, force, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyResource", reflect.TypeOf((*MockClient)(nil).ApplyResource), ctx, namespace, name, path, resource, body, force, dryRun)
+}
+
 // CopyFileFromPod mocks base method.
 func (m *MockClient) CopyFileFromPod(ctx context.Context, w http.ResponseWriter, namespace, name, container, srcPath string) error {
 	m.ctrl.T.Helper()
@@ -189,6 +209,21 @@ func (mr *MockClientMockRecorder) GetDashboards(ctx, cluster, namespace interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDashboards", reflect.TypeOf((*MockClient)(nil).GetDashboards), ctx, cluster, namespace)
 }
 
+// GetInventory mocks base method.
+func (m *MockClient) GetInventory(ctx context.Context) (*inventory.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventory", ctx)
+	ret0, _ := ret[0].(*inventory.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInventory indicates an expected call of GetInventory.
+func (mr *MockClientMockRecorder) GetInventory(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventory", reflect.TypeOf((*MockClient)(nil).GetInventory), ctx)
+}
+
 // GetLogs mocks base method.
 func (m *MockClient) GetLogs(ctx context.Context, namespace, name, container, regex string, since, tail int64, previous bool) (string, error) {
 	m.ctrl.T.Helper()
@@ -219,19 +254,79 @@ func (mr *MockClientMockRecorder) GetNamespaces(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNamespaces", reflect.TypeOf((*MockClient)(nil).GetNamespaces), ctx)
 }
 
+// GetNodesUsage mocks base method.
+func (m *MockClient) GetNodesUsage(ctx context.Context) ([]usage.Node, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodesUsage", ctx)
+	ret0, _ := ret[0].([]usage.Node)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNodesUsage indicates an expected call of GetNodesUsage.
+func (mr *MockClientMockRecorder) GetNodesUsage(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodesUsage", reflect.TypeOf((*MockClient)(nil).GetNodesUsage), ctx)
+}
+
+// GetPodsUsage mocks base method.
+func (m *MockClient) GetPodsUsage(ctx context.Context, namespace, labelSelector string) ([]usage.Pod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPodsUsage", ctx, namespace, labelSelector)
+	ret0, _ := ret[0].([]usage.Pod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPodsUsage indicates an expected call of GetPodsUsage.
+func (mr *MockClientMockRecorder) GetPodsUsage(ctx, namespace, labelSelector interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPodsUsage", reflect.TypeOf((*MockClient)(nil).GetPodsUsage), ctx, namespace, labelSelector)
+}
+
+// GetRelatedResources mocks base method.
+func (m *MockClient) GetRelatedResources(ctx context.Context, namespace, name, resource string) (*related.Resource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRelatedResources", ctx, namespace, name, resource)
+	ret0, _ := ret[0].(*related.Resource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRelatedResources indicates an expected call of GetRelatedResources.
+func (mr *MockClientMockRecorder) GetRelatedResources(ctx, namespace, name, resource interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRelatedResources", reflect.TypeOf((*MockClient)(nil).GetRelatedResources), ctx, namespace, name, resource)
+}
+
+// GetResourceValue mocks base method.
+func (m *MockClient) GetResourceValue(ctx context.Context, namespace, name, resource, key string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResourceValue", ctx, namespace, name, resource, key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResourceValue indicates an expected call of GetResourceValue.
+func (mr *MockClientMockRecorder) GetResourceValue(ctx, namespace, name, resource, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourceValue", reflect.TypeOf((*MockClient)(nil).GetResourceValue), ctx, namespace, name, resource, key)
+}
+
 // GetResources mocks base method.
-func (m *MockClient) GetResources(ctx context.Context, namespace, name, path, resource, paramName, param string) ([]byte, error) {
+func (m *MockClient) GetResources(ctx context.Context, namespace, name, path, resource, paramName, param string, options ListOptions) ([]byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetResources", ctx, namespace, name, path, resource, paramName, param)
+	ret := m.ctrl.Call(m, "GetResources", ctx, namespace, name, path, resource, paramName, param, options)
 	ret0, _ := ret[0].([]byte)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetResources indicates an expected call of GetResources.
-func (mr *MockClientMockRecorder) GetResources(ctx, namespace, name, path, resource, paramName, param interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) GetResources(ctx, namespace, name, path, resource, paramName, param, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResources", reflect.TypeOf((*MockClient)(nil).GetResources), ctx, namespace, name, path, resource, paramName, param)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResources", reflect.TypeOf((*MockClient)(nil).GetResources), ctx, namespace, name, path, resource, paramName, param, options)
 }
 
 // GetTeam mocks base method.
@@ -322,6 +417,118 @@ func (mr *MockClientMockRecorder) PatchResource(ctx, namespace, name, path, reso
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchResource", reflect.TypeOf((*MockClient)(nil).PatchResource), ctx, namespace, name, path, resource, subResource, body)
 }
 
+// Redactor mocks base method.
+func (m *MockClient) Redactor() *redact.Redactor {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redactor")
+	ret0, _ := ret[0].(*redact.Redactor)
+	return ret0
+}
+
+// Redactor indicates an expected call of Redactor.
+func (mr *MockClientMockRecorder) Redactor() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redactor", reflect.TypeOf((*MockClient)(nil).Redactor))
+}
+
+// RolloutHistory mocks base method.
+func (m *MockClient) RolloutHistory(ctx context.Context, namespace, name, resource string) ([]rollout.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RolloutHistory", ctx, namespace, name, resource)
+	ret0, _ := ret[0].([]rollout.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RolloutHistory indicates an expected call of RolloutHistory.
+func (mr *MockClientMockRecorder) RolloutHistory(ctx, namespace, name, resource interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RolloutHistory", reflect.TypeOf((*MockClient)(nil).RolloutHistory), ctx, namespace, name, resource)
+}
+
+// RolloutPause mocks base method.
+func (m *MockClient) RolloutPause(ctx context.Context, namespace, name, resource string, paused bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RolloutPause", ctx, namespace, name, resource, paused)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RolloutPause indicates an expected call of RolloutPause.
+func (mr *MockClientMockRecorder) RolloutPause(ctx, namespace, name, resource, paused interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RolloutPause", reflect.TypeOf((*MockClient)(nil).RolloutPause), ctx, namespace, name, resource, paused)
+}
+
+// RolloutRestart mocks base method.
+func (m *MockClient) RolloutRestart(ctx context.Context, namespace, name, resource string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RolloutRestart", ctx, namespace, name, resource)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RolloutRestart indicates an expected call of RolloutRestart.
+func (mr *MockClientMockRecorder) RolloutRestart(ctx, namespace, name, resource interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RolloutRestart", reflect.TypeOf((*MockClient)(nil).RolloutRestart), ctx, namespace, name, resource)
+}
+
+// RolloutScale mocks base method.
+func (m *MockClient) RolloutScale(ctx context.Context, namespace, name, resource string, replicas int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RolloutScale", ctx, namespace, name, resource, replicas)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RolloutScale indicates an expected call of RolloutScale.
+func (mr *MockClientMockRecorder) RolloutScale(ctx, namespace, name, resource, replicas interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RolloutScale", reflect.TypeOf((*MockClient)(nil).RolloutScale), ctx, namespace, name, resource, replicas)
+}
+
+// RolloutStatus mocks base method.
+func (m *MockClient) RolloutStatus(ctx context.Context, namespace, name, resource string) (*rollout.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RolloutStatus", ctx, namespace, name, resource)
+	ret0, _ := ret[0].(*rollout.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RolloutStatus indicates an expected call of RolloutStatus.
+func (mr *MockClientMockRecorder) RolloutStatus(ctx, namespace, name, resource interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RolloutStatus", reflect.TypeOf((*MockClient)(nil).RolloutStatus), ctx, namespace, name, resource)
+}
+
+// RolloutUndo mocks base method.
+func (m *MockClient) RolloutUndo(ctx context.Context, namespace, name, resource string, revision int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RolloutUndo", ctx, namespace, name, resource, revision)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RolloutUndo indicates an expected call of RolloutUndo.
+func (mr *MockClientMockRecorder) RolloutUndo(ctx, namespace, name, resource, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RolloutUndo", reflect.TypeOf((*MockClient)(nil).RolloutUndo), ctx, namespace, name, resource, revision)
+}
+
+// Stop mocks base method.
+func (m *MockClient) Stop() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Stop")
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockClientMockRecorder) Stop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockClient)(nil).Stop))
+}
+
 // StreamLogs mocks base method.
 func (m *MockClient) StreamLogs(ctx context.Context, conn *websocket.Conn, namespace, name, container string, since, tail int64, follow bool) error {
 	m.ctrl.T.Helper()
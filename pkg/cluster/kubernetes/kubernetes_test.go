@@ -366,3 +366,101 @@ func TestGetUser(t *testing.T) {
 		require.Equal(t, &userv1.UserSpec{Cluster: "cluster", Namespace: "default", Name: "user1"}, users)
 	})
 }
+
+type testCache struct {
+	labelSelector string
+	fieldSelector string
+}
+
+func (c *testCache) List(path, resource, namespace, labelSelector, fieldSelector string) ([]byte, bool) {
+	c.labelSelector = labelSelector
+	c.fieldSelector = fieldSelector
+	return []byte(`{"items":[]}`), resource == "pods"
+}
+
+func TestGetResourcesFromCache(t *testing.T) {
+	for _, tt := range []struct {
+		name                  string
+		resource              string
+		paramName             string
+		param                 string
+		options               ListOptions
+		expectedOk            bool
+		expectedLabelSelector string
+		expectedFieldSelector string
+	}{
+		{name: "should return resources from cache", resource: "pods", options: ListOptions{LabelSelector: "app=nginx"}, expectedOk: true, expectedLabelSelector: "app=nginx"},
+		{name: "should join selectors from param", resource: "pods", paramName: "labelSelector", param: "tier=frontend", options: ListOptions{LabelSelector: "app=nginx", FieldSelector: "metadata.name=nginx"}, expectedOk: true, expectedLabelSelector: "app=nginx,tier=frontend", expectedFieldSelector: "metadata.name=nginx"},
+		{name: "should not use cache for unsupported param", resource: "pods", paramName: "timeoutSeconds", param: "10"},
+		{name: "should not use cache when bypass is set", resource: "pods", options: ListOptions{BypassCache: true}},
+		{name: "should not use cache for limit", resource: "pods", options: ListOptions{Limit: 10}},
+		{name: "should not use cache for continue token", resource: "pods", options: ListOptions{Continue: "next"}},
+		{name: "should not use cache for resource which is not cached", resource: "services"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &testCache{}
+			client := client{cache: c, tracer: otel.Tracer("cluster")}
+
+			_, ok := client.getResourcesFromCache("default", "/api/v1", tt.resource, tt.paramName, tt.param, tt.options)
+			require.Equal(t, tt.expectedOk, ok)
+			require.Equal(t, tt.expectedLabelSelector, c.labelSelector)
+			require.Equal(t, tt.expectedFieldSelector, c.fieldSelector)
+		})
+	}
+
+	t.Run("should not use cache when cache is disabled", func(t *testing.T) {
+		client := client{tracer: otel.Tracer("cluster")}
+		_, ok := client.getResourcesFromCache("default", "/api/v1", "pods", "", "", ListOptions{})
+		require.False(t, ok)
+	})
+}
+
+func TestGetResourceValue(t *testing.T) {
+	client := client{
+		clientset: fake.NewSimpleClientset(
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "db"}, Data: map[string][]byte{"password": []byte("secret")}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "app"}, Data: map[string]string{"token": "abc"}, BinaryData: map[string][]byte{"cert": []byte("cert")}},
+		),
+		tracer: otel.Tracer("cluster"),
+	}
+
+	for _, tt := range []struct {
+		name          string
+		resource      string
+		resourceName  string
+		key           string
+		expectedValue string
+		expectedError error
+	}{
+		{name: "should return secret value", resource: "secrets", resourceName: "db", key: "password", expectedValue: "secret"},
+		{name: "should return config map value", resource: "configmaps", resourceName: "app", key: "token", expectedValue: "abc"},
+		{name: "should return config map binary value", resource: "configmaps", resourceName: "app", key: "cert", expectedValue: "Y2VydA=="},
+		{name: "should return error for missing secret key", resource: "secrets", resourceName: "db", key: "username", expectedError: ErrKeyNotFound},
+		{name: "should return error for missing config map key", resource: "configmaps", resourceName: "app", key: "username", expectedError: ErrKeyNotFound},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := client.GetResourceValue(context.Background(), "default", tt.resourceName, tt.resource, tt.key)
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedValue, value)
+			}
+		})
+	}
+
+	t.Run("should return error for missing secret", func(t *testing.T) {
+		_, err := client.GetResourceValue(context.Background(), "default", "missing", "secrets", "password")
+		require.Error(t, err)
+	})
+
+	t.Run("should return error for missing config map", func(t *testing.T) {
+		_, err := client.GetResourceValue(context.Background(), "default", "missing", "configmaps", "password")
+		require.Error(t, err)
+	})
+
+	t.Run("should return error for unsupported resource", func(t *testing.T) {
+		_, err := client.GetResourceValue(context.Background(), "default", "nginx", "pods", "password")
+		require.Error(t, err)
+	})
+}
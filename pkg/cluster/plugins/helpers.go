@@ -16,3 +16,18 @@ func filterInstances(pluginType string, instances []plugin.Instance) []plugin.In
 
 	return filteredInstances
 }
+
+// FilterInstancesByContext returns the plugin instances, which should be served for the given context, when a cluster
+// serves multiple contexts from a Kubeconfig file. The "cluster" field of an instance can be used to scope the instance
+// to a single context. Instances without a "cluster" field are served for all contexts.
+func FilterInstancesByContext(context string, instances []plugin.Instance) []plugin.Instance {
+	var filteredInstances []plugin.Instance
+
+	for _, instance := range instances {
+		if instance.Cluster == "" || instance.Cluster == context {
+			filteredInstances = append(filteredInstances, instance)
+		}
+	}
+
+	return filteredInstances
+}
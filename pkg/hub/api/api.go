@@ -3,18 +3,23 @@ package api
 import (
 	"context"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	applicationsAPI "github.com/kobsio/kobs/pkg/hub/api/applications"
 	clustersAPI "github.com/kobsio/kobs/pkg/hub/api/clusters"
 	dashboardsAPI "github.com/kobsio/kobs/pkg/hub/api/dashboards"
+	preferencesAPI "github.com/kobsio/kobs/pkg/hub/api/preferences"
 	resourcesAPI "github.com/kobsio/kobs/pkg/hub/api/resources"
+	scorecardsAPI "github.com/kobsio/kobs/pkg/hub/api/scorecards"
 	teamsAPI "github.com/kobsio/kobs/pkg/hub/api/teams"
 	usersAPI "github.com/kobsio/kobs/pkg/hub/api/users"
 	"github.com/kobsio/kobs/pkg/hub/app/settings"
 	"github.com/kobsio/kobs/pkg/hub/auth"
 	"github.com/kobsio/kobs/pkg/hub/clusters"
 	"github.com/kobsio/kobs/pkg/hub/db"
+	"github.com/kobsio/kobs/pkg/hub/health"
+	"github.com/kobsio/kobs/pkg/hub/oncall"
 	"github.com/kobsio/kobs/pkg/hub/plugins"
 	"github.com/kobsio/kobs/pkg/instrument"
 	"github.com/kobsio/kobs/pkg/instrument/log"
@@ -32,14 +37,22 @@ type Config struct {
 }
 
 // Server is the interface of a hub service, which provides the options to start and stop the underlying http server.
+// Reload can be used to apply changed settings without restarting the http server.
 type Server interface {
 	Start()
 	Stop()
+	Reload(appSettings settings.Settings) (func(), error)
 }
 
-// server implements the Server interface.
+// server implements the Server interface. All requests are handled by the current router, which is replaced when the
+// settings are reloaded.
 type server struct {
-	server *http.Server
+	server         *http.Server
+	router         atomic.Pointer[chi.Mux]
+	authClient     auth.Client
+	clustersClient clusters.Client
+	dbClient       db.Client
+	pluginsClient  plugins.Client
 }
 
 // Start starts serving the hub server.
@@ -66,12 +79,33 @@ func (s *server) Stop() {
 	}
 }
 
-// New return a new hub server. It creates the underlying http server, with the given address.
+// Reload creates a new router with the provided settings. The returned function replaces the current router with the
+// new router, so that the caller can apply the new router together with other changes. If the router can not be
+// created, e.g. because the health rules are invalid, the current router is kept.
+func (s *server) Reload(appSettings settings.Settings) (func(), error) {
+	router, err := s.newRouter(appSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() {
+		s.router.Store(router)
+	}, nil
+}
+
+// newRouter creates the router for the hub server with the provided settings.
 //
 // We exclude the health check from all middlewares, because the health check just returns 200. Therefore we do not need
 // our defined middlewares like request id, metrics, auth or loggin. This also makes it easier to analyze the logs in a
 // Kubernetes cluster where the health check is called every x seconds, because we generate less logs.
-func New(config Config, appSettings settings.Settings, authClient auth.Client, clustersClient clusters.Client, dbClient db.Client, pluginsClient plugins.Client) (Server, error) {
+func (s *server) newRouter(appSettings settings.Settings) (*chi.Mux, error) {
+	healthEvaluator, err := health.NewEvaluator(appSettings.Health)
+	if err != nil {
+		return nil, err
+	}
+
+	oncallClient := oncall.NewClient(s.pluginsClient.Mount())
+
 	router := chi.NewRouter()
 	router.Use(recoverer.Handler)
 	router.Use(middleware.Compress(5))
@@ -88,25 +122,49 @@ func New(config Config, appSettings settings.Settings, authClient auth.Client, c
 
 	router.Route("/api", func(r chi.Router) {
 		r.Use(instrument.Handler())
-		r.Mount("/auth", authClient.Mount())
+		r.Mount("/auth", s.authClient.Mount())
+		r.Get("/tunnel", s.clustersClient.ServeTunnel)
 
 		r.Group(func(r chi.Router) {
-			r.Use(authClient.MiddlewareHandler)
-			r.Mount("/clusters", clustersAPI.Mount(dbClient, clustersClient))
-			r.Mount("/applications", applicationsAPI.Mount(appSettings, dbClient))
-			r.Mount("/teams", teamsAPI.Mount(appSettings, dbClient))
-			r.Mount("/users", usersAPI.Mount(appSettings, dbClient))
-			r.Mount("/dashboards", dashboardsAPI.Mount(dbClient))
-			r.Mount("/resources", resourcesAPI.Mount(appSettings, clustersClient, dbClient))
-			r.Mount("/plugins", pluginsClient.Mount())
+			r.Use(s.authClient.MiddlewareHandler)
+			r.Mount("/clusters", clustersAPI.Mount(s.dbClient, s.clustersClient))
+			r.Mount("/applications", applicationsAPI.Mount(appSettings, s.dbClient, oncallClient))
+			r.Mount("/teams", teamsAPI.Mount(appSettings, s.dbClient, oncallClient))
+			r.Mount("/users", usersAPI.Mount(appSettings, s.dbClient))
+			r.Mount("/dashboards", dashboardsAPI.Mount(appSettings, s.dbClient))
+			r.Mount("/preferences", preferencesAPI.Mount(s.dbClient))
+			r.Mount("/scorecards", scorecardsAPI.Mount(appSettings, s.dbClient))
+			r.Mount("/resources", resourcesAPI.Mount(appSettings, s.clustersClient, s.dbClient, healthEvaluator))
+			r.Mount("/plugins", s.pluginsClient.Mount())
 		})
 	})
 
-	return &server{
-		server: &http.Server{
-			Addr:              config.Address,
-			Handler:           router,
-			ReadHeaderTimeout: 3 * time.Second,
-		},
-	}, nil
+	return router, nil
+}
+
+// New return a new hub server. It creates the underlying http server, with the given address. The router of the server
+// is created with the provided settings and can be replaced via the Reload method.
+func New(config Config, appSettings settings.Settings, authClient auth.Client, clustersClient clusters.Client, dbClient db.Client, pluginsClient plugins.Client) (Server, error) {
+	s := &server{
+		authClient:     authClient,
+		clustersClient: clustersClient,
+		dbClient:       dbClient,
+		pluginsClient:  pluginsClient,
+	}
+
+	commit, err := s.Reload(appSettings)
+	if err != nil {
+		return nil, err
+	}
+	commit()
+
+	s.server = &http.Server{
+		Addr: config.Address,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			s.router.Load().ServeHTTP(w, r)
+		}),
+		ReadHeaderTimeout: 3 * time.Second,
+	}
+
+	return s, nil
 }
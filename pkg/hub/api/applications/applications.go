@@ -10,6 +10,7 @@ import (
 	"github.com/kobsio/kobs/pkg/hub/app/settings"
 	authContext "github.com/kobsio/kobs/pkg/hub/auth/context"
 	"github.com/kobsio/kobs/pkg/hub/db"
+	"github.com/kobsio/kobs/pkg/hub/oncall"
 	"github.com/kobsio/kobs/pkg/instrument/log"
 	"github.com/kobsio/kobs/pkg/utils/middleware/errresponse"
 
@@ -24,9 +25,10 @@ import (
 
 type Router struct {
 	*chi.Mux
-	appSettings settings.Settings
-	dbClient    db.Client
-	tracer      trace.Tracer
+	appSettings  settings.Settings
+	dbClient     db.Client
+	tracer       trace.Tracer
+	oncallClient oncall.Client
 }
 
 func (router *Router) getApplications(w http.ResponseWriter, r *http.Request) {
@@ -39,6 +41,7 @@ func (router *Router) getApplications(w http.ResponseWriter, r *http.Request) {
 	clusters := r.URL.Query()["cluster"]
 	namespaces := r.URL.Query()["namespace"]
 	tags := r.URL.Query()["tag"]
+	favorite := r.URL.Query().Get("favorite")
 	searchTerm := r.URL.Query().Get("searchTerm")
 	limit := r.URL.Query().Get("limit")
 	offset := r.URL.Query().Get("offset")
@@ -48,6 +51,7 @@ func (router *Router) getApplications(w http.ResponseWriter, r *http.Request) {
 	span.SetAttributes(attribute.Key("clusters").StringSlice(clusters))
 	span.SetAttributes(attribute.Key("namespaces").StringSlice(namespaces))
 	span.SetAttributes(attribute.Key("tags").StringSlice(tags))
+	span.SetAttributes(attribute.Key("favorite").String(favorite))
 	span.SetAttributes(attribute.Key("searchTerm").String(searchTerm))
 	span.SetAttributes(attribute.Key("limit").String(limit))
 	span.SetAttributes(attribute.Key("offset").String(offset))
@@ -86,7 +90,25 @@ func (router *Router) getApplications(w http.ResponseWriter, r *http.Request) {
 		teams = nil
 	}
 
-	applications, err := router.dbClient.GetApplicationsByFilter(ctx, teams, clusters, namespaces, tags, searchTerm, parsedLimit, parsedOffset)
+	// If the user only wants to see his favorite applications, we get the favorites from the preferences of the user
+	// and use them as filter. When the user doesn't have any favorites, we use an empty list, so that no applications
+	// are returned.
+	var ids []string
+	if parsedFavorite, _ := strconv.ParseBool(favorite); parsedFavorite {
+		preferences, err := router.dbClient.GetPreferences(ctx, user.ID)
+		if err != nil {
+			log.Error(ctx, "Failed to get preferences", zap.Error(err))
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get preferences")
+			return
+		}
+
+		ids = []string{}
+		ids = append(ids, preferences.Favorites.Applications...)
+	}
+
+	applications, err := router.dbClient.GetApplicationsByFilter(ctx, teams, clusters, namespaces, tags, ids, searchTerm, parsedLimit, parsedOffset)
 	if err != nil {
 		log.Error(ctx, "Failed to get applications", zap.Error(err))
 		span.RecordError(err)
@@ -95,7 +117,7 @@ func (router *Router) getApplications(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	count, err := router.dbClient.GetApplicationsByFilterCount(ctx, teams, clusters, namespaces, tags, searchTerm)
+	count, err := router.dbClient.GetApplicationsByFilterCount(ctx, teams, clusters, namespaces, tags, ids, searchTerm)
 	if err != nil {
 		log.Error(ctx, "Failed to get applications count", zap.Error(err))
 		span.RecordError(err)
@@ -170,9 +192,73 @@ func (router *Router) getApplication(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Add the application to the recently viewed items of the user. If this fails we only log the error, because it
+	// should not prevent the user from viewing the application.
+	if err := router.dbClient.AddRecentlyViewed(ctx, user.ID, db.PreferenceApplication, id); err != nil {
+		log.Warn(ctx, "Failed to add application to recently viewed items", zap.Error(err), zap.String("id", id))
+	}
+
 	render.JSON(w, r, application)
 }
 
+// getApplicationOnCall returns the on-call information for all teams of an application, which have on-call information
+// configured. Teams without on-call information are skipped.
+func (router *Router) getApplicationOnCall(w http.ResponseWriter, r *http.Request) {
+	ctx, span := router.tracer.Start(r.Context(), "getApplicationOnCall")
+	defer span.End()
+
+	user := authContext.MustGetUser(ctx)
+	id := r.URL.Query().Get("id")
+
+	span.SetAttributes(attribute.Key("id").String(id))
+
+	application, err := router.dbClient.GetApplicationByID(ctx, id)
+	if err != nil {
+		log.Error(ctx, "Failed to get application", zap.Error(err), zap.String("id", id))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get application")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	if application == nil {
+		log.Error(ctx, "Application was not found", zap.Error(err), zap.String("id", id))
+		span.RecordError(fmt.Errorf("application was not found"))
+		span.SetStatus(codes.Error, "application was not found")
+		errresponse.Render(w, r, http.StatusNotFound, "Application was not found")
+		return
+	}
+
+	if !user.HasApplicationAccess(application) {
+		log.Warn(ctx, "The user is not authorized to view the application", zap.String("id", id))
+		span.RecordError(fmt.Errorf("user is not authorized to view the application"))
+		span.SetStatus(codes.Error, "user is not authorized to view the application")
+		errresponse.Render(w, r, http.StatusForbidden, "You are not allowed to view the application")
+		return
+	}
+
+	onCalls := []*oncall.OnCall{}
+
+	if len(application.Teams) > 0 {
+		teams, err := router.dbClient.GetTeamsByIDs(ctx, application.Teams, "")
+		if err != nil {
+			log.Error(ctx, "Failed to get teams", zap.Error(err), zap.String("id", id))
+			errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get teams")
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return
+		}
+
+		for _, team := range teams {
+			if onCall := router.oncallClient.GetOnCall(ctx, team); onCall != nil {
+				onCalls = append(onCalls, onCall)
+			}
+		}
+	}
+
+	render.JSON(w, r, onCalls)
+}
+
 func (router *Router) getApplicationsByTeam(w http.ResponseWriter, r *http.Request) {
 	ctx, span := router.tracer.Start(r.Context(), "getApplications")
 	defer span.End()
@@ -217,7 +303,7 @@ func (router *Router) getApplicationsByTeam(w http.ResponseWriter, r *http.Reque
 		}
 	}
 
-	applications, err := router.dbClient.GetApplicationsByFilter(ctx, teams, nil, nil, nil, "", parsedLimit, parsedOffset)
+	applications, err := router.dbClient.GetApplicationsByFilter(ctx, teams, nil, nil, nil, nil, "", parsedLimit, parsedOffset)
 	if err != nil {
 		log.Error(ctx, "Failed to get applications", zap.Error(err))
 		span.RecordError(err)
@@ -226,7 +312,7 @@ func (router *Router) getApplicationsByTeam(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	count, err := router.dbClient.GetApplicationsByFilterCount(ctx, teams, nil, nil, nil, "")
+	count, err := router.dbClient.GetApplicationsByFilterCount(ctx, teams, nil, nil, nil, nil, "")
 	if err != nil {
 		log.Error(ctx, "Failed to get applications count", zap.Error(err))
 		span.RecordError(err)
@@ -330,18 +416,20 @@ func (router *Router) getApplicationGroups(w http.ResponseWriter, r *http.Reques
 	render.JSON(w, r, applicationsGroups)
 }
 
-func Mount(appSettings settings.Settings, dbClient db.Client) chi.Router {
+func Mount(appSettings settings.Settings, dbClient db.Client, oncallClient oncall.Client) chi.Router {
 	router := Router{
 		chi.NewRouter(),
 		appSettings,
 		dbClient,
 		otel.Tracer("applications"),
+		oncallClient,
 	}
 
 	router.Get("/", router.getApplications)
 	router.Get("/tags", router.getTags)
 	router.Get("/application", router.getApplication)
 	router.Post("/application", router.saveApplication)
+	router.Get("/oncall", router.getApplicationOnCall)
 	router.Get("/team", router.getApplicationsByTeam)
 	router.Get("/topology", router.getApplicationsTopology)
 	router.Get("/topology/application", router.getApplicationTopology)
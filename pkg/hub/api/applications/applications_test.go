@@ -9,10 +9,12 @@ import (
 	"testing"
 
 	applicationv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/application/v1"
+	teamv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/team/v1"
 	userv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/user/v1"
 	"github.com/kobsio/kobs/pkg/hub/app/settings"
 	authContext "github.com/kobsio/kobs/pkg/hub/auth/context"
 	"github.com/kobsio/kobs/pkg/hub/db"
+	"github.com/kobsio/kobs/pkg/hub/oncall"
 	"github.com/kobsio/kobs/pkg/utils"
 
 	"github.com/go-chi/chi/v5"
@@ -25,7 +27,7 @@ func TestGetApplications(t *testing.T) {
 	var newRouter = func(t *testing.T) (*db.MockClient, Router) {
 		ctrl := gomock.NewController(t)
 		dbClient := db.NewMockClient(ctrl)
-		router := Router{chi.NewRouter(), settings.Settings{}, dbClient, otel.Tracer("applications")}
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient, otel.Tracer("applications"), nil}
 
 		return dbClient, router
 	}
@@ -77,7 +79,7 @@ func TestGetApplications(t *testing.T) {
 
 	t.Run("should handle error from db client for applications", func(t *testing.T) {
 		dbClient, router := newRouter(t)
-		dbClient.EXPECT().GetApplicationsByFilter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("could not get applications"))
+		dbClient.EXPECT().GetApplicationsByFilter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("could not get applications"))
 
 		ctx := context.Background()
 		ctx = context.WithValue(ctx, chi.RouteCtxKey, chi.NewRouteContext())
@@ -93,13 +95,13 @@ func TestGetApplications(t *testing.T) {
 
 	t.Run("should handle error from db client for count", func(t *testing.T) {
 		dbClient, router := newRouter(t)
-		dbClient.EXPECT().GetApplicationsByFilter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]applicationv1.ApplicationSpec{
+		dbClient.EXPECT().GetApplicationsByFilter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]applicationv1.ApplicationSpec{
 			{
 				Name:      "foo",
 				Namespace: "bar",
 			},
 		}, nil)
-		dbClient.EXPECT().GetApplicationsByFilterCount(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(0, fmt.Errorf("could not get applications count"))
+		dbClient.EXPECT().GetApplicationsByFilterCount(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(0, fmt.Errorf("could not get applications count"))
 
 		ctx := context.Background()
 		ctx = context.WithValue(ctx, chi.RouteCtxKey, chi.NewRouteContext())
@@ -115,13 +117,13 @@ func TestGetApplications(t *testing.T) {
 
 	t.Run("should return all applications", func(t *testing.T) {
 		dbClient, router := newRouter(t)
-		dbClient.EXPECT().GetApplicationsByFilter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]applicationv1.ApplicationSpec{
+		dbClient.EXPECT().GetApplicationsByFilter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]applicationv1.ApplicationSpec{
 			{
 				Name:      "foo",
 				Namespace: "bar",
 			},
 		}, nil)
-		dbClient.EXPECT().GetApplicationsByFilterCount(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(1, nil)
+		dbClient.EXPECT().GetApplicationsByFilterCount(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(1, nil)
 
 		ctx := context.Background()
 		ctx = context.WithValue(ctx, chi.RouteCtxKey, chi.NewRouteContext())
@@ -134,13 +136,47 @@ func TestGetApplications(t *testing.T) {
 		utils.AssertStatusEq(t, w, http.StatusOK)
 		utils.AssertJSONEq(t, w, `{"applications": [{"name":"foo", "namespace":"bar", "topology": {}}], "count": 1}`)
 	})
+
+	t.Run("should handle error from db client for preferences", func(t *testing.T) {
+		dbClient, router := newRouter(t)
+		dbClient.EXPECT().GetPreferences(gomock.Any(), "user1@kobs.io").Return(nil, fmt.Errorf("could not get preferences"))
+
+		ctx := context.Background()
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, chi.NewRouteContext())
+		ctx = context.WithValue(ctx, authContext.UserKey, authContext.User{ID: "user1@kobs.io", Permissions: userv1.Permissions{Applications: []userv1.ApplicationPermissions{{Type: "all"}}}})
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/applications?all=true&favorite=true&limit=10&offset=0", nil)
+
+		w := httptest.NewRecorder()
+		router.getApplications(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get preferences"]}`)
+	})
+
+	t.Run("should return favorite applications", func(t *testing.T) {
+		dbClient, router := newRouter(t)
+		dbClient.EXPECT().GetPreferences(gomock.Any(), "user1@kobs.io").Return(&db.Preferences{User: "user1@kobs.io"}, nil)
+		dbClient.EXPECT().GetApplicationsByFilter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), []string{}, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		dbClient.EXPECT().GetApplicationsByFilterCount(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), []string{}, gomock.Any()).Return(0, nil)
+
+		ctx := context.Background()
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, chi.NewRouteContext())
+		ctx = context.WithValue(ctx, authContext.UserKey, authContext.User{ID: "user1@kobs.io", Permissions: userv1.Permissions{Applications: []userv1.ApplicationPermissions{{Type: "all"}}}})
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/applications?all=true&favorite=true&limit=10&offset=0", nil)
+
+		w := httptest.NewRecorder()
+		router.getApplications(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `{"applications": null, "count": 0}`)
+	})
 }
 
 func TestGetTags(t *testing.T) {
 	var newRouter = func(t *testing.T) (*db.MockClient, Router) {
 		ctrl := gomock.NewController(t)
 		dbClient := db.NewMockClient(ctrl)
-		router := Router{chi.NewRouter(), settings.Settings{}, dbClient, otel.Tracer("applications")}
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient, otel.Tracer("applications"), nil}
 
 		return dbClient, router
 	}
@@ -184,7 +220,7 @@ func TestGetApplication(t *testing.T) {
 	var newRouter = func(t *testing.T) (*db.MockClient, Router) {
 		ctrl := gomock.NewController(t)
 		dbClient := db.NewMockClient(ctrl)
-		router := Router{chi.NewRouter(), settings.Settings{}, dbClient, otel.Tracer("applications")}
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient, otel.Tracer("applications"), nil}
 
 		return dbClient, router
 	}
@@ -235,9 +271,10 @@ func TestGetApplication(t *testing.T) {
 		application := &applicationv1.ApplicationSpec{Cluster: "cluster1", Namespace: "namespace1", Teams: []string{"myteam"}}
 		dbClient, router := newRouter(t)
 		dbClient.EXPECT().GetApplicationByID(gomock.Any(), "id1").Return(application, nil)
+		dbClient.EXPECT().AddRecentlyViewed(gomock.Any(), "user1@kobs.io", db.PreferenceApplication, "id1").Return(fmt.Errorf("could not add recently viewed item"))
 
 		ctx := context.Background()
-		ctx = context.WithValue(ctx, authContext.UserKey, authContext.User{Teams: []string{"myteam"}, Permissions: userv1.Permissions{Applications: []userv1.ApplicationPermissions{{Type: "own"}}}})
+		ctx = context.WithValue(ctx, authContext.UserKey, authContext.User{ID: "user1@kobs.io", Teams: []string{"myteam"}, Permissions: userv1.Permissions{Applications: []userv1.ApplicationPermissions{{Type: "own"}}}})
 		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/applications/application?id=id1", nil)
 		w := httptest.NewRecorder()
 		router.getApplication(w, req)
@@ -254,11 +291,76 @@ func TestGetApplication(t *testing.T) {
 	})
 }
 
+func TestGetApplicationOnCall(t *testing.T) {
+	var newRouter = func(t *testing.T) (*db.MockClient, *oncall.MockClient, Router) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		oncallClient := oncall.NewMockClient(ctrl)
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient, otel.Tracer("applications"), oncallClient}
+
+		return dbClient, oncallClient, router
+	}
+
+	var newRequest = func() *http.Request {
+		ctx := context.WithValue(context.Background(), authContext.UserKey, authContext.User{Teams: []string{"team1"}, Permissions: userv1.Permissions{Applications: []userv1.ApplicationPermissions{{Type: "own"}}}})
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/applications/oncall?id=id1", nil)
+		return req
+	}
+
+	t.Run("should handle error from db client for application", func(t *testing.T) {
+		dbClient, _, router := newRouter(t)
+		dbClient.EXPECT().GetApplicationByID(gomock.Any(), "id1").Return(nil, fmt.Errorf("could not get application"))
+		w := httptest.NewRecorder()
+
+		router.getApplicationOnCall(w, newRequest())
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get application"]}`)
+	})
+
+	t.Run("should return error when user is not authorized", func(t *testing.T) {
+		dbClient, _, router := newRouter(t)
+		dbClient.EXPECT().GetApplicationByID(gomock.Any(), "id1").Return(&applicationv1.ApplicationSpec{Teams: []string{"team2"}}, nil)
+		w := httptest.NewRecorder()
+
+		router.getApplicationOnCall(w, newRequest())
+
+		utils.AssertStatusEq(t, w, http.StatusForbidden)
+		utils.AssertJSONEq(t, w, `{"errors": ["You are not allowed to view the application"]}`)
+	})
+
+	t.Run("should handle error from db client for teams", func(t *testing.T) {
+		dbClient, _, router := newRouter(t)
+		dbClient.EXPECT().GetApplicationByID(gomock.Any(), "id1").Return(&applicationv1.ApplicationSpec{Teams: []string{"team1"}}, nil)
+		dbClient.EXPECT().GetTeamsByIDs(gomock.Any(), []string{"team1"}, "").Return(nil, fmt.Errorf("could not get teams"))
+		w := httptest.NewRecorder()
+
+		router.getApplicationOnCall(w, newRequest())
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get teams"]}`)
+	})
+
+	t.Run("should return on-call information of teams", func(t *testing.T) {
+		dbClient, oncallClient, router := newRouter(t)
+		dbClient.EXPECT().GetApplicationByID(gomock.Any(), "id1").Return(&applicationv1.ApplicationSpec{Teams: []string{"team1", "team2"}}, nil)
+		dbClient.EXPECT().GetTeamsByIDs(gomock.Any(), []string{"team1", "team2"}, "").Return([]teamv1.TeamSpec{{ID: "team1"}, {ID: "team2"}}, nil)
+		oncallClient.EXPECT().GetOnCall(gomock.Any(), teamv1.TeamSpec{ID: "team1"}).Return(&oncall.OnCall{Team: "team1", Participants: []string{"user1@kobs.io"}})
+		oncallClient.EXPECT().GetOnCall(gomock.Any(), teamv1.TeamSpec{ID: "team2"}).Return(nil)
+		w := httptest.NewRecorder()
+
+		router.getApplicationOnCall(w, newRequest())
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `[{"team":"team1","participants":["user1@kobs.io"]}]`)
+	})
+}
+
 func TestGetApplicationsByTeam(t *testing.T) {
 	var newRouter = func(t *testing.T) (*db.MockClient, Router) {
 		ctrl := gomock.NewController(t)
 		dbClient := db.NewMockClient(ctrl)
-		router := Router{chi.NewRouter(), settings.Settings{}, dbClient, otel.Tracer("applications")}
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient, otel.Tracer("applications"), nil}
 
 		return dbClient, router
 	}
@@ -301,7 +403,7 @@ func TestGetApplicationsByTeam(t *testing.T) {
 
 	t.Run("should handle error from db client", func(t *testing.T) {
 		dbClient, router := newRouter(t)
-		dbClient.EXPECT().GetApplicationsByFilter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("could not get applications"))
+		dbClient.EXPECT().GetApplicationsByFilter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("could not get applications"))
 
 		ctx := context.Background()
 		ctx = context.WithValue(ctx, authContext.UserKey, authContext.User{Permissions: userv1.Permissions{Applications: []userv1.ApplicationPermissions{{Type: "all"}}}})
@@ -315,8 +417,8 @@ func TestGetApplicationsByTeam(t *testing.T) {
 
 	t.Run("should handle error from db client for applications count", func(t *testing.T) {
 		dbClient, router := newRouter(t)
-		dbClient.EXPECT().GetApplicationsByFilter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
-		dbClient.EXPECT().GetApplicationsByFilterCount(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(0, fmt.Errorf("could not get applications count"))
+		dbClient.EXPECT().GetApplicationsByFilter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		dbClient.EXPECT().GetApplicationsByFilterCount(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(0, fmt.Errorf("could not get applications count"))
 
 		ctx := context.Background()
 		ctx = context.WithValue(ctx, authContext.UserKey, authContext.User{Permissions: userv1.Permissions{Applications: []userv1.ApplicationPermissions{{Type: "all"}}}})
@@ -335,8 +437,8 @@ func TestGetApplicationsByTeam(t *testing.T) {
 			Namespace: "namespace1",
 			Teams:     []string{"team1"},
 		}
-		dbClient.EXPECT().GetApplicationsByFilter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]applicationv1.ApplicationSpec{application}, nil)
-		dbClient.EXPECT().GetApplicationsByFilterCount(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(20, nil)
+		dbClient.EXPECT().GetApplicationsByFilter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]applicationv1.ApplicationSpec{application}, nil)
+		dbClient.EXPECT().GetApplicationsByFilterCount(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(20, nil)
 
 		ctx := context.Background()
 		ctx = context.WithValue(ctx, authContext.UserKey, authContext.User{Permissions: userv1.Permissions{Applications: []userv1.ApplicationPermissions{{Type: "all"}}}})
@@ -364,7 +466,7 @@ func TestSaveApplication(t *testing.T) {
 		dbClient := db.NewMockClient(ctrl)
 		router := Router{chi.NewRouter(), settings.Settings{Save: struct {
 			Enabled bool `json:"enabled"`
-		}{Enabled: saveEnabled}}, dbClient, otel.Tracer("applications")}
+		}{Enabled: saveEnabled}}, dbClient, otel.Tracer("applications"), nil}
 
 		return dbClient, router
 	}
@@ -454,7 +556,7 @@ func TestGetApplicationGroups(t *testing.T) {
 	var newRouter = func(t *testing.T) (*db.MockClient, Router) {
 		ctrl := gomock.NewController(t)
 		dbClient := db.NewMockClient(ctrl)
-		router := Router{chi.NewRouter(), settings.Settings{}, dbClient, otel.Tracer("applications")}
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient, otel.Tracer("applications"), nil}
 
 		return dbClient, router
 	}
@@ -517,6 +619,6 @@ func TestGetApplicationGroups(t *testing.T) {
 }
 
 func TestMount(t *testing.T) {
-	router := Mount(settings.Settings{}, nil)
+	router := Mount(settings.Settings{}, nil, nil)
 	require.NotNil(t, router)
 }
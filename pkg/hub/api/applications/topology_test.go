@@ -24,7 +24,7 @@ func TestGetApplicationsTopology(t *testing.T) {
 	var newRouter = func(t *testing.T) (*db.MockClient, Router) {
 		ctrl := gomock.NewController(t)
 		dbClient := db.NewMockClient(ctrl)
-		router := Router{chi.NewRouter(), settings.Settings{}, dbClient, otel.Tracer("applications")}
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient, otel.Tracer("applications"), nil}
 
 		return dbClient, router
 	}
@@ -45,7 +45,7 @@ func TestGetApplicationsTopology(t *testing.T) {
 
 	t.Run("should handle error from db client", func(t *testing.T) {
 		dbClient, router := newRouter(t)
-		dbClient.EXPECT().GetApplicationsByFilter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("get applications by filter failed"))
+		dbClient.EXPECT().GetApplicationsByFilter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("get applications by filter failed"))
 
 		ctx := context.Background()
 		ctx = context.WithValue(ctx, authContext.UserKey, authContext.User{Teams: []string{"team@test.test"}})
@@ -60,7 +60,7 @@ func TestGetApplicationsTopology(t *testing.T) {
 
 	t.Run("should handle error from db client for sourceID", func(t *testing.T) {
 		dbClient, router := newRouter(t)
-		dbClient.EXPECT().GetApplicationsByFilter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		dbClient.EXPECT().GetApplicationsByFilter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
 		dbClient.EXPECT().GetTopologyByIDs(gomock.Any(), "sourceID", gomock.Any()).Return(nil, fmt.Errorf("get topology by ids failed for sourceID"))
 
 		ctx := context.Background()
@@ -76,7 +76,7 @@ func TestGetApplicationsTopology(t *testing.T) {
 
 	t.Run("should handle error from db client for targetID", func(t *testing.T) {
 		dbClient, router := newRouter(t)
-		dbClient.EXPECT().GetApplicationsByFilter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		dbClient.EXPECT().GetApplicationsByFilter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
 		dbClient.EXPECT().GetTopologyByIDs(gomock.Any(), "sourceID", gomock.Any()).Return(nil, nil)
 		dbClient.EXPECT().GetTopologyByIDs(gomock.Any(), "targetID", gomock.Any()).Return(nil, fmt.Errorf("get topology by ids failed for targetID"))
 
@@ -101,7 +101,7 @@ func TestGetApplicationsTopology(t *testing.T) {
 		application := applicationv1.ApplicationSpec{ID: "applicationID"}
 
 		dbClient, router := newRouter(t)
-		dbClient.EXPECT().GetApplicationsByFilter(gomock.Any(), teams, clusters, namespaces, tags, searchTerm, 0, 0).Return([]applicationv1.ApplicationSpec{application}, nil)
+		dbClient.EXPECT().GetApplicationsByFilter(gomock.Any(), teams, clusters, namespaces, tags, nil, searchTerm, 0, 0).Return([]applicationv1.ApplicationSpec{application}, nil)
 
 		sourceTopology := []db.Topology{
 			{
@@ -186,7 +186,7 @@ func TestGetApplicationTopology(t *testing.T) {
 	var newRouter = func(t *testing.T) (*db.MockClient, Router) {
 		ctrl := gomock.NewController(t)
 		dbClient := db.NewMockClient(ctrl)
-		router := Router{chi.NewRouter(), settings.Settings{}, dbClient, otel.Tracer("applications")}
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient, otel.Tracer("applications"), nil}
 
 		return dbClient, router
 	}
@@ -1,10 +1,13 @@
 package clusters
 
 import (
+	"encoding/json"
 	"net/http"
 	"sort"
 
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/inventory"
 	"github.com/kobsio/kobs/pkg/hub/api/resources"
+	authContext "github.com/kobsio/kobs/pkg/hub/auth/context"
 	"github.com/kobsio/kobs/pkg/hub/clusters"
 	"github.com/kobsio/kobs/pkg/hub/db"
 	"github.com/kobsio/kobs/pkg/instrument/log"
@@ -68,6 +71,234 @@ func (router *Router) getResources(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, resources.GetResources(crds))
 }
 
+// getInventory returns the inventory of the provided clusters, which is collected by the watcher. The inventory contains
+// the Kubernetes version, the versions of all Nodes, the allocatable resources and the installed CRD groups of a cluster,
+// so that it can be used to track the upgrade status of all clusters. If no cluster is provided the inventory of all
+// clusters is returned.
+func (router *Router) getInventory(w http.ResponseWriter, r *http.Request) {
+	clusters := r.URL.Query()["cluster"]
+
+	inventories, err := router.dbClient.GetInventories(r.Context(), clusters)
+	if err != nil {
+		log.Error(r.Context(), "Failed to get inventory", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get inventory")
+		return
+	}
+
+	if inventories == nil {
+		inventories = []inventory.Inventory{}
+	}
+
+	render.JSON(w, r, inventories)
+}
+
+// getRegisteredClusters returns all clusters which were registered via the API. The tokens of the clusters are removed
+// from the returned list, so that they can not be retrieved by a user.
+func (router *Router) getRegisteredClusters(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := authContext.MustGetUser(ctx)
+
+	registeredClusters, err := router.dbClient.GetClusters(ctx)
+	if err != nil {
+		log.Error(ctx, "Failed to get registered clusters", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get registered clusters")
+		return
+	}
+
+	filteredClusters := []db.Cluster{}
+	for _, registeredCluster := range registeredClusters {
+		if user.HasResourceAccess(registeredCluster.Name, "", "clusters", "get") {
+			registeredCluster.Token = ""
+			filteredClusters = append(filteredClusters, registeredCluster)
+		}
+	}
+
+	render.JSON(w, r, filteredClusters)
+}
+
+// registerCluster registers a new cluster or updates an existing registered cluster. It is not possible to register a
+// cluster with the same name as a cluster from the configuration file. To register a new cluster the user needs the
+// "post" verb, to update an existing cluster the user also needs the "patch" verb, because otherwise a user could
+// overwrite the address and token of a cluster he is not allowed to edit. When an existing cluster is updated, the
+// disabled state of the cluster is kept. After the cluster is saved in the database, we refresh the clusters client, so
+// that the cluster can be used immediately.
+func (router *Router) registerCluster(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := authContext.MustGetUser(ctx)
+
+	var registeredCluster db.Cluster
+
+	err := json.NewDecoder(r.Body).Decode(&registeredCluster)
+	if err != nil {
+		log.Error(ctx, "Failed to decode request body", zap.Error(err))
+		errresponse.Render(w, r, http.StatusBadRequest, "Failed to decode request body")
+		return
+	}
+
+	if registeredCluster.Name == "" || registeredCluster.Name == db.UserDashboardsCluster || (registeredCluster.Address == "" && !registeredCluster.Tunnel) || (registeredCluster.Tunnel && registeredCluster.Token == "") {
+		log.Error(ctx, "Invalid cluster data")
+		errresponse.Render(w, r, http.StatusBadRequest, "Invalid cluster data")
+		return
+	}
+
+	if !user.HasResourceAccess(registeredCluster.Name, "", "clusters", "post") {
+		log.Warn(ctx, "The user is not authorized to register the cluster", zap.String("cluster", registeredCluster.Name))
+		errresponse.Render(w, r, http.StatusForbidden, "You are not allowed to register the cluster")
+		return
+	}
+
+	if router.clusterClient.IsConfigured(registeredCluster.Name) {
+		log.Warn(ctx, "The cluster is defined in the configuration file", zap.String("cluster", registeredCluster.Name))
+		errresponse.Render(w, r, http.StatusConflict, "The cluster is defined in the configuration file")
+		return
+	}
+
+	existingCluster, err := router.dbClient.GetClusterByName(ctx, registeredCluster.Name)
+	if err != nil && err != db.ErrClusterNotFound {
+		log.Error(ctx, "Failed to get cluster", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get cluster")
+		return
+	}
+
+	if existingCluster != nil {
+		if !user.HasResourceAccess(registeredCluster.Name, "", "clusters", "patch") {
+			log.Warn(ctx, "The user is not authorized to update the cluster", zap.String("cluster", registeredCluster.Name))
+			errresponse.Render(w, r, http.StatusForbidden, "You are not allowed to update the cluster")
+			return
+		}
+
+		registeredCluster.Disabled = existingCluster.Disabled
+		registeredCluster.CreatedAt = existingCluster.CreatedAt
+	}
+
+	err = router.dbClient.SaveCluster(ctx, &registeredCluster)
+	if err != nil {
+		log.Error(ctx, "Failed to save cluster", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to save cluster")
+		return
+	}
+
+	router.refresh(w, r)
+}
+
+// updateClusterToken updates the token of a registered cluster.
+func (router *Router) updateClusterToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var data struct {
+		Token string `json:"token"`
+	}
+
+	err := json.NewDecoder(r.Body).Decode(&data)
+	if err != nil {
+		log.Error(ctx, "Failed to decode request body", zap.Error(err))
+		errresponse.Render(w, r, http.StatusBadRequest, "Failed to decode request body")
+		return
+	}
+
+	if data.Token == "" {
+		log.Error(ctx, "Token is required")
+		errresponse.Render(w, r, http.StatusBadRequest, "Token is required")
+		return
+	}
+
+	router.updateCluster(w, r, func(registeredCluster *db.Cluster) {
+		registeredCluster.Token = data.Token
+	})
+}
+
+// disableCluster disables a registered cluster. A disabled cluster is kept in the database, but it is not used by the
+// hub and watcher until it is enabled again.
+func (router *Router) disableCluster(w http.ResponseWriter, r *http.Request) {
+	router.updateCluster(w, r, func(registeredCluster *db.Cluster) {
+		registeredCluster.Disabled = true
+	})
+}
+
+// enableCluster enables a registered cluster, which was disabled before.
+func (router *Router) enableCluster(w http.ResponseWriter, r *http.Request) {
+	router.updateCluster(w, r, func(registeredCluster *db.Cluster) {
+		registeredCluster.Disabled = false
+	})
+}
+
+// updateCluster gets the registered cluster from the "name" parameter, applies the given update function and saves the
+// cluster in the database. It is used by all handlers which are modifying an existing cluster.
+func (router *Router) updateCluster(w http.ResponseWriter, r *http.Request, update func(registeredCluster *db.Cluster)) {
+	ctx := r.Context()
+	user := authContext.MustGetUser(ctx)
+	name := r.URL.Query().Get("name")
+
+	if !user.HasResourceAccess(name, "", "clusters", "patch") {
+		log.Warn(ctx, "The user is not authorized to edit the cluster", zap.String("cluster", name))
+		errresponse.Render(w, r, http.StatusForbidden, "You are not allowed to edit the cluster")
+		return
+	}
+
+	registeredCluster, err := router.dbClient.GetClusterByName(ctx, name)
+	if err != nil {
+		if err == db.ErrClusterNotFound {
+			errresponse.Render(w, r, http.StatusNotFound, "Cluster not found")
+			return
+		}
+
+		log.Error(ctx, "Failed to get cluster", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get cluster")
+		return
+	}
+
+	update(registeredCluster)
+
+	err = router.dbClient.SaveCluster(ctx, registeredCluster)
+	if err != nil {
+		log.Error(ctx, "Failed to save cluster", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to save cluster")
+		return
+	}
+
+	router.refresh(w, r)
+}
+
+// removeCluster removes a registered cluster from the database.
+func (router *Router) removeCluster(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := authContext.MustGetUser(ctx)
+	name := r.URL.Query().Get("name")
+
+	if !user.HasResourceAccess(name, "", "clusters", "delete") {
+		log.Warn(ctx, "The user is not authorized to remove the cluster", zap.String("cluster", name))
+		errresponse.Render(w, r, http.StatusForbidden, "You are not allowed to remove the cluster")
+		return
+	}
+
+	err := router.dbClient.DeleteCluster(ctx, name)
+	if err != nil {
+		if err == db.ErrClusterNotFound {
+			errresponse.Render(w, r, http.StatusNotFound, "Cluster not found")
+			return
+		}
+
+		log.Error(ctx, "Failed to remove cluster", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to remove cluster")
+		return
+	}
+
+	router.refresh(w, r)
+}
+
+// refresh refreshes the clusters client after a registered cluster was changed, so that the changes are used
+// immediately by the hub. The watcher picks up the changes in its next refresh.
+func (router *Router) refresh(w http.ResponseWriter, r *http.Request) {
+	err := router.clusterClient.Refresh(r.Context())
+	if err != nil {
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to refresh clusters")
+		return
+	}
+
+	render.Status(r, http.StatusNoContent)
+	render.JSON(w, r, nil)
+}
+
 func Mount(dbClient db.Client, clusterClient clusters.Client) chi.Router {
 	router := Router{
 		chi.NewRouter(),
@@ -78,6 +309,13 @@ func Mount(dbClient db.Client, clusterClient clusters.Client) chi.Router {
 	router.Get("/", router.getClusters)
 	router.Get("/namespaces", router.getNamespaces)
 	router.Get("/resources", router.getResources)
+	router.Get("/inventory", router.getInventory)
+	router.Get("/registered", router.getRegisteredClusters)
+	router.Post("/register", router.registerCluster)
+	router.Put("/token", router.updateClusterToken)
+	router.Put("/disable", router.disableCluster)
+	router.Put("/enable", router.enableCluster)
+	router.Delete("/remove", router.removeCluster)
 
 	return router
 }
@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	userv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/user/v1"
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/inventory"
+	authContext "github.com/kobsio/kobs/pkg/hub/auth/context"
 	"github.com/kobsio/kobs/pkg/hub/clusters"
 	"github.com/kobsio/kobs/pkg/hub/clusters/cluster"
 	"github.com/kobsio/kobs/pkg/hub/db"
@@ -87,6 +91,53 @@ func TestGetNamespaces(t *testing.T) {
 	})
 }
 
+func TestGetInventory(t *testing.T) {
+	t.Run("should handle error from db client", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetInventories(gomock.Any(), []string{"dev-de1"}).Return(nil, fmt.Errorf("could not get inventories"))
+		clusterClient := clusters.NewMockClient(ctrl)
+		router := Router{chi.NewRouter(), dbClient, clusterClient}
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/inventory?cluster=dev-de1", nil)
+		w := httptest.NewRecorder()
+		router.getInventory(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get inventory"]}`)
+	})
+
+	t.Run("should return empty list", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetInventories(gomock.Any(), nil).Return(nil, nil)
+		clusterClient := clusters.NewMockClient(ctrl)
+		router := Router{chi.NewRouter(), dbClient, clusterClient}
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/inventory", nil)
+		w := httptest.NewRecorder()
+		router.getInventory(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `[]`)
+	})
+
+	t.Run("should return inventory", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetInventories(gomock.Any(), []string{"dev-de1"}).Return([]inventory.Inventory{{Cluster: "dev-de1", KubernetesVersion: "v1.27.3", KobsVersion: "v0.12.0", NodesCount: 1, Nodes: []inventory.Node{{Name: "node1", KubeletVersion: "v1.27.3"}}, CRDGroups: []string{"kobs.io"}, UpdatedAt: 1}}, nil)
+		clusterClient := clusters.NewMockClient(ctrl)
+		router := Router{chi.NewRouter(), dbClient, clusterClient}
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/inventory?cluster=dev-de1", nil)
+		w := httptest.NewRecorder()
+		router.getInventory(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `[{"cluster":"dev-de1","kubernetesVersion":"v1.27.3","platform":"","kobsVersion":"v0.12.0","nodesCount":1,"nodes":[{"name":"node1","kubeletVersion":"v1.27.3","osImage":"","operatingSystem":"","architecture":"","kernelVersion":"","containerRuntimeVersion":"","allocatable":{"cpu":0,"memory":0}}],"allocatable":{"cpu":0,"memory":0},"crdGroups":["kobs.io"],"updatedAt":1}]`)
+	})
+}
+
 func TestGetResources(t *testing.T) {
 	t.Run("should handle error from db client", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -122,6 +173,344 @@ func TestGetResources(t *testing.T) {
 	})
 }
 
+func newClustersRequest(method, path, body string, resources []userv1.Resources) *http.Request {
+	user := authContext.User{ID: "foo", Permissions: userv1.Permissions{Resources: resources}}
+
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, chi.RouteCtxKey, chi.NewRouteContext())
+	ctx = context.WithValue(ctx, authContext.UserKey, user)
+	req, _ := http.NewRequestWithContext(ctx, method, path, strings.NewReader(body))
+	return req
+}
+
+var adminResources = []userv1.Resources{{Clusters: []string{"*"}, Namespaces: []string{"*"}, Resources: []string{"clusters"}, Verbs: []string{"*"}}}
+
+func TestGetRegisteredClusters(t *testing.T) {
+	t.Run("should handle error from db client", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetClusters(gomock.Any()).Return(nil, fmt.Errorf("unexpected error"))
+		router := Router{chi.NewRouter(), dbClient, clusters.NewMockClient(ctrl)}
+
+		w := httptest.NewRecorder()
+		router.getRegisteredClusters(w, newClustersRequest(http.MethodGet, "/registered", "", adminResources))
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get registered clusters"]}`)
+	})
+
+	t.Run("should return clusters without tokens", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetClusters(gomock.Any()).Return([]db.Cluster{
+			{Name: "cluster-1", Address: "http://localhost:15221", Token: "secret"},
+			{Name: "cluster-2", Address: "http://localhost:15222", Token: "secret"},
+		}, nil)
+		router := Router{chi.NewRouter(), dbClient, clusters.NewMockClient(ctrl)}
+
+		w := httptest.NewRecorder()
+		router.getRegisteredClusters(w, newClustersRequest(http.MethodGet, "/registered", "", []userv1.Resources{{Clusters: []string{"cluster-1"}, Namespaces: []string{"*"}, Resources: []string{"clusters"}, Verbs: []string{"get"}}}))
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `[{"name": "cluster-1", "address": "http://localhost:15221", "tunnel": false, "disabled": false, "createdAt": 0, "updatedAt": 0}]`)
+	})
+}
+
+func TestRegisterCluster(t *testing.T) {
+	for _, tt := range []struct {
+		name               string
+		body               string
+		resources          []userv1.Resources
+		prepare            func(dbClient *db.MockClient, clusterClient *clusters.MockClient)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "should fail for invalid body",
+			body:               `[]`,
+			resources:          adminResources,
+			prepare:            func(dbClient *db.MockClient, clusterClient *clusters.MockClient) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"errors": ["Failed to decode request body"]}`,
+		},
+		{
+			name:               "should fail for missing name",
+			body:               `{"address": "http://localhost:15221"}`,
+			resources:          adminResources,
+			prepare:            func(dbClient *db.MockClient, clusterClient *clusters.MockClient) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"errors": ["Invalid cluster data"]}`,
+		},
+		{
+			name:               "should fail for tunnel without token",
+			body:               `{"name": "cluster-1", "tunnel": true}`,
+			prepare:            func(dbClient *db.MockClient, clusterClient *clusters.MockClient) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"errors": ["Invalid cluster data"]}`,
+		},
+		{
+			name:               "should fail for reserved name",
+			body:               `{"name": "@hub", "address": "http://localhost:15221"}`,
+			prepare:            func(dbClient *db.MockClient, clusterClient *clusters.MockClient) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"errors": ["Invalid cluster data"]}`,
+		},
+		{
+			name:               "should fail for missing permissions",
+			body:               `{"name": "cluster-1", "address": "http://localhost:15221"}`,
+			prepare:            func(dbClient *db.MockClient, clusterClient *clusters.MockClient) {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       `{"errors": ["You are not allowed to register the cluster"]}`,
+		},
+		{
+			name:      "should fail for configured cluster",
+			body:      `{"name": "cluster-1", "address": "http://localhost:15221"}`,
+			resources: adminResources,
+			prepare: func(dbClient *db.MockClient, clusterClient *clusters.MockClient) {
+				clusterClient.EXPECT().IsConfigured("cluster-1").Return(true)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedBody:       `{"errors": ["The cluster is defined in the configuration file"]}`,
+		},
+		{
+			name:      "should fail to get existing cluster",
+			body:      `{"name": "cluster-1", "address": "http://localhost:15221"}`,
+			resources: adminResources,
+			prepare: func(dbClient *db.MockClient, clusterClient *clusters.MockClient) {
+				clusterClient.EXPECT().IsConfigured("cluster-1").Return(false)
+				dbClient.EXPECT().GetClusterByName(gomock.Any(), "cluster-1").Return(nil, fmt.Errorf("unexpected error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       `{"errors": ["Failed to get cluster"]}`,
+		},
+		{
+			name:      "should fail to update existing cluster without patch permissions",
+			body:      `{"name": "cluster-1", "address": "http://localhost:15221"}`,
+			resources: []userv1.Resources{{Clusters: []string{"*"}, Namespaces: []string{"*"}, Resources: []string{"clusters"}, Verbs: []string{"post"}}},
+			prepare: func(dbClient *db.MockClient, clusterClient *clusters.MockClient) {
+				clusterClient.EXPECT().IsConfigured("cluster-1").Return(false)
+				dbClient.EXPECT().GetClusterByName(gomock.Any(), "cluster-1").Return(&db.Cluster{Name: "cluster-1", Address: "http://localhost:15222"}, nil)
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       `{"errors": ["You are not allowed to update the cluster"]}`,
+		},
+		{
+			name:      "should update existing cluster",
+			body:      `{"name": "cluster-1", "address": "http://localhost:15221", "token": "secret"}`,
+			resources: adminResources,
+			prepare: func(dbClient *db.MockClient, clusterClient *clusters.MockClient) {
+				clusterClient.EXPECT().IsConfigured("cluster-1").Return(false)
+				dbClient.EXPECT().GetClusterByName(gomock.Any(), "cluster-1").Return(&db.Cluster{Name: "cluster-1", Address: "http://localhost:15222", Disabled: true, CreatedAt: 1}, nil)
+				dbClient.EXPECT().SaveCluster(gomock.Any(), &db.Cluster{Name: "cluster-1", Address: "http://localhost:15221", Token: "secret", Disabled: true, CreatedAt: 1}).Return(nil)
+				clusterClient.EXPECT().Refresh(gomock.Any()).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+			expectedBody:       `null`,
+		},
+		{
+			name:      "should fail to save cluster",
+			body:      `{"name": "cluster-1", "address": "http://localhost:15221"}`,
+			resources: adminResources,
+			prepare: func(dbClient *db.MockClient, clusterClient *clusters.MockClient) {
+				clusterClient.EXPECT().IsConfigured("cluster-1").Return(false)
+				dbClient.EXPECT().GetClusterByName(gomock.Any(), "cluster-1").Return(nil, db.ErrClusterNotFound)
+				dbClient.EXPECT().SaveCluster(gomock.Any(), gomock.Any()).Return(fmt.Errorf("unexpected error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       `{"errors": ["Failed to save cluster"]}`,
+		},
+		{
+			name:      "should fail to refresh clusters",
+			body:      `{"name": "cluster-1", "address": "http://localhost:15221"}`,
+			resources: adminResources,
+			prepare: func(dbClient *db.MockClient, clusterClient *clusters.MockClient) {
+				clusterClient.EXPECT().IsConfigured("cluster-1").Return(false)
+				dbClient.EXPECT().GetClusterByName(gomock.Any(), "cluster-1").Return(nil, db.ErrClusterNotFound)
+				dbClient.EXPECT().SaveCluster(gomock.Any(), gomock.Any()).Return(nil)
+				clusterClient.EXPECT().Refresh(gomock.Any()).Return(fmt.Errorf("unexpected error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       `{"errors": ["Failed to refresh clusters"]}`,
+		},
+		{
+			name:      "should register cluster",
+			body:      `{"name": "cluster-1", "tunnel": true, "token": "secret"}`,
+			resources: adminResources,
+			prepare: func(dbClient *db.MockClient, clusterClient *clusters.MockClient) {
+				clusterClient.EXPECT().IsConfigured("cluster-1").Return(false)
+				dbClient.EXPECT().GetClusterByName(gomock.Any(), "cluster-1").Return(nil, db.ErrClusterNotFound)
+				dbClient.EXPECT().SaveCluster(gomock.Any(), &db.Cluster{Name: "cluster-1", Tunnel: true, Token: "secret"}).Return(nil)
+				clusterClient.EXPECT().Refresh(gomock.Any()).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+			expectedBody:       `null`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			dbClient := db.NewMockClient(ctrl)
+			clusterClient := clusters.NewMockClient(ctrl)
+			tt.prepare(dbClient, clusterClient)
+			router := Router{chi.NewRouter(), dbClient, clusterClient}
+
+			w := httptest.NewRecorder()
+			router.registerCluster(w, newClustersRequest(http.MethodPost, "/register", tt.body, tt.resources))
+
+			utils.AssertStatusEq(t, w, tt.expectedStatusCode)
+			utils.AssertJSONEq(t, w, tt.expectedBody)
+		})
+	}
+}
+
+func TestUpdateCluster(t *testing.T) {
+	t.Run("should fail for invalid body", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		router := Router{chi.NewRouter(), db.NewMockClient(ctrl), clusters.NewMockClient(ctrl)}
+
+		w := httptest.NewRecorder()
+		router.updateClusterToken(w, newClustersRequest(http.MethodPut, "/token?name=cluster-1", `[]`, adminResources))
+
+		utils.AssertStatusEq(t, w, http.StatusBadRequest)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to decode request body"]}`)
+	})
+
+	t.Run("should fail for empty token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		router := Router{chi.NewRouter(), db.NewMockClient(ctrl), clusters.NewMockClient(ctrl)}
+
+		w := httptest.NewRecorder()
+		router.updateClusterToken(w, newClustersRequest(http.MethodPut, "/token?name=cluster-1", `{"token": ""}`, adminResources))
+
+		utils.AssertStatusEq(t, w, http.StatusBadRequest)
+		utils.AssertJSONEq(t, w, `{"errors": ["Token is required"]}`)
+	})
+
+	t.Run("should fail for missing permissions", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		router := Router{chi.NewRouter(), db.NewMockClient(ctrl), clusters.NewMockClient(ctrl)}
+
+		w := httptest.NewRecorder()
+		router.disableCluster(w, newClustersRequest(http.MethodPut, "/disable?name=cluster-1", "", nil))
+
+		utils.AssertStatusEq(t, w, http.StatusForbidden)
+		utils.AssertJSONEq(t, w, `{"errors": ["You are not allowed to edit the cluster"]}`)
+	})
+
+	t.Run("should fail for not existing cluster", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetClusterByName(gomock.Any(), "cluster-1").Return(nil, db.ErrClusterNotFound)
+		router := Router{chi.NewRouter(), dbClient, clusters.NewMockClient(ctrl)}
+
+		w := httptest.NewRecorder()
+		router.enableCluster(w, newClustersRequest(http.MethodPut, "/enable?name=cluster-1", "", adminResources))
+
+		utils.AssertStatusEq(t, w, http.StatusNotFound)
+		utils.AssertJSONEq(t, w, `{"errors": ["Cluster not found"]}`)
+	})
+
+	t.Run("should fail to get cluster", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetClusterByName(gomock.Any(), "cluster-1").Return(nil, fmt.Errorf("unexpected error"))
+		router := Router{chi.NewRouter(), dbClient, clusters.NewMockClient(ctrl)}
+
+		w := httptest.NewRecorder()
+		router.enableCluster(w, newClustersRequest(http.MethodPut, "/enable?name=cluster-1", "", adminResources))
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get cluster"]}`)
+	})
+
+	t.Run("should update token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetClusterByName(gomock.Any(), "cluster-1").Return(&db.Cluster{Name: "cluster-1", Token: "old"}, nil)
+		dbClient.EXPECT().SaveCluster(gomock.Any(), &db.Cluster{Name: "cluster-1", Token: "new"}).Return(nil)
+		clusterClient := clusters.NewMockClient(ctrl)
+		clusterClient.EXPECT().Refresh(gomock.Any()).Return(nil)
+		router := Router{chi.NewRouter(), dbClient, clusterClient}
+
+		w := httptest.NewRecorder()
+		router.updateClusterToken(w, newClustersRequest(http.MethodPut, "/token?name=cluster-1", `{"token": "new"}`, adminResources))
+
+		utils.AssertStatusEq(t, w, http.StatusNoContent)
+	})
+
+	t.Run("should disable and enable cluster", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetClusterByName(gomock.Any(), "cluster-1").Return(&db.Cluster{Name: "cluster-1"}, nil)
+		dbClient.EXPECT().SaveCluster(gomock.Any(), &db.Cluster{Name: "cluster-1", Disabled: true}).Return(nil)
+		dbClient.EXPECT().GetClusterByName(gomock.Any(), "cluster-1").Return(&db.Cluster{Name: "cluster-1", Disabled: true}, nil)
+		dbClient.EXPECT().SaveCluster(gomock.Any(), &db.Cluster{Name: "cluster-1"}).Return(nil)
+		clusterClient := clusters.NewMockClient(ctrl)
+		clusterClient.EXPECT().Refresh(gomock.Any()).Return(nil).Times(2)
+		router := Router{chi.NewRouter(), dbClient, clusterClient}
+
+		w := httptest.NewRecorder()
+		router.disableCluster(w, newClustersRequest(http.MethodPut, "/disable?name=cluster-1", "", adminResources))
+		utils.AssertStatusEq(t, w, http.StatusNoContent)
+
+		w = httptest.NewRecorder()
+		router.enableCluster(w, newClustersRequest(http.MethodPut, "/enable?name=cluster-1", "", adminResources))
+		utils.AssertStatusEq(t, w, http.StatusNoContent)
+	})
+}
+
+func TestRemoveCluster(t *testing.T) {
+	t.Run("should fail for missing permissions", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		router := Router{chi.NewRouter(), db.NewMockClient(ctrl), clusters.NewMockClient(ctrl)}
+
+		w := httptest.NewRecorder()
+		router.removeCluster(w, newClustersRequest(http.MethodDelete, "/remove?name=cluster-1", "", nil))
+
+		utils.AssertStatusEq(t, w, http.StatusForbidden)
+		utils.AssertJSONEq(t, w, `{"errors": ["You are not allowed to remove the cluster"]}`)
+	})
+
+	t.Run("should fail for not existing cluster", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().DeleteCluster(gomock.Any(), "cluster-1").Return(db.ErrClusterNotFound)
+		router := Router{chi.NewRouter(), dbClient, clusters.NewMockClient(ctrl)}
+
+		w := httptest.NewRecorder()
+		router.removeCluster(w, newClustersRequest(http.MethodDelete, "/remove?name=cluster-1", "", adminResources))
+
+		utils.AssertStatusEq(t, w, http.StatusNotFound)
+		utils.AssertJSONEq(t, w, `{"errors": ["Cluster not found"]}`)
+	})
+
+	t.Run("should fail to remove cluster", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().DeleteCluster(gomock.Any(), "cluster-1").Return(fmt.Errorf("unexpected error"))
+		router := Router{chi.NewRouter(), dbClient, clusters.NewMockClient(ctrl)}
+
+		w := httptest.NewRecorder()
+		router.removeCluster(w, newClustersRequest(http.MethodDelete, "/remove?name=cluster-1", "", adminResources))
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to remove cluster"]}`)
+	})
+
+	t.Run("should remove cluster", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().DeleteCluster(gomock.Any(), "cluster-1").Return(nil)
+		clusterClient := clusters.NewMockClient(ctrl)
+		clusterClient.EXPECT().Refresh(gomock.Any()).Return(nil)
+		router := Router{chi.NewRouter(), dbClient, clusterClient}
+
+		w := httptest.NewRecorder()
+		router.removeCluster(w, newClustersRequest(http.MethodDelete, "/remove?name=cluster-1", "", adminResources))
+
+		utils.AssertStatusEq(t, w, http.StatusNoContent)
+	})
+}
+
 func TestMount(t *testing.T) {
 	router := Mount(nil, nil)
 	require.NotNil(t, router)
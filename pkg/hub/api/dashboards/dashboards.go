@@ -1,11 +1,15 @@
 package dashboards
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 
 	dashboardv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
+	"github.com/kobsio/kobs/pkg/hub/app/settings"
+	authContext "github.com/kobsio/kobs/pkg/hub/auth/context"
+	"github.com/kobsio/kobs/pkg/hub/dashboards"
 	"github.com/kobsio/kobs/pkg/hub/db"
 	"github.com/kobsio/kobs/pkg/instrument/log"
 	"github.com/kobsio/kobs/pkg/utils/middleware/errresponse"
@@ -13,11 +17,24 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 type Router struct {
 	*chi.Mux
-	dbClient db.Client
+	appSettings settings.Settings
+	dbClient    db.Client
+}
+
+// getUser returns the user from the request context. If the context doesn't contain a user, we return an empty user,
+// so that the user can only view public user dashboards.
+func getUser(ctx context.Context) *authContext.User {
+	user, err := authContext.GetUser(ctx)
+	if err != nil {
+		return &authContext.User{}
+	}
+
+	return user
 }
 
 func (router *Router) getDashboards(w http.ResponseWriter, r *http.Request) {
@@ -45,11 +62,11 @@ func (router *Router) getDashboardsFromReferences(w http.ResponseWriter, r *http
 		return
 	}
 
-	var dashboards []*dashboardv1.DashboardSpec
+	var specs []*dashboardv1.DashboardSpec
 
 	for _, reference := range references {
 		if reference.Inline != nil {
-			dashboards = append(dashboards, &dashboardv1.DashboardSpec{
+			specs = append(specs, &dashboardv1.DashboardSpec{
 				Cluster:     "",
 				Namespace:   "",
 				Name:        "",
@@ -59,9 +76,15 @@ func (router *Router) getDashboardsFromReferences(w http.ResponseWriter, r *http
 				Variables:   addPlaceholdersAsVariables(nil, reference.Inline.Variables, reference.Placeholders),
 				Rows:        reference.Inline.Rows,
 			})
+			evaluateRows(specs[len(specs)-1], reference.Placeholders)
 		} else {
-			dashboard, err := router.dbClient.GetDashboardByID(r.Context(), fmt.Sprintf("/cluster/%s/namespace/%s/name/%s", reference.Cluster, reference.Namespace, reference.Name))
+			dashboard, err := dashboards.GetByID(r.Context(), router.dbClient, fmt.Sprintf("/cluster/%s/namespace/%s/name/%s", reference.Cluster, reference.Namespace, reference.Name), getUser(r.Context()))
 			if err != nil {
+				if err == dashboards.ErrAccessDenied {
+					errresponse.Render(w, r, http.StatusForbidden, "You are not allowed to view the dashboard")
+					return
+				}
+
 				log.Error(r.Context(), "Failed to get dashboard", zap.Error(err))
 				errresponse.Render(w, r, http.StatusBadRequest, "Failed to get dashboard")
 				return
@@ -69,12 +92,13 @@ func (router *Router) getDashboardsFromReferences(w http.ResponseWriter, r *http
 
 			dashboard.Title = reference.Title
 			dashboard.Variables = addPlaceholdersAsVariables(dashboard.Placeholders, dashboard.Variables, reference.Placeholders)
-			dashboards = append(dashboards, dashboard)
+			evaluateRows(dashboard, reference.Placeholders)
+			specs = append(specs, dashboard)
 		}
 	}
 
-	log.Debug(r.Context(), "Get dashboards result", zap.Int("dashboardsCount", len(dashboards)))
-	render.JSON(w, r, dashboards)
+	log.Debug(r.Context(), "Get dashboards result", zap.Int("dashboardsCount", len(specs)))
+	render.JSON(w, r, specs)
 }
 
 func (router *Router) getDashboard(w http.ResponseWriter, r *http.Request) {
@@ -88,26 +112,342 @@ func (router *Router) getDashboard(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	dashboard, err := router.dbClient.GetDashboardByID(r.Context(), id)
+	user := getUser(r.Context())
+
+	dashboard, err := dashboards.GetByID(r.Context(), router.dbClient, id, user)
 	if err != nil {
+		if err == dashboards.ErrAccessDenied {
+			errresponse.Render(w, r, http.StatusForbidden, "You are not allowed to view the dashboard")
+			return
+		}
+
 		log.Error(r.Context(), "Failed to get dashboard", zap.Error(err), zap.String("dashboard", id))
 		errresponse.Render(w, r, http.StatusBadRequest, "Failed to get dashboard")
 		return
 	}
 
+	// Add the dashboard to the recently viewed items of the user. When this fails we only log the error, because the
+	// user should still be able to view the dashboard.
+	if user.ID != "" {
+		err = router.dbClient.AddRecentlyViewed(r.Context(), user.ID, db.PreferenceDashboard, id)
+		if err != nil {
+			log.Warn(r.Context(), "Failed to add dashboard to recently viewed items", zap.Error(err), zap.String("dashboard", id))
+		}
+	}
+
 	dashboard.Variables = addPlaceholdersAsVariables(dashboard.Placeholders, dashboard.Variables, placeholders)
+	evaluateRows(dashboard, placeholders)
+	render.JSON(w, r, dashboard)
+}
+
+// validateReference validates the reference from the request body against the referenced dashboard. It returns all
+// problems of the dashboard (e.g. variables which are using a non-existing plugin instance) and of the reference (e.g.
+// missing required placeholders or placeholders with a wrong type).
+func (router *Router) validateReference(w http.ResponseWriter, r *http.Request) {
+	var reference dashboardv1.Reference
+
+	err := json.NewDecoder(r.Body).Decode(&reference)
+	if err != nil {
+		log.Error(r.Context(), "Failed to decode request body", zap.Error(err))
+		errresponse.Render(w, r, http.StatusBadRequest, "Failed to decode request body")
+		return
+	}
+
+	var dashboard *dashboardv1.DashboardSpec
+
+	if reference.Inline != nil {
+		dashboard = &dashboardv1.DashboardSpec{
+			Variables: reference.Inline.Variables,
+			Rows:      reference.Inline.Rows,
+		}
+	} else {
+		dashboard, err = dashboards.GetByID(r.Context(), router.dbClient, fmt.Sprintf("/cluster/%s/namespace/%s/name/%s", reference.Cluster, reference.Namespace, reference.Name), getUser(r.Context()))
+		if err != nil {
+			log.Error(r.Context(), "Failed to get dashboard", zap.Error(err))
+			errresponse.Render(w, r, http.StatusBadRequest, "Failed to get dashboard")
+			return
+		}
+	}
+
+	plugins, err := router.dbClient.GetPlugins(r.Context())
+	if err != nil {
+		log.Error(r.Context(), "Failed to get plugins", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get plugins")
+		return
+	}
+
+	problems := []string{}
+	problems = append(problems, dashboards.Validate(dashboard, plugins)...)
+	problems = append(problems, dashboards.ValidateReference(reference, dashboard)...)
+
+	log.Debug(r.Context(), "Validate reference result", zap.Int("problemsCount", len(problems)))
+	render.JSON(w, r, struct {
+		Problems []string `json:"problems"`
+	}{problems})
+}
+
+// getUserDashboards returns all user dashboards, which can be viewed by the current user.
+func (router *Router) getUserDashboards(w http.ResponseWriter, r *http.Request) {
+	user := authContext.MustGetUser(r.Context())
+
+	userDashboards, err := router.dbClient.GetUserDashboards(r.Context(), user.ID, user.Teams)
+	if err != nil {
+		log.Error(r.Context(), "Failed to get user dashboards", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get user dashboards")
+		return
+	}
+
+	log.Debug(r.Context(), "Get user dashboards result", zap.Int("dashboardsCount", len(userDashboards)))
+	render.JSON(w, r, userDashboards)
+}
+
+// saveUserDashboard creates or updates a user dashboard. The cluster of a user dashboard is always the user dashboards
+// cluster, so that the dashboard can be referenced like a dashboard from a Dashboard CR. When the dashboard already
+// exists, only the owner of the dashboard is allowed to update it.
+func (router *Router) saveUserDashboard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := authContext.MustGetUser(ctx)
+
+	if !router.appSettings.Save.Enabled {
+		errresponse.Render(w, r, http.StatusMethodNotAllowed, "Save is disabled")
+		return
+	}
+
+	var dashboard db.UserDashboard
+
+	err := json.NewDecoder(r.Body).Decode(&dashboard)
+	if err != nil {
+		log.Error(ctx, "Failed to decode request body", zap.Error(err))
+		errresponse.Render(w, r, http.StatusBadRequest, "Failed to decode request body")
+		return
+	}
+
+	if dashboard.Visibility == "" {
+		dashboard.Visibility = db.VisibilityPrivate
+	}
+
+	if err := validateUserDashboard(&dashboard); err != nil {
+		log.Error(ctx, "Invalid dashboard data", zap.Error(err))
+		errresponse.Render(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid dashboard data: %s", err.Error()))
+		return
+	}
+
+	dashboard.Cluster = db.UserDashboardsCluster
+	dashboard.ID = fmt.Sprintf("/cluster/%s/namespace/%s/name/%s", dashboard.Cluster, dashboard.Namespace, dashboard.Name)
+	dashboard.Owner = user.ID
+	dashboard.CreatedAt = 0
+
+	existingDashboard, err := router.dbClient.GetUserDashboardByID(ctx, dashboard.ID)
+	if err != nil && err != db.ErrDashboardNotFound {
+		log.Error(ctx, "Failed to get dashboard", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get dashboard")
+		return
+	}
+
+	if existingDashboard != nil {
+		if !dashboards.CanEdit(user, existingDashboard) {
+			log.Warn(ctx, "The user is not authorized to edit the dashboard")
+			errresponse.Render(w, r, http.StatusForbidden, "You are not allowed to edit the dashboard")
+			return
+		}
+
+		dashboard.CreatedAt = existingDashboard.CreatedAt
+	}
+
+	router.saveAndRender(w, r, &dashboard)
+}
+
+// deleteUserDashboard deletes the user dashboard with the provided id. Only the owner of the dashboard is allowed to
+// delete it.
+func (router *Router) deleteUserDashboard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := authContext.MustGetUser(ctx)
+	id := r.URL.Query().Get("id")
+
+	if !router.appSettings.Save.Enabled {
+		errresponse.Render(w, r, http.StatusMethodNotAllowed, "Save is disabled")
+		return
+	}
+
+	dashboard, err := router.dbClient.GetUserDashboardByID(ctx, id)
+	if err != nil {
+		if err == db.ErrDashboardNotFound {
+			errresponse.Render(w, r, http.StatusNotFound, "Dashboard not found")
+			return
+		}
+
+		log.Error(ctx, "Failed to get dashboard", zap.Error(err), zap.String("dashboard", id))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get dashboard")
+		return
+	}
+
+	if !dashboards.CanEdit(user, dashboard) {
+		log.Warn(ctx, "The user is not authorized to delete the dashboard")
+		errresponse.Render(w, r, http.StatusForbidden, "You are not allowed to delete the dashboard")
+		return
+	}
+
+	err = router.dbClient.DeleteUserDashboard(ctx, id)
+	if err != nil {
+		log.Error(ctx, "Failed to delete dashboard", zap.Error(err), zap.String("dashboard", id))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to delete dashboard")
+		return
+	}
+
+	render.Status(r, http.StatusNoContent)
+	render.JSON(w, r, nil)
+}
+
+// forkDashboard creates a copy of a dashboard as new private user dashboard of the current user. The dashboard which
+// should be forked can be a user dashboard, which can be viewed by the user, or a dashboard from a Dashboard CR.
+func (router *Router) forkDashboard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := authContext.MustGetUser(ctx)
+	id := r.URL.Query().Get("id")
+	namespace := r.URL.Query().Get("namespace")
+	name := r.URL.Query().Get("name")
+
+	if !router.appSettings.Save.Enabled {
+		errresponse.Render(w, r, http.StatusMethodNotAllowed, "Save is disabled")
+		return
+	}
+
+	source, err := dashboards.GetByID(ctx, router.dbClient, id, user)
+	if err != nil {
+		if err == dashboards.ErrAccessDenied {
+			errresponse.Render(w, r, http.StatusForbidden, "You are not allowed to view the dashboard")
+			return
+		}
+
+		log.Error(ctx, "Failed to get dashboard", zap.Error(err), zap.String("dashboard", id))
+		errresponse.Render(w, r, http.StatusBadRequest, "Failed to get dashboard")
+		return
+	}
+
+	dashboard := db.UserDashboard{
+		DashboardSpec: *source,
+		Owner:         user.ID,
+		Visibility:    db.VisibilityPrivate,
+	}
+	dashboard.Cluster = db.UserDashboardsCluster
+	dashboard.Namespace = namespace
+	dashboard.Name = name
+	dashboard.ID = fmt.Sprintf("/cluster/%s/namespace/%s/name/%s", dashboard.Cluster, dashboard.Namespace, dashboard.Name)
+
+	if err := validateUserDashboard(&dashboard); err != nil {
+		log.Error(ctx, "Invalid dashboard data", zap.Error(err))
+		errresponse.Render(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid dashboard data: %s", err.Error()))
+		return
+	}
+
+	_, err = router.dbClient.GetUserDashboardByID(ctx, dashboard.ID)
+	if err == nil {
+		errresponse.Render(w, r, http.StatusConflict, "Dashboard already exists")
+		return
+	} else if err != db.ErrDashboardNotFound {
+		log.Error(ctx, "Failed to get dashboard", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get dashboard")
+		return
+	}
+
+	router.saveAndRender(w, r, &dashboard)
+}
+
+// saveAndRender validates the dashboard against the existing plugin instances, saves it and returns the saved
+// dashboard. Like for the dashboards from the watcher, the problems are saved with the dashboard, so that they can be
+// shown in the frontend.
+func (router *Router) saveAndRender(w http.ResponseWriter, r *http.Request, dashboard *db.UserDashboard) {
+	ctx := r.Context()
+
+	plugins, err := router.dbClient.GetPlugins(ctx)
+	if err != nil {
+		log.Warn(ctx, "Failed to get plugins, skip validation of plugin instances", zap.Error(err))
+		plugins = nil
+	}
+
+	dashboard.Problems = dashboards.Validate(&dashboard.DashboardSpec, plugins)
+
+	err = router.dbClient.SaveUserDashboard(ctx, dashboard)
+	if err != nil {
+		log.Error(ctx, "Failed to save dashboard", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to save dashboard")
+		return
+	}
+
 	render.JSON(w, r, dashboard)
 }
 
-func Mount(dbClient db.Client) chi.Router {
+// exportDashboard returns the dashboard with the provided id as Dashboard CR in YAML format, so that a user dashboard
+// can be applied to a Kubernetes cluster.
+func (router *Router) exportDashboard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := r.URL.Query().Get("id")
+
+	dashboard, err := dashboards.GetByID(ctx, router.dbClient, id, getUser(ctx))
+	if err != nil {
+		if err == dashboards.ErrAccessDenied {
+			errresponse.Render(w, r, http.StatusForbidden, "You are not allowed to view the dashboard")
+			return
+		}
+
+		log.Error(ctx, "Failed to get dashboard", zap.Error(err), zap.String("dashboard", id))
+		errresponse.Render(w, r, http.StatusBadRequest, "Failed to get dashboard")
+		return
+	}
+
+	data, err := dashboards.Export(dashboard)
+	if err != nil {
+		log.Error(ctx, "Failed to export dashboard", zap.Error(err), zap.String("dashboard", id))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to export dashboard")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/yaml")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("%s.yaml", dashboard.Name)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// validateUserDashboard checks that the namespace and name of a user dashboard are valid Kubernetes names, so that
+// the dashboard can be exported as Dashboard CR, and that the visibility is valid.
+func validateUserDashboard(dashboard *db.UserDashboard) error {
+	if errs := validation.IsDNS1123Label(dashboard.Namespace); len(errs) > 0 {
+		return fmt.Errorf("invalid namespace %q", dashboard.Namespace)
+	}
+
+	if errs := validation.IsDNS1123Subdomain(dashboard.Name); len(errs) > 0 {
+		return fmt.Errorf("invalid name %q", dashboard.Name)
+	}
+
+	switch dashboard.Visibility {
+	case db.VisibilityPrivate, db.VisibilityPublic:
+	case db.VisibilityTeams:
+		if len(dashboard.Teams) == 0 {
+			return fmt.Errorf("teams are required for visibility %q", dashboard.Visibility)
+		}
+	default:
+		return fmt.Errorf("invalid visibility %q", dashboard.Visibility)
+	}
+
+	return nil
+}
+
+func Mount(appSettings settings.Settings, dbClient db.Client) chi.Router {
 	router := Router{
 		chi.NewRouter(),
+		appSettings,
 		dbClient,
 	}
 
 	router.Get("/", router.getDashboards)
 	router.Post("/", router.getDashboardsFromReferences)
 	router.Get("/dashboard", router.getDashboard)
+	router.Post("/validate", router.validateReference)
+	router.Get("/export", router.exportDashboard)
+	router.Get("/user", router.getUserDashboards)
+	router.Post("/user", router.saveUserDashboard)
+	router.Delete("/user", router.deleteUserDashboard)
+	router.Post("/user/fork", router.forkDashboard)
 
 	return router
 }
@@ -2,6 +2,7 @@ package dashboards
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -9,7 +10,10 @@ import (
 	"testing"
 
 	dashboardv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
+	"github.com/kobsio/kobs/pkg/hub/app/settings"
+	authContext "github.com/kobsio/kobs/pkg/hub/auth/context"
 	"github.com/kobsio/kobs/pkg/hub/db"
+	"github.com/kobsio/kobs/pkg/plugins/plugin"
 	"github.com/kobsio/kobs/pkg/utils"
 
 	"github.com/go-chi/chi/v5"
@@ -23,7 +27,7 @@ func TestGetDashboards(t *testing.T) {
 		dbClient := db.NewMockClient(ctrl)
 		dbClient.EXPECT().GetDashboards(gomock.Any(), []string{"test"}, []string{"default"}).Return(nil, fmt.Errorf("unexpected error"))
 
-		router := Router{chi.NewRouter(), dbClient}
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
 		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/dashboards?cluster=test&namespace=default", nil)
 		w := httptest.NewRecorder()
 
@@ -38,7 +42,7 @@ func TestGetDashboards(t *testing.T) {
 		dbClient := db.NewMockClient(ctrl)
 		dbClient.EXPECT().GetDashboards(gomock.Any(), []string{"test"}, []string{"default"}).Return([]dashboardv1.DashboardSpec{{Title: "test", Cluster: "test", Namespace: "default", Name: "test"}}, nil)
 
-		router := Router{chi.NewRouter(), dbClient}
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
 		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/dashboards?cluster=test&namespace=default", nil)
 		w := httptest.NewRecorder()
 
@@ -54,7 +58,7 @@ func TestGetDashboardsFromReferences(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		dbClient := db.NewMockClient(ctrl)
 
-		router := Router{chi.NewRouter(), dbClient}
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
 		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/dashboards", strings.NewReader("bad json"))
 		w := httptest.NewRecorder()
 
@@ -69,7 +73,7 @@ func TestGetDashboardsFromReferences(t *testing.T) {
 		dbClient := db.NewMockClient(ctrl)
 		dbClient.EXPECT().GetDashboardByID(gomock.Any(), "/cluster/cluster1/namespace/namespace1/name/name1").Return(nil, fmt.Errorf("could not get dashboard"))
 
-		router := Router{chi.NewRouter(), dbClient}
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
 		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/dashboards", strings.NewReader(`[{"cluster":"cluster1","namespace":"namespace1","name":"name1","title":"Title 1"}]`))
 		w := httptest.NewRecorder()
 
@@ -79,12 +83,28 @@ func TestGetDashboardsFromReferences(t *testing.T) {
 		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get dashboard"]}`)
 	})
 
+	t.Run("should return forbidden for user dashboard which is not shared with the user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetUserDashboardByID(gomock.Any(), "/cluster/@hub/namespace/default/name/test").Return(&db.UserDashboard{Owner: "user2@kobs.io", Visibility: db.VisibilityPrivate}, nil)
+
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
+		ctx := context.WithValue(context.Background(), authContext.UserKey, authContext.User{ID: "user1@kobs.io"})
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/dashboards", strings.NewReader(`[{"cluster":"@hub","namespace":"default","name":"test","title":"Title 1"}]`))
+		w := httptest.NewRecorder()
+
+		router.getDashboardsFromReferences(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusForbidden)
+		utils.AssertJSONEq(t, w, `{"errors": ["You are not allowed to view the dashboard"]}`)
+	})
+
 	t.Run("should return dashboards", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		dbClient := db.NewMockClient(ctrl)
 		dbClient.EXPECT().GetDashboardByID(gomock.Any(), "/cluster/cluster1/namespace/namespace1/name/name1").Return(&dashboardv1.DashboardSpec{}, nil)
 
-		router := Router{chi.NewRouter(), dbClient}
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
 		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/dashboards", strings.NewReader(`[{"title":"Kubernetes Workloads","inline":{"rows":[{"panels":[{"title":"Workloads","plugin":{"name":"resources","options":[{"namespaces":["test-service"],"resources":["pods","deployments"],"selector":"app=test-service"}]}}]}]}},{"cluster":"cluster1","namespace":"namespace1","name":"name1","title":"Title 1"}]`))
 		w := httptest.NewRecorder()
 
@@ -100,7 +120,7 @@ func TestGetDashboard(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		dbClient := db.NewMockClient(ctrl)
 		dbClient.EXPECT().GetDashboardByID(gomock.Any(), "/cluster/cluster1/namespace/namespace1/name/name1").Return(nil, fmt.Errorf("could not get dashboard"))
-		router := Router{chi.NewRouter(), dbClient}
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
 		router.Get("/dashboard", router.getDashboard)
 
 		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/dashboard?id=/cluster/cluster1/namespace/namespace1/name/name1", nil)
@@ -112,11 +132,28 @@ func TestGetDashboard(t *testing.T) {
 		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get dashboard"]}`)
 	})
 
+	t.Run("should return forbidden for user dashboard which is not shared with the user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetUserDashboardByID(gomock.Any(), "/cluster/@hub/namespace/default/name/test").Return(&db.UserDashboard{Owner: "user2@kobs.io", Visibility: db.VisibilityPrivate}, nil)
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
+		router.Get("/dashboard", router.getDashboard)
+
+		ctx := context.WithValue(context.Background(), authContext.UserKey, authContext.User{ID: "user1@kobs.io"})
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/dashboard?id=/cluster/@hub/namespace/default/name/test", nil)
+		w := httptest.NewRecorder()
+
+		router.getDashboard(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusForbidden)
+		utils.AssertJSONEq(t, w, `{"errors": ["You are not allowed to view the dashboard"]}`)
+	})
+
 	t.Run("should return dashboards", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		dbClient := db.NewMockClient(ctrl)
 		dbClient.EXPECT().GetDashboardByID(gomock.Any(), "/cluster/cluster1/namespace/namespace1/name/name1").Return(&dashboardv1.DashboardSpec{Placeholders: []dashboardv1.Placeholder{{Name: "key1"}}}, nil)
-		router := Router{chi.NewRouter(), dbClient}
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
 		router.Get("/dashboard", router.getDashboard)
 
 		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/dashboard?id=/cluster/cluster1/namespace/namespace1/name/name1&key1=value1", nil)
@@ -127,9 +164,488 @@ func TestGetDashboard(t *testing.T) {
 		utils.AssertStatusEq(t, w, http.StatusOK)
 		utils.AssertJSONEq(t, w, `{"placeholders":[{"name":"key1"}],"variables":[{"name":"key1","label":"key1","hide":true,"plugin":{"type":"core","name":"placeholder","options":{"type":"string","value":"value1"}}}],"rows":null}`)
 	})
+	t.Run("should return rows which should be shown", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetDashboardByID(gomock.Any(), "/cluster/cluster1/namespace/namespace1/name/name1").Return(&dashboardv1.DashboardSpec{
+			Placeholders: []dashboardv1.Placeholder{{Name: "key1"}},
+			Rows: []dashboardv1.Row{
+				{Title: "row1", If: `"{% .key1 %}" === "value1"`},
+				{Title: "row2", If: `"{% .key1 %}" !== "value1"`},
+				{Title: "row3", If: `"{% .var1 %}" !== ""`},
+				{Title: "row4", If: `"{% .key1 %}" ==`},
+			},
+		}, nil)
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/dashboard?id=/cluster/cluster1/namespace/namespace1/name/name1&key1=value1", nil)
+		w := httptest.NewRecorder()
+
+		router.getDashboard(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+
+		var dashboard dashboardv1.DashboardSpec
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&dashboard))
+		require.Equal(t, []dashboardv1.Row{{Title: "row1", Panels: nil}, {Title: "row3", If: `"{% .var1 %}" !== ""`}, {Title: "row4", If: `"{% .key1 %}" ==`}}, dashboard.Rows)
+		require.Len(t, dashboard.Problems, 1)
+	})
+
+	t.Run("should add dashboard to recently viewed items", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetDashboardByID(gomock.Any(), "/cluster/cluster1/namespace/namespace1/name/name1").Return(&dashboardv1.DashboardSpec{}, nil)
+		dbClient.EXPECT().AddRecentlyViewed(gomock.Any(), "user1@kobs.io", db.PreferenceDashboard, "/cluster/cluster1/namespace/namespace1/name/name1").Return(fmt.Errorf("could not add recently viewed item"))
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
+
+		ctx := context.WithValue(context.Background(), authContext.UserKey, authContext.User{ID: "user1@kobs.io"})
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/dashboard?id=/cluster/cluster1/namespace/namespace1/name/name1", nil)
+		w := httptest.NewRecorder()
+
+		router.getDashboard(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `{"rows":null}`)
+	})
+}
+
+func TestValidateReference(t *testing.T) {
+	t.Run("should return error for invalid request body", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/validate", strings.NewReader("bad json"))
+		w := httptest.NewRecorder()
+
+		router.validateReference(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusBadRequest)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to decode request body"]}`)
+	})
+
+	t.Run("should handle error from db client for dashboard", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetDashboardByID(gomock.Any(), "/cluster/cluster1/namespace/namespace1/name/name1").Return(nil, fmt.Errorf("could not get dashboard"))
+
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/validate", strings.NewReader(`{"cluster":"cluster1","namespace":"namespace1","name":"name1","title":"Title 1"}`))
+		w := httptest.NewRecorder()
+
+		router.validateReference(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusBadRequest)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get dashboard"]}`)
+	})
+
+	t.Run("should handle error from db client for plugins", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetPlugins(gomock.Any()).Return(nil, fmt.Errorf("could not get plugins"))
+
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/validate", strings.NewReader(`{"title":"Title 1","inline":{"rows":[]}}`))
+		w := httptest.NewRecorder()
+
+		router.validateReference(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get plugins"]}`)
+	})
+
+	t.Run("should return all problems", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetDashboardByID(gomock.Any(), "/cluster/cluster1/namespace/namespace1/name/name1").Return(&dashboardv1.DashboardSpec{
+			Placeholders: []dashboardv1.Placeholder{{Name: "namespace"}, {Name: "replicas", Type: "number"}},
+			Variables:    []dashboardv1.Variable{{Name: "pod", Plugin: dashboardv1.Plugin{Cluster: "cluster1", Type: "prometheus", Name: "prometheus"}}},
+		}, nil)
+		dbClient.EXPECT().GetPlugins(gomock.Any()).Return([]plugin.Instance{{Cluster: "hub", Type: "prometheus", Name: "prometheus"}}, nil)
+
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/validate", strings.NewReader(`{"cluster":"cluster1","namespace":"namespace1","name":"name1","title":"Title 1","placeholders":{"replicas":"three"}}`))
+		w := httptest.NewRecorder()
+
+		router.validateReference(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `{"problems":["variable \"pod\": plugin instance cluster1/prometheus/prometheus does not exist","placeholder \"namespace\": value is required","placeholder \"replicas\": value \"three\" is not a number"]}`)
+	})
+
+	t.Run("should return empty list for valid reference", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetPlugins(gomock.Any()).Return(nil, nil)
+
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/validate", strings.NewReader(`{"title":"Title 1","inline":{"rows":[{"panels":[{"title":"Workloads","plugin":{"type":"core","name":"resources"}}]}]}}`))
+		w := httptest.NewRecorder()
+
+		router.validateReference(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `{"problems":[]}`)
+	})
+}
+
+func TestGetUserDashboards(t *testing.T) {
+	user := authContext.User{ID: "user1@kobs.io", Teams: []string{"team1"}}
+
+	t.Run("should return error from db client", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetUserDashboards(gomock.Any(), "user1@kobs.io", []string{"team1"}).Return(nil, fmt.Errorf("unexpected error"))
+
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), authContext.UserKey, user), http.MethodGet, "/user", nil)
+		w := httptest.NewRecorder()
+
+		router.getUserDashboards(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get user dashboards"]}`)
+	})
+
+	t.Run("should return dashboards", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetUserDashboards(gomock.Any(), "user1@kobs.io", []string{"team1"}).Return([]db.UserDashboard{{DashboardSpec: dashboardv1.DashboardSpec{Title: "test"}, Owner: "user1@kobs.io", Visibility: "private"}}, nil)
+
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), authContext.UserKey, user), http.MethodGet, "/user", nil)
+		w := httptest.NewRecorder()
+
+		router.getUserDashboards(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `[{"title":"test","rows":null,"owner":"user1@kobs.io","visibility":"private","createdAt":0}]`)
+	})
+}
+
+func TestSaveUserDashboard(t *testing.T) {
+	user := authContext.User{ID: "user1@kobs.io"}
+	appSettings := settings.Settings{}
+	appSettings.Save.Enabled = true
+
+	for _, tt := range []struct {
+		name               string
+		appSettings        settings.Settings
+		body               string
+		prepare            func(dbClient *db.MockClient)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "should return error when save is disabled",
+			appSettings:        settings.Settings{},
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedBody:       `{"errors": ["Save is disabled"]}`,
+		},
+		{
+			name:               "should return error for invalid request body",
+			appSettings:        appSettings,
+			body:               `bad json`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"errors": ["Failed to decode request body"]}`,
+		},
+		{
+			name:               "should return error for invalid name",
+			appSettings:        appSettings,
+			body:               `{"namespace":"default","name":"Invalid Name"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"errors": ["Invalid dashboard data: invalid name \"Invalid Name\""]}`,
+		},
+		{
+			name:               "should return error for visibility teams without teams",
+			appSettings:        appSettings,
+			body:               `{"namespace":"default","name":"test","visibility":"teams"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"errors": ["Invalid dashboard data: teams are required for visibility \"teams\""]}`,
+		},
+		{
+			name:        "should return error when user is not the owner",
+			appSettings: appSettings,
+			body:        `{"namespace":"default","name":"test"}`,
+			prepare: func(dbClient *db.MockClient) {
+				dbClient.EXPECT().GetUserDashboardByID(gomock.Any(), "/cluster/@hub/namespace/default/name/test").Return(&db.UserDashboard{Owner: "user2@kobs.io"}, nil)
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       `{"errors": ["You are not allowed to edit the dashboard"]}`,
+		},
+		{
+			name:        "should return error when get dashboard fails",
+			appSettings: appSettings,
+			body:        `{"namespace":"default","name":"test"}`,
+			prepare: func(dbClient *db.MockClient) {
+				dbClient.EXPECT().GetUserDashboardByID(gomock.Any(), "/cluster/@hub/namespace/default/name/test").Return(nil, fmt.Errorf("unexpected error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       `{"errors": ["Failed to get dashboard"]}`,
+		},
+		{
+			name:        "should return error when save fails",
+			appSettings: appSettings,
+			body:        `{"namespace":"default","name":"test"}`,
+			prepare: func(dbClient *db.MockClient) {
+				dbClient.EXPECT().GetUserDashboardByID(gomock.Any(), "/cluster/@hub/namespace/default/name/test").Return(nil, db.ErrDashboardNotFound)
+				dbClient.EXPECT().GetPlugins(gomock.Any()).Return(nil, nil)
+				dbClient.EXPECT().SaveUserDashboard(gomock.Any(), gomock.Any()).Return(fmt.Errorf("unexpected error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       `{"errors": ["Failed to save dashboard"]}`,
+		},
+		{
+			name:        "should save dashboard",
+			appSettings: appSettings,
+			body:        `{"cluster":"dev","namespace":"default","name":"test","title":"Test","owner":"user2@kobs.io","rows":[{"panels":[{"title":"Panel","plugin":{"type":"prometheus","cluster":"dev","name":"prometheus"}}]}]}`,
+			prepare: func(dbClient *db.MockClient) {
+				dbClient.EXPECT().GetUserDashboardByID(gomock.Any(), "/cluster/@hub/namespace/default/name/test").Return(&db.UserDashboard{Owner: "user1@kobs.io", CreatedAt: 1}, nil)
+				dbClient.EXPECT().GetPlugins(gomock.Any()).Return([]plugin.Instance{}, nil)
+				dbClient.EXPECT().SaveUserDashboard(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"id":"/cluster/@hub/namespace/default/name/test","cluster":"@hub","namespace":"default","name":"test","title":"Test","rows":[{"panels":[{"title":"Panel","plugin":{"type":"prometheus","cluster":"dev","name":"prometheus"}}]}],"problems":["panel \"Panel\": plugin instance dev/prometheus/prometheus does not exist"],"owner":"user1@kobs.io","visibility":"private","createdAt":1}`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			dbClient := db.NewMockClient(ctrl)
+			if tt.prepare != nil {
+				tt.prepare(dbClient)
+			}
+
+			router := Router{chi.NewRouter(), tt.appSettings, dbClient}
+			req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), authContext.UserKey, user), http.MethodPost, "/user", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			router.saveUserDashboard(w, req)
+
+			utils.AssertStatusEq(t, w, tt.expectedStatusCode)
+			utils.AssertJSONEq(t, w, tt.expectedBody)
+		})
+	}
+}
+
+func TestDeleteUserDashboard(t *testing.T) {
+	user := authContext.User{ID: "user1@kobs.io"}
+	appSettings := settings.Settings{}
+	appSettings.Save.Enabled = true
+
+	for _, tt := range []struct {
+		name               string
+		appSettings        settings.Settings
+		prepare            func(dbClient *db.MockClient)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "should return error when save is disabled",
+			appSettings:        settings.Settings{},
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedBody:       `{"errors": ["Save is disabled"]}`,
+		},
+		{
+			name:        "should return error for not existing dashboard",
+			appSettings: appSettings,
+			prepare: func(dbClient *db.MockClient) {
+				dbClient.EXPECT().GetUserDashboardByID(gomock.Any(), "/cluster/@hub/namespace/default/name/test").Return(nil, db.ErrDashboardNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"errors": ["Dashboard not found"]}`,
+		},
+		{
+			name:        "should return error when user is not the owner",
+			appSettings: appSettings,
+			prepare: func(dbClient *db.MockClient) {
+				dbClient.EXPECT().GetUserDashboardByID(gomock.Any(), "/cluster/@hub/namespace/default/name/test").Return(&db.UserDashboard{Owner: "user2@kobs.io", Visibility: db.VisibilityPublic}, nil)
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       `{"errors": ["You are not allowed to delete the dashboard"]}`,
+		},
+		{
+			name:        "should return error when delete fails",
+			appSettings: appSettings,
+			prepare: func(dbClient *db.MockClient) {
+				dbClient.EXPECT().GetUserDashboardByID(gomock.Any(), "/cluster/@hub/namespace/default/name/test").Return(&db.UserDashboard{Owner: "user1@kobs.io"}, nil)
+				dbClient.EXPECT().DeleteUserDashboard(gomock.Any(), "/cluster/@hub/namespace/default/name/test").Return(fmt.Errorf("unexpected error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       `{"errors": ["Failed to delete dashboard"]}`,
+		},
+		{
+			name:        "should delete dashboard",
+			appSettings: appSettings,
+			prepare: func(dbClient *db.MockClient) {
+				dbClient.EXPECT().GetUserDashboardByID(gomock.Any(), "/cluster/@hub/namespace/default/name/test").Return(&db.UserDashboard{Owner: "user1@kobs.io"}, nil)
+				dbClient.EXPECT().DeleteUserDashboard(gomock.Any(), "/cluster/@hub/namespace/default/name/test").Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+			expectedBody:       `null`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			dbClient := db.NewMockClient(ctrl)
+			if tt.prepare != nil {
+				tt.prepare(dbClient)
+			}
+
+			router := Router{chi.NewRouter(), tt.appSettings, dbClient}
+			req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), authContext.UserKey, user), http.MethodDelete, "/user?id=/cluster/@hub/namespace/default/name/test", nil)
+			w := httptest.NewRecorder()
+
+			router.deleteUserDashboard(w, req)
+
+			utils.AssertStatusEq(t, w, tt.expectedStatusCode)
+			utils.AssertJSONEq(t, w, tt.expectedBody)
+		})
+	}
+}
+
+func TestForkDashboard(t *testing.T) {
+	user := authContext.User{ID: "user1@kobs.io"}
+	appSettings := settings.Settings{}
+	appSettings.Save.Enabled = true
+
+	for _, tt := range []struct {
+		name               string
+		appSettings        settings.Settings
+		url                string
+		prepare            func(dbClient *db.MockClient)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "should return error when save is disabled",
+			appSettings:        settings.Settings{},
+			url:                "/user/fork",
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedBody:       `{"errors": ["Save is disabled"]}`,
+		},
+		{
+			name:        "should return error when user can not view the dashboard",
+			appSettings: appSettings,
+			url:         "/user/fork?id=/cluster/@hub/namespace/default/name/test&namespace=default&name=fork",
+			prepare: func(dbClient *db.MockClient) {
+				dbClient.EXPECT().GetUserDashboardByID(gomock.Any(), "/cluster/@hub/namespace/default/name/test").Return(&db.UserDashboard{Owner: "user2@kobs.io", Visibility: db.VisibilityPrivate}, nil)
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       `{"errors": ["You are not allowed to view the dashboard"]}`,
+		},
+		{
+			name:        "should return error when dashboard can not be loaded",
+			appSettings: appSettings,
+			url:         "/user/fork?id=/cluster/dev/namespace/default/name/test&namespace=default&name=fork",
+			prepare: func(dbClient *db.MockClient) {
+				dbClient.EXPECT().GetDashboardByID(gomock.Any(), "/cluster/dev/namespace/default/name/test").Return(nil, fmt.Errorf("unexpected error"))
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"errors": ["Failed to get dashboard"]}`,
+		},
+		{
+			name:        "should return error for invalid name",
+			appSettings: appSettings,
+			url:         "/user/fork?id=/cluster/dev/namespace/default/name/test&namespace=default",
+			prepare: func(dbClient *db.MockClient) {
+				dbClient.EXPECT().GetDashboardByID(gomock.Any(), "/cluster/dev/namespace/default/name/test").Return(&dashboardv1.DashboardSpec{}, nil)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"errors": ["Invalid dashboard data: invalid name \"\""]}`,
+		},
+		{
+			name:        "should return error when dashboard already exists",
+			appSettings: appSettings,
+			url:         "/user/fork?id=/cluster/dev/namespace/default/name/test&namespace=default&name=fork",
+			prepare: func(dbClient *db.MockClient) {
+				dbClient.EXPECT().GetDashboardByID(gomock.Any(), "/cluster/dev/namespace/default/name/test").Return(&dashboardv1.DashboardSpec{}, nil)
+				dbClient.EXPECT().GetUserDashboardByID(gomock.Any(), "/cluster/@hub/namespace/default/name/fork").Return(&db.UserDashboard{}, nil)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedBody:       `{"errors": ["Dashboard already exists"]}`,
+		},
+		{
+			name:        "should fork dashboard",
+			appSettings: appSettings,
+			url:         "/user/fork?id=/cluster/dev/namespace/default/name/test&namespace=default&name=fork",
+			prepare: func(dbClient *db.MockClient) {
+				dbClient.EXPECT().GetDashboardByID(gomock.Any(), "/cluster/dev/namespace/default/name/test").Return(&dashboardv1.DashboardSpec{ID: "/cluster/dev/namespace/default/name/test", Cluster: "dev", Namespace: "default", Name: "test", Title: "Test"}, nil)
+				dbClient.EXPECT().GetUserDashboardByID(gomock.Any(), "/cluster/@hub/namespace/default/name/fork").Return(nil, db.ErrDashboardNotFound)
+				dbClient.EXPECT().GetPlugins(gomock.Any()).Return(nil, fmt.Errorf("unexpected error"))
+				dbClient.EXPECT().SaveUserDashboard(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"id":"/cluster/@hub/namespace/default/name/fork","cluster":"@hub","namespace":"default","name":"fork","title":"Test","rows":null,"owner":"user1@kobs.io","visibility":"private","createdAt":0}`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			dbClient := db.NewMockClient(ctrl)
+			if tt.prepare != nil {
+				tt.prepare(dbClient)
+			}
+
+			router := Router{chi.NewRouter(), tt.appSettings, dbClient}
+			req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), authContext.UserKey, user), http.MethodPost, tt.url, nil)
+			w := httptest.NewRecorder()
+
+			router.forkDashboard(w, req)
+
+			utils.AssertStatusEq(t, w, tt.expectedStatusCode)
+			utils.AssertJSONEq(t, w, tt.expectedBody)
+		})
+	}
+}
+
+func TestExportDashboard(t *testing.T) {
+	t.Run("should return error when user can not view the dashboard", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetUserDashboardByID(gomock.Any(), "/cluster/@hub/namespace/default/name/test").Return(&db.UserDashboard{Owner: "user2@kobs.io", Visibility: db.VisibilityPrivate}, nil)
+
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/export?id=/cluster/@hub/namespace/default/name/test", nil)
+		w := httptest.NewRecorder()
+
+		router.exportDashboard(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusForbidden)
+		utils.AssertJSONEq(t, w, `{"errors": ["You are not allowed to view the dashboard"]}`)
+	})
+
+	t.Run("should return error when dashboard can not be loaded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetDashboardByID(gomock.Any(), "/cluster/dev/namespace/default/name/test").Return(nil, fmt.Errorf("unexpected error"))
+
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/export?id=/cluster/dev/namespace/default/name/test", nil)
+		w := httptest.NewRecorder()
+
+		router.exportDashboard(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusBadRequest)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get dashboard"]}`)
+	})
+
+	t.Run("should export dashboard", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetUserDashboardByID(gomock.Any(), "/cluster/@hub/namespace/default/name/test").Return(&db.UserDashboard{DashboardSpec: dashboardv1.DashboardSpec{Cluster: "hub", Namespace: "default", Name: "test", Title: "Test"}, Owner: "user1@kobs.io", Visibility: db.VisibilityPrivate}, nil)
+
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), authContext.UserKey, authContext.User{ID: "user1@kobs.io"}), http.MethodGet, "/export?id=/cluster/@hub/namespace/default/name/test", nil)
+		w := httptest.NewRecorder()
+
+		router.exportDashboard(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		require.Equal(t, "application/yaml", w.Header().Get("Content-Type"))
+		require.Equal(t, "apiVersion: kobs.io/v1\nkind: Dashboard\nmetadata:\n  name: test\n  namespace: default\nspec:\n  rows: null\n  title: Test\n", w.Body.String())
+	})
 }
 
 func TestMount(t *testing.T) {
-	router := Mount(nil)
+	router := Mount(settings.Settings{}, nil)
 	require.NotNil(t, router)
 }
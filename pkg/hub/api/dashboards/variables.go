@@ -2,8 +2,10 @@ package dashboards
 
 import (
 	"encoding/json"
+	"slices"
 
 	dashboardv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
+	"github.com/kobsio/kobs/pkg/hub/dashboards"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 )
@@ -49,3 +51,17 @@ func addPlaceholdersAsVariables(placeholders []dashboardv1.Placeholder, variable
 
 	return newVariables
 }
+
+// evaluateRows evaluates the conditions of the rows of the provided dashboard with the values of the placeholders, so
+// that only the rows which should be shown are returned. Problems with the conditions are added to the problems of the
+// dashboard, when they are not already reported by the validation of the dashboard.
+func evaluateRows(dashboard *dashboardv1.DashboardSpec, placeholderValues map[string]string) {
+	rows, problems := dashboards.EvaluateRows(dashboard.Rows, dashboards.GetValues(dashboard.Placeholders, placeholderValues))
+	dashboard.Rows = rows
+
+	for _, problem := range problems {
+		if !slices.Contains(dashboard.Problems, problem) {
+			dashboard.Problems = append(dashboard.Problems, problem)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package preferences
+
+import (
+	"net/http"
+
+	authContext "github.com/kobsio/kobs/pkg/hub/auth/context"
+	"github.com/kobsio/kobs/pkg/hub/db"
+	"github.com/kobsio/kobs/pkg/instrument/log"
+	"github.com/kobsio/kobs/pkg/utils/middleware/errresponse"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+)
+
+type Router struct {
+	*chi.Mux
+	dbClient db.Client
+}
+
+// isValidFavoriteType checks if the provided type can be used for a favorite. Users can mark applications, dashboards
+// and teams as favorite.
+func isValidFavoriteType(itemType string) bool {
+	return itemType == db.PreferenceApplication || itemType == db.PreferenceDashboard || itemType == db.PreferenceTeam
+}
+
+// getPreferences returns the favorites and the recently viewed items of the user from the request context.
+func (router *Router) getPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := authContext.MustGetUser(ctx)
+
+	preferences, err := router.dbClient.GetPreferences(ctx, user.ID)
+	if err != nil {
+		log.Error(ctx, "Failed to get preferences", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get preferences")
+		return
+	}
+
+	render.JSON(w, r, preferences)
+}
+
+// addFavorite marks the item with the provided type and id as favorite for the user from the request context.
+func (router *Router) addFavorite(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := authContext.MustGetUser(ctx)
+	itemType := r.URL.Query().Get("type")
+	id := r.URL.Query().Get("id")
+
+	log.Debug(ctx, "Add favorite parameters", zap.String("type", itemType), zap.String("id", id))
+
+	if !isValidFavoriteType(itemType) || id == "" {
+		log.Warn(ctx, "Invalid favorite", zap.String("type", itemType), zap.String("id", id))
+		errresponse.Render(w, r, http.StatusBadRequest, "Invalid favorite")
+		return
+	}
+
+	err := router.dbClient.AddFavorite(ctx, user.ID, itemType, id)
+	if err != nil {
+		log.Error(ctx, "Failed to add favorite", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to add favorite")
+		return
+	}
+
+	render.Status(r, http.StatusNoContent)
+	render.JSON(w, r, nil)
+}
+
+// removeFavorite removes the item with the provided type and id from the favorites of the user from the request
+// context.
+func (router *Router) removeFavorite(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := authContext.MustGetUser(ctx)
+	itemType := r.URL.Query().Get("type")
+	id := r.URL.Query().Get("id")
+
+	log.Debug(ctx, "Remove favorite parameters", zap.String("type", itemType), zap.String("id", id))
+
+	if !isValidFavoriteType(itemType) || id == "" {
+		log.Warn(ctx, "Invalid favorite", zap.String("type", itemType), zap.String("id", id))
+		errresponse.Render(w, r, http.StatusBadRequest, "Invalid favorite")
+		return
+	}
+
+	err := router.dbClient.RemoveFavorite(ctx, user.ID, itemType, id)
+	if err != nil {
+		log.Error(ctx, "Failed to remove favorite", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to remove favorite")
+		return
+	}
+
+	render.Status(r, http.StatusNoContent)
+	render.JSON(w, r, nil)
+}
+
+// deleteRecentlyViewed clears the list of recently viewed items of the user from the request context.
+func (router *Router) deleteRecentlyViewed(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := authContext.MustGetUser(ctx)
+
+	err := router.dbClient.DeleteRecentlyViewed(ctx, user.ID)
+	if err != nil {
+		log.Error(ctx, "Failed to delete recently viewed items", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to delete recently viewed items")
+		return
+	}
+
+	render.Status(r, http.StatusNoContent)
+	render.JSON(w, r, nil)
+}
+
+func Mount(dbClient db.Client) chi.Router {
+	router := Router{
+		chi.NewRouter(),
+		dbClient,
+	}
+
+	router.Get("/", router.getPreferences)
+	router.Post("/favorites", router.addFavorite)
+	router.Delete("/favorites", router.removeFavorite)
+	router.Delete("/recentlyviewed", router.deleteRecentlyViewed)
+
+	return router
+}
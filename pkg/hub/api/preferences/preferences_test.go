@@ -0,0 +1,166 @@
+package preferences
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	authContext "github.com/kobsio/kobs/pkg/hub/auth/context"
+	"github.com/kobsio/kobs/pkg/hub/db"
+	"github.com/kobsio/kobs/pkg/utils"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func newRequest(method, target string) *http.Request {
+	ctx := context.WithValue(context.Background(), authContext.UserKey, authContext.User{ID: "user1@kobs.io"})
+	req, _ := http.NewRequestWithContext(ctx, method, target, nil)
+	return req
+}
+
+func TestGetPreferences(t *testing.T) {
+	t.Run("should handle error from db client", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetPreferences(gomock.Any(), "user1@kobs.io").Return(nil, fmt.Errorf("could not get preferences"))
+
+		router := Router{chi.NewRouter(), dbClient}
+		w := httptest.NewRecorder()
+		router.getPreferences(w, newRequest(http.MethodGet, "/"))
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get preferences"]}`)
+	})
+
+	t.Run("should return preferences", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetPreferences(gomock.Any(), "user1@kobs.io").Return(&db.Preferences{
+			User:           "user1@kobs.io",
+			Favorites:      db.Favorites{Applications: []string{"app1"}},
+			RecentlyViewed: []db.RecentlyViewed{{Type: db.PreferenceDashboard, ID: "dashboard1", ViewedAt: 1}},
+			UpdatedAt:      1,
+		}, nil)
+
+		router := Router{chi.NewRouter(), dbClient}
+		w := httptest.NewRecorder()
+		router.getPreferences(w, newRequest(http.MethodGet, "/"))
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `{"user":"user1@kobs.io","favorites":{"applications":["app1"],"dashboards":null,"teams":null},"recentlyViewed":[{"type":"dashboard","id":"dashboard1","viewedAt":1}],"updatedAt":1}`)
+	})
+}
+
+func TestAddFavorite(t *testing.T) {
+	t.Run("should return error for invalid type", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+
+		router := Router{chi.NewRouter(), dbClient}
+		w := httptest.NewRecorder()
+		router.addFavorite(w, newRequest(http.MethodPost, "/favorites?type=user&id=user2"))
+
+		utils.AssertStatusEq(t, w, http.StatusBadRequest)
+		utils.AssertJSONEq(t, w, `{"errors": ["Invalid favorite"]}`)
+	})
+
+	t.Run("should handle error from db client", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().AddFavorite(gomock.Any(), "user1@kobs.io", db.PreferenceApplication, "app1").Return(fmt.Errorf("could not add favorite"))
+
+		router := Router{chi.NewRouter(), dbClient}
+		w := httptest.NewRecorder()
+		router.addFavorite(w, newRequest(http.MethodPost, "/favorites?type=application&id=app1"))
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to add favorite"]}`)
+	})
+
+	t.Run("should add favorite", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().AddFavorite(gomock.Any(), "user1@kobs.io", db.PreferenceApplication, "app1").Return(nil)
+
+		router := Router{chi.NewRouter(), dbClient}
+		w := httptest.NewRecorder()
+		router.addFavorite(w, newRequest(http.MethodPost, "/favorites?type=application&id=app1"))
+
+		utils.AssertStatusEq(t, w, http.StatusNoContent)
+	})
+}
+
+func TestRemoveFavorite(t *testing.T) {
+	t.Run("should return error for missing id", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+
+		router := Router{chi.NewRouter(), dbClient}
+		w := httptest.NewRecorder()
+		router.removeFavorite(w, newRequest(http.MethodDelete, "/favorites?type=team"))
+
+		utils.AssertStatusEq(t, w, http.StatusBadRequest)
+		utils.AssertJSONEq(t, w, `{"errors": ["Invalid favorite"]}`)
+	})
+
+	t.Run("should handle error from db client", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().RemoveFavorite(gomock.Any(), "user1@kobs.io", db.PreferenceTeam, "team1").Return(fmt.Errorf("could not remove favorite"))
+
+		router := Router{chi.NewRouter(), dbClient}
+		w := httptest.NewRecorder()
+		router.removeFavorite(w, newRequest(http.MethodDelete, "/favorites?type=team&id=team1"))
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to remove favorite"]}`)
+	})
+
+	t.Run("should remove favorite", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().RemoveFavorite(gomock.Any(), "user1@kobs.io", db.PreferenceTeam, "team1").Return(nil)
+
+		router := Router{chi.NewRouter(), dbClient}
+		w := httptest.NewRecorder()
+		router.removeFavorite(w, newRequest(http.MethodDelete, "/favorites?type=team&id=team1"))
+
+		utils.AssertStatusEq(t, w, http.StatusNoContent)
+	})
+}
+
+func TestDeleteRecentlyViewed(t *testing.T) {
+	t.Run("should handle error from db client", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().DeleteRecentlyViewed(gomock.Any(), "user1@kobs.io").Return(fmt.Errorf("could not delete recently viewed items"))
+
+		router := Router{chi.NewRouter(), dbClient}
+		w := httptest.NewRecorder()
+		router.deleteRecentlyViewed(w, newRequest(http.MethodDelete, "/recentlyviewed"))
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to delete recently viewed items"]}`)
+	})
+
+	t.Run("should delete recently viewed items", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().DeleteRecentlyViewed(gomock.Any(), "user1@kobs.io").Return(nil)
+
+		router := Router{chi.NewRouter(), dbClient}
+		w := httptest.NewRecorder()
+		router.deleteRecentlyViewed(w, newRequest(http.MethodDelete, "/recentlyviewed"))
+
+		utils.AssertStatusEq(t, w, http.StatusNoContent)
+	})
+}
+
+func TestMount(t *testing.T) {
+	router := Mount(nil)
+	require.NotNil(t, router)
+}
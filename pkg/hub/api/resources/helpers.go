@@ -1,9 +1,16 @@
 package resources
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/kobsio/kobs/pkg/cluster/kubernetes"
+	userv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/user/v1"
+	authContext "github.com/kobsio/kobs/pkg/hub/auth/context"
+	"github.com/kobsio/kobs/pkg/hub/health"
 )
 
 type Resource struct {
@@ -291,3 +298,258 @@ func GetResource(id string) Resource {
 
 	return Resource{}
 }
+
+// rolloutVerbs maps the rollout operations of the cluster API to the verb, which a user must have in his resources
+// permissions to run the operation.
+var rolloutVerbs = map[string]string{
+	"restart": userv1.VerbRestart,
+	"scale":   userv1.VerbScale,
+	"pause":   userv1.VerbPause,
+	"resume":  userv1.VerbResume,
+	"status":  userv1.VerbStatus,
+	"history": userv1.VerbHistory,
+	"undo":    userv1.VerbUndo,
+}
+
+// getRolloutVerb returns the verb which is required for the rollout operation in the given url path. If the path is
+// not a path for a rollout operation the second return value is false.
+func getRolloutVerb(urlPath string) (string, bool) {
+	index := strings.LastIndex(urlPath, "/rollout/")
+	if index == -1 {
+		return "", false
+	}
+
+	verb, ok := rolloutVerbs[urlPath[index+len("/rollout/"):]]
+	return verb, ok
+}
+
+// relatedResources maps the kinds, which can be returned by the related resources API of a cluster, to the resource
+// which is used in the resources permissions of a user.
+var relatedResources = map[string]string{
+	"Deployment":  "deployments",
+	"StatefulSet": "statefulsets",
+	"DaemonSet":   "daemonsets",
+	"ReplicaSet":  "replicasets",
+	"Pod":         "pods",
+	"Service":     "services",
+	"Endpoints":   "endpoints",
+	"Ingress":     "ingresses",
+}
+
+// filterRelatedResources removes all related resources from the given resource, which the user is not allowed to get,
+// together with the resources which are related to them. The events of a resource are removed, when the user is not
+// allowed to get the events in the namespace of the resource. Related resources with an unknown kind are always
+// removed.
+func filterRelatedResources(user *authContext.User, cluster string, resource map[string]any) {
+	namespace, _ := resource["namespace"].(string)
+	if !user.HasResourceAccess(cluster, namespace, "events", "get") {
+		delete(resource, "events")
+	}
+
+	related, ok := resource["related"].([]any)
+	if !ok {
+		return
+	}
+
+	var filteredRelated []any
+	for _, item := range related {
+		relatedResource, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		kind, _ := relatedResource["kind"].(string)
+		relatedNamespace, _ := relatedResource["namespace"].(string)
+		if name, ok := relatedResources[kind]; !ok || !user.HasResourceAccess(cluster, relatedNamespace, name, "get") {
+			continue
+		}
+
+		filterRelatedResources(user, cluster, relatedResource)
+		filteredRelated = append(filteredRelated, relatedResource)
+	}
+
+	if len(filteredRelated) == 0 {
+		delete(resource, "related")
+		return
+	}
+
+	resource["related"] = filteredRelated
+}
+
+// getUsageResource returns the resource which is required to get the usage in the given url path, i.e. "pods" for the
+// usage of Pods and "nodes" for the usage of Nodes. If the path is not a path for the usage the second return value is
+// false.
+func getUsageResource(urlPath string) (string, bool) {
+	switch {
+	case strings.HasSuffix(urlPath, "/usage/pods"):
+		return "pods", true
+	case strings.HasSuffix(urlPath, "/usage/nodes"):
+		return "nodes", true
+	default:
+		return "", false
+	}
+}
+
+// resourceTarget is a single combination of resource, cluster and namespace for which we have to get the list of
+// resources. The continue field is only set when we want to get the next page of resources.
+type resourceTarget struct {
+	Resource  string `json:"resource"`
+	Cluster   string `json:"cluster"`
+	Namespace string `json:"namespace"`
+	Continue  string `json:"continue,omitempty"`
+}
+
+// newResourceTargets returns all combinations of the given resources, clusters and namespaces. If no namespaces are
+// provided, we create one target per resource and cluster with an empty namespace, which means that the resources from
+// all namespaces are returned.
+func newResourceTargets(resources, clusters, namespaces []string) []resourceTarget {
+	if len(namespaces) == 0 {
+		namespaces = []string{""}
+	}
+
+	var targets []resourceTarget
+	for _, resource := range resources {
+		for _, cluster := range clusters {
+			for _, namespace := range namespaces {
+				targets = append(targets, resourceTarget{Resource: resource, Cluster: cluster, Namespace: namespace})
+			}
+		}
+	}
+
+	return targets
+}
+
+// encodeContinueToken encodes the given target into an opaque continue token. The token contains the resource,
+// cluster and namespace, so that the user only has to pass the tokens to get the next page for all combinations which
+// have more resources.
+func encodeContinueToken(target resourceTarget) string {
+	data, _ := json.Marshal(target)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeContinueTokens decodes the given continue tokens into a list of targets. An error is returned when one of the
+// tokens is invalid.
+func decodeContinueTokens(tokens []string) ([]resourceTarget, error) {
+	var targets []resourceTarget
+
+	for _, token := range tokens {
+		data, err := base64.RawURLEncoding.DecodeString(token)
+		if err != nil {
+			return nil, err
+		}
+
+		var target resourceTarget
+		if err := json.Unmarshal(data, &target); err != nil {
+			return nil, err
+		}
+
+		if target.Resource == "" || target.Cluster == "" || target.Continue == "" {
+			return nil, fmt.Errorf("invalid continue token")
+		}
+
+		targets = append(targets, target)
+	}
+
+	return targets, nil
+}
+
+// aggregateResourceResponses combines the results for each resource, cluster and namespace into a list of
+// ResourceResponses. The resources are sorted by their title, the clusters and namespaces by their name.
+func aggregateResourceResponses(results []ResourceStreamResponse) []ResourceResponse {
+	var resourceResponses []ResourceResponse
+	resourceIndexes := make(map[string]int)
+	clusterIndexes := make(map[string]map[string]int)
+
+	for _, result := range results {
+		resourceIndex, ok := resourceIndexes[result.Resource.ID]
+		if !ok {
+			resourceResponses = append(resourceResponses, ResourceResponse{Resource: result.Resource, Dashboards: result.Dashboards})
+			resourceIndex = len(resourceResponses) - 1
+			resourceIndexes[result.Resource.ID] = resourceIndex
+			clusterIndexes[result.Resource.ID] = make(map[string]int)
+		}
+
+		if result.scope == resourceScope {
+			resourceResponses[resourceIndex].Error = result.Error
+			continue
+		}
+
+		clusterIndex, ok := clusterIndexes[result.Resource.ID][result.Cluster]
+		if !ok {
+			resourceResponses[resourceIndex].Clusters = append(resourceResponses[resourceIndex].Clusters, ResourceResponseCluster{Cluster: result.Cluster})
+			clusterIndex = len(resourceResponses[resourceIndex].Clusters) - 1
+			clusterIndexes[result.Resource.ID][result.Cluster] = clusterIndex
+		}
+
+		if result.scope == clusterScope {
+			resourceResponses[resourceIndex].Clusters[clusterIndex].Error = result.Error
+			continue
+		}
+
+		resourceResponses[resourceIndex].Clusters[clusterIndex].Namespaces = append(resourceResponses[resourceIndex].Clusters[clusterIndex].Namespaces, ResourceResponseNamespace{
+			Namespace: result.Namespace,
+			Error:     result.Error,
+			Manifest:  result.Manifest,
+			Health:    result.Health,
+			Continue:  result.Continue,
+		})
+	}
+
+	for i := range resourceResponses {
+		for j := range resourceResponses[i].Clusters {
+			sort.Slice(resourceResponses[i].Clusters[j].Namespaces, func(k, l int) bool {
+				return resourceResponses[i].Clusters[j].Namespaces[k].Namespace < resourceResponses[i].Clusters[j].Namespaces[l].Namespace
+			})
+		}
+
+		sort.Slice(resourceResponses[i].Clusters, func(k, l int) bool {
+			return resourceResponses[i].Clusters[k].Cluster < resourceResponses[i].Clusters[l].Cluster
+		})
+	}
+
+	sort.Slice(resourceResponses, func(i, j int) bool {
+		return resourceResponses[i].Resource.Title < resourceResponses[j].Resource.Title
+	})
+
+	return resourceResponses
+}
+
+// aggregateHealthResponses combines the results for all resource, cluster and namespace combinations into the health
+// response. The summary of the response is the combination of the summaries of all results.
+func aggregateHealthResponses(results []ResourceStreamResponse) HealthResponse {
+	healthResponse := HealthResponse{Resources: []HealthResponseResource{}}
+
+	for _, result := range results {
+		resource := HealthResponseResource{
+			Resource:  result.Resource.ID,
+			Cluster:   result.Cluster,
+			Namespace: result.Namespace,
+			Error:     result.Error,
+			Items:     []health.Item{},
+		}
+
+		if result.Health != nil {
+			resource.Summary = result.Health.Summary
+			for _, item := range result.Health.Items {
+				if item.Health.Status != health.StatusHealthy {
+					resource.Items = append(resource.Items, item)
+				}
+			}
+		}
+
+		healthResponse.Summary.Merge(resource.Summary)
+		healthResponse.Resources = append(healthResponse.Resources, resource)
+	}
+
+	sort.Slice(healthResponse.Resources, func(i, j int) bool {
+		if healthResponse.Resources[i].Resource != healthResponse.Resources[j].Resource {
+			return healthResponse.Resources[i].Resource < healthResponse.Resources[j].Resource
+		}
+		if healthResponse.Resources[i].Cluster != healthResponse.Resources[j].Cluster {
+			return healthResponse.Resources[i].Cluster < healthResponse.Resources[j].Cluster
+		}
+		return healthResponse.Resources[i].Namespace < healthResponse.Resources[j].Namespace
+	})
+
+	return healthResponse
+}
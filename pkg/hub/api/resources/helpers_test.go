@@ -42,3 +42,70 @@ func TestGetResource(t *testing.T) {
 		require.Equal(t, Resource{}, GetResource("this doesnt exist"))
 	})
 }
+
+func TestGetRolloutVerb(t *testing.T) {
+	for _, tt := range []struct {
+		path         string
+		expectedVerb string
+		expectedOk   bool
+	}{
+		{path: "/api/resources/rollout/restart", expectedVerb: "restart", expectedOk: true},
+		{path: "/api/resources/rollout/resume", expectedVerb: "resume", expectedOk: true},
+		{path: "/api/resources/rollout/undo", expectedVerb: "undo", expectedOk: true},
+		{path: "/api/resources/rollout/unknown", expectedVerb: "", expectedOk: false},
+		{path: "/api/resources", expectedVerb: "", expectedOk: false},
+	} {
+		t.Run(tt.path, func(t *testing.T) {
+			verb, ok := getRolloutVerb(tt.path)
+			require.Equal(t, tt.expectedVerb, verb)
+			require.Equal(t, tt.expectedOk, ok)
+		})
+	}
+}
+
+func TestGetUsageResource(t *testing.T) {
+	for _, tt := range []struct {
+		path             string
+		expectedResource string
+		expectedOk       bool
+	}{
+		{path: "/api/resources/usage/pods", expectedResource: "pods", expectedOk: true},
+		{path: "/api/resources/usage/nodes", expectedResource: "nodes", expectedOk: true},
+		{path: "/api/resources/usage", expectedResource: "", expectedOk: false},
+		{path: "/api/resources", expectedResource: "", expectedOk: false},
+	} {
+		t.Run(tt.path, func(t *testing.T) {
+			resource, ok := getUsageResource(tt.path)
+			require.Equal(t, tt.expectedResource, resource)
+			require.Equal(t, tt.expectedOk, ok)
+		})
+	}
+}
+
+func TestContinueTokens(t *testing.T) {
+	t.Run("should encode and decode continue tokens", func(t *testing.T) {
+		target := resourceTarget{Resource: "pods", Cluster: "dev-de1", Namespace: "default", Continue: "next"}
+		targets, err := decodeContinueTokens([]string{encodeContinueToken(target)})
+		require.NoError(t, err)
+		require.Equal(t, []resourceTarget{target}, targets)
+	})
+
+	t.Run("should fail for invalid base64", func(t *testing.T) {
+		_, err := decodeContinueTokens([]string{"!"})
+		require.Error(t, err)
+	})
+
+	t.Run("should fail for incomplete token", func(t *testing.T) {
+		_, err := decodeContinueTokens([]string{encodeContinueToken(resourceTarget{Resource: "pods"})})
+		require.Error(t, err)
+	})
+}
+
+func TestNewResourceTargets(t *testing.T) {
+	require.Equal(t, []resourceTarget{
+		{Resource: "pods", Cluster: "dev-de1"},
+		{Resource: "pods", Cluster: "dev-de2"},
+	}, newResourceTargets([]string{"pods"}, []string{"dev-de1", "dev-de2"}, nil))
+
+	require.Len(t, newResourceTargets([]string{"pods", "services"}, []string{"dev-de1"}, []string{"default", "kube-system"}), 4)
+}
@@ -2,18 +2,24 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	userv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/user/v1"
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/diff"
 	"github.com/kobsio/kobs/pkg/hub/app/settings"
 	authContext "github.com/kobsio/kobs/pkg/hub/auth/context"
 	"github.com/kobsio/kobs/pkg/hub/clusters"
+	"github.com/kobsio/kobs/pkg/hub/clusters/cluster"
 	"github.com/kobsio/kobs/pkg/hub/db"
+	"github.com/kobsio/kobs/pkg/hub/health"
 	"github.com/kobsio/kobs/pkg/instrument/log"
 	"github.com/kobsio/kobs/pkg/utils/middleware/errresponse"
 
@@ -39,15 +45,105 @@ type ResourceResponseNamespace struct {
 	Namespace string         `json:"namespace"`
 	Error     string         `json:"error"`
 	Manifest  map[string]any `json:"manifest"`
+	Health    *health.List   `json:"health,omitempty"`
+	Continue  string         `json:"continue,omitempty"`
 }
 
+// ResourceStreamResponse is the result for a single resource, cluster and namespace. In the streaming mode each result
+// is written as a single line of JSON. In the default mode all results are combined into a list of ResourceResponses.
+// The continue field is only set, when there are more resources available. The value can then be passed via the
+// "continue" parameter to get the next page of resources. The health field is only set, when the health of the
+// resources was requested via the "health" parameter.
+type ResourceStreamResponse struct {
+	Resource   Resource             `json:"resource"`
+	Dashboards []settings.Dashboard `json:"dashboards,omitempty"`
+	Cluster    string               `json:"cluster"`
+	Namespace  string               `json:"namespace"`
+	Error      string               `json:"error"`
+	Manifest   map[string]any       `json:"manifest"`
+	Health     *health.List         `json:"health,omitempty"`
+	Continue   string               `json:"continue,omitempty"`
+
+	// scope defines to which level of the ResourceResponse the error of the result belongs, so that the results can be
+	// combined into the same format as it is used in the default mode.
+	scope string
+}
+
+const (
+	resourceScope  = "resource"
+	clusterScope   = "cluster"
+	namespaceScope = "namespace"
+)
+
+// resourcesQuery contains the query parameters, which are passed to each cluster when we get a list of resources.
+type resourcesQuery struct {
+	paramName     string
+	param         string
+	labelSelector string
+	fieldSelector string
+	limit         string
+	bypassCache   bool
+	health        bool
+}
+
+// RelatedResponse is the response for a single cluster of the related resources handler. If we were not able to get
+// the related resources for the cluster the error field is set, otherwise the resource field contains the requested
+// resource together with all related resources and events.
+type RelatedResponse struct {
+	Cluster  string         `json:"cluster"`
+	Error    string         `json:"error"`
+	Resource map[string]any `json:"resource"`
+}
+
+// DiffResponse is the response of the diff handler. It contains the structured list of changes between the two
+// resources and a unified diff of the YAML representation of both resources.
+type DiffResponse struct {
+	Changes []diff.Change `json:"changes"`
+	Unified string        `json:"unified"`
+}
+
+// HealthResponse is the response of the health handler. It contains the aggregated health of all requested resources
+// and the health for each resource, cluster and namespace combination. For each combination only the resources which
+// are not healthy are returned, to keep the response small.
+type HealthResponse struct {
+	Summary   health.Summary           `json:"summary"`
+	Resources []HealthResponseResource `json:"resources"`
+}
+
+type HealthResponseResource struct {
+	Resource  string         `json:"resource"`
+	Cluster   string         `json:"cluster"`
+	Namespace string         `json:"namespace"`
+	Error     string         `json:"error"`
+	Summary   health.Summary `json:"summary"`
+	Items     []health.Item  `json:"items"`
+}
+
+// ApplicationResourcesResponse is the response of the application handler. It contains all resources which are
+// selected by the resources section of an application together with their health and the aggregated health of all
+// resources.
+type ApplicationResourcesResponse struct {
+	Health    health.Summary     `json:"health"`
+	Resources []ResourceResponse `json:"resources"`
+}
+
+// defaultApplicationResources are the resources which are selected for an application, when the application doesn't
+// define any kinds in the resources section.
+var defaultApplicationResources = []string{"daemonsets", "deployments", "pods", "services", "statefulsets"}
+
+// defaultHealthResources are the resources which are used to calculate the health summary, when the user doesn't
+// provide any resources.
+var defaultHealthResources = []string{"daemonsets", "deployments", "jobs", "persistentvolumeclaims", "pods", "statefulsets"}
+
 // Router implements the resources API. It implement a chi.Mux and contains the satellites and store client and the
-// configured integration to serve our needs.
+// configured integration to serve our needs. The health evaluator is used to add the health of each resource to the
+// returned manifests.
 type Router struct {
 	*chi.Mux
-	appSettings    settings.Settings
-	clustersClient clusters.Client
-	dbClient       db.Client
+	appSettings     settings.Settings
+	clustersClient  clusters.Client
+	dbClient        db.Client
+	healthEvaluator health.Evaluator
 }
 
 func (router *Router) resourcesHandler(w http.ResponseWriter, r *http.Request) {
@@ -83,6 +179,29 @@ func (router *Router) resourcesHandler(w http.ResponseWriter, r *http.Request) {
 				errresponse.Render(w, r, http.StatusUnauthorized)
 				return
 			}
+		} else if strings.HasSuffix(r.URL.Path, "/reveal") {
+			// Revealing the value of a Secret or ConfigMap requires the special "reveal" verb, because the values are
+			// redacted for users with the "get" verb. Each request is written to the audit log, so that it is possible
+			// to see who accessed which value.
+			if !user.HasResourceAccess(clusterHeader, r.URL.Query().Get("namespace"), r.URL.Query().Get("resource"), userv1.VerbReveal) {
+				log.Warn(ctx, "User is not authorized to reveal value", zap.Bool("audit", true), zap.String("user", user.ID), zap.String("cluster", clusterHeader), zap.String("namespace", r.URL.Query().Get("namespace")), zap.String("resource", r.URL.Query().Get("resource")), zap.String("name", r.URL.Query().Get("name")), zap.String("key", r.URL.Query().Get("key")))
+				errresponse.Render(w, r, http.StatusUnauthorized)
+				return
+			}
+
+			log.Info(ctx, "User revealed value", zap.Bool("audit", true), zap.String("user", user.ID), zap.String("cluster", clusterHeader), zap.String("namespace", r.URL.Query().Get("namespace")), zap.String("resource", r.URL.Query().Get("resource")), zap.String("name", r.URL.Query().Get("name")), zap.String("key", r.URL.Query().Get("key")))
+		} else if resource, ok := getUsageResource(r.URL.Path); ok {
+			if !user.HasResourceAccess(clusterHeader, r.URL.Query().Get("namespace"), resource, "get") {
+				log.Warn(ctx, "User is not authorized to access resource", zap.String("cluster", clusterHeader), zap.String("namespace", r.URL.Query().Get("namespace")), zap.String("resource", resource), zap.String("verb", "get"))
+				errresponse.Render(w, r, http.StatusUnauthorized)
+				return
+			}
+		} else if verb, ok := getRolloutVerb(r.URL.Path); ok {
+			if !user.HasResourceAccess(clusterHeader, r.URL.Query().Get("namespace"), r.URL.Query().Get("resource"), verb) {
+				log.Warn(ctx, "User is not authorized to access resource", zap.String("cluster", clusterHeader), zap.String("namespace", r.URL.Query().Get("namespace")), zap.String("resource", r.URL.Query().Get("resource")), zap.String("verb", verb))
+				errresponse.Render(w, r, http.StatusUnauthorized)
+				return
+			}
 		} else {
 			if !user.HasResourceAccess(clusterHeader, r.URL.Query().Get("namespace"), r.URL.Query().Get("resource"), r.Method) {
 				log.Warn(ctx, "User is not authorized to access resource", zap.String("cluster", clusterHeader), zap.String("namespace", r.URL.Query().Get("namespace")), zap.String("resource", r.URL.Query().Get("resource")), zap.String("method", r.Method))
@@ -108,180 +227,608 @@ func (router *Router) resourcesHandler(w http.ResponseWriter, r *http.Request) {
 	// resource, cluster and namespace to the corresponding cluster. The results of each request will be combined into
 	// one response.
 	//
-	// To minimize the time a user has to wait for the result, we parallize all requests via wait groups.
+	// When the user provides one or more continue tokens, we only request the next page for the resource, cluster and
+	// namespace combinations from the tokens. The tokens are returned for each namespace when the user sets a limit
+	// and there are more resources available.
+	//
+	// The "bypassCache" parameter is passed to each cluster, so that the lists are always loaded from the Kubernetes API
+	// server, even if the cluster has a cache for the requested resources. When the "health" parameter is set, the
+	// health of each resource is evaluated and returned alongside the manifest.
 	clusters := r.URL.Query()["cluster"]
 	namespaces := r.URL.Query()["namespace"]
 	resources := r.URL.Query()["resource"]
-	paramName := r.URL.Query().Get("paramName")
-	param := r.URL.Query().Get("param")
+	continueTokens := r.URL.Query()["continue"]
+	query := resourcesQuery{
+		paramName:     r.URL.Query().Get("paramName"),
+		param:         r.URL.Query().Get("param"),
+		labelSelector: r.URL.Query().Get("labelSelector"),
+		fieldSelector: r.URL.Query().Get("fieldSelector"),
+		limit:         r.URL.Query().Get("limit"),
+	}
+	query.bypassCache, _ = strconv.ParseBool(r.URL.Query().Get("bypassCache"))
+	query.health, _ = strconv.ParseBool(r.URL.Query().Get("health"))
+	stream, _ := strconv.ParseBool(r.URL.Query().Get("stream"))
 
-	log.Debug(r.Context(), "Get resources", zap.Strings("clusters", clusters), zap.Strings("namespaces", namespaces), zap.Strings("resources", resources), zap.String("paramName", paramName), zap.String("param", param))
+	log.Debug(r.Context(), "Get resources", zap.Strings("clusters", clusters), zap.Strings("namespaces", namespaces), zap.Strings("resources", resources), zap.String("paramName", query.paramName), zap.String("param", query.param), zap.String("labelSelector", query.labelSelector), zap.String("fieldSelector", query.fieldSelector), zap.String("limit", query.limit), zap.Bool("bypassCache", query.bypassCache), zap.Bool("health", query.health), zap.Int("continueTokens", len(continueTokens)), zap.Bool("stream", stream))
 
-	if len(clusters) == 0 {
-		log.Warn(ctx, "The parameter 'cluster' is required", zap.Strings("resources", resources))
-		errresponse.Render(w, r, http.StatusBadRequest, "The parameter 'cluster' is required")
-		return
+	if query.limit != "" {
+		if _, err := strconv.ParseInt(query.limit, 10, 64); err != nil {
+			log.Warn(ctx, "Failed to parse 'limit' parameter", zap.Error(err))
+			errresponse.Render(w, r, http.StatusBadRequest, "Failed to parse 'limit' parameter")
+			return
+		}
 	}
 
-	if len(resources) == 0 {
-		log.Warn(ctx, "The parameter 'resource' is required", zap.Strings("resources", resources))
-		errresponse.Render(w, r, http.StatusBadRequest, "The parameter 'resource' is required")
+	var targets []resourceTarget
+
+	if len(continueTokens) > 0 {
+		var err error
+		targets, err = decodeContinueTokens(continueTokens)
+		if err != nil {
+			log.Warn(ctx, "Invalid 'continue' parameter", zap.Error(err))
+			errresponse.Render(w, r, http.StatusBadRequest, "Invalid 'continue' parameter")
+			return
+		}
+	} else {
+		if len(clusters) == 0 {
+			log.Warn(ctx, "The parameter 'cluster' is required", zap.Strings("resources", resources))
+			errresponse.Render(w, r, http.StatusBadRequest, "The parameter 'cluster' is required")
+			return
+		}
+
+		if len(resources) == 0 {
+			log.Warn(ctx, "The parameter 'resource' is required", zap.Strings("resources", resources))
+			errresponse.Render(w, r, http.StatusBadRequest, "The parameter 'resource' is required")
+			return
+		}
+
+		targets = newResourceTargets(resources, clusters, namespaces)
+	}
+
+	// In the streaming mode we write each result as a single line of JSON as soon as we receive it, so that the user
+	// can already render the first results, while we are still waiting for the other clusters. This also means, that we
+	// do not have to keep all manifests in memory until the last cluster returned its result.
+	if stream {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+
+		flusher, _ := w.(http.Flusher)
+		encoder := json.NewEncoder(w)
+		muWriter := &sync.Mutex{}
+
+		router.getResources(ctx, user, targets, query, func(result ResourceStreamResponse) {
+			muWriter.Lock()
+			defer muWriter.Unlock()
+
+			if err := encoder.Encode(result); err != nil {
+				log.Warn(ctx, "Failed to write resources", zap.Error(err))
+				return
+			}
+
+			if flusher != nil {
+				flusher.Flush()
+			}
+		})
 		return
 	}
 
-	var resourceResponses []ResourceResponse
+	var results []ResourceStreamResponse
+	muResults := &sync.Mutex{}
+
+	router.getResources(ctx, user, targets, query, func(result ResourceStreamResponse) {
+		muResults.Lock()
+		results = append(results, result)
+		muResults.Unlock()
+	})
+
+	render.JSON(w, r, aggregateResourceResponses(results))
+}
+
+// getResources makes one request for each of the given targets to the corresponding cluster. Each result is passed to
+// the emit function as soon as it is available. To minimize the time a user has to wait for the result, we parallize
+// all requests via wait groups. The function returns when all requests are finished.
+func (router *Router) getResources(ctx context.Context, user *authContext.User, targets []resourceTarget, query resourcesQuery, emit func(result ResourceStreamResponse)) {
+	targetsByResource := make(map[string]map[string][]resourceTarget)
+	for _, target := range targets {
+		if _, ok := targetsByResource[target.Resource]; !ok {
+			targetsByResource[target.Resource] = make(map[string][]resourceTarget)
+		}
+		targetsByResource[target.Resource][target.Cluster] = append(targetsByResource[target.Resource][target.Cluster], target)
+	}
 
-	muResourceResponses := &sync.Mutex{}
-	var wgResourceResponses sync.WaitGroup
-	wgResourceResponses.Add(len(resources))
+	var wgResources sync.WaitGroup
+	wgResources.Add(len(targetsByResource))
 
-	for _, resource := range resources {
-		go func(resource string) {
-			defer wgResourceResponses.Done()
+	for resourceID, targetsByCluster := range targetsByResource {
+		go func(resourceID string, targetsByCluster map[string][]resourceTarget) {
+			defer wgResources.Done()
 
-			var r Resource
-			r = GetResource(resource)
+			r := GetResource(resourceID)
 			if r.ID == "" {
-				crd, err := router.dbClient.GetCRDByID(ctx, resource)
+				crd, err := router.dbClient.GetCRDByID(ctx, resourceID)
 				if err != nil {
-					log.Error(ctx, "Resource not found", zap.Error(err), zap.String("resource", resource))
-					muResourceResponses.Lock()
-					resourceResponses = append(resourceResponses, ResourceResponse{Resource: Resource{ID: resource, Title: resource}, Error: "Resource not found"})
-					muResourceResponses.Unlock()
+					log.Error(ctx, "Resource not found", zap.Error(err), zap.String("resource", resourceID))
+					emit(ResourceStreamResponse{Resource: Resource{ID: resourceID, Title: resourceID}, Error: "Resource not found", scope: resourceScope})
 					return
 				}
 
 				r = CRDToResource(*crd)
 			}
 
-			dashboards := router.appSettings.GetDashboardsFromResourcesIntegrations(resource)
+			dashboards := router.appSettings.GetDashboardsFromResourcesIntegrations(resourceID)
 
-			var resourceResponseCluster []ResourceResponseCluster
-
-			muClusters := &sync.Mutex{}
 			var wgClusters sync.WaitGroup
-			wgClusters.Add(len(clusters))
+			wgClusters.Add(len(targetsByCluster))
 
-			for _, cluster := range clusters {
-				go func(cluster string) {
+			for cluster, clusterTargets := range targetsByCluster {
+				go func(cluster string, clusterTargets []resourceTarget) {
 					defer wgClusters.Done()
 
 					clusterClient := router.clustersClient.GetCluster(cluster)
-					if clusterClient != nil {
-						if len(namespaces) == 0 {
-							if !user.HasResourceAccess(cluster, "", resource, "get") {
-								log.Warn(ctx, "User is not authorized to access the resource", zap.String("cluster", cluster), zap.String("namespace", "*"), zap.String("resource", resource), zap.String("verb", "get"))
-								muClusters.Lock()
-								resourceResponseCluster = append(resourceResponseCluster, ResourceResponseCluster{Cluster: cluster, Namespaces: []ResourceResponseNamespace{{Namespace: "", Error: fmt.Sprintf("You are not authorized to access the resources in %s", cluster)}}})
-								muClusters.Unlock()
-								return
-							}
-
-							ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-							defer cancel()
-
-							manifest, err := clusterClient.Request(ctx, http.MethodGet, fmt.Sprintf("/api/resources?namespace=%s&resource=%s&path=%s&paramName=%s&param=%s", "", r.Resource, r.Path, paramName, url.QueryEscape(param)), nil)
-							if err != nil {
-								log.Error(ctx, "Failed to get resources", zap.Error(err))
-								muClusters.Lock()
-								resourceResponseCluster = append(resourceResponseCluster, ResourceResponseCluster{Cluster: cluster, Namespaces: []ResourceResponseNamespace{{Namespace: "", Error: fmt.Sprintf("Failed to get resources for %s", cluster)}}})
-								muClusters.Unlock()
-								return
-							}
-
-							muClusters.Lock()
-							resourceResponseCluster = append(resourceResponseCluster, ResourceResponseCluster{Cluster: cluster, Namespaces: []ResourceResponseNamespace{{Namespace: "", Manifest: manifest}}})
-							muClusters.Unlock()
-							return
-						}
-
-						var resourceResponseNamespace []ResourceResponseNamespace
-
-						muNamespaces := &sync.Mutex{}
-						var wgNamespaces sync.WaitGroup
-						wgNamespaces.Add(len(namespaces))
-
-						for _, namespace := range namespaces {
-							go func(namespace string) {
-								defer wgNamespaces.Done()
-
-								if !user.HasResourceAccess(cluster, namespace, resource, "get") {
-									log.Warn(ctx, "User is not authorized to access the resource", zap.String("cluster", cluster), zap.String("namespace", namespace), zap.String("resource", resource), zap.String("verb", "get"))
-									muNamespaces.Lock()
-									resourceResponseNamespace = append(resourceResponseNamespace, ResourceResponseNamespace{Namespace: namespace, Error: fmt.Sprintf("You are not authorized to access the resources in %s / %s", cluster, namespace)})
-									muNamespaces.Unlock()
-									return
-								}
-
-								ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-								defer cancel()
-
-								if r.Scope == "Cluster" {
-									namespace = ""
-								}
-
-								manifest, err := clusterClient.Request(ctx, http.MethodGet, fmt.Sprintf("/api/resources?namespace=%s&resource=%s&path=%s&paramName=%s&param=%s", namespace, r.Resource, r.Path, paramName, param), nil)
-								if err != nil {
-									log.Error(ctx, "Failed to get resources", zap.Error(err))
-									muNamespaces.Lock()
-									resourceResponseNamespace = append(resourceResponseNamespace, ResourceResponseNamespace{Namespace: namespace, Error: fmt.Sprintf("Failed to get resources for %s / %s", cluster, namespace)})
-									muNamespaces.Unlock()
-									return
-								}
-
-								muNamespaces.Lock()
-								resourceResponseNamespace = append(resourceResponseNamespace, ResourceResponseNamespace{Namespace: namespace, Manifest: manifest})
-								muNamespaces.Unlock()
-							}(namespace)
-						}
-
-						wgNamespaces.Wait()
-
-						sort.Slice(resourceResponseNamespace, func(i, j int) bool {
-							return resourceResponseNamespace[i].Namespace < resourceResponseNamespace[j].Namespace
-						})
-
-						muClusters.Lock()
-						resourceResponseCluster = append(resourceResponseCluster, ResourceResponseCluster{Cluster: cluster, Namespaces: resourceResponseNamespace})
-						muClusters.Unlock()
+					if clusterClient == nil {
+						emit(ResourceStreamResponse{Resource: r, Dashboards: dashboards, Cluster: cluster, Error: fmt.Sprintf("Failed to find cluster %s", cluster), scope: clusterScope})
 						return
 					}
 
-					muClusters.Lock()
-					resourceResponseCluster = append(resourceResponseCluster, ResourceResponseCluster{Cluster: cluster, Error: fmt.Sprintf("Failed to find cluster %s", cluster)})
-					muClusters.Unlock()
-				}(cluster)
+					var wgNamespaces sync.WaitGroup
+					wgNamespaces.Add(len(clusterTargets))
+
+					for _, target := range clusterTargets {
+						go func(target resourceTarget) {
+							defer wgNamespaces.Done()
+
+							result := router.getResourcesForTarget(ctx, user, clusterClient, r, target, query)
+							result.Dashboards = dashboards
+							emit(result)
+						}(target)
+					}
+
+					wgNamespaces.Wait()
+				}(cluster, clusterTargets)
 			}
 
 			wgClusters.Wait()
+		}(resourceID, targetsByCluster)
+	}
+
+	wgResources.Wait()
+}
+
+// getResourcesForTarget returns the resources for a single target. If the user is not allowed to access the resources
+// or the request to the cluster fails, the error field of the returned result is set.
+func (router *Router) getResourcesForTarget(ctx context.Context, user *authContext.User, clusterClient cluster.Client, r Resource, target resourceTarget, query resourcesQuery) ResourceStreamResponse {
+	namespace := target.Namespace
+
+	location := target.Cluster
+	if namespace != "" {
+		location = fmt.Sprintf("%s / %s", target.Cluster, namespace)
+	}
+
+	if !user.HasResourceAccess(target.Cluster, namespace, target.Resource, "get") {
+		log.Warn(ctx, "User is not authorized to access the resource", zap.String("cluster", target.Cluster), zap.String("namespace", namespace), zap.String("resource", target.Resource), zap.String("verb", "get"))
+		return ResourceStreamResponse{Resource: r, Cluster: target.Cluster, Namespace: namespace, Error: fmt.Sprintf("You are not authorized to access the resources in %s", location), scope: namespaceScope}
+	}
+
+	requestCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if r.Scope == "Cluster" {
+		namespace = ""
+	}
+
+	params := url.Values{}
+	params.Set("namespace", namespace)
+	params.Set("resource", r.Resource)
+	params.Set("path", r.Path)
+	params.Set("paramName", query.paramName)
+	params.Set("param", query.param)
+	if query.labelSelector != "" {
+		params.Set("labelSelector", query.labelSelector)
+	}
+	if query.fieldSelector != "" {
+		params.Set("fieldSelector", query.fieldSelector)
+	}
+	if query.limit != "" {
+		params.Set("limit", query.limit)
+	}
+	if target.Continue != "" {
+		params.Set("continue", target.Continue)
+	}
+	if query.bypassCache {
+		params.Set("bypassCache", "true")
+	}
+
+	manifest, err := clusterClient.Request(requestCtx, http.MethodGet, fmt.Sprintf("/api/resources?%s", params.Encode()), nil)
+	if err != nil {
+		log.Error(ctx, "Failed to get resources", zap.Error(err))
+		return ResourceStreamResponse{Resource: r, Cluster: target.Cluster, Namespace: namespace, Error: fmt.Sprintf("Failed to get resources for %s", location), scope: namespaceScope}
+	}
+
+	result := ResourceStreamResponse{Resource: r, Cluster: target.Cluster, Namespace: namespace, Manifest: manifest, scope: namespaceScope}
+
+	if query.health {
+		result.Health = router.healthEvaluator.EvaluateList(target.Resource, manifest)
+	}
+
+	if metadata, ok := manifest["metadata"].(map[string]any); ok {
+		if continueToken, ok := metadata["continue"].(string); ok && continueToken != "" {
+			result.Continue = encodeContinueToken(resourceTarget{Resource: target.Resource, Cluster: target.Cluster, Namespace: target.Namespace, Continue: continueToken})
+		}
+	}
+
+	return result
+}
+
+// healthHandler returns the aggregated health of the resources in the selected clusters and namespaces. The resources
+// can be filtered via a label selector, so that the handler can also be used to get the health of all resources which
+// belong to an application. If the user doesn't select any resources, we use the default workload resources.
+func (router *Router) healthHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := authContext.MustGetUser(ctx)
+
+	clusters := r.URL.Query()["cluster"]
+	namespaces := r.URL.Query()["namespace"]
+	resources := r.URL.Query()["resource"]
+	labelSelector := r.URL.Query().Get("labelSelector")
+
+	log.Debug(ctx, "Get health", zap.Strings("clusters", clusters), zap.Strings("namespaces", namespaces), zap.Strings("resources", resources), zap.String("labelSelector", labelSelector))
 
-			sort.Slice(resourceResponseCluster, func(i, j int) bool {
-				return resourceResponseCluster[i].Cluster < resourceResponseCluster[j].Cluster
-			})
+	if len(clusters) == 0 {
+		log.Warn(ctx, "The parameter 'cluster' is required")
+		errresponse.Render(w, r, http.StatusBadRequest, "The parameter 'cluster' is required")
+		return
+	}
 
-			muResourceResponses.Lock()
-			resourceResponses = append(resourceResponses, ResourceResponse{Resource: r, Clusters: resourceResponseCluster, Dashboards: dashboards})
-			muResourceResponses.Unlock()
-		}(resource)
+	if len(resources) == 0 {
+		resources = defaultHealthResources
 	}
 
-	wgResourceResponses.Wait()
+	var results []ResourceStreamResponse
+	muResults := &sync.Mutex{}
 
-	sort.Slice(resourceResponses, func(i, j int) bool {
-		return resourceResponses[i].Resource.Title < resourceResponses[j].Resource.Title
+	router.getResources(ctx, user, newResourceTargets(resources, clusters, namespaces), resourcesQuery{labelSelector: labelSelector, health: true}, func(result ResourceStreamResponse) {
+		muResults.Lock()
+		results = append(results, result)
+		muResults.Unlock()
 	})
 
-	render.JSON(w, r, resourceResponses)
+	render.JSON(w, r, aggregateHealthResponses(results))
+}
+
+// applicationHandler returns the resources which belong to an application. The resources are selected via the kinds,
+// namespaces and label selector from the resources section of the application. The resources are always returned
+// with their health, so that the application page can show an inventory of the application.
+func (router *Router) applicationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := authContext.MustGetUser(ctx)
+	id := r.URL.Query().Get("id")
+
+	log.Debug(ctx, "Get application resources", zap.String("id", id))
+
+	application, err := router.dbClient.GetApplicationByID(ctx, id)
+	if err != nil {
+		log.Error(ctx, "Failed to get application", zap.Error(err), zap.String("id", id))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get application")
+		return
+	}
+
+	if application == nil {
+		log.Warn(ctx, "Application was not found", zap.String("id", id))
+		errresponse.Render(w, r, http.StatusNotFound, "Application was not found")
+		return
+	}
+
+	if !user.HasApplicationAccess(application) {
+		log.Warn(ctx, "The user is not authorized to view the application", zap.String("id", id))
+		errresponse.Render(w, r, http.StatusForbidden, "You are not allowed to view the application")
+		return
+	}
+
+	if application.Resources == nil {
+		render.JSON(w, r, ApplicationResourcesResponse{Resources: []ResourceResponse{}})
+		return
+	}
+
+	kinds := application.Resources.Kinds
+	if len(kinds) == 0 {
+		kinds = defaultApplicationResources
+	}
+
+	namespaces := application.Resources.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{application.Namespace}
+	}
+
+	var results []ResourceStreamResponse
+	muResults := &sync.Mutex{}
+
+	router.getResources(ctx, user, newResourceTargets(kinds, []string{application.Cluster}, namespaces), resourcesQuery{labelSelector: application.Resources.LabelSelector, health: true}, func(result ResourceStreamResponse) {
+		muResults.Lock()
+		results = append(results, result)
+		muResults.Unlock()
+	})
+
+	response := ApplicationResourcesResponse{Resources: aggregateResourceResponses(results)}
+	for _, result := range results {
+		if result.Health != nil {
+			response.Health.Merge(result.Health.Summary)
+		}
+	}
+
+	render.JSON(w, r, response)
+}
+
+// getRelatedResources returns the related resources for the resource with the given namespace, name and resource type
+// from the given cluster.
+func (router *Router) getRelatedResources(clusterClient cluster.Client, namespace, name, resource string) (map[string]any, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	return clusterClient.Request(ctx, http.MethodGet, fmt.Sprintf("/api/resources/related?namespace=%s&name=%s&resource=%s", url.QueryEscape(namespace), url.QueryEscape(name), url.QueryEscape(resource)), nil)
+}
+
+// relatedHandler returns the related resources (e.g. the ReplicaSets and Pods of a Deployment) and their events for a
+// resource. The resource is identified by its namespace, name and resource type. Similar to the resourcesHandler a
+// user can select multiple clusters, so that the request is sent to each cluster in parallel and the results are
+// combined into one response.
+//
+// The response only contains the related resources and events, which the user is allowed to get. Therefore requests
+// for a single cluster via the `x-kobs-cluster` header or query parameter are not proxied like all other requests,
+// because we have to filter the response of the cluster.
+func (router *Router) relatedHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := authContext.MustGetUser(ctx)
+
+	clusterHeader := r.Header.Get("x-kobs-cluster")
+	if clusterHeader == "" {
+		clusterHeader = r.URL.Query().Get("x-kobs-cluster")
+	}
+
+	clusters := r.URL.Query()["cluster"]
+	namespace := r.URL.Query().Get("namespace")
+	name := r.URL.Query().Get("name")
+	resource := r.URL.Query().Get("resource")
+
+	log.Debug(ctx, "Get related resources", zap.String("clusterHeader", clusterHeader), zap.Strings("clusters", clusters), zap.String("namespace", namespace), zap.String("name", name), zap.String("resource", resource))
+
+	if clusterHeader == "" && len(clusters) == 0 {
+		log.Warn(ctx, "The parameter 'cluster' is required")
+		errresponse.Render(w, r, http.StatusBadRequest, "The parameter 'cluster' is required")
+		return
+	}
+
+	if name == "" || resource == "" {
+		log.Warn(ctx, "The parameters 'name' and 'resource' are required")
+		errresponse.Render(w, r, http.StatusBadRequest, "The parameters 'name' and 'resource' are required")
+		return
+	}
+
+	if clusterHeader != "" {
+		clusterClient := router.clustersClient.GetCluster(clusterHeader)
+		if clusterClient == nil {
+			log.Warn(ctx, fmt.Sprintf("Invalid cluster name: %s", clusterHeader), zap.String("cluster", clusterHeader))
+			errresponse.Render(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid cluster name: %s", clusterHeader))
+			return
+		}
+
+		if !user.HasResourceAccess(clusterHeader, namespace, resource, "get") {
+			log.Warn(ctx, "User is not authorized to access resource", zap.String("cluster", clusterHeader), zap.String("namespace", namespace), zap.String("resource", resource), zap.String("verb", "get"))
+			errresponse.Render(w, r, http.StatusUnauthorized)
+			return
+		}
+
+		relatedResource, err := router.getRelatedResources(clusterClient, namespace, name, resource)
+		if err != nil {
+			log.Error(ctx, "Failed to get related resources", zap.Error(err), zap.String("cluster", clusterHeader))
+			errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get related resources")
+			return
+		}
+
+		filterRelatedResources(user, clusterHeader, relatedResource)
+		render.JSON(w, r, relatedResource)
+		return
+	}
+
+	var relatedResponses []RelatedResponse
+
+	muRelatedResponses := &sync.Mutex{}
+	var wgRelatedResponses sync.WaitGroup
+	wgRelatedResponses.Add(len(clusters))
+
+	for _, cluster := range clusters {
+		go func(cluster string) {
+			defer wgRelatedResponses.Done()
+
+			relatedResponse := RelatedResponse{Cluster: cluster}
+			defer func() {
+				muRelatedResponses.Lock()
+				relatedResponses = append(relatedResponses, relatedResponse)
+				muRelatedResponses.Unlock()
+			}()
+
+			clusterClient := router.clustersClient.GetCluster(cluster)
+			if clusterClient == nil {
+				relatedResponse.Error = fmt.Sprintf("Failed to find cluster %s", cluster)
+				return
+			}
+
+			if !user.HasResourceAccess(cluster, namespace, resource, "get") {
+				log.Warn(ctx, "User is not authorized to access the resource", zap.String("cluster", cluster), zap.String("namespace", namespace), zap.String("resource", resource), zap.String("verb", "get"))
+				relatedResponse.Error = fmt.Sprintf("You are not authorized to access the resources in %s / %s", cluster, namespace)
+				return
+			}
+
+			relatedResource, err := router.getRelatedResources(clusterClient, namespace, name, resource)
+			if err != nil {
+				log.Error(ctx, "Failed to get related resources", zap.Error(err), zap.String("cluster", cluster))
+				relatedResponse.Error = fmt.Sprintf("Failed to get related resources for %s", cluster)
+				return
+			}
+
+			filterRelatedResources(user, cluster, relatedResource)
+			relatedResponse.Resource = relatedResource
+		}(cluster)
+	}
+
+	wgRelatedResponses.Wait()
+
+	sort.Slice(relatedResponses, func(i, j int) bool {
+		return relatedResponses[i].Cluster < relatedResponses[j].Cluster
+	})
+
+	render.JSON(w, r, relatedResponses)
+}
+
+// diffHandler compares the manifest of a resource in two different clusters and / or namespaces. The resource is
+// identified by its name and resource type and is fetched from both locations in parallel via the cluster clients.
+// Before the manifests are compared all fields which would always differ (e.g. the status and the managed fields) are
+// removed.
+func (router *Router) diffHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := authContext.MustGetUser(ctx)
+
+	name := r.URL.Query().Get("name")
+	resourceID := r.URL.Query().Get("resource")
+	fromCluster := r.URL.Query().Get("fromCluster")
+	fromNamespace := r.URL.Query().Get("fromNamespace")
+	toCluster := r.URL.Query().Get("toCluster")
+	toNamespace := r.URL.Query().Get("toNamespace")
+
+	log.Debug(ctx, "Get diff", zap.String("name", name), zap.String("resource", resourceID), zap.String("fromCluster", fromCluster), zap.String("fromNamespace", fromNamespace), zap.String("toCluster", toCluster), zap.String("toNamespace", toNamespace))
+
+	if name == "" || resourceID == "" || fromCluster == "" || toCluster == "" {
+		log.Warn(ctx, "The parameters 'name', 'resource', 'fromCluster' and 'toCluster' are required")
+		errresponse.Render(w, r, http.StatusBadRequest, "The parameters 'name', 'resource', 'fromCluster' and 'toCluster' are required")
+		return
+	}
+
+	res := GetResource(resourceID)
+	if res.ID == "" {
+		crd, err := router.dbClient.GetCRDByID(ctx, resourceID)
+		if err != nil {
+			log.Error(ctx, "Resource not found", zap.Error(err), zap.String("resource", resourceID))
+			errresponse.Render(w, r, http.StatusBadRequest, "Resource not found")
+			return
+		}
+
+		res = CRDToResource(*crd)
+	}
+
+	if res.Scope == "Cluster" {
+		fromNamespace = ""
+		toNamespace = ""
+	}
+
+	var clusterClients []cluster.Client
+
+	for _, location := range [][]string{{fromCluster, fromNamespace}, {toCluster, toNamespace}} {
+		clusterClient := router.clustersClient.GetCluster(location[0])
+		if clusterClient == nil {
+			log.Warn(ctx, fmt.Sprintf("Invalid cluster name: %s", location[0]), zap.String("cluster", location[0]))
+			errresponse.Render(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid cluster name: %s", location[0]))
+			return
+		}
+
+		if !user.HasResourceAccess(location[0], location[1], resourceID, "get") {
+			log.Warn(ctx, "User is not authorized to access the resource", zap.String("cluster", location[0]), zap.String("namespace", location[1]), zap.String("resource", resourceID), zap.String("verb", "get"))
+			errresponse.Render(w, r, http.StatusForbidden, fmt.Sprintf("You are not authorized to access the resource in %s", diffLocation(location[0], location[1])))
+			return
+		}
+
+		clusterClients = append(clusterClients, clusterClient)
+	}
+
+	var from, to map[string]any
+	var fromErr, toErr error
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		from, fromErr = router.getResource(ctx, clusterClients[0], fromNamespace, name, res)
+	}()
+
+	go func() {
+		defer wg.Done()
+		to, toErr = router.getResource(ctx, clusterClients[1], toNamespace, name, res)
+	}()
+
+	wg.Wait()
+
+	if fromErr != nil {
+		errresponse.Render(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to get resource from %s", diffLocation(fromCluster, fromNamespace)))
+		return
+	}
+
+	if toErr != nil {
+		errresponse.Render(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to get resource from %s", diffLocation(toCluster, toNamespace)))
+		return
+	}
+
+	from = diff.Clean(from)
+	to = diff.Clean(to)
+
+	unified, err := diff.Unified(diffLocation(fromCluster, fromNamespace), diffLocation(toCluster, toNamespace), from, to)
+	if err != nil {
+		log.Error(ctx, "Failed to create unified diff", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to create unified diff")
+		return
+	}
+
+	render.JSON(w, r, DiffResponse{
+		Changes: diff.Objects(from, to),
+		Unified: unified,
+	})
+}
+
+// getResource returns a single resource from the given namespace via the cluster client.
+func (router *Router) getResource(ctx context.Context, clusterClient cluster.Client, namespace, name string, r Resource) (map[string]any, error) {
+	requestCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	params := url.Values{}
+	params.Set("namespace", namespace)
+	params.Set("name", name)
+	params.Set("resource", r.Resource)
+	params.Set("path", r.Path)
+
+	manifest, err := clusterClient.Request(requestCtx, http.MethodGet, fmt.Sprintf("/api/resources?%s", params.Encode()), nil)
+	if err != nil {
+		log.Error(ctx, "Failed to get resource", zap.Error(err), zap.String("cluster", clusterClient.GetName()), zap.String("namespace", namespace))
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// diffLocation returns the location of a resource in the format "cluster/namespace", which is used in the unified diff
+// and in error messages.
+func diffLocation(cluster, namespace string) string {
+	if namespace == "" {
+		return cluster
+	}
+
+	return fmt.Sprintf("%s/%s", cluster, namespace)
 }
 
-func Mount(appSettings settings.Settings, clustersClient clusters.Client, dbClient db.Client) chi.Router {
+func Mount(appSettings settings.Settings, clustersClient clusters.Client, dbClient db.Client, healthEvaluator health.Evaluator) chi.Router {
 	router := Router{
 		chi.NewRouter(),
 		appSettings,
 		clustersClient,
 		dbClient,
+		healthEvaluator,
 	}
 
+	router.Get("/related", router.relatedHandler)
+	router.Get("/diff", router.diffHandler)
+	router.Get("/health", router.healthHandler)
+	router.Get("/application", router.applicationHandler)
 	router.HandleFunc("/*", router.resourcesHandler)
 
 	return router
@@ -0,0 +1,576 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	applicationv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/application/v1"
+	userv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/user/v1"
+	"github.com/kobsio/kobs/pkg/hub/app/settings"
+	authContext "github.com/kobsio/kobs/pkg/hub/auth/context"
+	"github.com/kobsio/kobs/pkg/hub/clusters"
+	"github.com/kobsio/kobs/pkg/hub/clusters/cluster"
+	"github.com/kobsio/kobs/pkg/hub/db"
+	"github.com/kobsio/kobs/pkg/hub/health"
+	"github.com/kobsio/kobs/pkg/utils"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourcesHandler(t *testing.T) {
+	var newRouter = func(t *testing.T) (*clusters.MockClient, *cluster.MockClient, *db.MockClient, Router) {
+		ctrl := gomock.NewController(t)
+		clustersClient := clusters.NewMockClient(ctrl)
+		clusterClient := cluster.NewMockClient(ctrl)
+		dbClient := db.NewMockClient(ctrl)
+		healthEvaluator, _ := health.NewEvaluator(health.Config{})
+		router := Router{chi.NewRouter(), settings.Settings{}, clustersClient, dbClient, healthEvaluator}
+
+		return clustersClient, clusterClient, dbClient, router
+	}
+
+	user := authContext.User{Permissions: userv1.Permissions{Resources: []userv1.Resources{{Clusters: []string{"dev-de1"}, Namespaces: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"get"}}}}}
+
+	for _, tt := range []struct {
+		name         string
+		url          string
+		expectedBody string
+	}{
+		{name: "should fail when cluster parameter is missing", url: "/?resource=pods", expectedBody: `{"errors": ["The parameter 'cluster' is required"]}`},
+		{name: "should fail when resource parameter is missing", url: "/?cluster=dev-de1", expectedBody: `{"errors": ["The parameter 'resource' is required"]}`},
+		{name: "should fail for invalid limit", url: "/?cluster=dev-de1&resource=pods&limit=abc", expectedBody: `{"errors": ["Failed to parse 'limit' parameter"]}`},
+		{name: "should fail for invalid continue token", url: "/?continue=abc", expectedBody: `{"errors": ["Invalid 'continue' parameter"]}`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, _, router := newRouter(t)
+
+			ctx := context.WithValue(context.Background(), authContext.UserKey, user)
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+
+			router.resourcesHandler(w, req)
+
+			utils.AssertStatusEq(t, w, http.StatusBadRequest)
+			utils.AssertJSONEq(t, w, tt.expectedBody)
+		})
+	}
+
+	t.Run("should return resources", func(t *testing.T) {
+		clustersClient, clusterClient, dbClient, router := newRouter(t)
+		dbClient.EXPECT().GetCRDByID(gomock.Any(), "unknown").Return(nil, fmt.Errorf("not found"))
+		clustersClient.EXPECT().GetCluster("dev-de1").Return(clusterClient)
+		clustersClient.EXPECT().GetCluster("dev-de2").Return(nil)
+		clusterClient.EXPECT().Request(gomock.Any(), http.MethodGet, "/api/resources?labelSelector=app%3Dnginx&limit=1&namespace=default&param=&paramName=&path=%2Fapi%2Fv1&resource=pods", nil).Return(map[string]any{"metadata": map[string]any{"continue": "next"}}, nil)
+		clusterClient.EXPECT().Request(gomock.Any(), http.MethodGet, "/api/resources?labelSelector=app%3Dnginx&limit=1&namespace=kube-system&param=&paramName=&path=%2Fapi%2Fv1&resource=pods", nil).Return(nil, fmt.Errorf("unexpected error"))
+
+		ctx := context.WithValue(context.Background(), authContext.UserKey, user)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/?cluster=dev-de1&cluster=dev-de2&namespace=default&namespace=kube-system&resource=pods&resource=unknown&labelSelector=app%3Dnginx&limit=1", nil)
+		w := httptest.NewRecorder()
+
+		router.resourcesHandler(w, req)
+
+		continueToken := encodeContinueToken(resourceTarget{Resource: "pods", Cluster: "dev-de1", Namespace: "default", Continue: "next"})
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `[
+			{"resource": {"id": "pods", "isCRD": false, "path": "/api/v1", "resource": "pods", "title": "Pods", "description": "`+GetResource("pods").Description+`", "scope": "Namespaced", "columns": null}, "error": "", "dashboards": null, "clusters": [
+				{"cluster": "dev-de1", "error": "", "namespaces": [
+					{"namespace": "default", "error": "", "manifest": {"metadata": {"continue": "next"}}, "continue": "`+continueToken+`"},
+					{"namespace": "kube-system", "error": "Failed to get resources for dev-de1 / kube-system", "manifest": null}
+				]},
+				{"cluster": "dev-de2", "error": "Failed to find cluster dev-de2", "namespaces": null}
+			]},
+			{"resource": {"id": "unknown", "isCRD": false, "path": "", "resource": "", "title": "unknown", "description": "", "scope": "", "columns": null}, "error": "Resource not found", "dashboards": null, "clusters": null}
+		]`)
+	})
+
+	t.Run("should return next page for continue tokens", func(t *testing.T) {
+		clustersClient, clusterClient, _, router := newRouter(t)
+		clustersClient.EXPECT().GetCluster("dev-de1").Return(clusterClient)
+		clusterClient.EXPECT().Request(gomock.Any(), http.MethodGet, "/api/resources?bypassCache=true&continue=next&limit=1&namespace=default&param=&paramName=&path=%2Fapi%2Fv1&resource=pods", nil).Return(map[string]any{"items": []any{}}, nil)
+
+		continueToken := encodeContinueToken(resourceTarget{Resource: "pods", Cluster: "dev-de1", Namespace: "default", Continue: "next"})
+
+		ctx := context.WithValue(context.Background(), authContext.UserKey, user)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/?limit=1&bypassCache=true&continue="+continueToken, nil)
+		w := httptest.NewRecorder()
+
+		router.resourcesHandler(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		require.Contains(t, w.Body.String(), `"namespaces":[{"namespace":"default","error":"","manifest":{"items":[]}}]`)
+	})
+
+	t.Run("should stream resources", func(t *testing.T) {
+		clustersClient, clusterClient, _, router := newRouter(t)
+		clustersClient.EXPECT().GetCluster("dev-de1").Return(clusterClient)
+		clusterClient.EXPECT().Request(gomock.Any(), http.MethodGet, gomock.Any(), nil).Return(map[string]any{"items": []any{}}, nil).Times(2)
+
+		ctx := context.WithValue(context.Background(), authContext.UserKey, user)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/?cluster=dev-de1&namespace=default&namespace=kube-system&resource=pods&stream=true", nil)
+		w := httptest.NewRecorder()
+
+		router.resourcesHandler(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		require.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+		require.Len(t, lines, 2)
+		for _, line := range lines {
+			var result ResourceStreamResponse
+			require.NoError(t, json.Unmarshal([]byte(line), &result))
+			require.Equal(t, "dev-de1", result.Cluster)
+			require.Equal(t, "pods", result.Resource.ID)
+		}
+	})
+
+	t.Run("should return resources with health", func(t *testing.T) {
+		clustersClient, clusterClient, _, router := newRouter(t)
+		clustersClient.EXPECT().GetCluster("dev-de1").Return(clusterClient)
+		clusterClient.EXPECT().Request(gomock.Any(), http.MethodGet, gomock.Any(), nil).Return(map[string]any{"items": []any{
+			map[string]any{"metadata": map[string]any{"namespace": "default", "name": "nginx"}, "status": map[string]any{"phase": "Pending"}},
+		}}, nil)
+
+		ctx := context.WithValue(context.Background(), authContext.UserKey, user)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/?cluster=dev-de1&namespace=default&resource=pods&health=true", nil)
+		w := httptest.NewRecorder()
+
+		router.resourcesHandler(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		require.Contains(t, w.Body.String(), `"health":{"summary":{"status":"Progressing","total":1,"healthy":0,"progressing":1,"degraded":0,"unknown":0},"items":[{"namespace":"default","name":"nginx","health":{"status":"Progressing"}}]}`)
+	})
+
+	t.Run("should fail to reveal value without reveal verb", func(t *testing.T) {
+		clustersClient, clusterClient, _, router := newRouter(t)
+		clustersClient.EXPECT().GetCluster("dev-de1").Return(clusterClient)
+
+		ctx := context.WithValue(context.Background(), authContext.UserKey, user)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/reveal?namespace=default&name=db&resource=secrets&key=password", nil)
+		req.Header.Set("x-kobs-cluster", "dev-de1")
+		w := httptest.NewRecorder()
+
+		router.resourcesHandler(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusUnauthorized)
+	})
+
+	t.Run("should proxy reveal request with reveal verb", func(t *testing.T) {
+		clustersClient, clusterClient, _, router := newRouter(t)
+		clustersClient.EXPECT().GetCluster("dev-de1").Return(clusterClient)
+		clusterClient.EXPECT().Proxy(gomock.Any(), gomock.Any())
+
+		revealUser := authContext.User{ID: "user1", Permissions: userv1.Permissions{Resources: []userv1.Resources{{Clusters: []string{"dev-de1"}, Namespaces: []string{"*"}, Resources: []string{"secrets"}, Verbs: []string{"reveal"}}}}}
+
+		ctx := context.WithValue(context.Background(), authContext.UserKey, revealUser)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/reveal?namespace=default&name=db&resource=secrets&key=password", nil)
+		req.Header.Set("x-kobs-cluster", "dev-de1")
+		w := httptest.NewRecorder()
+
+		router.resourcesHandler(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+	})
+}
+
+func TestHealthHandler(t *testing.T) {
+	var newRouter = func(t *testing.T) (*clusters.MockClient, *cluster.MockClient, Router) {
+		ctrl := gomock.NewController(t)
+		clustersClient := clusters.NewMockClient(ctrl)
+		clusterClient := cluster.NewMockClient(ctrl)
+		healthEvaluator, _ := health.NewEvaluator(health.Config{})
+		router := Router{chi.NewRouter(), settings.Settings{}, clustersClient, nil, healthEvaluator}
+
+		return clustersClient, clusterClient, router
+	}
+
+	user := authContext.User{Permissions: userv1.Permissions{Resources: []userv1.Resources{{Clusters: []string{"dev-de1"}, Namespaces: []string{"default"}, Resources: []string{"*"}, Verbs: []string{"get"}}}}}
+
+	t.Run("should fail when cluster parameter is missing", func(t *testing.T) {
+		_, _, router := newRouter(t)
+
+		ctx := context.WithValue(context.Background(), authContext.UserKey, user)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/health?namespace=default", nil)
+		w := httptest.NewRecorder()
+
+		router.healthHandler(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusBadRequest)
+		utils.AssertJSONEq(t, w, `{"errors": ["The parameter 'cluster' is required"]}`)
+	})
+
+	t.Run("should return health", func(t *testing.T) {
+		clustersClient, clusterClient, router := newRouter(t)
+		clustersClient.EXPECT().GetCluster("dev-de1").Return(clusterClient).Times(2)
+		clusterClient.EXPECT().Request(gomock.Any(), http.MethodGet, "/api/resources?labelSelector=app%3Dnginx&namespace=default&param=&paramName=&path=%2Fapis%2Fapps%2Fv1&resource=deployments", nil).Return(map[string]any{"items": []any{
+			map[string]any{"metadata": map[string]any{"namespace": "default", "name": "nginx"}, "spec": map[string]any{"replicas": float64(1)}, "status": map[string]any{"replicas": float64(1), "updatedReplicas": float64(1), "availableReplicas": float64(1)}},
+		}}, nil)
+		clusterClient.EXPECT().Request(gomock.Any(), http.MethodGet, "/api/resources?labelSelector=app%3Dnginx&namespace=default&param=&paramName=&path=%2Fapi%2Fv1&resource=pods", nil).Return(map[string]any{"items": []any{
+			map[string]any{"metadata": map[string]any{"namespace": "default", "name": "nginx-1"}, "status": map[string]any{"phase": "Running"}},
+			map[string]any{"metadata": map[string]any{"namespace": "default", "name": "nginx-2"}, "status": map[string]any{"phase": "Failed", "reason": "Evicted"}},
+		}}, nil)
+
+		ctx := context.WithValue(context.Background(), authContext.UserKey, user)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/health?cluster=dev-de1&namespace=default&namespace=kube-system&resource=deployments&resource=pods&labelSelector=app%3Dnginx", nil)
+		w := httptest.NewRecorder()
+
+		router.healthHandler(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `{
+			"summary": {"status": "Degraded", "total": 3, "healthy": 2, "progressing": 0, "degraded": 1, "unknown": 0},
+			"resources": [
+				{"resource": "deployments", "cluster": "dev-de1", "namespace": "default", "error": "", "summary": {"status": "Healthy", "total": 1, "healthy": 1, "progressing": 0, "degraded": 0, "unknown": 0}, "items": []},
+				{"resource": "deployments", "cluster": "dev-de1", "namespace": "kube-system", "error": "You are not authorized to access the resources in dev-de1 / kube-system", "summary": {"status": "", "total": 0, "healthy": 0, "progressing": 0, "degraded": 0, "unknown": 0}, "items": []},
+				{"resource": "pods", "cluster": "dev-de1", "namespace": "default", "error": "", "summary": {"status": "Degraded", "total": 2, "healthy": 1, "progressing": 0, "degraded": 1, "unknown": 0}, "items": [{"namespace": "default", "name": "nginx-2", "health": {"status": "Degraded", "message": "Evicted"}}]},
+				{"resource": "pods", "cluster": "dev-de1", "namespace": "kube-system", "error": "You are not authorized to access the resources in dev-de1 / kube-system", "summary": {"status": "", "total": 0, "healthy": 0, "progressing": 0, "degraded": 0, "unknown": 0}, "items": []}
+			]
+		}`)
+	})
+}
+
+func TestApplicationHandler(t *testing.T) {
+	var newRouter = func(t *testing.T) (*clusters.MockClient, *cluster.MockClient, *db.MockClient, Router) {
+		ctrl := gomock.NewController(t)
+		clustersClient := clusters.NewMockClient(ctrl)
+		clusterClient := cluster.NewMockClient(ctrl)
+		dbClient := db.NewMockClient(ctrl)
+		healthEvaluator, _ := health.NewEvaluator(health.Config{})
+		router := Router{chi.NewRouter(), settings.Settings{}, clustersClient, dbClient, healthEvaluator}
+
+		return clustersClient, clusterClient, dbClient, router
+	}
+
+	user := authContext.User{Permissions: userv1.Permissions{
+		Applications: []userv1.ApplicationPermissions{{Type: "all"}},
+		Resources:    []userv1.Resources{{Clusters: []string{"dev-de1"}, Namespaces: []string{"*"}, Resources: []string{"pods"}, Verbs: []string{"get"}}},
+	}}
+
+	t.Run("should fail when application can not be loaded", func(t *testing.T) {
+		_, _, dbClient, router := newRouter(t)
+		dbClient.EXPECT().GetApplicationByID(gomock.Any(), "/cluster/dev-de1/namespace/default/name/nginx").Return(nil, fmt.Errorf("unexpected error"))
+
+		ctx := context.WithValue(context.Background(), authContext.UserKey, user)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/application?id=/cluster/dev-de1/namespace/default/name/nginx", nil)
+		w := httptest.NewRecorder()
+
+		router.applicationHandler(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get application"]}`)
+	})
+
+	t.Run("should fail when application is not found", func(t *testing.T) {
+		_, _, dbClient, router := newRouter(t)
+		dbClient.EXPECT().GetApplicationByID(gomock.Any(), "/cluster/dev-de1/namespace/default/name/nginx").Return(nil, nil)
+
+		ctx := context.WithValue(context.Background(), authContext.UserKey, user)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/application?id=/cluster/dev-de1/namespace/default/name/nginx", nil)
+		w := httptest.NewRecorder()
+
+		router.applicationHandler(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusNotFound)
+		utils.AssertJSONEq(t, w, `{"errors": ["Application was not found"]}`)
+	})
+
+	t.Run("should fail when user is not allowed to view the application", func(t *testing.T) {
+		_, _, dbClient, router := newRouter(t)
+		dbClient.EXPECT().GetApplicationByID(gomock.Any(), "/cluster/dev-de1/namespace/default/name/nginx").Return(&applicationv1.ApplicationSpec{Cluster: "dev-de1", Namespace: "default", Name: "nginx"}, nil)
+
+		ctx := context.WithValue(context.Background(), authContext.UserKey, authContext.User{})
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/application?id=/cluster/dev-de1/namespace/default/name/nginx", nil)
+		w := httptest.NewRecorder()
+
+		router.applicationHandler(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusForbidden)
+		utils.AssertJSONEq(t, w, `{"errors": ["You are not allowed to view the application"]}`)
+	})
+
+	t.Run("should return empty list when application has no resources", func(t *testing.T) {
+		_, _, dbClient, router := newRouter(t)
+		dbClient.EXPECT().GetApplicationByID(gomock.Any(), "/cluster/dev-de1/namespace/default/name/nginx").Return(&applicationv1.ApplicationSpec{Cluster: "dev-de1", Namespace: "default", Name: "nginx"}, nil)
+
+		ctx := context.WithValue(context.Background(), authContext.UserKey, user)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/application?id=/cluster/dev-de1/namespace/default/name/nginx", nil)
+		w := httptest.NewRecorder()
+
+		router.applicationHandler(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `{"health": {"status": "", "total": 0, "healthy": 0, "progressing": 0, "degraded": 0, "unknown": 0}, "resources": []}`)
+	})
+
+	t.Run("should return resources", func(t *testing.T) {
+		clustersClient, clusterClient, dbClient, router := newRouter(t)
+		dbClient.EXPECT().GetApplicationByID(gomock.Any(), "/cluster/dev-de1/namespace/default/name/nginx").Return(&applicationv1.ApplicationSpec{Cluster: "dev-de1", Namespace: "default", Name: "nginx", Resources: &applicationv1.Resources{Kinds: []string{"pods", "services"}, LabelSelector: "app=nginx"}}, nil)
+		clustersClient.EXPECT().GetCluster("dev-de1").Return(clusterClient).Times(2)
+		clusterClient.EXPECT().Request(gomock.Any(), http.MethodGet, "/api/resources?labelSelector=app%3Dnginx&namespace=default&param=&paramName=&path=%2Fapi%2Fv1&resource=pods", nil).Return(map[string]any{"items": []any{
+			map[string]any{"metadata": map[string]any{"namespace": "default", "name": "nginx"}, "status": map[string]any{"phase": "Running"}},
+		}}, nil)
+
+		ctx := context.WithValue(context.Background(), authContext.UserKey, user)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/application?id=/cluster/dev-de1/namespace/default/name/nginx", nil)
+		w := httptest.NewRecorder()
+
+		router.applicationHandler(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+
+		var response ApplicationResourcesResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, health.Summary{Status: health.StatusHealthy, Total: 1, Healthy: 1}, response.Health)
+		require.Len(t, response.Resources, 2)
+		require.Equal(t, "pods", response.Resources[0].Resource.ID)
+		require.Equal(t, &health.List{Summary: health.Summary{Status: health.StatusHealthy, Total: 1, Healthy: 1}, Items: []health.Item{{Namespace: "default", Name: "nginx", Health: health.Health{Status: health.StatusHealthy}}}}, response.Resources[0].Clusters[0].Namespaces[0].Health)
+		require.Equal(t, "services", response.Resources[1].Resource.ID)
+		require.Equal(t, "You are not authorized to access the resources in dev-de1 / default", response.Resources[1].Clusters[0].Namespaces[0].Error)
+	})
+}
+
+func TestRelatedHandler(t *testing.T) {
+	var newRouter = func(t *testing.T) (*clusters.MockClient, *cluster.MockClient, Router) {
+		ctrl := gomock.NewController(t)
+		clustersClient := clusters.NewMockClient(ctrl)
+		clusterClient := cluster.NewMockClient(ctrl)
+		healthEvaluator, _ := health.NewEvaluator(health.Config{})
+		router := Router{chi.NewRouter(), settings.Settings{}, clustersClient, nil, healthEvaluator}
+
+		return clustersClient, clusterClient, router
+	}
+
+	user := authContext.User{Permissions: userv1.Permissions{Resources: []userv1.Resources{{Clusters: []string{"dev-de1"}, Namespaces: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"get"}}}}}
+
+	t.Run("should fail when cluster parameter is missing", func(t *testing.T) {
+		_, _, router := newRouter(t)
+
+		ctx := context.WithValue(context.Background(), authContext.UserKey, user)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/related?namespace=default&name=nginx&resource=deployments", nil)
+		w := httptest.NewRecorder()
+
+		router.relatedHandler(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusBadRequest)
+		utils.AssertJSONEq(t, w, `{"errors": ["The parameter 'cluster' is required"]}`)
+	})
+
+	t.Run("should fail when name or resource parameter is missing", func(t *testing.T) {
+		_, _, router := newRouter(t)
+
+		ctx := context.WithValue(context.Background(), authContext.UserKey, user)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/related?cluster=dev-de1&namespace=default&resource=deployments", nil)
+		w := httptest.NewRecorder()
+
+		router.relatedHandler(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusBadRequest)
+		utils.AssertJSONEq(t, w, `{"errors": ["The parameters 'name' and 'resource' are required"]}`)
+	})
+
+	t.Run("should return related resources for all clusters", func(t *testing.T) {
+		clustersClient, clusterClient, router := newRouter(t)
+		clustersClient.EXPECT().GetCluster("dev-de1").Return(clusterClient)
+		clustersClient.EXPECT().GetCluster("dev-de2").Return(clusterClient)
+		clustersClient.EXPECT().GetCluster("dev-de3").Return(nil)
+		clusterClient.EXPECT().Request(gomock.Any(), http.MethodGet, "/api/resources/related?namespace=default&name=nginx&resource=deployments", nil).Return(map[string]any{"kind": "Deployment"}, nil)
+
+		ctx := context.WithValue(context.Background(), authContext.UserKey, user)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/related?cluster=dev-de1&cluster=dev-de2&cluster=dev-de3&namespace=default&name=nginx&resource=deployments", nil)
+		w := httptest.NewRecorder()
+
+		router.relatedHandler(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `[
+			{"cluster": "dev-de1", "error": "", "resource": {"kind": "Deployment"}},
+			{"cluster": "dev-de2", "error": "You are not authorized to access the resources in dev-de2 / default", "resource": null},
+			{"cluster": "dev-de3", "error": "Failed to find cluster dev-de3", "resource": null}
+		]`)
+	})
+
+	t.Run("should only return related resources the user is allowed to get", func(t *testing.T) {
+		clustersClient, clusterClient, router := newRouter(t)
+		clustersClient.EXPECT().GetCluster("dev-de1").Return(clusterClient)
+		clusterClient.EXPECT().Request(gomock.Any(), http.MethodGet, "/api/resources/related?namespace=default&name=nginx&resource=deployments", nil).Return(map[string]any{
+			"kind": "Deployment", "namespace": "default", "events": []any{map[string]any{"reason": "ScalingReplicaSet"}},
+			"related": []any{map[string]any{
+				"kind": "ReplicaSet", "namespace": "default", "events": []any{map[string]any{"reason": "SuccessfulCreate"}},
+				"related": []any{map[string]any{"kind": "Pod", "namespace": "default"}},
+			}},
+		}, nil)
+
+		restrictedUser := authContext.User{Permissions: userv1.Permissions{Resources: []userv1.Resources{{Clusters: []string{"dev-de1"}, Namespaces: []string{"*"}, Resources: []string{"deployments", "replicasets"}, Verbs: []string{"get"}}}}}
+		ctx := context.WithValue(context.Background(), authContext.UserKey, restrictedUser)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/related?cluster=dev-de1&namespace=default&name=nginx&resource=deployments", nil)
+		w := httptest.NewRecorder()
+
+		router.relatedHandler(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `[{"cluster": "dev-de1", "error": "", "resource": {"kind": "Deployment", "namespace": "default", "related": [{"kind": "ReplicaSet", "namespace": "default"}]}}]`)
+	})
+
+	t.Run("should return filtered related resources for single cluster", func(t *testing.T) {
+		clustersClient, clusterClient, router := newRouter(t)
+		clustersClient.EXPECT().GetCluster("dev-de1").Return(clusterClient)
+		clusterClient.EXPECT().Request(gomock.Any(), http.MethodGet, "/api/resources/related?namespace=default&name=nginx&resource=services", nil).Return(map[string]any{
+			"kind": "Service", "namespace": "default",
+			"related": []any{map[string]any{"kind": "Endpoints", "namespace": "default"}},
+		}, nil)
+
+		restrictedUser := authContext.User{Permissions: userv1.Permissions{Resources: []userv1.Resources{{Clusters: []string{"dev-de1"}, Namespaces: []string{"*"}, Resources: []string{"services", "events"}, Verbs: []string{"get"}}}}}
+		ctx := context.WithValue(context.Background(), authContext.UserKey, restrictedUser)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/related?namespace=default&name=nginx&resource=services", nil)
+		req.Header.Set("x-kobs-cluster", "dev-de1")
+		w := httptest.NewRecorder()
+
+		router.relatedHandler(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `{"kind": "Service", "namespace": "default"}`)
+	})
+
+	t.Run("should not return related resources for single cluster without access", func(t *testing.T) {
+		clustersClient, clusterClient, router := newRouter(t)
+		clustersClient.EXPECT().GetCluster("dev-de2").Return(clusterClient)
+
+		ctx := context.WithValue(context.Background(), authContext.UserKey, user)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/related?namespace=default&name=nginx&resource=services", nil)
+		req.Header.Set("x-kobs-cluster", "dev-de2")
+		w := httptest.NewRecorder()
+
+		router.relatedHandler(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusUnauthorized)
+	})
+
+	t.Run("should handle error from cluster client", func(t *testing.T) {
+		clustersClient, clusterClient, router := newRouter(t)
+		clustersClient.EXPECT().GetCluster("dev-de1").Return(clusterClient)
+		clusterClient.EXPECT().Request(gomock.Any(), http.MethodGet, gomock.Any(), nil).Return(nil, fmt.Errorf("unexpected error"))
+
+		ctx := context.WithValue(context.Background(), authContext.UserKey, user)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/related?cluster=dev-de1&namespace=default&name=nginx&resource=deployments", nil)
+		w := httptest.NewRecorder()
+
+		router.relatedHandler(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `[{"cluster": "dev-de1", "error": "Failed to get related resources for dev-de1", "resource": null}]`)
+	})
+}
+
+func TestDiffHandler(t *testing.T) {
+	var newRouter = func(t *testing.T) (*clusters.MockClient, *cluster.MockClient, *db.MockClient, Router) {
+		ctrl := gomock.NewController(t)
+		clustersClient := clusters.NewMockClient(ctrl)
+		clusterClient := cluster.NewMockClient(ctrl)
+		dbClient := db.NewMockClient(ctrl)
+		healthEvaluator, _ := health.NewEvaluator(health.Config{})
+		router := Router{chi.NewRouter(), settings.Settings{}, clustersClient, dbClient, healthEvaluator}
+
+		return clustersClient, clusterClient, dbClient, router
+	}
+
+	user := authContext.User{Permissions: userv1.Permissions{Resources: []userv1.Resources{{Clusters: []string{"staging"}, Namespaces: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"get"}}, {Clusters: []string{"production"}, Namespaces: []string{"default"}, Resources: []string{"deployments"}, Verbs: []string{"get"}}}}}
+	resourceURL := "/api/resources?name=nginx&namespace=default&path=%2Fapis%2Fapps%2Fv1&resource=deployments"
+
+	var doRequest = func(t *testing.T, router Router, url string) *httptest.ResponseRecorder {
+		ctx := context.WithValue(context.Background(), authContext.UserKey, user)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		w := httptest.NewRecorder()
+
+		router.diffHandler(w, req)
+		return w
+	}
+
+	t.Run("should fail when parameters are missing", func(t *testing.T) {
+		_, _, _, router := newRouter(t)
+
+		w := doRequest(t, router, "/diff?resource=deployments&fromCluster=staging&toCluster=production")
+
+		utils.AssertStatusEq(t, w, http.StatusBadRequest)
+		utils.AssertJSONEq(t, w, `{"errors": ["The parameters 'name', 'resource', 'fromCluster' and 'toCluster' are required"]}`)
+	})
+
+	t.Run("should fail when resource is not found", func(t *testing.T) {
+		_, _, dbClient, router := newRouter(t)
+		dbClient.EXPECT().GetCRDByID(gomock.Any(), "foo").Return(nil, fmt.Errorf("not found"))
+
+		w := doRequest(t, router, "/diff?name=nginx&resource=foo&fromCluster=staging&toCluster=production")
+
+		utils.AssertStatusEq(t, w, http.StatusBadRequest)
+		utils.AssertJSONEq(t, w, `{"errors": ["Resource not found"]}`)
+	})
+
+	t.Run("should fail for invalid cluster", func(t *testing.T) {
+		clustersClient, _, _, router := newRouter(t)
+		clustersClient.EXPECT().GetCluster("staging").Return(nil)
+
+		w := doRequest(t, router, "/diff?name=nginx&resource=deployments&fromCluster=staging&fromNamespace=default&toCluster=production&toNamespace=default")
+
+		utils.AssertStatusEq(t, w, http.StatusBadRequest)
+		utils.AssertJSONEq(t, w, `{"errors": ["Invalid cluster name: staging"]}`)
+	})
+
+	t.Run("should fail when user is not authorized", func(t *testing.T) {
+		clustersClient, clusterClient, _, router := newRouter(t)
+		clustersClient.EXPECT().GetCluster("staging").Return(clusterClient)
+		clustersClient.EXPECT().GetCluster("production").Return(clusterClient)
+
+		w := doRequest(t, router, "/diff?name=nginx&resource=deployments&fromCluster=staging&fromNamespace=default&toCluster=production&toNamespace=kube-system")
+
+		utils.AssertStatusEq(t, w, http.StatusForbidden)
+		utils.AssertJSONEq(t, w, `{"errors": ["You are not authorized to access the resource in production/kube-system"]}`)
+	})
+
+	t.Run("should fail when resource can not be fetched", func(t *testing.T) {
+		clustersClient, _, _, router := newRouter(t)
+		ctrl := gomock.NewController(t)
+		stagingClient := cluster.NewMockClient(ctrl)
+		stagingClient.EXPECT().Request(gomock.Any(), http.MethodGet, resourceURL, nil).Return(map[string]any{"kind": "Deployment"}, nil)
+		productionClient := cluster.NewMockClient(ctrl)
+		productionClient.EXPECT().Request(gomock.Any(), http.MethodGet, resourceURL, nil).Return(nil, fmt.Errorf("unexpected error"))
+		productionClient.EXPECT().GetName().Return("production")
+		clustersClient.EXPECT().GetCluster("staging").Return(stagingClient)
+		clustersClient.EXPECT().GetCluster("production").Return(productionClient)
+
+		w := doRequest(t, router, "/diff?name=nginx&resource=deployments&fromCluster=staging&fromNamespace=default&toCluster=production&toNamespace=default")
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get resource from production/default"]}`)
+	})
+
+	t.Run("should return diff", func(t *testing.T) {
+		clustersClient, _, _, router := newRouter(t)
+		ctrl := gomock.NewController(t)
+		stagingClient := cluster.NewMockClient(ctrl)
+		stagingClient.EXPECT().Request(gomock.Any(), http.MethodGet, resourceURL, nil).Return(map[string]any{"kind": "Deployment", "metadata": map[string]any{"name": "nginx", "uid": "1"}, "spec": map[string]any{"replicas": float64(1)}, "status": map[string]any{"replicas": float64(1)}}, nil)
+		productionClient := cluster.NewMockClient(ctrl)
+		productionClient.EXPECT().Request(gomock.Any(), http.MethodGet, resourceURL, nil).Return(map[string]any{"kind": "Deployment", "metadata": map[string]any{"name": "nginx", "uid": "2"}, "spec": map[string]any{"replicas": float64(3)}, "status": map[string]any{"replicas": float64(3)}}, nil)
+		clustersClient.EXPECT().GetCluster("staging").Return(stagingClient)
+		clustersClient.EXPECT().GetCluster("production").Return(productionClient)
+
+		w := doRequest(t, router, "/diff?name=nginx&resource=deployments&fromCluster=staging&fromNamespace=default&toCluster=production&toNamespace=default")
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `{
+			"changes": [{"path": "/spec/replicas", "type": "replace", "from": 1, "to": 3}],
+			"unified": "--- staging/default\n+++ production/default\n@@ -2,4 +2,4 @@\n metadata:\n   name: nginx\n spec:\n-  replicas: 1\n+  replicas: 3\n"
+		}`)
+	})
+}
+
+func TestMount(t *testing.T) {
+	router := Mount(settings.Settings{}, nil, nil, nil)
+	require.NotNil(t, router)
+}
@@ -0,0 +1,179 @@
+package scorecards
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kobsio/kobs/pkg/hub/app/settings"
+	authContext "github.com/kobsio/kobs/pkg/hub/auth/context"
+	"github.com/kobsio/kobs/pkg/hub/db"
+	"github.com/kobsio/kobs/pkg/instrument/log"
+	"github.com/kobsio/kobs/pkg/utils/middleware/errresponse"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+)
+
+type Router struct {
+	*chi.Mux
+	appSettings settings.Settings
+	dbClient    db.Client
+}
+
+// parseTimeRange parses the "timeStart" and "timeEnd" parameters of the provided request. The parameters must be
+// provided in seconds.
+func parseTimeRange(r *http.Request) (time.Time, time.Time, string, error) {
+	timeStart, err := strconv.ParseInt(r.URL.Query().Get("timeStart"), 10, 64)
+	if err != nil {
+		return time.Time{}, time.Time{}, "Failed to parse 'timeStart' parameter", err
+	}
+
+	timeEnd, err := strconv.ParseInt(r.URL.Query().Get("timeEnd"), 10, 64)
+	if err != nil {
+		return time.Time{}, time.Time{}, "Failed to parse 'timeEnd' parameter", err
+	}
+
+	return time.Unix(timeStart, 0), time.Unix(timeEnd, 0), "", nil
+}
+
+// getRules returns all configured scorecard rules, so that the description of a rule can be shown in the frontend.
+func (router *Router) getRules(w http.ResponseWriter, r *http.Request) {
+	render.JSON(w, r, router.appSettings.Scorecards.Rules)
+}
+
+// getApplicationScorecard returns the latest scorecard of an application together with the history of the score in the
+// provided time range.
+func (router *Router) getApplicationScorecard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := authContext.MustGetUser(ctx)
+	id := r.URL.Query().Get("id")
+
+	log.Debug(ctx, "Get application scorecard parameters", zap.String("id", id))
+
+	timeStart, timeEnd, message, err := parseTimeRange(r)
+	if err != nil {
+		log.Error(ctx, message, zap.Error(err))
+		errresponse.Render(w, r, http.StatusBadRequest, message)
+		return
+	}
+
+	application, err := router.dbClient.GetApplicationByID(ctx, id)
+	if err != nil {
+		log.Error(ctx, "Failed to get application", zap.Error(err), zap.String("id", id))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get application")
+		return
+	}
+
+	if application == nil {
+		log.Error(ctx, "Application was not found", zap.String("id", id))
+		errresponse.Render(w, r, http.StatusNotFound, "Application was not found")
+		return
+	}
+
+	if !user.HasApplicationAccess(application) {
+		log.Warn(ctx, "The user is not authorized to view the application", zap.String("id", id))
+		errresponse.Render(w, r, http.StatusForbidden, "You are not allowed to view the application")
+		return
+	}
+
+	scorecards, err := router.dbClient.GetLatestScorecards(ctx, nil, []string{id})
+	if err != nil {
+		log.Error(ctx, "Failed to get scorecard", zap.Error(err), zap.String("id", id))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get scorecard")
+		return
+	}
+
+	history, err := router.dbClient.GetScorecardHistory(ctx, nil, []string{id}, timeStart, timeEnd)
+	if err != nil {
+		log.Error(ctx, "Failed to get scorecard history", zap.Error(err), zap.String("id", id))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get scorecard history")
+		return
+	}
+
+	data := struct {
+		Scorecard *db.Scorecard         `json:"scorecard"`
+		History   []db.ScorecardHistory `json:"history"`
+	}{
+		History: history,
+	}
+
+	if len(scorecards) > 0 {
+		data.Scorecard = &scorecards[0]
+	}
+
+	render.JSON(w, r, data)
+}
+
+// getTeamScorecard returns the latest scorecards of all applications of a team and the average score of these
+// applications together with the history of the average score in the provided time range.
+func (router *Router) getTeamScorecard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := authContext.MustGetUser(ctx)
+	id := r.URL.Query().Get("id")
+
+	log.Debug(ctx, "Get team scorecard parameters", zap.String("id", id))
+
+	if !user.HasTeamAccess(id) {
+		log.Warn(ctx, "The user is not authorized to view the team", zap.String("id", id))
+		errresponse.Render(w, r, http.StatusForbidden, "You are not allowed to view the team")
+		return
+	}
+
+	timeStart, timeEnd, message, err := parseTimeRange(r)
+	if err != nil {
+		log.Error(ctx, message, zap.Error(err))
+		errresponse.Render(w, r, http.StatusBadRequest, message)
+		return
+	}
+
+	scorecards, err := router.dbClient.GetLatestScorecards(ctx, []string{id}, nil)
+	if err != nil {
+		log.Error(ctx, "Failed to get scorecards", zap.Error(err), zap.String("id", id))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get scorecards")
+		return
+	}
+
+	history, err := router.dbClient.GetScorecardHistory(ctx, []string{id}, nil, timeStart, timeEnd)
+	if err != nil {
+		log.Error(ctx, "Failed to get scorecard history", zap.Error(err), zap.String("id", id))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get scorecard history")
+		return
+	}
+
+	var score float64
+	for _, scorecard := range scorecards {
+		score = score + scorecard.Score
+	}
+	if len(scorecards) > 0 {
+		score = math.Round(score/float64(len(scorecards))*100) / 100
+	}
+
+	data := struct {
+		Score      float64               `json:"score"`
+		Scorecards []db.Scorecard        `json:"scorecards"`
+		History    []db.ScorecardHistory `json:"history"`
+	}{
+		score,
+		scorecards,
+		history,
+	}
+
+	render.JSON(w, r, data)
+}
+
+func Mount(appSettings settings.Settings, dbClient db.Client) chi.Router {
+	router := Router{
+		chi.NewRouter(),
+		appSettings,
+		dbClient,
+	}
+
+	router.Get("/rules", router.getRules)
+	router.Get("/application", router.getApplicationScorecard)
+	router.Get("/team", router.getTeamScorecard)
+
+	return router
+}
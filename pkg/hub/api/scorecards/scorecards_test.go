@@ -0,0 +1,195 @@
+package scorecards
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	applicationv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/application/v1"
+	userv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/user/v1"
+	"github.com/kobsio/kobs/pkg/hub/app/settings"
+	authContext "github.com/kobsio/kobs/pkg/hub/auth/context"
+	"github.com/kobsio/kobs/pkg/hub/db"
+	"github.com/kobsio/kobs/pkg/hub/scorecards"
+	"github.com/kobsio/kobs/pkg/utils"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func newRequest(user authContext.User, target string) *http.Request {
+	ctx := context.WithValue(context.Background(), authContext.UserKey, user)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	return req
+}
+
+func TestGetRules(t *testing.T) {
+	router := Router{chi.NewRouter(), settings.Settings{Scorecards: scorecards.Config{Rules: []scorecards.Rule{{ID: "owner", Title: "Owner", Type: scorecards.RuleTypeOwner}}}}, nil}
+	w := httptest.NewRecorder()
+	router.getRules(w, newRequest(authContext.User{ID: "user1@kobs.io"}, "/rules"))
+
+	utils.AssertStatusEq(t, w, http.StatusOK)
+	utils.AssertJSONEq(t, w, `[{"id":"owner","title":"Owner","description":"","weight":0,"type":"owner","expression":"","plugin":null,"options":null}]`)
+}
+
+func TestGetApplicationScorecard(t *testing.T) {
+	var newRouter = func(t *testing.T) (*db.MockClient, Router) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
+
+		return dbClient, router
+	}
+
+	user := authContext.User{ID: "user1@kobs.io", Permissions: userv1.Permissions{Applications: []userv1.ApplicationPermissions{{Type: "all"}}}}
+	timeStart := time.Unix(1, 0)
+	timeEnd := time.Unix(2, 0)
+
+	t.Run("should return error for invalid time range", func(t *testing.T) {
+		_, router := newRouter(t)
+		w := httptest.NewRecorder()
+		router.getApplicationScorecard(w, newRequest(user, "/application?id=app1&timeEnd=2"))
+
+		utils.AssertStatusEq(t, w, http.StatusBadRequest)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to parse 'timeStart' parameter"]}`)
+	})
+
+	t.Run("should handle error when getting application", func(t *testing.T) {
+		dbClient, router := newRouter(t)
+		dbClient.EXPECT().GetApplicationByID(gomock.Any(), "app1").Return(nil, fmt.Errorf("could not get application"))
+		w := httptest.NewRecorder()
+		router.getApplicationScorecard(w, newRequest(user, "/application?id=app1&timeStart=1&timeEnd=2"))
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get application"]}`)
+	})
+
+	t.Run("should return error when application was not found", func(t *testing.T) {
+		dbClient, router := newRouter(t)
+		dbClient.EXPECT().GetApplicationByID(gomock.Any(), "app1").Return(nil, nil)
+		w := httptest.NewRecorder()
+		router.getApplicationScorecard(w, newRequest(user, "/application?id=app1&timeStart=1&timeEnd=2"))
+
+		utils.AssertStatusEq(t, w, http.StatusNotFound)
+		utils.AssertJSONEq(t, w, `{"errors": ["Application was not found"]}`)
+	})
+
+	t.Run("should return error when user is not authorized", func(t *testing.T) {
+		dbClient, router := newRouter(t)
+		dbClient.EXPECT().GetApplicationByID(gomock.Any(), "app1").Return(&applicationv1.ApplicationSpec{ID: "app1"}, nil)
+		w := httptest.NewRecorder()
+		router.getApplicationScorecard(w, newRequest(authContext.User{ID: "user1@kobs.io"}, "/application?id=app1&timeStart=1&timeEnd=2"))
+
+		utils.AssertStatusEq(t, w, http.StatusForbidden)
+		utils.AssertJSONEq(t, w, `{"errors": ["You are not allowed to view the application"]}`)
+	})
+
+	t.Run("should handle error when getting scorecard", func(t *testing.T) {
+		dbClient, router := newRouter(t)
+		dbClient.EXPECT().GetApplicationByID(gomock.Any(), "app1").Return(&applicationv1.ApplicationSpec{ID: "app1"}, nil)
+		dbClient.EXPECT().GetLatestScorecards(gomock.Any(), nil, []string{"app1"}).Return(nil, fmt.Errorf("could not get scorecards"))
+		w := httptest.NewRecorder()
+		router.getApplicationScorecard(w, newRequest(user, "/application?id=app1&timeStart=1&timeEnd=2"))
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get scorecard"]}`)
+	})
+
+	t.Run("should handle error when getting history", func(t *testing.T) {
+		dbClient, router := newRouter(t)
+		dbClient.EXPECT().GetApplicationByID(gomock.Any(), "app1").Return(&applicationv1.ApplicationSpec{ID: "app1"}, nil)
+		dbClient.EXPECT().GetLatestScorecards(gomock.Any(), nil, []string{"app1"}).Return(nil, nil)
+		dbClient.EXPECT().GetScorecardHistory(gomock.Any(), nil, []string{"app1"}, timeStart, timeEnd).Return(nil, fmt.Errorf("could not get history"))
+		w := httptest.NewRecorder()
+		router.getApplicationScorecard(w, newRequest(user, "/application?id=app1&timeStart=1&timeEnd=2"))
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get scorecard history"]}`)
+	})
+
+	t.Run("should return scorecard", func(t *testing.T) {
+		dbClient, router := newRouter(t)
+		dbClient.EXPECT().GetApplicationByID(gomock.Any(), "app1").Return(&applicationv1.ApplicationSpec{ID: "app1"}, nil)
+		dbClient.EXPECT().GetLatestScorecards(gomock.Any(), nil, []string{"app1"}).Return([]db.Scorecard{{Application: "app1", Score: 50, CreatedAt: time.Unix(2, 0).UTC()}}, nil)
+		dbClient.EXPECT().GetScorecardHistory(gomock.Any(), nil, []string{"app1"}, timeStart, timeEnd).Return([]db.ScorecardHistory{{CreatedAt: time.Unix(2, 0).UTC(), Score: 50, Applications: 1}}, nil)
+		w := httptest.NewRecorder()
+		router.getApplicationScorecard(w, newRequest(user, "/application?id=app1&timeStart=1&timeEnd=2"))
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `{"scorecard":{"application":"app1","teams":null,"score":50,"results":null,"createdAt":"1970-01-01T00:00:02Z"},"history":[{"createdAt":"1970-01-01T00:00:02Z","score":50,"applications":1}]}`)
+	})
+}
+
+func TestGetTeamScorecard(t *testing.T) {
+	var newRouter = func(t *testing.T) (*db.MockClient, Router) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
+
+		return dbClient, router
+	}
+
+	user := authContext.User{ID: "user1@kobs.io", Permissions: userv1.Permissions{Teams: []string{"team1"}}}
+	timeStart := time.Unix(1, 0)
+	timeEnd := time.Unix(2, 0)
+
+	t.Run("should return error when user is not authorized", func(t *testing.T) {
+		_, router := newRouter(t)
+		w := httptest.NewRecorder()
+		router.getTeamScorecard(w, newRequest(authContext.User{ID: "user1@kobs.io"}, "/team?id=team1&timeStart=1&timeEnd=2"))
+
+		utils.AssertStatusEq(t, w, http.StatusForbidden)
+		utils.AssertJSONEq(t, w, `{"errors": ["You are not allowed to view the team"]}`)
+	})
+
+	t.Run("should return error for invalid time range", func(t *testing.T) {
+		_, router := newRouter(t)
+		w := httptest.NewRecorder()
+		router.getTeamScorecard(w, newRequest(user, "/team?id=team1&timeStart=1"))
+
+		utils.AssertStatusEq(t, w, http.StatusBadRequest)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to parse 'timeEnd' parameter"]}`)
+	})
+
+	t.Run("should handle error when getting scorecards", func(t *testing.T) {
+		dbClient, router := newRouter(t)
+		dbClient.EXPECT().GetLatestScorecards(gomock.Any(), []string{"team1"}, nil).Return(nil, fmt.Errorf("could not get scorecards"))
+		w := httptest.NewRecorder()
+		router.getTeamScorecard(w, newRequest(user, "/team?id=team1&timeStart=1&timeEnd=2"))
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get scorecards"]}`)
+	})
+
+	t.Run("should handle error when getting history", func(t *testing.T) {
+		dbClient, router := newRouter(t)
+		dbClient.EXPECT().GetLatestScorecards(gomock.Any(), []string{"team1"}, nil).Return(nil, nil)
+		dbClient.EXPECT().GetScorecardHistory(gomock.Any(), []string{"team1"}, nil, timeStart, timeEnd).Return(nil, fmt.Errorf("could not get history"))
+		w := httptest.NewRecorder()
+		router.getTeamScorecard(w, newRequest(user, "/team?id=team1&timeStart=1&timeEnd=2"))
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get scorecard history"]}`)
+	})
+
+	t.Run("should return scorecards", func(t *testing.T) {
+		dbClient, router := newRouter(t)
+		dbClient.EXPECT().GetLatestScorecards(gomock.Any(), []string{"team1"}, nil).Return([]db.Scorecard{{Application: "app1", Score: 50, CreatedAt: time.Unix(2, 0).UTC()}, {Application: "app2", Score: 100, CreatedAt: time.Unix(2, 0).UTC()}, {Application: "app3", Score: 0, CreatedAt: time.Unix(2, 0).UTC()}}, nil)
+		dbClient.EXPECT().GetScorecardHistory(gomock.Any(), []string{"team1"}, nil, timeStart, timeEnd).Return(nil, nil)
+		w := httptest.NewRecorder()
+		router.getTeamScorecard(w, newRequest(user, "/team?id=team1&timeStart=1&timeEnd=2"))
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		require.Contains(t, w.Body.String(), `"score":50,`)
+		require.Contains(t, w.Body.String(), `"history":null`)
+	})
+}
+
+func TestMount(t *testing.T) {
+	router := Mount(settings.Settings{}, nil)
+	require.NotNil(t, router)
+}
@@ -0,0 +1,110 @@
+package teams
+
+import (
+	"slices"
+	"sort"
+
+	userv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/user/v1"
+	"github.com/kobsio/kobs/pkg/hub/db"
+)
+
+const (
+	// sourceUser is used for memberships which are defined via the "teams" field of a User CR.
+	sourceUser = "user"
+	// sourceOIDC is used for memberships which were observed in the group claims of the OIDC provider when a user
+	// signed in.
+	sourceOIDC = "oidc"
+)
+
+// Member is a single member of a team. The sources contain the information from where we know that the user is a
+// member of the team (a User CR and / or the group claims of the OIDC provider).
+type Member struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name,omitempty"`
+	Sources []string `json:"sources"`
+}
+
+// Membership is a single team of a user together with the sources of the membership.
+type Membership struct {
+	Team    string   `json:"team"`
+	Sources []string `json:"sources"`
+}
+
+// mergeMembers merges the users from the User CRs and the memberships from the OIDC provider into a single list of
+// members. The members are sorted by their id.
+func mergeMembers(users []userv1.UserSpec, memberships []db.Membership) []Member {
+	members := make(map[string]*Member)
+
+	add := func(id, name, source string) {
+		if member, ok := members[id]; ok {
+			if member.Name == "" {
+				member.Name = name
+			}
+			if !slices.Contains(member.Sources, source) {
+				member.Sources = append(member.Sources, source)
+			}
+			return
+		}
+
+		members[id] = &Member{ID: id, Name: name, Sources: []string{source}}
+	}
+
+	for _, user := range users {
+		add(user.ID, user.DisplayName, sourceUser)
+	}
+
+	for _, membership := range memberships {
+		add(membership.User, membership.Name, sourceOIDC)
+	}
+
+	mergedMembers := make([]Member, 0, len(members))
+	for _, member := range members {
+		mergedMembers = append(mergedMembers, *member)
+	}
+
+	sort.Slice(mergedMembers, func(i, j int) bool {
+		return mergedMembers[i].ID < mergedMembers[j].ID
+	})
+
+	return mergedMembers
+}
+
+// mergeMemberships merges the teams from the User CR and the membership from the OIDC provider of a user into a single
+// list of memberships. The memberships are sorted by the team id.
+func mergeMemberships(user *userv1.UserSpec, membership *db.Membership) []Membership {
+	memberships := make(map[string]*Membership)
+
+	add := func(team, source string) {
+		if m, ok := memberships[team]; ok {
+			if !slices.Contains(m.Sources, source) {
+				m.Sources = append(m.Sources, source)
+			}
+			return
+		}
+
+		memberships[team] = &Membership{Team: team, Sources: []string{source}}
+	}
+
+	if user != nil {
+		for _, team := range user.Teams {
+			add(team, sourceUser)
+		}
+	}
+
+	if membership != nil {
+		for _, team := range membership.Teams {
+			add(team, sourceOIDC)
+		}
+	}
+
+	mergedMemberships := make([]Membership, 0, len(memberships))
+	for _, m := range memberships {
+		mergedMemberships = append(mergedMemberships, *m)
+	}
+
+	sort.Slice(mergedMemberships, func(i, j int) bool {
+		return mergedMemberships[i].Team < mergedMemberships[j].Team
+	})
+
+	return mergedMemberships
+}
@@ -5,10 +5,12 @@ import (
 	"net/http"
 	"strconv"
 
+	applicationv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/application/v1"
 	teamv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/team/v1"
 	"github.com/kobsio/kobs/pkg/hub/app/settings"
 	authContext "github.com/kobsio/kobs/pkg/hub/auth/context"
 	"github.com/kobsio/kobs/pkg/hub/db"
+	"github.com/kobsio/kobs/pkg/hub/oncall"
 	"github.com/kobsio/kobs/pkg/instrument/log"
 	"github.com/kobsio/kobs/pkg/utils"
 	"github.com/kobsio/kobs/pkg/utils/middleware/errresponse"
@@ -20,8 +22,9 @@ import (
 
 type Router struct {
 	*chi.Mux
-	appSettings settings.Settings
-	dbClient    db.Client
+	appSettings  settings.Settings
+	dbClient     db.Client
+	oncallClient oncall.Client
 }
 
 func (router *Router) getTeams(w http.ResponseWriter, r *http.Request) {
@@ -91,6 +94,116 @@ func (router *Router) getTeam(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, team)
 }
 
+// getTeamOnCall returns the on-call information of a team. This contains the participants which are currently on-call
+// as well as the Slack channel and the escalation contacts of the team.
+func (router *Router) getTeamOnCall(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := authContext.MustGetUser(ctx)
+	id := r.URL.Query().Get("id")
+
+	if !user.HasTeamAccess(id) {
+		log.Warn(r.Context(), "The user is not authorized to view the team", zap.String("id", id))
+		errresponse.Render(w, r, http.StatusForbidden, "You are not allowed to view the team")
+		return
+	}
+
+	team, err := router.dbClient.GetTeamByID(r.Context(), id)
+	if err != nil {
+		log.Error(r.Context(), "Failed to get team", zap.Error(err), zap.String("id", id))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get team")
+		return
+	}
+
+	onCall := router.oncallClient.GetOnCall(ctx, *team)
+	if onCall == nil {
+		log.Debug(r.Context(), "Team has no on-call information", zap.String("id", id))
+		errresponse.Render(w, r, http.StatusNotFound, "Team has no on-call information")
+		return
+	}
+
+	render.JSON(w, r, onCall)
+}
+
+// getTeamMembers returns all members of a team together with the applications which are owned by the team. The members
+// are computed from the "teams" field of the User CRs and from the group claims of the OIDC provider, which were
+// observed when a user signed in.
+func (router *Router) getTeamMembers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := authContext.MustGetUser(ctx)
+	id := r.URL.Query().Get("id")
+
+	if !user.HasTeamAccess(id) {
+		log.Warn(ctx, "The user is not authorized to view the team", zap.String("id", id))
+		errresponse.Render(w, r, http.StatusForbidden, "You are not allowed to view the team")
+		return
+	}
+
+	users, err := router.dbClient.GetUsersByTeam(ctx, id)
+	if err != nil {
+		log.Error(ctx, "Failed to get users", zap.Error(err), zap.String("id", id))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get members")
+		return
+	}
+
+	memberships, err := router.dbClient.GetMembershipsByTeam(ctx, id)
+	if err != nil {
+		log.Error(ctx, "Failed to get memberships", zap.Error(err), zap.String("id", id))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get members")
+		return
+	}
+
+	applications, err := router.dbClient.GetApplicationsByFilter(ctx, []string{id}, nil, nil, nil, nil, "", 0, 0)
+	if err != nil {
+		log.Error(ctx, "Failed to get applications", zap.Error(err), zap.String("id", id))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get applications")
+		return
+	}
+
+	data := struct {
+		Members      []Member                        `json:"members"`
+		Applications []applicationv1.ApplicationSpec `json:"applications"`
+	}{
+		mergeMembers(users, memberships),
+		applications,
+	}
+
+	render.JSON(w, r, data)
+}
+
+// getUserTeams returns all teams of a user together with the source of each membership. If no user id is provided, the
+// teams of the current user are returned. To view the teams of another user, the user must have access to all teams.
+func (router *Router) getUserTeams(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := authContext.MustGetUser(ctx)
+	id := r.URL.Query().Get("id")
+
+	if id == "" {
+		id = user.ID
+	}
+
+	if id != user.ID && !user.HasTeamAccess("*") {
+		log.Warn(ctx, "The user is not authorized to view the teams of another user", zap.String("id", id))
+		errresponse.Render(w, r, http.StatusForbidden, "You are not allowed to view the teams of the user")
+		return
+	}
+
+	userSpec, err := router.dbClient.GetUserByID(ctx, id)
+	if err != nil {
+		log.Error(ctx, "Failed to get user", zap.Error(err), zap.String("id", id))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get teams")
+		return
+	}
+
+	membership, err := router.dbClient.GetMembershipByUser(ctx, id)
+	if err != nil {
+		log.Error(ctx, "Failed to get membership", zap.Error(err), zap.String("id", id))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get teams")
+		return
+	}
+
+	render.JSON(w, r, mergeMemberships(userSpec, membership))
+}
+
 func (router *Router) saveTeam(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	user := authContext.MustGetUser(ctx)
@@ -132,15 +245,19 @@ func (router *Router) saveTeam(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, nil)
 }
 
-func Mount(appSettings settings.Settings, dbClient db.Client) chi.Router {
+func Mount(appSettings settings.Settings, dbClient db.Client, oncallClient oncall.Client) chi.Router {
 	router := Router{
 		chi.NewRouter(),
 		appSettings,
 		dbClient,
+		oncallClient,
 	}
 
 	router.Get("/", router.getTeams)
 	router.Get("/team", router.getTeam)
+	router.Get("/oncall", router.getTeamOnCall)
+	router.Get("/members", router.getTeamMembers)
+	router.Get("/user", router.getUserTeams)
 	router.Post("/team", router.saveTeam)
 
 	return router
@@ -8,14 +8,17 @@ import (
 	"strings"
 	"testing"
 
+	applicationv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/application/v1"
 	teamv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/team/v1"
 	userv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/user/v1"
 	"github.com/kobsio/kobs/pkg/hub/app/settings"
 	authContext "github.com/kobsio/kobs/pkg/hub/auth/context"
 	"github.com/kobsio/kobs/pkg/hub/db"
+	"github.com/kobsio/kobs/pkg/hub/oncall"
 	"github.com/kobsio/kobs/pkg/utils"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 )
@@ -26,7 +29,7 @@ func TestGetTeams(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		dbClient := db.NewMockClient(ctrl)
 
-		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient, nil}
 		ctx := context.Background()
 		ctx = context.WithValue(ctx, chi.RouteCtxKey, chi.NewRouteContext())
 		ctx = context.WithValue(ctx, authContext.UserKey, user)
@@ -45,7 +48,7 @@ func TestGetTeams(t *testing.T) {
 		dbClient := db.NewMockClient(ctrl)
 		dbClient.EXPECT().GetTeams(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("could not get teams"))
 
-		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient, nil}
 		ctx := context.Background()
 		ctx = context.WithValue(ctx, chi.RouteCtxKey, chi.NewRouteContext())
 		ctx = context.WithValue(ctx, authContext.UserKey, user)
@@ -64,7 +67,7 @@ func TestGetTeams(t *testing.T) {
 		dbClient := db.NewMockClient(ctrl)
 		dbClient.EXPECT().GetTeams(gomock.Any(), gomock.Any()).Return([]teamv1.TeamSpec{{ID: "team1"}, {ID: "team2"}, {ID: "team3"}, {ID: "team1"}, {ID: "team2"}}, nil)
 
-		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient, nil}
 		ctx := context.Background()
 		ctx = context.WithValue(ctx, chi.RouteCtxKey, chi.NewRouteContext())
 		ctx = context.WithValue(ctx, authContext.UserKey, user)
@@ -84,7 +87,7 @@ func TestGetTeams(t *testing.T) {
 		dbClient := db.NewMockClient(ctrl)
 		dbClient.EXPECT().GetTeamsByIDs(gomock.Any(), teamIDs, gomock.Any()).Return(nil, fmt.Errorf("could not get teams"))
 
-		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient, nil}
 		ctx := context.Background()
 		ctx = context.WithValue(ctx, chi.RouteCtxKey, chi.NewRouteContext())
 		ctx = context.WithValue(ctx, authContext.UserKey, user)
@@ -104,7 +107,7 @@ func TestGetTeams(t *testing.T) {
 		dbClient := db.NewMockClient(ctrl)
 		dbClient.EXPECT().GetTeamsByIDs(gomock.Any(), teamIDs, gomock.Any()).Return([]teamv1.TeamSpec{{ID: "team1"}, {ID: "team1"}}, nil)
 
-		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient, nil}
 		ctx := context.Background()
 		ctx = context.WithValue(ctx, chi.RouteCtxKey, chi.NewRouteContext())
 		ctx = context.WithValue(ctx, authContext.UserKey, user)
@@ -124,7 +127,7 @@ func TestGetTeam(t *testing.T) {
 
 		ctrl := gomock.NewController(t)
 		dbClient := db.NewMockClient(ctrl)
-		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient, nil}
 		ctx := context.Background()
 		ctx = context.WithValue(ctx, chi.RouteCtxKey, chi.NewRouteContext())
 		ctx = context.WithValue(ctx, authContext.UserKey, user)
@@ -145,7 +148,7 @@ func TestGetTeam(t *testing.T) {
 		dbClient := db.NewMockClient(ctrl)
 		dbClient.EXPECT().GetTeamByID(gomock.Any(), teamID).Return(nil, fmt.Errorf("could not get team"))
 
-		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient, nil}
 		ctx := context.Background()
 		ctx = context.WithValue(ctx, chi.RouteCtxKey, chi.NewRouteContext())
 		ctx = context.WithValue(ctx, authContext.UserKey, user)
@@ -166,7 +169,7 @@ func TestGetTeam(t *testing.T) {
 		dbClient := db.NewMockClient(ctrl)
 		dbClient.EXPECT().GetTeamByID(gomock.Any(), teamID).Return(&teamv1.TeamSpec{ID: teamID}, nil)
 
-		router := Router{chi.NewRouter(), settings.Settings{}, dbClient}
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient, nil}
 		ctx := context.Background()
 		ctx = context.WithValue(ctx, chi.RouteCtxKey, chi.NewRouteContext())
 		ctx = context.WithValue(ctx, authContext.UserKey, user)
@@ -180,13 +183,260 @@ func TestGetTeam(t *testing.T) {
 	})
 }
 
+func TestGetTeamOnCall(t *testing.T) {
+	var newRouter = func(t *testing.T) (*db.MockClient, *oncall.MockClient, Router) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		oncallClient := oncall.NewMockClient(ctrl)
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient, oncallClient}
+
+		return dbClient, oncallClient, router
+	}
+
+	var newRequest = func(user authContext.User) *http.Request {
+		ctx := context.Background()
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, chi.NewRouteContext())
+		ctx = context.WithValue(ctx, authContext.UserKey, user)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/teams/oncall?id=team1", nil)
+		return req
+	}
+
+	t.Run("should return error if user is not authorized", func(t *testing.T) {
+		_, _, router := newRouter(t)
+		w := httptest.NewRecorder()
+
+		router.getTeamOnCall(w, newRequest(authContext.User{ID: "foo"}))
+
+		utils.AssertStatusEq(t, w, http.StatusForbidden)
+		utils.AssertJSONEq(t, w, `{"errors": ["You are not allowed to view the team"]}`)
+	})
+
+	t.Run("should handle error from db client", func(t *testing.T) {
+		dbClient, _, router := newRouter(t)
+		dbClient.EXPECT().GetTeamByID(gomock.Any(), "team1").Return(nil, fmt.Errorf("could not get team"))
+		w := httptest.NewRecorder()
+
+		router.getTeamOnCall(w, newRequest(authContext.User{ID: "foo", Permissions: userv1.Permissions{Teams: []string{"team1"}}}))
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get team"]}`)
+	})
+
+	t.Run("should return error if team has no on-call information", func(t *testing.T) {
+		dbClient, oncallClient, router := newRouter(t)
+		dbClient.EXPECT().GetTeamByID(gomock.Any(), "team1").Return(&teamv1.TeamSpec{ID: "team1"}, nil)
+		oncallClient.EXPECT().GetOnCall(gomock.Any(), teamv1.TeamSpec{ID: "team1"}).Return(nil)
+		w := httptest.NewRecorder()
+
+		router.getTeamOnCall(w, newRequest(authContext.User{ID: "foo", Permissions: userv1.Permissions{Teams: []string{"team1"}}}))
+
+		utils.AssertStatusEq(t, w, http.StatusNotFound)
+		utils.AssertJSONEq(t, w, `{"errors": ["Team has no on-call information"]}`)
+	})
+
+	t.Run("should return on-call information", func(t *testing.T) {
+		dbClient, oncallClient, router := newRouter(t)
+		dbClient.EXPECT().GetTeamByID(gomock.Any(), "team1").Return(&teamv1.TeamSpec{ID: "team1"}, nil)
+		oncallClient.EXPECT().GetOnCall(gomock.Any(), teamv1.TeamSpec{ID: "team1"}).Return(&oncall.OnCall{Team: "team1", SlackChannel: "#team1", Participants: []string{"user1@kobs.io"}})
+		w := httptest.NewRecorder()
+
+		router.getTeamOnCall(w, newRequest(authContext.User{ID: "foo", Permissions: userv1.Permissions{Teams: []string{"team1"}}}))
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `{"team":"team1","slackChannel":"#team1","participants":["user1@kobs.io"]}`)
+	})
+
+	t.Run("should return participants via mounted route", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetTeamByID(gomock.Any(), "team1").Return(&teamv1.TeamSpec{ID: "team1", OnCall: &teamv1.OnCall{Opsgenie: &teamv1.Opsgenie{Name: "opsgenie", Schedule: "schedule1"}}}, nil)
+
+		pluginsRouter := chi.NewRouter()
+		pluginsRouter.Get("/opsgenie/oncall", func(w http.ResponseWriter, r *http.Request) {
+			render.JSON(w, r, []string{"user1@kobs.io"})
+		})
+
+		// The teams router is mounted in the same way as in the hub API, so that the request for the participants is
+		// made with the context of the API request.
+		router := chi.NewRouter()
+		router.Route("/api", func(r chi.Router) {
+			r.Use(func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), authContext.UserKey, authContext.User{ID: "foo", Permissions: userv1.Permissions{Teams: []string{"team1"}}})))
+				})
+			})
+			r.Mount("/teams", Mount(settings.Settings{}, dbClient, oncall.NewClient(pluginsRouter)))
+		})
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/teams/oncall?id=team1", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `{"team":"team1","participants":["user1@kobs.io"]}`)
+	})
+}
+
+func TestGetTeamMembers(t *testing.T) {
+	var newRouter = func(t *testing.T) (*db.MockClient, Router) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient, nil}
+
+		return dbClient, router
+	}
+
+	var newRequest = func(user authContext.User) *http.Request {
+		ctx := context.Background()
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, chi.NewRouteContext())
+		ctx = context.WithValue(ctx, authContext.UserKey, user)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/teams/members?id=team1", nil)
+		return req
+	}
+
+	t.Run("should return error if user is not authorized", func(t *testing.T) {
+		_, router := newRouter(t)
+		w := httptest.NewRecorder()
+
+		router.getTeamMembers(w, newRequest(authContext.User{ID: "foo"}))
+
+		utils.AssertStatusEq(t, w, http.StatusForbidden)
+		utils.AssertJSONEq(t, w, `{"errors": ["You are not allowed to view the team"]}`)
+	})
+
+	t.Run("should handle error when getting users", func(t *testing.T) {
+		dbClient, router := newRouter(t)
+		dbClient.EXPECT().GetUsersByTeam(gomock.Any(), "team1").Return(nil, fmt.Errorf("could not get users"))
+		w := httptest.NewRecorder()
+
+		router.getTeamMembers(w, newRequest(authContext.User{ID: "foo", Permissions: userv1.Permissions{Teams: []string{"team1"}}}))
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get members"]}`)
+	})
+
+	t.Run("should handle error when getting memberships", func(t *testing.T) {
+		dbClient, router := newRouter(t)
+		dbClient.EXPECT().GetUsersByTeam(gomock.Any(), "team1").Return(nil, nil)
+		dbClient.EXPECT().GetMembershipsByTeam(gomock.Any(), "team1").Return(nil, fmt.Errorf("could not get memberships"))
+		w := httptest.NewRecorder()
+
+		router.getTeamMembers(w, newRequest(authContext.User{ID: "foo", Permissions: userv1.Permissions{Teams: []string{"team1"}}}))
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get members"]}`)
+	})
+
+	t.Run("should handle error when getting applications", func(t *testing.T) {
+		dbClient, router := newRouter(t)
+		dbClient.EXPECT().GetUsersByTeam(gomock.Any(), "team1").Return(nil, nil)
+		dbClient.EXPECT().GetMembershipsByTeam(gomock.Any(), "team1").Return(nil, nil)
+		dbClient.EXPECT().GetApplicationsByFilter(gomock.Any(), []string{"team1"}, nil, nil, nil, nil, "", 0, 0).Return(nil, fmt.Errorf("could not get applications"))
+		w := httptest.NewRecorder()
+
+		router.getTeamMembers(w, newRequest(authContext.User{ID: "foo", Permissions: userv1.Permissions{Teams: []string{"team1"}}}))
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get applications"]}`)
+	})
+
+	t.Run("should return members and applications", func(t *testing.T) {
+		dbClient, router := newRouter(t)
+		dbClient.EXPECT().GetUsersByTeam(gomock.Any(), "team1").Return([]userv1.UserSpec{{ID: "user1@kobs.io", DisplayName: "User 1"}, {ID: "user2@kobs.io"}}, nil)
+		dbClient.EXPECT().GetMembershipsByTeam(gomock.Any(), "team1").Return([]db.Membership{{User: "user2@kobs.io", Name: "User 2", Teams: []string{"team1"}}, {User: "user0@kobs.io", Teams: []string{"team1"}}}, nil)
+		dbClient.EXPECT().GetApplicationsByFilter(gomock.Any(), []string{"team1"}, nil, nil, nil, nil, "", 0, 0).Return([]applicationv1.ApplicationSpec{{ID: "app1"}}, nil)
+		w := httptest.NewRecorder()
+
+		router.getTeamMembers(w, newRequest(authContext.User{ID: "foo", Permissions: userv1.Permissions{Teams: []string{"team1"}}}))
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `{"members":[{"id":"user0@kobs.io","sources":["oidc"]},{"id":"user1@kobs.io","name":"User 1","sources":["user"]},{"id":"user2@kobs.io","name":"User 2","sources":["user","oidc"]}],"applications":[{"id":"app1","topology":{}}]}`)
+	})
+}
+
+func TestGetUserTeams(t *testing.T) {
+	var newRouter = func(t *testing.T) (*db.MockClient, Router) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		router := Router{chi.NewRouter(), settings.Settings{}, dbClient, nil}
+
+		return dbClient, router
+	}
+
+	var newRequest = func(user authContext.User, id string) *http.Request {
+		ctx := context.Background()
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, chi.NewRouteContext())
+		ctx = context.WithValue(ctx, authContext.UserKey, user)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/teams/user?id="+id, nil)
+		return req
+	}
+
+	t.Run("should return error if user is not authorized", func(t *testing.T) {
+		_, router := newRouter(t)
+		w := httptest.NewRecorder()
+
+		router.getUserTeams(w, newRequest(authContext.User{ID: "user1@kobs.io"}, "user2@kobs.io"))
+
+		utils.AssertStatusEq(t, w, http.StatusForbidden)
+		utils.AssertJSONEq(t, w, `{"errors": ["You are not allowed to view the teams of the user"]}`)
+	})
+
+	t.Run("should handle error when getting user", func(t *testing.T) {
+		dbClient, router := newRouter(t)
+		dbClient.EXPECT().GetUserByID(gomock.Any(), "user1@kobs.io").Return(nil, fmt.Errorf("could not get user"))
+		w := httptest.NewRecorder()
+
+		router.getUserTeams(w, newRequest(authContext.User{ID: "user1@kobs.io"}, ""))
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get teams"]}`)
+	})
+
+	t.Run("should handle error when getting membership", func(t *testing.T) {
+		dbClient, router := newRouter(t)
+		dbClient.EXPECT().GetUserByID(gomock.Any(), "user1@kobs.io").Return(nil, nil)
+		dbClient.EXPECT().GetMembershipByUser(gomock.Any(), "user1@kobs.io").Return(nil, fmt.Errorf("could not get membership"))
+		w := httptest.NewRecorder()
+
+		router.getUserTeams(w, newRequest(authContext.User{ID: "user1@kobs.io"}, ""))
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get teams"]}`)
+	})
+
+	t.Run("should return teams of the current user", func(t *testing.T) {
+		dbClient, router := newRouter(t)
+		dbClient.EXPECT().GetUserByID(gomock.Any(), "user1@kobs.io").Return(&userv1.UserSpec{ID: "user1@kobs.io", Teams: []string{"team2", "team1"}}, nil)
+		dbClient.EXPECT().GetMembershipByUser(gomock.Any(), "user1@kobs.io").Return(&db.Membership{User: "user1@kobs.io", Teams: []string{"team1", "team3"}}, nil)
+		w := httptest.NewRecorder()
+
+		router.getUserTeams(w, newRequest(authContext.User{ID: "user1@kobs.io"}, ""))
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `[{"team":"team1","sources":["user","oidc"]},{"team":"team2","sources":["user"]},{"team":"team3","sources":["oidc"]}]`)
+	})
+
+	t.Run("should return teams of another user", func(t *testing.T) {
+		dbClient, router := newRouter(t)
+		dbClient.EXPECT().GetUserByID(gomock.Any(), "user2@kobs.io").Return(nil, nil)
+		dbClient.EXPECT().GetMembershipByUser(gomock.Any(), "user2@kobs.io").Return(nil, nil)
+		w := httptest.NewRecorder()
+
+		router.getUserTeams(w, newRequest(authContext.User{ID: "user1@kobs.io", Permissions: userv1.Permissions{Teams: []string{"*"}}}, "user2@kobs.io"))
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `[]`)
+	})
+}
+
 func TestSaveTeam(t *testing.T) {
 	var newRouter = func(t *testing.T, saveEnabled bool) (*db.MockClient, Router) {
 		ctrl := gomock.NewController(t)
 		dbClient := db.NewMockClient(ctrl)
 		router := Router{chi.NewRouter(), settings.Settings{Save: struct {
 			Enabled bool `json:"enabled"`
-		}{Enabled: saveEnabled}}, dbClient}
+		}{Enabled: saveEnabled}}, dbClient, nil}
 
 		return dbClient, router
 	}
@@ -273,6 +523,6 @@ func TestSaveTeam(t *testing.T) {
 }
 
 func TestMount(t *testing.T) {
-	router := Mount(settings.Settings{}, nil)
+	router := Mount(settings.Settings{}, nil, nil)
 	require.NotNil(t, router)
 }
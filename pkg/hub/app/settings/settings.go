@@ -7,6 +7,8 @@ import (
 
 	dashboardv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
 	userv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/user/v1"
+	"github.com/kobsio/kobs/pkg/hub/health"
+	"github.com/kobsio/kobs/pkg/hub/scorecards"
 )
 
 type Settings struct {
@@ -16,6 +18,8 @@ type Settings struct {
 	DefaultNavigation []userv1.Navigation     `json:"defaultNavigation"`
 	DefaultDashboards []dashboardv1.Reference `json:"defaultDashboards"`
 	Integrations      Integrations            `json:"integrations"`
+	Health            health.Config           `json:"health"`
+	Scorecards        scorecards.Config       `json:"scorecards"`
 }
 
 type Integrations struct {
@@ -87,3 +91,17 @@ func (s *Settings) GetDashboardsFromResourcesIntegrations(resource string) []Das
 
 	return dashboards
 }
+
+// Validate checks the health rules and the scorecards configuration, so that an invalid configuration can be rejected
+// before it is used by the hub.
+func (s *Settings) Validate() error {
+	if _, err := health.NewEvaluator(s.Health); err != nil {
+		return fmt.Errorf("invalid health configuration: %w", err)
+	}
+
+	if err := s.Scorecards.Validate(); err != nil {
+		return fmt.Errorf("invalid scorecards configuration: %w", err)
+	}
+
+	return nil
+}
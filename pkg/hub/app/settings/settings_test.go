@@ -5,6 +5,8 @@ import (
 
 	dashboardv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
 	userv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/user/v1"
+	"github.com/kobsio/kobs/pkg/hub/health"
+	"github.com/kobsio/kobs/pkg/hub/scorecards"
 
 	"github.com/stretchr/testify/require"
 )
@@ -77,3 +79,23 @@ func TestGetDashboards(t *testing.T) {
 		require.Equal(t, []dashboardv1.Reference{{Title: "user"}}, settings.GetDashboards(&userv1.UserSpec{Dashboards: []dashboardv1.Reference{{Title: "user"}}}))
 	})
 }
+
+func TestValidate(t *testing.T) {
+	t.Run("should return no error for empty settings", func(t *testing.T) {
+		require.NoError(t, (&Settings{}).Validate())
+	})
+
+	t.Run("should return error for invalid health rule", func(t *testing.T) {
+		err := (&Settings{Health: health.Config{Rules: []health.ResourceRules{{Resource: "pods", Rules: []health.Rule{{Status: health.StatusHealthy, Expression: "object."}}}}}}).Validate()
+		require.ErrorContains(t, err, "invalid health configuration")
+	})
+
+	t.Run("should return error for invalid scorecard rule", func(t *testing.T) {
+		err := (&Settings{Scorecards: scorecards.Config{Rules: []scorecards.Rule{{ID: "test", Type: "invalid"}}}}).Validate()
+		require.ErrorContains(t, err, "invalid scorecards configuration")
+	})
+
+	t.Run("should return no error for valid settings", func(t *testing.T) {
+		require.NoError(t, (&Settings{Scorecards: scorecards.Config{Rules: []scorecards.Rule{{ID: "owner", Type: scorecards.RuleTypeOwner}}}}).Validate())
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/kobsio/kobs/pkg/hub/app/settings"
@@ -29,11 +30,12 @@ import (
 type Client interface {
 	MiddlewareHandler(next http.Handler) http.Handler
 	Mount() chi.Router
+	SetSettings(appSettings settings.Settings)
 }
 
 type client struct {
 	config       Config
-	appSettings  settings.Settings
+	appSettings  atomic.Pointer[settings.Settings]
 	router       *chi.Mux
 	dbClient     db.Client
 	oidcConfig   *oauth2.Config
@@ -74,6 +76,21 @@ func (c *client) MiddlewareHandler(next http.Handler) http.Handler {
 	return http.HandlerFunc(fn)
 }
 
+// SetSettings replaces the settings of the auth client, which are used to get the default dashboards and navigation
+// for a user. It is used to apply changed settings without restarting the hub.
+func (c *client) SetSettings(appSettings settings.Settings) {
+	c.appSettings.Store(&appSettings)
+}
+
+// getSettings returns the current settings of the auth client. If no settings were set, the empty settings are
+// returned.
+func (c *client) getSettings() *settings.Settings {
+	if appSettings := c.appSettings.Load(); appSettings != nil {
+		return appSettings
+	}
+	return &settings.Settings{}
+}
+
 // Mount returns the router of the auth client, which can be used within another chi router to mount the authentication
 // endpoint in the hub API.
 func (c *client) Mount() chi.Router {
@@ -123,8 +140,8 @@ func (c *client) authHandler(w http.ResponseWriter, r *http.Request) {
 
 	render.JSON(w, r, userResponse{
 		User:       session.User,
-		Dashboards: c.appSettings.GetDashboards(user),
-		Navigation: c.appSettings.GetNavigation(user),
+		Dashboards: c.getSettings().GetDashboards(user),
+		Navigation: c.getSettings().GetNavigation(user),
 	})
 }
 
@@ -216,8 +233,8 @@ func (c *client) signinHandler(w http.ResponseWriter, r *http.Request) {
 
 	render.JSON(w, r, userResponse{
 		User:       authContextUser,
-		Dashboards: c.appSettings.GetDashboards(user),
-		Navigation: c.appSettings.GetNavigation(user),
+		Dashboards: c.getSettings().GetDashboards(user),
+		Navigation: c.getSettings().GetNavigation(user),
 	})
 }
 
@@ -348,6 +365,13 @@ func (c *client) oidcCallbackHandler(w http.ResponseWriter, r *http.Request) {
 		Teams: claims.Groups,
 	}
 
+	// Save the groups from the claims as teams of the user, so that we can show the members of a team, which are only
+	// known via the OIDC provider. If this fails we continue with the sign in, because the membership is only used for
+	// informational purposes.
+	if err := c.dbClient.SaveMembership(ctx, claims.Email, claims.Name, claims.Groups); err != nil {
+		log.Warn(ctx, "Failed to save membership", zap.Error(err))
+	}
+
 	user, err := c.dbClient.GetUserByID(ctx, authContextUser.ID)
 	if err != nil {
 		log.Warn(ctx, "Failed to get user from database", zap.Error(err))
@@ -407,8 +431,8 @@ func (c *client) oidcCallbackHandler(w http.ResponseWriter, r *http.Request) {
 	}{
 		User: userResponse{
 			User:       *authContextUser,
-			Dashboards: c.appSettings.GetDashboards(user),
-			Navigation: c.appSettings.GetNavigation(user),
+			Dashboards: c.getSettings().GetDashboards(user),
+			Navigation: c.getSettings().GetNavigation(user),
 		},
 		URL: strings.TrimPrefix(state, c.config.OIDC.State),
 	}
@@ -441,13 +465,14 @@ func NewClient(config Config, appSettings settings.Settings, dbClient db.Client)
 
 	c := &client{
 		config:       config,
-		appSettings:  appSettings,
 		router:       chi.NewRouter(),
 		oidcConfig:   oidcConfig,
 		oidcProvider: oidcProvider,
 		dbClient:     dbClient,
 	}
 
+	c.SetSettings(appSettings)
+
 	c.router.Get("/", c.authHandler)
 	c.router.Post("/signin", c.signinHandler)
 	c.router.Get("/signout", c.signoutHandler)
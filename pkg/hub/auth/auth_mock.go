@@ -10,6 +10,7 @@ import (
 
 	chi "github.com/go-chi/chi/v5"
 	gomock "github.com/golang/mock/gomock"
+	settings "github.com/kobsio/kobs/pkg/hub/app/settings"
 )
 
 // MockClient is a mock of Client interface.
@@ -62,3 +63,15 @@ func (mr *MockClientMockRecorder) Mount() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Mount", reflect.TypeOf((*MockClient)(nil).Mount))
 }
+
+// SetSettings mocks base method.
+func (m *MockClient) SetSettings(appSettings settings.Settings) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSettings", appSettings)
+}
+
+// SetSettings indicates an expected call of SetSettings.
+func (mr *MockClientMockRecorder) SetSettings(appSettings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSettings", reflect.TypeOf((*MockClient)(nil).SetSettings), appSettings)
+}
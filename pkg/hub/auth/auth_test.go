@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	dashboardv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
 	teamv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/team/v1"
 	userv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/user/v1"
 	"github.com/kobsio/kobs/pkg/hub/app/settings"
@@ -164,6 +165,20 @@ func TestMount(t *testing.T) {
 	require.NotNil(t, c.Mount())
 }
 
+func TestSetSettings(t *testing.T) {
+	t.Run("should return empty settings when no settings are set", func(t *testing.T) {
+		c := client{}
+		require.Equal(t, &settings.Settings{}, c.getSettings())
+	})
+
+	t.Run("should return the latest settings", func(t *testing.T) {
+		c := client{}
+		c.SetSettings(settings.Settings{DefaultDashboards: []dashboardv1.Reference{{Title: "first"}}})
+		c.SetSettings(settings.Settings{DefaultDashboards: []dashboardv1.Reference{{Title: "second"}}})
+		require.Equal(t, []dashboardv1.Reference{{Title: "second"}}, c.getSettings().GetDashboards(nil))
+	})
+}
+
 func TestAuthHandler(t *testing.T) {
 	t.Run("should fail when no token is set", func(t *testing.T) {
 		client := client{}
@@ -0,0 +1,235 @@
+package cluster
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes"
+	applicationv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/application/v1"
+	dashboardv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
+	teamv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/team/v1"
+	userv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/user/v1"
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/inventory"
+	"github.com/kobsio/kobs/pkg/plugins/plugin"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// agentlessAddress is the address which is used for all requests against the cluster API of an agentless cluster. The
+// requests are never sent over the network, because they are directly passed to the in-process handler, so that only
+// the path and query of the address are important.
+const agentlessAddress = "http://agentless"
+
+// agentlessClient implements the Client interface for clusters, where no kobs cluster is running. Instead of sending
+// the requests to a kobs cluster, the client uses a Kubernetes client, which is talking directly to the Kubernetes API
+// server and serves the cluster API in-process.
+type agentlessClient struct {
+	config           Config
+	kubernetesClient kubernetes.Client
+	handler          http.Handler
+	httpClient       *http.Client
+	tracer           trace.Tracer
+}
+
+// handlerTransport is a http.RoundTripper, which passes all requests to the given handler instead of sending them over
+// the network.
+type handlerTransport struct {
+	handler http.Handler
+}
+
+func (t *handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	w := httptest.NewRecorder()
+	t.handler.ServeHTTP(w, req)
+	return w.Result(), nil
+}
+
+func (c *agentlessClient) GetName() string {
+	return c.config.Name
+}
+
+func (c *agentlessClient) GetPlugins(ctx context.Context) ([]plugin.Instance, error) {
+	ctx, span := c.tracer.Start(ctx, "client.GetPlugins")
+	span.SetAttributes(attribute.Key("client").String(c.config.Name))
+	defer span.End()
+
+	res, err := doRequest[[]plugin.Instance](ctx, c.httpClient, "", http.MethodGet, agentlessAddress+"/api/plugins", nil)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return res, err
+}
+
+func (c *agentlessClient) GetNamespaces(ctx context.Context) ([]string, error) {
+	ctx, span := c.tracer.Start(ctx, "client.GetNamespaces")
+	span.SetAttributes(attribute.Key("client").String(c.config.Name))
+	defer span.End()
+
+	res, err := c.kubernetesClient.GetNamespaces(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return res, err
+}
+
+func (c *agentlessClient) GetCRDs(ctx context.Context) ([]kubernetes.CRD, error) {
+	ctx, span := c.tracer.Start(ctx, "client.GetCRDs")
+	span.SetAttributes(attribute.Key("client").String(c.config.Name))
+	defer span.End()
+
+	res, err := c.kubernetesClient.GetCRDs(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return res, err
+}
+
+func (c *agentlessClient) GetInventory(ctx context.Context) (*inventory.Inventory, error) {
+	ctx, span := c.tracer.Start(ctx, "client.GetInventory")
+	span.SetAttributes(attribute.Key("client").String(c.config.Name))
+	defer span.End()
+
+	res, err := c.kubernetesClient.GetInventory(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	// No kobs cluster is running in an agentless cluster, so that the kobs version of the inventory must be empty.
+	// Otherwise the inventory would contain the version of the hub or watcher, which is serving the cluster API.
+	res.KobsVersion = ""
+
+	return res, nil
+}
+
+func (c *agentlessClient) GetApplications(ctx context.Context) ([]applicationv1.ApplicationSpec, error) {
+	ctx, span := c.tracer.Start(ctx, "client.GetApplications")
+	span.SetAttributes(attribute.Key("client").String(c.config.Name))
+	defer span.End()
+
+	res, err := c.kubernetesClient.GetApplications(ctx, c.config.Name, "")
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return res, err
+}
+
+func (c *agentlessClient) GetDashboards(ctx context.Context) ([]dashboardv1.DashboardSpec, error) {
+	ctx, span := c.tracer.Start(ctx, "client.GetDashboards")
+	span.SetAttributes(attribute.Key("client").String(c.config.Name))
+	defer span.End()
+
+	res, err := c.kubernetesClient.GetDashboards(ctx, c.config.Name, "")
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return res, err
+}
+
+func (c *agentlessClient) GetTeams(ctx context.Context) ([]teamv1.TeamSpec, error) {
+	ctx, span := c.tracer.Start(ctx, "client.GetTeams")
+	span.SetAttributes(attribute.Key("client").String(c.config.Name))
+	defer span.End()
+
+	res, err := c.kubernetesClient.GetTeams(ctx, c.config.Name, "")
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return res, err
+}
+
+func (c *agentlessClient) GetUsers(ctx context.Context) ([]userv1.UserSpec, error) {
+	ctx, span := c.tracer.Start(ctx, "client.GetUsers")
+	span.SetAttributes(attribute.Key("client").String(c.config.Name))
+	defer span.End()
+
+	res, err := c.kubernetesClient.GetUsers(ctx, c.config.Name, "")
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return res, err
+}
+
+func (c *agentlessClient) Request(ctx context.Context, method, url string, body io.Reader) (map[string]any, error) {
+	ctx, span := c.tracer.Start(ctx, "client.Request")
+	span.SetAttributes(attribute.Key("client").String(c.config.Name))
+	span.SetAttributes(attribute.Key("method").String(method))
+	span.SetAttributes(attribute.Key("url").String(url))
+	defer span.End()
+
+	res, err := doRequest[map[string]any](ctx, c.httpClient, "", method, agentlessAddress+url, body)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return res, err
+}
+
+// Proxy passes the request directly to the in-process cluster API. Since the original response writer is passed to the
+// handler, this also works for requests which are upgraded to a WebSocket connection (e.g. terminal and logs).
+//
+// The route context of the hub router must be removed from the request, because otherwise chi handles the cluster API
+// like a sub-router and routes the request via the remaining route path instead of the full path of the request.
+func (c *agentlessClient) Proxy(w http.ResponseWriter, r *http.Request) {
+	ctx, span := c.tracer.Start(r.Context(), "client.Proxy")
+	span.SetAttributes(attribute.Key("client").String(c.config.Name))
+	defer span.End()
+
+	r.Header.Del("Authorization")
+	c.handler.ServeHTTP(w, r.WithContext(context.WithValue(ctx, chi.RouteCtxKey, nil)))
+}
+
+// Stop stops the Kubernetes client of the cluster, so that the informers of the cache are stopped. It must be called
+// when the cluster is removed.
+func (c *agentlessClient) Stop() {
+	c.kubernetesClient.Stop()
+}
+
+// NewAgentlessClient returns a new client for an agentless cluster. It creates a Kubernetes client from the "kubernetes"
+// configuration of the cluster and uses the given newHandler function to create the cluster API for the cluster.
+func NewAgentlessClient(config Config, newHandler NewHandler) (Client, error) {
+	kubernetesClient, err := kubernetes.NewClient(*config.Kubernetes)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAgentlessClient(config, kubernetesClient, newHandler)
+}
+
+func newAgentlessClient(config Config, kubernetesClient kubernetes.Client, newHandler NewHandler) (Client, error) {
+	handler, err := newHandler(config.Plugins, kubernetesClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &agentlessClient{
+		config:           config,
+		kubernetesClient: kubernetesClient,
+		handler:          handler,
+		httpClient: &http.Client{
+			Transport: &handlerTransport{handler: handler},
+		},
+		tracer: otel.Tracer("client"),
+	}, nil
+}
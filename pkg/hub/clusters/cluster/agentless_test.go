@@ -0,0 +1,156 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes"
+	applicationv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/application/v1"
+	dashboardv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
+	teamv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/team/v1"
+	userv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/user/v1"
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/inventory"
+	"github.com/kobsio/kobs/pkg/plugins/plugin"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentlessClient(t *testing.T) {
+	var newClient = func(t *testing.T) (*kubernetes.MockClient, Client) {
+		ctrl := gomock.NewController(t)
+		kubernetesClient := kubernetes.NewMockClient(ctrl)
+
+		client, err := newAgentlessClient(Config{Name: "dev-de1", Plugins: []plugin.Instance{{Name: "prometheus"}}}, kubernetesClient, func(instances []plugin.Instance, kubernetesClient kubernetes.Client) (http.Handler, error) {
+			router := chi.NewRouter()
+			router.Get("/api/plugins", func(w http.ResponseWriter, r *http.Request) {
+				render.JSON(w, r, instances)
+			})
+			router.Get("/api/resources", func(w http.ResponseWriter, r *http.Request) {
+				render.JSON(w, r, map[string]any{"namespace": r.URL.Query().Get("namespace"), "authorization": r.Header.Get("Authorization")})
+			})
+			router.Get("/api/resources/logs", func(w http.ResponseWriter, r *http.Request) {
+				render.JSON(w, r, map[string]any{"name": r.URL.Query().Get("name")})
+			})
+			return router, nil
+		})
+		require.NoError(t, err)
+
+		return kubernetesClient, client
+	}
+
+	t.Run("should fail when handler can not be created", func(t *testing.T) {
+		_, err := newAgentlessClient(Config{}, nil, func(instances []plugin.Instance, kubernetesClient kubernetes.Client) (http.Handler, error) {
+			return nil, fmt.Errorf("unexpected error")
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("should return name", func(t *testing.T) {
+		_, client := newClient(t)
+		require.Equal(t, "dev-de1", client.GetName())
+	})
+
+	t.Run("should return plugins from handler", func(t *testing.T) {
+		_, client := newClient(t)
+		plugins, err := client.GetPlugins(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []plugin.Instance{{Name: "prometheus"}}, plugins)
+	})
+
+	t.Run("should return resources from kubernetes client", func(t *testing.T) {
+		kubernetesClient, client := newClient(t)
+		kubernetesClient.EXPECT().GetNamespaces(gomock.Any()).Return([]string{"default"}, nil)
+		kubernetesClient.EXPECT().GetCRDs(gomock.Any()).Return(nil, fmt.Errorf("unexpected error"))
+		kubernetesClient.EXPECT().GetInventory(gomock.Any()).Return(&inventory.Inventory{KubernetesVersion: "v1.27.3", KobsVersion: "v0.12.0"}, nil)
+		kubernetesClient.EXPECT().GetApplications(gomock.Any(), "dev-de1", "").Return([]applicationv1.ApplicationSpec{{Name: "app"}}, nil)
+		kubernetesClient.EXPECT().GetDashboards(gomock.Any(), "dev-de1", "").Return([]dashboardv1.DashboardSpec{{Name: "dashboard"}}, nil)
+		kubernetesClient.EXPECT().GetTeams(gomock.Any(), "dev-de1", "").Return([]teamv1.TeamSpec{{ID: "team"}}, nil)
+		kubernetesClient.EXPECT().GetUsers(gomock.Any(), "dev-de1", "").Return([]userv1.UserSpec{{ID: "user"}}, nil)
+
+		namespaces, err := client.GetNamespaces(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []string{"default"}, namespaces)
+
+		_, err = client.GetCRDs(context.Background())
+		require.Error(t, err)
+
+		inv, err := client.GetInventory(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "v1.27.3", inv.KubernetesVersion)
+		require.Empty(t, inv.KobsVersion)
+
+		applications, err := client.GetApplications(context.Background())
+		require.NoError(t, err)
+		require.Len(t, applications, 1)
+
+		dashboards, err := client.GetDashboards(context.Background())
+		require.NoError(t, err)
+		require.Len(t, dashboards, 1)
+
+		teams, err := client.GetTeams(context.Background())
+		require.NoError(t, err)
+		require.Len(t, teams, 1)
+
+		users, err := client.GetUsers(context.Background())
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+	})
+
+	t.Run("should pass request to handler", func(t *testing.T) {
+		_, client := newClient(t)
+		res, err := client.Request(context.Background(), http.MethodGet, "/api/resources?namespace=default", nil)
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"namespace": "default", "authorization": "Bearer "}, res)
+
+		_, err = client.Request(context.Background(), http.MethodGet, "/api/unknown", nil)
+		require.Error(t, err)
+	})
+
+	t.Run("should proxy request to handler", func(t *testing.T) {
+		_, client := newClient(t)
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/resources?namespace=kube-system", nil)
+		req.Header.Set("Authorization", "Bearer token")
+		w := httptest.NewRecorder()
+
+		client.Proxy(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.JSONEq(t, `{"namespace": "kube-system", "authorization": ""}`, w.Body.String())
+	})
+
+	t.Run("should proxy request from mounted hub route to handler", func(t *testing.T) {
+		_, client := newClient(t)
+
+		// The router is mounted in the same way as in the hub API, so that the request contains the route context of
+		// the hub router, when it is passed to the in-process cluster API.
+		router := chi.NewRouter()
+		router.Route("/api", func(r chi.Router) {
+			r.Group(func(r chi.Router) {
+				r.Mount("/resources", http.HandlerFunc(client.Proxy))
+			})
+		})
+
+		for _, tt := range []struct {
+			url      string
+			expected string
+		}{
+			{url: "/api/resources?namespace=kube-system", expected: `{"namespace": "kube-system", "authorization": ""}`},
+			{url: "/api/resources/logs?name=pod1", expected: `{"name": "pod1"}`},
+		} {
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code, tt.url)
+			require.JSONEq(t, tt.expected, w.Body.String(), tt.url)
+		}
+	})
+}
@@ -4,7 +4,9 @@ package cluster
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
@@ -14,11 +16,13 @@ import (
 	dashboardv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
 	teamv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/team/v1"
 	userv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/user/v1"
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/inventory"
 	"github.com/kobsio/kobs/pkg/instrument/log"
 	"github.com/kobsio/kobs/pkg/plugins/plugin"
 	"github.com/kobsio/kobs/pkg/utils/middleware/errresponse"
 	"github.com/kobsio/kobs/pkg/utils/middleware/roundtripper"
 
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -27,29 +31,50 @@ import (
 	"go.uber.org/zap"
 )
 
+// Config is the configuration for a single cluster. A cluster can be accessed via a kobs cluster, which is running in the
+// Kubernetes cluster. In this case the "address" and "token" of the kobs cluster are required. If it is not possible to
+// run a kobs cluster, the hub can also talk directly to the Kubernetes API server of the cluster, when the "kubernetes"
+// configuration is set. In this case the plugins for the cluster can be set via the "plugins" field.
+//
+// If the kobs cluster is not reachable by the hub, the "tunnel" field can be set. In this case the kobs cluster opens a
+// tunnel to the hub and all requests are sent through this tunnel, so that the "address" is not required.
 type Config struct {
-	Name    string `json:"name"`
-	Address string `json:"address"`
-	Token   string `json:"token"`
+	Name       string             `json:"name"`
+	Address    string             `json:"address"`
+	Token      string             `json:"token"`
+	Tunnel     bool               `json:"tunnel"`
+	Kubernetes *kubernetes.Config `json:"kubernetes"`
+	Plugins    []plugin.Instance  `json:"plugins"`
 }
 
+// NewHandler is the function, which is used to create the cluster API for an agentless cluster. It is passed to the
+// clusters client, so that the hub must not import the cluster API and all plugins directly.
+type NewHandler func(instances []plugin.Instance, kubernetesClient kubernetes.Client) (http.Handler, error)
+
 type Client interface {
 	GetName() string
 	GetPlugins(ctx context.Context) ([]plugin.Instance, error)
 	GetNamespaces(ctx context.Context) ([]string, error)
 	GetCRDs(ctx context.Context) ([]kubernetes.CRD, error)
+	GetInventory(ctx context.Context) (*inventory.Inventory, error)
 	GetApplications(ctx context.Context) ([]applicationv1.ApplicationSpec, error)
 	GetDashboards(ctx context.Context) ([]dashboardv1.DashboardSpec, error)
 	GetTeams(ctx context.Context) ([]teamv1.TeamSpec, error)
 	GetUsers(ctx context.Context) ([]userv1.UserSpec, error)
 	Request(ctx context.Context, method, url string, body io.Reader) (map[string]any, error)
 	Proxy(w http.ResponseWriter, r *http.Request)
+	Stop()
 }
 
+// tunnelAddress is the address, which is used for all requests to a cluster, which is connected via a tunnel. The host
+// is never resolved, because the connection is always opened through the tunnel.
+const tunnelAddress = "http://tunnel"
+
 type client struct {
 	config     Config
 	httpClient *http.Client
 	proxyURL   *url.URL
+	transport  http.RoundTripper
 	tracer     trace.Tracer
 }
 
@@ -99,6 +124,20 @@ func (c *client) GetCRDs(ctx context.Context) ([]kubernetes.CRD, error) {
 	return res, err
 }
 
+func (c *client) GetInventory(ctx context.Context) (*inventory.Inventory, error) {
+	ctx, span := c.tracer.Start(ctx, "client.GetInventory")
+	span.SetAttributes(attribute.Key("client").String(c.config.Name))
+	defer span.End()
+
+	res, err := doRequest[*inventory.Inventory](ctx, c.httpClient, c.config.Token, http.MethodGet, c.config.Address+"/api/resources/inventory", nil)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return res, err
+}
+
 func (c *client) GetApplications(ctx context.Context) ([]applicationv1.ApplicationSpec, error) {
 	ctx, span := c.tracer.Start(ctx, "client.GetApplications")
 	span.SetAttributes(attribute.Key("client").String(c.config.Name))
@@ -171,6 +210,15 @@ func (c *client) Request(ctx context.Context, method, url string, body io.Reader
 	return res, err
 }
 
+// Stop closes all idle connections of the client. It must be called when the cluster is removed. Only the connections of
+// a transport which is owned by the client are closed, because all other clients are sharing the default round tripper
+// and closing its idle connections would affect all clusters.
+func (c *client) Stop() {
+	if transport, ok := c.transport.(*http.Transport); ok {
+		transport.CloseIdleConnections()
+	}
+}
+
 func (c *client) Proxy(w http.ResponseWriter, r *http.Request) {
 	ctx, span := c.tracer.Start(r.Context(), "client.Proxy")
 	span.SetAttributes(attribute.Key("client").String(c.config.Name))
@@ -178,6 +226,7 @@ func (c *client) Proxy(w http.ResponseWriter, r *http.Request) {
 
 	proxy := httputil.NewSingleHostReverseProxy(c.proxyURL)
 	proxy.FlushInterval = -1
+	proxy.Transport = c.transport
 
 	originalDirector := proxy.Director
 	proxy.Director = func(req *http.Request) {
@@ -211,3 +260,40 @@ func NewClient(config Config) (Client, error) {
 		tracer:   otel.Tracer("client"),
 	}, nil
 }
+
+// NewTunnelClient returns a new client for a cluster, which is connected to the hub via a tunnel. The client is the same
+// as for all other clusters, but all connections are opened through the tunnel of the cluster via the given dial
+// function. Since the transport is also used for the proxy, this also works for WebSocket connections.
+//
+// The token of the cluster is required, because it is used to authenticate the cluster when it opens the tunnel.
+func NewTunnelClient(config Config, dial func(ctx context.Context, name string) (net.Conn, error)) (Client, error) {
+	if config.Token == "" {
+		return nil, fmt.Errorf("token is required for tunnel cluster %s", config.Name)
+	}
+
+	proxyURL, err := url.Parse(tunnelAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	config.Address = tunnelAddress
+
+	// We disable keep-alives, because opening a new stream in the tunnel is cheap and we do not have to care about
+	// idle connections, which are belonging to an old tunnel after the cluster reconnects.
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return dial(ctx, config.Name)
+		},
+		DisableKeepAlives: true,
+	}
+
+	return &client{
+		config: config,
+		httpClient: &http.Client{
+			Transport: otelhttp.NewTransport(transport),
+		},
+		proxyURL:  proxyURL,
+		transport: transport,
+		tracer:    otel.Tracer("client"),
+	}, nil
+}
@@ -16,6 +16,7 @@ import (
 	v10 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
 	v11 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/team/v1"
 	v12 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/user/v1"
+	inventory "github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/inventory"
 	plugin "github.com/kobsio/kobs/pkg/plugins/plugin"
 )
 
@@ -87,6 +88,21 @@ func (mr *MockClientMockRecorder) GetDashboards(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDashboards", reflect.TypeOf((*MockClient)(nil).GetDashboards), ctx)
 }
 
+// GetInventory mocks base method.
+func (m *MockClient) GetInventory(ctx context.Context) (*inventory.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventory", ctx)
+	ret0, _ := ret[0].(*inventory.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInventory indicates an expected call of GetInventory.
+func (mr *MockClientMockRecorder) GetInventory(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventory", reflect.TypeOf((*MockClient)(nil).GetInventory), ctx)
+}
+
 // GetName mocks base method.
 func (m *MockClient) GetName() string {
 	m.ctrl.T.Helper()
@@ -187,3 +203,15 @@ func (mr *MockClientMockRecorder) Request(ctx, method, url, body interface{}) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Request", reflect.TypeOf((*MockClient)(nil).Request), ctx, method, url, body)
 }
+
+// Stop mocks base method.
+func (m *MockClient) Stop() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Stop")
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockClientMockRecorder) Stop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockClient)(nil).Stop))
+}
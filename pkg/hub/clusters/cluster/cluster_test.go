@@ -3,8 +3,10 @@ package cluster
 import (
 	"context"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -54,6 +56,17 @@ func TestGetCRDs(t *testing.T) {
 	require.Empty(t, crds)
 }
 
+func TestGetInventory(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	client, _ := NewClient(Config{Address: ts.URL})
+
+	inventory, err := client.GetInventory(context.Background())
+	require.Error(t, err)
+	require.Nil(t, inventory)
+}
+
 func TestGetApplications(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
 	defer ts.Close()
@@ -189,3 +202,70 @@ func TestNewClient(t *testing.T) {
 		require.NotEmpty(t, client)
 	})
 }
+
+func TestNewTunnelClient(t *testing.T) {
+	t.Run("should fail for empty token", func(t *testing.T) {
+		client, err := NewTunnelClient(Config{Name: "dev-de1", Tunnel: true}, nil)
+		require.Error(t, err)
+		require.Nil(t, client)
+	})
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"host": "` + r.Host + `", "authorization": "` + r.Header.Get("Authorization") + `"}`))
+	}))
+	defer ts.Close()
+
+	var dialedName string
+	client, err := NewTunnelClient(Config{Name: "dev-de1", Token: "token", Tunnel: true}, func(ctx context.Context, name string) (net.Conn, error) {
+		dialedName = name
+		return net.Dial("tcp", ts.Listener.Addr().String())
+	})
+	require.NoError(t, err)
+
+	t.Run("should send request through tunnel", func(t *testing.T) {
+		res, err := client.Request(context.Background(), http.MethodGet, "/api/resources", nil)
+		require.NoError(t, err)
+		require.Equal(t, "dev-de1", dialedName)
+		require.Equal(t, map[string]any{"host": "tunnel", "authorization": "Bearer token"}, res)
+	})
+
+	t.Run("should proxy request through tunnel", func(t *testing.T) {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/resources", nil)
+		w := httptest.NewRecorder()
+
+		client.Proxy(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.JSONEq(t, `{"host": "tunnel", "authorization": "Bearer token"}`, w.Body.String())
+	})
+}
+
+func TestStop(t *testing.T) {
+	var connections atomic.Int32
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`null`))
+	}))
+	ts.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	ts.Start()
+	defer ts.Close()
+
+	client1, err := NewClient(Config{Name: "dev-de1", Address: ts.URL})
+	require.NoError(t, err)
+
+	client2, err := NewClient(Config{Name: "dev-de2", Address: ts.URL})
+	require.NoError(t, err)
+
+	_, err = client1.Request(context.Background(), http.MethodGet, "/api/resources", nil)
+	require.NoError(t, err)
+
+	client2.Stop()
+
+	_, err = client1.Request(context.Background(), http.MethodGet, "/api/resources", nil)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), connections.Load())
+}
@@ -3,26 +3,65 @@ package clusters
 //go:generate mockgen -source=clusters.go -destination=./clusters_mock.go -package=clusters Client
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
 	cluster "github.com/kobsio/kobs/pkg/hub/clusters/cluster"
+	"github.com/kobsio/kobs/pkg/hub/db"
+	"github.com/kobsio/kobs/pkg/instrument/log"
+	"github.com/kobsio/kobs/pkg/utils/tunnel"
+
+	"go.uber.org/zap"
 )
 
+// RefreshInterval is the interval in which the clusters, which are registered via the API, are reloaded from the
+// database.
+const RefreshInterval = 30 * time.Second
+
 type Config []cluster.Config
 
 type Client interface {
 	GetClusters() []cluster.Client
 	GetCluster(name string) cluster.Client
+	IsConfigured(name string) bool
+	Refresh(ctx context.Context) error
+	Watch()
+	ServeTunnel(w http.ResponseWriter, r *http.Request)
+}
+
+// registeredCluster is a cluster, which was registered via the API, together with the client for the cluster. We keep
+// the configuration, so that we only have to create a new client when the configuration of the cluster was changed.
+type registeredCluster struct {
+	config cluster.Config
+	client cluster.Client
 }
 
+// client implements the Client interface. It contains the clusters from the configuration file, which can not be
+// changed during runtime and the clusters which were registered via the API. The registered clusters are loaded from
+// the database via the Refresh method.
 type client struct {
-	clusters []cluster.Client
+	mu                 sync.RWMutex
+	clusters           []cluster.Client
+	configuredClusters []cluster.Client
+	configuredTokens   map[string]string
+	registeredClusters map[string]registeredCluster
+	newHandler         cluster.NewHandler
+	tunnelServer       tunnel.Server
+	dbClient           db.Client
 }
 
 func (c *client) GetClusters() []cluster.Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	return c.clusters
 }
 
 func (c *client) GetCluster(name string) cluster.Client {
-	for _, cluster := range c.clusters {
+	for _, cluster := range c.GetClusters() {
 		if cluster.GetName() == name {
 			return cluster
 		}
@@ -31,19 +70,172 @@ func (c *client) GetCluster(name string) cluster.Client {
 	return nil
 }
 
-func NewClient(config Config) (Client, error) {
-	var clusters []cluster.Client
+// IsConfigured returns true when the cluster with the given name is defined in the configuration file. These clusters
+// can not be managed via the API.
+func (c *client) IsConfigured(name string) bool {
+	for _, cluster := range c.configuredClusters {
+		if cluster.GetName() == name {
+			return true
+		}
+	}
 
-	for _, satelliteConfig := range config {
-		clusterClient, err := cluster.NewClient(satelliteConfig)
+	return false
+}
+
+// Refresh loads all registered clusters from the database. A new client is created for all clusters which were added
+// or where the configuration was changed. Clusters which were removed or disabled are removed from the list of
+// clusters. If a registered cluster has the same name as a cluster from the configuration file, it is ignored.
+func (c *client) Refresh(ctx context.Context) error {
+	if c.dbClient == nil {
+		return nil
+	}
+
+	dbClusters, err := c.dbClient.GetClusters(ctx)
+	if err != nil {
+		log.Error(ctx, "Failed to get clusters", zap.Error(err))
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tunnelTokens := make(map[string]string)
+	for name, token := range c.configuredTokens {
+		tunnelTokens[name] = token
+	}
+
+	registeredClusters := make(map[string]registeredCluster)
+	clusters := append([]cluster.Client{}, c.configuredClusters...)
+
+	for _, dbCluster := range dbClusters {
+		if dbCluster.Disabled || c.IsConfigured(dbCluster.Name) {
+			continue
+		}
+
+		config := cluster.Config{
+			Name:    dbCluster.Name,
+			Address: dbCluster.Address,
+			Token:   dbCluster.Token,
+			Tunnel:  dbCluster.Tunnel,
+		}
+
+		if config.Tunnel {
+			tunnelTokens[config.Name] = config.Token
+		}
+
+		if existingCluster, ok := c.registeredClusters[config.Name]; ok && existingCluster.config.Address == config.Address && existingCluster.config.Token == config.Token && existingCluster.config.Tunnel == config.Tunnel {
+			registeredClusters[config.Name] = existingCluster
+			clusters = append(clusters, existingCluster.client)
+			continue
+		}
+
+		clusterClient, err := c.newClusterClient(config)
 		if err != nil {
-			return nil, err
+			log.Error(ctx, "Failed to create client for registered cluster", zap.Error(err), zap.String("cluster", config.Name))
+			continue
 		}
 
+		log.Info(ctx, "Registered cluster was added or updated", zap.String("cluster", config.Name))
+		registeredClusters[config.Name] = registeredCluster{config: config, client: clusterClient}
 		clusters = append(clusters, clusterClient)
 	}
 
-	return &client{
-		clusters: clusters,
-	}, nil
+	// The clients of all clusters which were removed or replaced by a new client must be stopped, so that they do not
+	// leak any resources.
+	for name, existingCluster := range c.registeredClusters {
+		newCluster, ok := registeredClusters[name]
+		if !ok {
+			log.Info(ctx, "Registered cluster was removed", zap.String("cluster", name))
+		}
+		if !ok || newCluster.client != existingCluster.client {
+			existingCluster.client.Stop()
+		}
+	}
+
+	c.tunnelServer.SetTokens(tunnelTokens)
+	c.registeredClusters = registeredClusters
+	c.clusters = clusters
+
+	return nil
+}
+
+// Watch refreshes the registered clusters in the RefreshInterval. This should be called in a new go routine.
+func (c *client) Watch() {
+	ticker := time.NewTicker(RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		<-ticker.C
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		c.Refresh(ctx)
+		cancel()
+	}
+}
+
+// ServeTunnel accepts a new tunnel from a cluster. The tunnel can only be opened by clusters, where the "tunnel" option
+// is set.
+func (c *client) ServeTunnel(w http.ResponseWriter, r *http.Request) {
+	c.tunnelServer.ServeHTTP(w, r)
+}
+
+// newClusterClient creates a new client for the given cluster configuration. When the "kubernetes" field is set for a
+// cluster we create an agentless client, which uses the newHandler function to serve the cluster API in-process. When
+// the "tunnel" field is set, we create a client which sends all requests through the tunnel opened by the kobs cluster.
+// Otherwise we create a client, which sends all requests to the kobs cluster at the configured address.
+func (c *client) newClusterClient(config cluster.Config) (cluster.Client, error) {
+	if config.Name == db.UserDashboardsCluster {
+		return nil, fmt.Errorf("cluster name %s is reserved", config.Name)
+	}
+
+	if config.Kubernetes != nil {
+		if c.newHandler == nil {
+			return nil, fmt.Errorf("agentless cluster %s is not supported", config.Name)
+		}
+
+		return cluster.NewAgentlessClient(config, c.newHandler)
+	}
+
+	if config.Tunnel {
+		return cluster.NewTunnelClient(config, c.tunnelServer.Dial)
+	}
+
+	return cluster.NewClient(config)
+}
+
+// NewClient returns a new clusters client for the given configuration. Besides the clusters from the configuration, the
+// client also contains all clusters which were registered via the API and which are saved in the database. If the
+// dbClient is nil only the clusters from the configuration are used.
+func NewClient(config Config, newHandler cluster.NewHandler, dbClient db.Client) (Client, error) {
+	c := &client{
+		configuredTokens:   make(map[string]string),
+		registeredClusters: make(map[string]registeredCluster),
+		newHandler:         newHandler,
+		dbClient:           dbClient,
+	}
+
+	for _, clusterConfig := range config {
+		if clusterConfig.Tunnel {
+			c.configuredTokens[clusterConfig.Name] = clusterConfig.Token
+		}
+	}
+	c.tunnelServer = tunnel.NewServer(c.configuredTokens)
+
+	for _, clusterConfig := range config {
+		clusterClient, err := c.newClusterClient(clusterConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		c.configuredClusters = append(c.configuredClusters, clusterClient)
+	}
+	c.clusters = c.configuredClusters
+
+	// A failure to load the registered clusters should not prevent the start of the hub or watcher, because the
+	// clusters are loaded again in the next refresh.
+	if err := c.Refresh(context.Background()); err != nil {
+		log.Warn(context.Background(), "Failed to load registered clusters", zap.Error(err))
+	}
+
+	return c, nil
 }
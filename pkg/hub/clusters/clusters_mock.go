@@ -5,6 +5,8 @@
 package clusters
 
 import (
+	context "context"
+	http "net/http"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -61,3 +63,55 @@ func (mr *MockClientMockRecorder) GetClusters() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusters", reflect.TypeOf((*MockClient)(nil).GetClusters))
 }
+
+// IsConfigured mocks base method.
+func (m *MockClient) IsConfigured(name string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsConfigured", name)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsConfigured indicates an expected call of IsConfigured.
+func (mr *MockClientMockRecorder) IsConfigured(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsConfigured", reflect.TypeOf((*MockClient)(nil).IsConfigured), name)
+}
+
+// Refresh mocks base method.
+func (m *MockClient) Refresh(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockClientMockRecorder) Refresh(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockClient)(nil).Refresh), ctx)
+}
+
+// ServeTunnel mocks base method.
+func (m *MockClient) ServeTunnel(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ServeTunnel", w, r)
+}
+
+// ServeTunnel indicates an expected call of ServeTunnel.
+func (mr *MockClientMockRecorder) ServeTunnel(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServeTunnel", reflect.TypeOf((*MockClient)(nil).ServeTunnel), w, r)
+}
+
+// Watch mocks base method.
+func (m *MockClient) Watch() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Watch")
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockClientMockRecorder) Watch() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockClient)(nil).Watch))
+}
@@ -1,8 +1,17 @@
 package clusters
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes"
+	"github.com/kobsio/kobs/pkg/hub/clusters/cluster"
+	"github.com/kobsio/kobs/pkg/hub/db"
+
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -13,7 +22,7 @@ var testConfig = Config{{
 }}
 
 func TestGetClusters(t *testing.T) {
-	client, _ := NewClient(testConfig)
+	client, _ := NewClient(testConfig, nil, nil)
 	clusters := client.GetClusters()
 
 	require.NotEmpty(t, clusters)
@@ -21,7 +30,7 @@ func TestGetClusters(t *testing.T) {
 }
 
 func TestGetAddress(t *testing.T) {
-	client, _ := NewClient(testConfig)
+	client, _ := NewClient(testConfig, nil, nil)
 
 	t.Run("cluster found", func(t *testing.T) {
 		cluster := client.GetCluster("foobar")
@@ -36,13 +45,116 @@ func TestGetAddress(t *testing.T) {
 
 func TestNewClient(t *testing.T) {
 	t.Run("create new client fails", func(t *testing.T) {
-		_, err := NewClient(Config{{Address: " http://localhost:15221"}})
+		_, err := NewClient(Config{{Address: " http://localhost:15221"}}, nil, nil)
+		require.Error(t, err)
+	})
+
+	t.Run("create new agentless client fails without handler", func(t *testing.T) {
+		_, err := NewClient(Config{{Name: "agentless", Kubernetes: &kubernetes.Config{}}}, nil, nil)
 		require.Error(t, err)
 	})
 
+	t.Run("create new client fails for reserved name", func(t *testing.T) {
+		_, err := NewClient(Config{{Name: db.UserDashboardsCluster, Address: "http://localhost:15221"}}, nil, nil)
+		require.EqualError(t, err, "cluster name @hub is reserved")
+	})
+
 	t.Run("create new client succeeds", func(t *testing.T) {
-		client, err := NewClient(Config{{Address: "http://localhost:15221"}})
+		client, err := NewClient(Config{{Address: "http://localhost:15221"}}, nil, nil)
 		require.NoError(t, err)
 		require.NotEmpty(t, client)
 	})
 }
+
+func TestServeTunnel(t *testing.T) {
+	client, err := NewClient(Config{{Name: "tunnel", Token: "token", Tunnel: true}}, nil, nil)
+	require.NoError(t, err)
+	require.NotNil(t, client.GetCluster("tunnel"))
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/tunnel", nil)
+	req.Header.Set("x-kobs-cluster", "tunnel")
+	req.Header.Set("Authorization", "Bearer invalid")
+	w := httptest.NewRecorder()
+
+	client.ServeTunnel(w, req)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestRefresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	dbClient := db.NewMockClient(ctrl)
+
+	dbClient.EXPECT().GetClusters(gomock.Any()).Return(nil, fmt.Errorf("unexpected error"))
+	client, err := NewClient(testConfig, nil, dbClient)
+	require.NoError(t, err)
+	require.Len(t, client.GetClusters(), 1)
+
+	t.Run("should add registered clusters", func(t *testing.T) {
+		dbClient.EXPECT().GetClusters(gomock.Any()).Return([]db.Cluster{
+			{Name: "foobar", Address: "http://localhost:15222"},
+			{Name: "dev-de1", Address: "http://localhost:15221"},
+			{Name: "dev-de2", Address: "http://localhost:15221", Disabled: true},
+			{Name: "dev-de3", Tunnel: true, Token: "token"},
+		}, nil)
+
+		require.NoError(t, client.Refresh(context.Background()))
+
+		var names []string
+		for _, cluster := range client.GetClusters() {
+			names = append(names, cluster.GetName())
+		}
+		require.Equal(t, []string{"foobar", "dev-de1", "dev-de3"}, names)
+		require.True(t, client.IsConfigured("foobar"))
+		require.False(t, client.IsConfigured("dev-de1"))
+	})
+
+	t.Run("should keep unchanged clusters and remove deleted clusters", func(t *testing.T) {
+		existingCluster := client.GetCluster("dev-de1")
+
+		dbClient.EXPECT().GetClusters(gomock.Any()).Return([]db.Cluster{
+			{Name: "dev-de1", Address: "http://localhost:15221"},
+		}, nil)
+
+		require.NoError(t, client.Refresh(context.Background()))
+		require.Len(t, client.GetClusters(), 2)
+		require.Same(t, existingCluster, client.GetCluster("dev-de1"))
+		require.Nil(t, client.GetCluster("dev-de3"))
+	})
+
+	t.Run("should return error", func(t *testing.T) {
+		dbClient.EXPECT().GetClusters(gomock.Any()).Return(nil, fmt.Errorf("unexpected error"))
+		require.Error(t, client.Refresh(context.Background()))
+		require.Len(t, client.GetClusters(), 2)
+	})
+}
+
+func TestRefreshStopsClusters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	dbClient := db.NewMockClient(ctrl)
+
+	dbClient.EXPECT().GetClusters(gomock.Any()).Return(nil, nil)
+	clustersClient, err := NewClient(testConfig, nil, dbClient)
+	require.NoError(t, err)
+
+	removedCluster := cluster.NewMockClient(ctrl)
+	removedCluster.EXPECT().Stop()
+	replacedCluster := cluster.NewMockClient(ctrl)
+	replacedCluster.EXPECT().Stop()
+	unchangedCluster := cluster.NewMockClient(ctrl)
+	unchangedCluster.EXPECT().GetName().Return("dev-de1").AnyTimes()
+
+	c := clustersClient.(*client)
+	c.registeredClusters["dev-de1"] = registeredCluster{config: cluster.Config{Name: "dev-de1", Address: "http://localhost:15221"}, client: unchangedCluster}
+	c.registeredClusters["dev-de2"] = registeredCluster{config: cluster.Config{Name: "dev-de2", Address: "http://localhost:15221"}, client: removedCluster}
+	c.registeredClusters["dev-de3"] = registeredCluster{config: cluster.Config{Name: "dev-de3", Address: "http://localhost:15221"}, client: replacedCluster}
+
+	dbClient.EXPECT().GetClusters(gomock.Any()).Return([]db.Cluster{
+		{Name: "dev-de1", Address: "http://localhost:15221"},
+		{Name: "dev-de3", Address: "http://localhost:15222"},
+	}, nil)
+
+	require.NoError(t, clustersClient.Refresh(context.Background()))
+	require.Len(t, clustersClient.GetClusters(), 3)
+	require.Same(t, unchangedCluster, clustersClient.GetCluster("dev-de1"))
+	require.NotSame(t, replacedCluster, clustersClient.GetCluster("dev-de3"))
+}
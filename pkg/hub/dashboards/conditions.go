@@ -0,0 +1,232 @@
+package dashboards
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	dashboardv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
+
+	"github.com/google/cel-go/cel"
+)
+
+var (
+	// ErrUnresolvedVariable is returned when a condition uses a variable, for which we do not have a value. This is the
+	// case for variables which are resolved in the frontend, e.g. variables which are using a plugin.
+	ErrUnresolvedVariable = fmt.Errorf("condition uses an unresolved variable")
+
+	// conditionVariable matches a variable in a condition. Variables must have the same form as in all other fields of
+	// a dashboard, e.g. "{% .name %}".
+	conditionVariable = regexp.MustCompile(`^\{%\s*\.([a-zA-Z0-9_\-]+)\s*%\}`)
+)
+
+// conditionCostLimit is the maximum cost for the evaluation of a condition, so that a condition can not block the
+// evaluation of a dashboard.
+const conditionCostLimit = 10000
+
+// renderCondition replaces all variables in the provided condition and returns a CEL expression together with the
+// values of the CEL variables used in the expression, so that a value can not change the expression itself: Variables
+// within a string literal (e.g. "{% .name %}") are escaped, all other variables are replaced with a CEL variable, which
+// has the type of the value (see conditionValue). The "===" and "!==" operators are replaced with "==" and "!=", so
+// that existing conditions written in JavaScript are still working. If a variable is missing in the provided values
+// ErrUnresolvedVariable is returned. If the values are nil, all variables are replaced with an empty string, which can
+// be used to validate a condition.
+func renderCondition(condition string, values map[string]string) (string, map[string]any, error) {
+	var expression strings.Builder
+	var quote byte
+	identifiers := make(map[string]string)
+	variables := make(map[string]any)
+
+	for i := 0; i < len(condition); {
+		if match := conditionVariable.FindStringSubmatch(condition[i:]); match != nil {
+			value, ok := values[match[1]]
+			if !ok && values != nil {
+				return "", nil, ErrUnresolvedVariable
+			}
+
+			if quote == 0 {
+				identifier, ok := identifiers[match[1]]
+				if !ok {
+					identifier = fmt.Sprintf("_v%d", len(identifiers))
+					identifiers[match[1]] = identifier
+					variables[identifier] = conditionValue(value)
+				}
+
+				expression.WriteString(identifier)
+			} else {
+				expression.WriteString(escapeStringLiteral(value, quote))
+			}
+
+			i = i + len(match[0])
+			continue
+		}
+
+		c := condition[i]
+
+		switch {
+		case quote != 0 && c == '\\' && i+1 < len(condition):
+			expression.WriteString(condition[i : i+2])
+			i = i + 2
+			continue
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == 0 && (strings.HasPrefix(condition[i:], "===") || strings.HasPrefix(condition[i:], "!==")):
+			expression.WriteString(condition[i : i+2])
+			i = i + 3
+			continue
+		}
+
+		expression.WriteByte(c)
+		i = i + 1
+	}
+
+	return expression.String(), variables, nil
+}
+
+// conditionValue converts the provided value of a variable, so that it can be used as value of a CEL variable.
+// Integers, floats and the values "true" and "false" are converted to the corresponding type, so that they can be used
+// in comparisons, e.g. "{% .replicas %} > 1". All other values are used as string.
+func conditionValue(value string) any {
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return i
+	}
+
+	if f, err := strconv.ParseFloat(value, 64); err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+		return f
+	}
+
+	if value == "true" || value == "false" {
+		return value == "true"
+	}
+
+	return value
+}
+
+// escapeStringLiteral escapes the provided value, so that it can be used within a CEL string literal, which uses the
+// provided quote character.
+func escapeStringLiteral(value string, quote byte) string {
+	quoted := strconv.Quote(value)
+	quoted = quoted[1 : len(quoted)-1]
+
+	if quote == '\'' {
+		quoted = strings.ReplaceAll(quoted, `'`, `\'`)
+	}
+
+	return quoted
+}
+
+// compileCondition compiles the provided CEL expression. The provided variables are declared with the dynamic type,
+// because the type of a variable depends on its value. The expression must return a bool.
+func compileCondition(expression string, variables map[string]any) (cel.Program, error) {
+	options := []cel.EnvOption{cel.CrossTypeNumericComparisons(true)}
+	for identifier := range variables {
+		options = append(options, cel.Variable(identifier, cel.DynType))
+	}
+
+	env, err := cel.NewEnv(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, fmt.Errorf("expression must return a bool, got %s", ast.OutputType())
+	}
+
+	return env.Program(ast, cel.CostLimit(conditionCostLimit))
+}
+
+// ValidateCondition checks that the provided condition is a valid expression, which returns a bool. All variables in
+// the condition are replaced with an empty string for the validation.
+func ValidateCondition(condition string) error {
+	expression, variables, err := renderCondition(condition, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = compileCondition(expression, variables)
+	return err
+}
+
+// EvaluateCondition evaluates the provided condition with the provided variable values and returns the result.
+func EvaluateCondition(condition string, values map[string]string) (bool, error) {
+	expression, variables, err := renderCondition(condition, values)
+	if err != nil {
+		return false, err
+	}
+
+	program, err := compileCondition(expression, variables)
+	if err != nil {
+		return false, err
+	}
+
+	out, _, err := program.Eval(variables)
+	if err != nil {
+		return false, err
+	}
+
+	result, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression must return a bool, got %T", out.Value())
+	}
+
+	return result, nil
+}
+
+// EvaluateRows evaluates the conditions of the provided rows and returns all rows which should be shown together with
+// a list of problems for invalid conditions. The condition of a shown row is removed, so that it isn't evaluated again
+// in the frontend. Rows with a condition which uses an unresolved variable are returned unchanged, so that the
+// condition can be evaluated in the frontend. Rows with an invalid condition are also returned unchanged, because the
+// frontend evaluated conditions as JavaScript before and not every JavaScript expression is a valid CEL expression.
+func EvaluateRows(rows []dashboardv1.Row, values map[string]string) ([]dashboardv1.Row, []string) {
+	var filteredRows []dashboardv1.Row
+	var problems []string
+
+	for i, row := range rows {
+		if row.If == "" {
+			filteredRows = append(filteredRows, row)
+			continue
+		}
+
+		show, err := EvaluateCondition(row.If, values)
+		if err != nil {
+			if err != ErrUnresolvedVariable {
+				problems = append(problems, fmt.Sprintf("row %d: invalid condition %q: %s", i, row.If, err.Error()))
+			}
+
+			filteredRows = append(filteredRows, row)
+			continue
+		}
+
+		if show {
+			row.If = ""
+			filteredRows = append(filteredRows, row)
+		}
+	}
+
+	return filteredRows, problems
+}
+
+// GetValues returns the values of the placeholders of a dashboard. If a value for a placeholder is not provided, the
+// default value of the placeholder is used.
+func GetValues(placeholders []dashboardv1.Placeholder, placeholderValues map[string]string) map[string]string {
+	values := make(map[string]string)
+
+	for _, placeholder := range placeholders {
+		values[placeholder.Name] = placeholder.Default
+	}
+
+	for key, value := range placeholderValues {
+		values[key] = value
+	}
+
+	return values
+}
@@ -0,0 +1,107 @@
+package dashboards
+
+import (
+	"testing"
+
+	dashboardv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderCondition(t *testing.T) {
+	for _, tt := range []struct {
+		name               string
+		condition          string
+		values             map[string]string
+		expectedExpression string
+		expectedVariables  map[string]any
+		expectedErr        error
+	}{
+		{name: "should replace variable in double quotes", condition: `"{% .name %}" != ""`, values: map[string]string{"name": "foo"}, expectedExpression: `"foo" != ""`, expectedVariables: map[string]any{}},
+		{name: "should replace variable in single quotes", condition: `'{% .name %}' == 'foo'`, values: map[string]string{"name": "foo"}, expectedExpression: `'foo' == 'foo'`, expectedVariables: map[string]any{}},
+		{name: "should replace variable without quotes", condition: `{% .name %} == "foo"`, values: map[string]string{"name": "foo"}, expectedExpression: `_v0 == "foo"`, expectedVariables: map[string]any{"_v0": "foo"}},
+		{name: "should replace variables with typed values", condition: `{% .replicas %} > 1 && {% .ratio %} < 0.5 && {% .enabled %} && {% .replicas %} != {% .name %}`, values: map[string]string{"replicas": "3", "ratio": "0.25", "enabled": "true", "name": "foo"}, expectedExpression: `_v0 > 1 && _v1 < 0.5 && _v2 && _v0 != _v3`, expectedVariables: map[string]any{"_v0": int64(3), "_v1": 0.25, "_v2": true, "_v3": "foo"}},
+		{name: "should escape double quotes", condition: `"{% .name %}" == ""`, values: map[string]string{"name": `" || true || "`}, expectedExpression: `"\" || true || \"" == ""`, expectedVariables: map[string]any{}},
+		{name: "should escape single quotes", condition: `'{% .name %}' == ''`, values: map[string]string{"name": `' || true || '`}, expectedExpression: `'\' || true || \'' == ''`, expectedVariables: map[string]any{}},
+		{name: "should replace javascript operators", condition: `"{% .name %}" !== "" && "{% .name %}" === "==="`, values: map[string]string{"name": "foo"}, expectedExpression: `"foo" != "" && "foo" == "==="`, expectedVariables: map[string]any{}},
+		{name: "should replace variables with empty string", condition: `"{% .name %}" != ""`, values: nil, expectedExpression: `"" != ""`, expectedVariables: map[string]any{}},
+		{name: "should return error for unresolved variable", condition: `"{% .name %}" != ""`, values: map[string]string{}, expectedErr: ErrUnresolvedVariable},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			expression, variables, err := renderCondition(tt.condition, tt.values)
+			require.Equal(t, tt.expectedErr, err)
+			require.Equal(t, tt.expectedExpression, expression)
+			require.Equal(t, tt.expectedVariables, variables)
+		})
+	}
+}
+
+func TestValidateCondition(t *testing.T) {
+	require.NoError(t, ValidateCondition(`"{% .name %}" !== ""`))
+	require.NoError(t, ValidateCondition(`"{% .name %}".startsWith("foo") || size("{% .name %}") > 3`))
+	require.NoError(t, ValidateCondition(`{% .replicas %} > 1`))
+	require.EqualError(t, ValidateCondition(`"{% .name %}"`), "expression must return a bool, got string")
+	require.Error(t, ValidateCondition(`"{% .name %}" !=`))
+	require.Error(t, ValidateCondition(`window.alert("{% .name %}")`))
+}
+
+func TestEvaluateCondition(t *testing.T) {
+	result, err := EvaluateCondition(`"{% .name %}" !== ""`, map[string]string{"name": "foo"})
+	require.NoError(t, err)
+	require.True(t, result)
+
+	result, err = EvaluateCondition(`"{% .name %}" !== ""`, map[string]string{"name": ""})
+	require.NoError(t, err)
+	require.False(t, result)
+
+	result, err = EvaluateCondition(`int("{% .replicas %}") > 1`, map[string]string{"replicas": "3"})
+	require.NoError(t, err)
+	require.True(t, result)
+
+	_, err = EvaluateCondition(`int("{% .replicas %}") > 1`, map[string]string{"replicas": "three"})
+	require.Error(t, err)
+
+	result, err = EvaluateCondition(`{% .replicas %} > 1`, map[string]string{"replicas": "3"})
+	require.NoError(t, err)
+	require.True(t, result)
+
+	result, err = EvaluateCondition(`{% .replicas %} > 1`, map[string]string{"replicas": "1"})
+	require.NoError(t, err)
+	require.False(t, result)
+
+	result, err = EvaluateCondition(`{% .ratio %} > 1`, map[string]string{"ratio": "1.5"})
+	require.NoError(t, err)
+	require.True(t, result)
+
+	result, err = EvaluateCondition(`{% .enabled %} && {% .name %} == "foo"`, map[string]string{"enabled": "true", "name": "foo"})
+	require.NoError(t, err)
+	require.True(t, result)
+
+	result, err = EvaluateCondition(`{% .name %} == "foo"`, map[string]string{"name": "foo || true"})
+	require.NoError(t, err)
+	require.False(t, result)
+}
+
+func TestEvaluateRows(t *testing.T) {
+	rows, problems := EvaluateRows([]dashboardv1.Row{
+		{Title: "row1"},
+		{Title: "row2", If: `"{% .name %}" !== ""`},
+		{Title: "row3", If: `"{% .name %}" === ""`},
+		{Title: "row4", If: `"{% .variable %}" === ""`},
+		{Title: "row5", If: `"{% .name %}" ===`},
+	}, map[string]string{"name": "foo"})
+
+	require.Equal(t, []dashboardv1.Row{
+		{Title: "row1"},
+		{Title: "row2"},
+		{Title: "row4", If: `"{% .variable %}" === ""`},
+		{Title: "row5", If: `"{% .name %}" ===`},
+	}, rows)
+	require.Len(t, problems, 1)
+	require.Contains(t, problems[0], `row 4: invalid condition "\"{% .name %}\" ===":`)
+}
+
+func TestGetValues(t *testing.T) {
+	values := GetValues([]dashboardv1.Placeholder{{Name: "namespace", Default: "default"}, {Name: "name"}}, map[string]string{"name": "foo", "other": "bar"})
+	require.Equal(t, map[string]string{"namespace": "default", "name": "foo", "other": "bar"}, values)
+}
@@ -0,0 +1,94 @@
+package dashboards
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	dashboardv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
+	authContext "github.com/kobsio/kobs/pkg/hub/auth/context"
+	"github.com/kobsio/kobs/pkg/hub/db"
+
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	// ErrAccessDenied is returned when a user tries to get a user dashboard, which is not shared with the user.
+	ErrAccessDenied = fmt.Errorf("access denied")
+)
+
+// CanView checks if the provided user is allowed to view the user dashboard. The owner can always view the dashboard,
+// all other users can only view the dashboard when it is public or when it is shared with one of their teams.
+func CanView(user *authContext.User, dashboard *db.UserDashboard) bool {
+	if CanEdit(user, dashboard) || dashboard.Visibility == db.VisibilityPublic {
+		return true
+	}
+
+	if dashboard.Visibility == db.VisibilityTeams {
+		for _, dashboardTeam := range dashboard.Teams {
+			for _, userTeam := range user.Teams {
+				if userTeam == dashboardTeam {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// CanEdit checks if the provided user is allowed to edit or delete the user dashboard. This is only allowed for the
+// owner of the dashboard.
+func CanEdit(user *authContext.User, dashboard *db.UserDashboard) bool {
+	return user.ID != "" && user.ID == dashboard.Owner
+}
+
+// GetByID returns the dashboard with the provided id. Dashboards from the user dashboards cluster are loaded from the
+// user dashboards, all other dashboards are loaded from the dashboards synced by the watcher. If a user is provided, it
+// is checked that the user is allowed to view a user dashboard, if the user is nil (e.g. for reports) the check is
+// skipped.
+func GetByID(ctx context.Context, dbClient db.Client, id string, user *authContext.User) (*dashboardv1.DashboardSpec, error) {
+	if !strings.HasPrefix(id, fmt.Sprintf("/cluster/%s/", db.UserDashboardsCluster)) {
+		return dbClient.GetDashboardByID(ctx, id)
+	}
+
+	dashboard, err := dbClient.GetUserDashboardByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if user != nil && !CanView(user, dashboard) {
+		return nil, ErrAccessDenied
+	}
+
+	return &dashboard.DashboardSpec, nil
+}
+
+// Export returns the provided dashboard as Dashboard CR in YAML format, which can be applied to a Kubernetes cluster.
+// All fields which are set by kobs (e.g. the id or the problems of a dashboard) are removed from the spec.
+func Export(dashboard *dashboardv1.DashboardSpec) ([]byte, error) {
+	spec := *dashboard
+	spec.ID = ""
+	spec.UpdatedAt = 0
+	spec.Cluster = ""
+	spec.Namespace = ""
+	spec.Name = ""
+	spec.Problems = nil
+
+	type metadata struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	}
+
+	return yaml.Marshal(struct {
+		APIVersion string                    `json:"apiVersion"`
+		Kind       string                    `json:"kind"`
+		Metadata   metadata                  `json:"metadata"`
+		Spec       dashboardv1.DashboardSpec `json:"spec"`
+	}{
+		APIVersion: "kobs.io/v1",
+		Kind:       "Dashboard",
+		Metadata:   metadata{Name: dashboard.Name, Namespace: dashboard.Namespace},
+		Spec:       spec,
+	})
+}
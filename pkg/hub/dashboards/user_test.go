@@ -0,0 +1,102 @@
+package dashboards
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	dashboardv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
+	authContext "github.com/kobsio/kobs/pkg/hub/auth/context"
+	"github.com/kobsio/kobs/pkg/hub/db"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanView(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		user      authContext.User
+		dashboard db.UserDashboard
+		expected  bool
+	}{
+		{name: "should allow owner", user: authContext.User{ID: "user1"}, dashboard: db.UserDashboard{Owner: "user1", Visibility: db.VisibilityPrivate}, expected: true},
+		{name: "should deny private dashboard", user: authContext.User{ID: "user2"}, dashboard: db.UserDashboard{Owner: "user1", Visibility: db.VisibilityPrivate}, expected: false},
+		{name: "should allow public dashboard", user: authContext.User{ID: "user2"}, dashboard: db.UserDashboard{Owner: "user1", Visibility: db.VisibilityPublic}, expected: true},
+		{name: "should allow team member", user: authContext.User{ID: "user2", Teams: []string{"team1"}}, dashboard: db.UserDashboard{Owner: "user1", Visibility: db.VisibilityTeams, Teams: []string{"team1"}}, expected: true},
+		{name: "should deny other team", user: authContext.User{ID: "user2", Teams: []string{"team2"}}, dashboard: db.UserDashboard{Owner: "user1", Visibility: db.VisibilityTeams, Teams: []string{"team1"}}, expected: false},
+		{name: "should deny empty user", user: authContext.User{}, dashboard: db.UserDashboard{Visibility: db.VisibilityPrivate}, expected: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, CanView(&tt.user, &tt.dashboard))
+		})
+	}
+}
+
+func TestGetByID(t *testing.T) {
+	t.Run("should return dashboard from cr", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetDashboardByID(gomock.Any(), "/cluster/dev/namespace/default/name/test").Return(&dashboardv1.DashboardSpec{Name: "test"}, nil)
+
+		dashboard, err := GetByID(context.Background(), dbClient, "/cluster/dev/namespace/default/name/test", &authContext.User{})
+		require.NoError(t, err)
+		require.Equal(t, "test", dashboard.Name)
+	})
+
+	t.Run("should return error for user dashboard", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetUserDashboardByID(gomock.Any(), "/cluster/@hub/namespace/default/name/test").Return(nil, fmt.Errorf("unexpected error"))
+
+		_, err := GetByID(context.Background(), dbClient, "/cluster/@hub/namespace/default/name/test", &authContext.User{})
+		require.EqualError(t, err, "unexpected error")
+	})
+
+	t.Run("should deny access to user dashboard", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetUserDashboardByID(gomock.Any(), "/cluster/@hub/namespace/default/name/test").Return(&db.UserDashboard{Owner: "user1", Visibility: db.VisibilityPrivate}, nil)
+
+		_, err := GetByID(context.Background(), dbClient, "/cluster/@hub/namespace/default/name/test", &authContext.User{ID: "user2"})
+		require.Equal(t, ErrAccessDenied, err)
+	})
+
+	t.Run("should return user dashboard without user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetUserDashboardByID(gomock.Any(), "/cluster/@hub/namespace/default/name/test").Return(&db.UserDashboard{DashboardSpec: dashboardv1.DashboardSpec{Name: "test"}, Owner: "user1", Visibility: db.VisibilityPrivate}, nil)
+
+		dashboard, err := GetByID(context.Background(), dbClient, "/cluster/@hub/namespace/default/name/test", nil)
+		require.NoError(t, err)
+		require.Equal(t, "test", dashboard.Name)
+	})
+}
+
+func TestExport(t *testing.T) {
+	data, err := Export(&dashboardv1.DashboardSpec{
+		ID:        "/cluster/@hub/namespace/default/name/test",
+		UpdatedAt: 1,
+		Cluster:   "@hub",
+		Namespace: "default",
+		Name:      "test",
+		Title:     "Test",
+		Rows:      []dashboardv1.Row{{Panels: []dashboardv1.Panel{{Title: "Panel", Plugin: dashboardv1.Plugin{Type: "core", Name: "applications"}}}}},
+		Problems:  []string{"problem"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, `apiVersion: kobs.io/v1
+kind: Dashboard
+metadata:
+  name: test
+  namespace: default
+spec:
+  rows:
+  - panels:
+    - plugin:
+        name: applications
+        type: core
+      title: Panel
+  title: Test
+`, string(data))
+}
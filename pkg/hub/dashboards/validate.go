@@ -0,0 +1,176 @@
+package dashboards
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	dashboardv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
+	"github.com/kobsio/kobs/pkg/plugins/plugin"
+
+	"sigs.k8s.io/yaml"
+)
+
+// placeholderTypes are the supported types for a placeholder. An empty type is handled like a string.
+var placeholderTypes = map[string]bool{
+	"":       true,
+	"string": true,
+	"number": true,
+	"object": true,
+}
+
+// coreVariables are the names of the core plugins, which can be used for variables.
+var coreVariables = map[string]bool{
+	"static":      true,
+	"placeholder": true,
+}
+
+// Validate validates a dashboard and returns a list of all problems. It checks that all placeholders and variables
+// have a unique name, that the placeholders have a valid type, that the conditions of all rows are valid expressions
+// and that all variables and panels are using an existing plugin instance. If the provided list of plugins is nil, the
+// plugin instances are not checked.
+func Validate(dashboard *dashboardv1.DashboardSpec, plugins []plugin.Instance) []string {
+	var problems []string
+	names := make(map[string]bool)
+
+	for i, placeholder := range dashboard.Placeholders {
+		if placeholder.Name == "" {
+			problems = append(problems, fmt.Sprintf("placeholder %d: name is missing", i))
+			continue
+		}
+
+		if names[placeholder.Name] {
+			problems = append(problems, fmt.Sprintf("placeholder %q: name is already used", placeholder.Name))
+		}
+		names[placeholder.Name] = true
+
+		if !placeholderTypes[placeholder.Type] {
+			problems = append(problems, fmt.Sprintf("placeholder %q: invalid type %q, must be string, number or object", placeholder.Name, placeholder.Type))
+		} else if placeholder.Default != "" {
+			if err := validateType(placeholder.Type, placeholder.Default); err != nil {
+				problems = append(problems, fmt.Sprintf("placeholder %q: invalid default value: %s", placeholder.Name, err.Error()))
+			}
+		}
+	}
+
+	for i, variable := range dashboard.Variables {
+		if variable.Name == "" {
+			problems = append(problems, fmt.Sprintf("variable %d: name is missing", i))
+			continue
+		}
+
+		if names[variable.Name] {
+			problems = append(problems, fmt.Sprintf("variable %q: name is already used", variable.Name))
+		}
+		names[variable.Name] = true
+
+		if variable.Plugin.Type == "core" {
+			if !coreVariables[variable.Plugin.Name] {
+				problems = append(problems, fmt.Sprintf("variable %q: invalid core plugin %q, must be static or placeholder", variable.Name, variable.Plugin.Name))
+			}
+			continue
+		}
+
+		if plugins != nil && !pluginExists(variable.Plugin, plugins) {
+			problems = append(problems, fmt.Sprintf("variable %q: plugin instance %s does not exist", variable.Name, formatPlugin(variable.Plugin)))
+		}
+	}
+
+	for i, row := range dashboard.Rows {
+		if row.If != "" {
+			if err := ValidateCondition(row.If); err != nil {
+				problems = append(problems, fmt.Sprintf("row %d: invalid condition %q: %s", i, row.If, err.Error()))
+			}
+		}
+	}
+
+	if plugins != nil {
+		for _, row := range dashboard.Rows {
+			for _, panel := range row.Panels {
+				if panel.Plugin.Type != "core" && !pluginExists(panel.Plugin, plugins) {
+					problems = append(problems, fmt.Sprintf("panel %q: plugin instance %s does not exist", panel.Title, formatPlugin(panel.Plugin)))
+				}
+			}
+		}
+	}
+
+	return problems
+}
+
+// ValidateReference validates the placeholders of a reference against the placeholders of the referenced dashboard.
+// All placeholders without a default value are required and the provided values must match the type of the
+// placeholder. Placeholders which are not defined in the dashboard are also reported, because they are most likely a
+// typo in the reference.
+func ValidateReference(reference dashboardv1.Reference, dashboard *dashboardv1.DashboardSpec) []string {
+	var problems []string
+	defined := make(map[string]bool)
+
+	for _, placeholder := range dashboard.Placeholders {
+		defined[placeholder.Name] = true
+
+		value, ok := reference.Placeholders[placeholder.Name]
+		if !ok {
+			if placeholder.Default == "" {
+				problems = append(problems, fmt.Sprintf("placeholder %q: value is required", placeholder.Name))
+			}
+			continue
+		}
+
+		if err := validateType(placeholder.Type, value); err != nil {
+			problems = append(problems, fmt.Sprintf("placeholder %q: %s", placeholder.Name, err.Error()))
+		}
+	}
+
+	var undefined []string
+	for name := range reference.Placeholders {
+		if !defined[name] {
+			undefined = append(undefined, name)
+		}
+	}
+
+	sort.Strings(undefined)
+	for _, name := range undefined {
+		problems = append(problems, fmt.Sprintf("placeholder %q: not defined in the dashboard", name))
+	}
+
+	return problems
+}
+
+// validateType checks if the provided value can be used for a placeholder with the given type. Numbers are inserted
+// without quotes and objects are parsed as YAML, so that invalid values would result in an invalid dashboard.
+func validateType(placeholderType, value string) error {
+	switch placeholderType {
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("value %q is not a number", value)
+		}
+	case "object":
+		var obj any
+		if err := yaml.Unmarshal([]byte(value), &obj); err != nil {
+			return fmt.Errorf("value is not a valid object: %s", err.Error())
+		}
+	}
+
+	return nil
+}
+
+// pluginExists checks if the plugin instance exists in the provided list of plugins. Plugins which are using a
+// variable in their cluster or name are always valid, because we can not know the value of the variable.
+func pluginExists(p dashboardv1.Plugin, plugins []plugin.Instance) bool {
+	if strings.Contains(p.Cluster, "{%") || strings.Contains(p.Name, "{%") {
+		return true
+	}
+
+	for _, instance := range plugins {
+		if instance.Cluster == p.Cluster && instance.Type == p.Type && instance.Name == p.Name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func formatPlugin(p dashboardv1.Plugin) string {
+	return fmt.Sprintf("%s/%s/%s", p.Cluster, p.Type, p.Name)
+}
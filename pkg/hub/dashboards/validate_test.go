@@ -0,0 +1,131 @@
+package dashboards
+
+import (
+	"testing"
+
+	dashboardv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
+	"github.com/kobsio/kobs/pkg/plugins/plugin"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	plugins := []plugin.Instance{{Cluster: "hub", Type: "prometheus", Name: "prometheus"}}
+
+	for _, tt := range []struct {
+		name             string
+		dashboard        dashboardv1.DashboardSpec
+		plugins          []plugin.Instance
+		expectedProblems []string
+	}{
+		{
+			name: "should return no problems for valid dashboard",
+			dashboard: dashboardv1.DashboardSpec{
+				Placeholders: []dashboardv1.Placeholder{{Name: "namespace"}, {Name: "replicas", Type: "number", Default: "1"}},
+				Variables: []dashboardv1.Variable{
+					{Name: "var1", Plugin: dashboardv1.Plugin{Type: "core", Name: "static"}},
+					{Name: "var2", Plugin: dashboardv1.Plugin{Cluster: "hub", Type: "prometheus", Name: "prometheus"}},
+				},
+				Rows: []dashboardv1.Row{{Panels: []dashboardv1.Panel{
+					{Title: "panel1", Plugin: dashboardv1.Plugin{Cluster: "hub", Type: "prometheus", Name: "prometheus"}},
+					{Title: "panel2", Plugin: dashboardv1.Plugin{Type: "core", Name: "resources"}},
+					{Title: "panel3", Plugin: dashboardv1.Plugin{Cluster: "{% .cluster %}", Type: "prometheus", Name: "prometheus"}},
+				}}},
+			},
+			plugins: plugins,
+		},
+		{
+			name: "should return problems for placeholders",
+			dashboard: dashboardv1.DashboardSpec{
+				Placeholders: []dashboardv1.Placeholder{
+					{Name: ""},
+					{Name: "namespace"},
+					{Name: "namespace"},
+					{Name: "replicas", Type: "int"},
+					{Name: "limit", Type: "number", Default: "ten"},
+				},
+			},
+			plugins: plugins,
+			expectedProblems: []string{
+				`placeholder 0: name is missing`,
+				`placeholder "namespace": name is already used`,
+				`placeholder "replicas": invalid type "int", must be string, number or object`,
+				`placeholder "limit": invalid default value: value "ten" is not a number`,
+			},
+		},
+		{
+			name: "should return problems for variables and panels",
+			dashboard: dashboardv1.DashboardSpec{
+				Placeholders: []dashboardv1.Placeholder{{Name: "namespace"}},
+				Variables: []dashboardv1.Variable{
+					{Name: "namespace", Plugin: dashboardv1.Plugin{Type: "core", Name: "static"}},
+					{Name: "var1", Plugin: dashboardv1.Plugin{Type: "core", Name: "dynamic"}},
+					{Name: "var2", Plugin: dashboardv1.Plugin{Cluster: "dev", Type: "prometheus", Name: "prometheus"}},
+					{},
+				},
+				Rows: []dashboardv1.Row{{Panels: []dashboardv1.Panel{
+					{Title: "panel1", Plugin: dashboardv1.Plugin{Cluster: "hub", Type: "jaeger", Name: "jaeger"}},
+				}}},
+			},
+			plugins: plugins,
+			expectedProblems: []string{
+				`variable "namespace": name is already used`,
+				`variable "var1": invalid core plugin "dynamic", must be static or placeholder`,
+				`variable "var2": plugin instance dev/prometheus/prometheus does not exist`,
+				`variable 3: name is missing`,
+				`panel "panel1": plugin instance hub/jaeger/jaeger does not exist`,
+			},
+		},
+		{
+			name: "should return problems for row conditions",
+			dashboard: dashboardv1.DashboardSpec{
+				Placeholders: []dashboardv1.Placeholder{{Name: "namespace"}},
+				Rows: []dashboardv1.Row{
+					{If: `"{% .namespace %}" !== ""`},
+					{If: `"{% .namespace %}"`},
+				},
+			},
+			expectedProblems: []string{
+				`row 1: invalid condition "\"{% .namespace %}\"": expression must return a bool, got string`,
+			},
+		},
+		{
+			name: "should not check plugins without plugins",
+			dashboard: dashboardv1.DashboardSpec{
+				Variables: []dashboardv1.Variable{{Name: "var1", Plugin: dashboardv1.Plugin{Cluster: "dev", Type: "prometheus", Name: "prometheus"}}},
+				Rows:      []dashboardv1.Row{{Panels: []dashboardv1.Panel{{Title: "panel1", Plugin: dashboardv1.Plugin{Cluster: "hub", Type: "jaeger", Name: "jaeger"}}}}},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expectedProblems, Validate(&tt.dashboard, tt.plugins))
+		})
+	}
+}
+
+func TestValidateReference(t *testing.T) {
+	dashboard := &dashboardv1.DashboardSpec{
+		Placeholders: []dashboardv1.Placeholder{
+			{Name: "namespace"},
+			{Name: "cluster", Default: "dev"},
+			{Name: "replicas", Type: "number"},
+			{Name: "labels", Type: "object"},
+		},
+	}
+
+	t.Run("should return no problems for valid reference", func(t *testing.T) {
+		problems := ValidateReference(dashboardv1.Reference{Placeholders: map[string]string{"namespace": "default", "replicas": "3", "labels": "app: kobs"}}, dashboard)
+		require.Nil(t, problems)
+	})
+
+	t.Run("should return all problems", func(t *testing.T) {
+		problems := ValidateReference(dashboardv1.Reference{Placeholders: map[string]string{"replicas": "three", "labels": "app: [", "name": "kobs", "app": "kobs"}}, dashboard)
+		require.Equal(t, []string{
+			`placeholder "namespace": value is required`,
+			`placeholder "replicas": value "three" is not a number`,
+			`placeholder "labels": value is not a valid object: error converting YAML to JSON: yaml: line 1: did not find expected node content`,
+			`placeholder "app": not defined in the dashboard`,
+			`placeholder "name": not defined in the dashboard`,
+		}, problems)
+	})
+}
@@ -0,0 +1,163 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+var (
+	// ErrClusterNotFound is our custom error which is returned when we are not able to find a cluster with the provided
+	// name.
+	ErrClusterNotFound = fmt.Errorf("cluster not found")
+)
+
+// Cluster is the structure of a cluster, which was registered via the API and which is saved in the database. The name
+// of the cluster is used as id. A cluster can be disabled, so that it is not used by the hub and watcher, without
+// removing it.
+type Cluster struct {
+	Name      string `json:"name" bson:"_id"`
+	Address   string `json:"address" bson:"address"`
+	Token     string `json:"token,omitempty" bson:"token"`
+	Tunnel    bool   `json:"tunnel" bson:"tunnel"`
+	Disabled  bool   `json:"disabled" bson:"disabled"`
+	CreatedAt int64  `json:"createdAt" bson:"createdAt"`
+	UpdatedAt int64  `json:"updatedAt" bson:"updatedAt"`
+}
+
+// SaveCluster creates or updates the provided cluster. When the cluster already exists, the creation time of the
+// cluster is kept.
+func (c *client) SaveCluster(ctx context.Context, cluster *Cluster) error {
+	ctx, span := c.tracer.Start(ctx, "db.SaveCluster")
+	span.SetAttributes(attribute.Key("name").String(cluster.Name))
+	defer span.End()
+
+	now := time.Now().UnixMilli()
+	cluster.UpdatedAt = now
+
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "address", Value: cluster.Address},
+			{Key: "token", Value: cluster.Token},
+			{Key: "tunnel", Value: cluster.Tunnel},
+			{Key: "disabled", Value: cluster.Disabled},
+			{Key: "updatedAt", Value: cluster.UpdatedAt},
+		}},
+		{Key: "$setOnInsert", Value: bson.D{
+			{Key: "createdAt", Value: now},
+		}},
+	}
+
+	_, err := c.coll(ctx, "clusters").UpdateOne(ctx, bson.D{{Key: "_id", Value: cluster.Name}}, update, options.Update().SetUpsert(true))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	if cluster.CreatedAt == 0 {
+		cluster.CreatedAt = now
+	}
+
+	return nil
+}
+
+// GetClusters returns all clusters, which were registered via the API, sorted by their name.
+func (c *client) GetClusters(ctx context.Context) ([]Cluster, error) {
+	ctx, span := c.tracer.Start(ctx, "db.GetClusters")
+	defer span.End()
+
+	var clusters []Cluster
+
+	cursor, err := c.coll(ctx, "clusters").Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	err = cursor.All(ctx, &clusters)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return clusters, nil
+}
+
+// GetClusterByName returns the registered cluster with the provided name. If the cluster does not exist
+// ErrClusterNotFound is returned.
+func (c *client) GetClusterByName(ctx context.Context, name string) (*Cluster, error) {
+	ctx, span := c.tracer.Start(ctx, "db.GetClusterByName")
+	span.SetAttributes(attribute.Key("name").String(name))
+	defer span.End()
+
+	res := c.coll(ctx, "clusters").FindOne(ctx, bson.D{{Key: "_id", Value: name}})
+	if res.Err() != nil {
+		span.RecordError(res.Err())
+		span.SetStatus(codes.Error, res.Err().Error())
+		if res.Err() == mongo.ErrNoDocuments {
+			return nil, ErrClusterNotFound
+		}
+		return nil, res.Err()
+	}
+
+	var cluster Cluster
+	err := res.Decode(&cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cluster, nil
+}
+
+// DeleteCluster removes the registered cluster with the provided name. If the cluster does not exist
+// ErrClusterNotFound is returned. When the cluster was removed, we also remove all the data which was saved by the
+// watcher for the cluster, e.g. the plugins, applications and dashboards, because it would never be updated again.
+func (c *client) DeleteCluster(ctx context.Context, name string) error {
+	ctx, span := c.tracer.Start(ctx, "db.DeleteCluster")
+	span.SetAttributes(attribute.Key("name").String(name))
+	defer span.End()
+
+	res, err := c.coll(ctx, "clusters").DeleteOne(ctx, bson.D{{Key: "_id", Value: name}})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	if res.DeletedCount != 1 {
+		span.RecordError(ErrClusterNotFound)
+		span.SetStatus(codes.Error, ErrClusterNotFound.Error())
+		return ErrClusterNotFound
+	}
+
+	for _, collection := range []string{"plugins", "namespaces", "applications", "dashboards", "teams", "users"} {
+		if _, err := c.coll(ctx, collection).DeleteMany(ctx, bson.D{{Key: "cluster", Value: name}}); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return err
+		}
+	}
+
+	if _, err := c.coll(ctx, "topology").DeleteMany(ctx, bson.D{{Key: "sourceCluster", Value: name}}); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	if _, err := c.coll(ctx, "inventories").DeleteOne(ctx, bson.D{{Key: "_id", Value: name}}); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
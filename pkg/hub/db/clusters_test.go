@@ -0,0 +1,83 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/kobsio/kobs/pkg/plugins/plugin"
+
+	"github.com/orlangure/gnomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusters(t *testing.T) {
+	uri, container := setupDatabase(t)
+	defer func(cs *gnomock.Container) {
+		err := gnomock.Stop(cs)
+		if err != nil {
+			t.Error(err)
+		}
+	}(container)
+	c, _ := NewClient(Config{URI: uri})
+
+	t.Run("SaveCluster", func(t *testing.T) {
+		ctx := ctx(t)
+
+		err := c.SaveCluster(ctx, &Cluster{Name: "dev-de1", Address: "http://localhost:15221", Token: "token"})
+		require.NoError(t, err)
+
+		cluster, err := c.GetClusterByName(ctx, "dev-de1")
+		require.NoError(t, err)
+		require.Equal(t, "http://localhost:15221", cluster.Address)
+		require.NotZero(t, cluster.CreatedAt)
+
+		cluster.Token = "newtoken"
+		err = c.SaveCluster(ctx, cluster)
+		require.NoError(t, err)
+
+		updatedCluster, err := c.GetClusterByName(ctx, "dev-de1")
+		require.NoError(t, err)
+		require.Equal(t, "newtoken", updatedCluster.Token)
+		require.Equal(t, cluster.CreatedAt, updatedCluster.CreatedAt)
+
+		err = c.SaveCluster(ctx, &Cluster{Name: "dev-de1", Address: "http://localhost:15222", Token: "token"})
+		require.NoError(t, err)
+
+		replacedCluster, err := c.GetClusterByName(ctx, "dev-de1")
+		require.NoError(t, err)
+		require.Equal(t, "http://localhost:15222", replacedCluster.Address)
+		require.Equal(t, cluster.CreatedAt, replacedCluster.CreatedAt)
+	})
+
+	t.Run("GetClusters", func(t *testing.T) {
+		ctx := ctx(t)
+
+		require.NoError(t, c.SaveCluster(ctx, &Cluster{Name: "dev-de2"}))
+		require.NoError(t, c.SaveCluster(ctx, &Cluster{Name: "dev-de1"}))
+
+		clusters, err := c.GetClusters(ctx)
+		require.NoError(t, err)
+		require.Len(t, clusters, 2)
+		require.Equal(t, "dev-de1", clusters[0].Name)
+		require.Equal(t, "dev-de2", clusters[1].Name)
+	})
+
+	t.Run("GetClusterByName", func(t *testing.T) {
+		_, err := c.GetClusterByName(ctx(t), "dev-de1")
+		require.Equal(t, ErrClusterNotFound, err)
+	})
+
+	t.Run("DeleteCluster", func(t *testing.T) {
+		ctx := ctx(t)
+
+		require.NoError(t, c.SaveCluster(ctx, &Cluster{Name: "dev-de1"}))
+		require.NoError(t, c.SavePlugins(ctx, "dev-de1", []plugin.Instance{{Name: "prometheus", Type: "prometheus"}}))
+		require.NoError(t, c.SavePlugins(ctx, "dev-de2", []plugin.Instance{{Name: "prometheus", Type: "prometheus"}}))
+		require.NoError(t, c.DeleteCluster(ctx, "dev-de1"))
+		require.Equal(t, ErrClusterNotFound, c.DeleteCluster(ctx, "dev-de1"))
+
+		plugins, err := c.GetPlugins(ctx)
+		require.NoError(t, err)
+		require.Len(t, plugins, 1)
+		require.Equal(t, "dev-de2", plugins[0].Cluster)
+	})
+}
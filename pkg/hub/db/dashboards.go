@@ -0,0 +1,160 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dashboardv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const (
+	// UserDashboardsCluster is the cluster which is used for all dashboards created by users in the hub. This allows
+	// us to reference a user dashboard in the same way as a dashboard from a Dashboard CR. The name is reserved and can
+	// not be used for a cluster, so that a user dashboard can not be confused with a dashboard from a real cluster.
+	UserDashboardsCluster = "@hub"
+
+	// VisibilityPrivate means that a user dashboard can only be viewed by its owner.
+	VisibilityPrivate = "private"
+	// VisibilityTeams means that a user dashboard can be viewed by its owner and all members of the dashboard teams.
+	VisibilityTeams = "teams"
+	// VisibilityPublic means that a user dashboard can be viewed by all users.
+	VisibilityPublic = "public"
+)
+
+var (
+	// ErrDashboardNotFound is our custom error which is returned when we are not able to find a user dashboard with
+	// the provided id.
+	ErrDashboardNotFound = fmt.Errorf("dashboard not found")
+)
+
+// UserDashboard is the structure of a dashboard, which was created by a user in the hub and which is saved in the
+// database. Next to the dashboard spec it contains the owner of the dashboard and the visibility, which defines who
+// is allowed to view the dashboard.
+type UserDashboard struct {
+	dashboardv1.DashboardSpec `bson:",inline"`
+	Owner                     string   `json:"owner" bson:"owner"`
+	Visibility                string   `json:"visibility" bson:"visibility"`
+	Teams                     []string `json:"teams,omitempty" bson:"teams"`
+	CreatedAt                 int64    `json:"createdAt" bson:"createdAt"`
+}
+
+// SaveUserDashboard creates or updates the provided user dashboard. When the dashboard already exists, the creation
+// time of the dashboard is kept.
+func (c *client) SaveUserDashboard(ctx context.Context, dashboard *UserDashboard) error {
+	ctx, span := c.tracer.Start(ctx, "db.SaveUserDashboard")
+	span.SetAttributes(attribute.Key("id").String(dashboard.ID))
+	defer span.End()
+
+	now := time.Now().UnixMilli()
+	dashboard.UpdatedAt = now
+	if dashboard.CreatedAt == 0 {
+		dashboard.CreatedAt = now
+	}
+
+	upsert := true
+	_, err := c.coll(ctx, "userdashboards").ReplaceOne(ctx, bson.D{{Key: "_id", Value: dashboard.ID}}, dashboard, &options.ReplaceOptions{Upsert: &upsert})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// GetUserDashboards returns all user dashboards, which can be viewed by the provided user. These are the dashboards
+// owned by the user, all public dashboards and the dashboards which are shared with one of the provided teams.
+func (c *client) GetUserDashboards(ctx context.Context, user string, teams []string) ([]UserDashboard, error) {
+	ctx, span := c.tracer.Start(ctx, "db.GetUserDashboards")
+	span.SetAttributes(attribute.Key("user").String(user))
+	span.SetAttributes(attribute.Key("teams").StringSlice(teams))
+	defer span.End()
+
+	var dashboards []UserDashboard
+
+	cursor, err := c.coll(ctx, "userdashboards").Find(ctx, userDashboardsFilter(user, teams), options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	err = cursor.All(ctx, &dashboards)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return dashboards, nil
+}
+
+// userDashboardsFilter returns the filter for the GetUserDashboards method. The "$in" operator requires an array, so
+// that we have to use an empty list instead of nil, when the user is not part of any team.
+func userDashboardsFilter(user string, teams []string) bson.M {
+	if teams == nil {
+		teams = []string{}
+	}
+
+	return bson.M{"$or": bson.A{
+		bson.M{"owner": user},
+		bson.M{"visibility": VisibilityPublic},
+		bson.M{"visibility": VisibilityTeams, "teams": bson.M{"$in": teams}},
+	}}
+}
+
+// GetUserDashboardByID returns the user dashboard with the provided id. If the dashboard does not exist
+// ErrDashboardNotFound is returned.
+func (c *client) GetUserDashboardByID(ctx context.Context, id string) (*UserDashboard, error) {
+	ctx, span := c.tracer.Start(ctx, "db.GetUserDashboardByID")
+	span.SetAttributes(attribute.Key("id").String(id))
+	defer span.End()
+
+	res := c.coll(ctx, "userdashboards").FindOne(ctx, bson.D{{Key: "_id", Value: id}})
+	if res.Err() != nil {
+		span.RecordError(res.Err())
+		span.SetStatus(codes.Error, res.Err().Error())
+		if res.Err() == mongo.ErrNoDocuments {
+			return nil, ErrDashboardNotFound
+		}
+		return nil, res.Err()
+	}
+
+	var dashboard UserDashboard
+	err := res.Decode(&dashboard)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dashboard, nil
+}
+
+// DeleteUserDashboard removes the user dashboard with the provided id. If the dashboard does not exist
+// ErrDashboardNotFound is returned.
+func (c *client) DeleteUserDashboard(ctx context.Context, id string) error {
+	ctx, span := c.tracer.Start(ctx, "db.DeleteUserDashboard")
+	span.SetAttributes(attribute.Key("id").String(id))
+	defer span.End()
+
+	res, err := c.coll(ctx, "userdashboards").DeleteOne(ctx, bson.D{{Key: "_id", Value: id}})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	if res.DeletedCount != 1 {
+		span.RecordError(ErrDashboardNotFound)
+		span.SetStatus(codes.Error, ErrDashboardNotFound.Error())
+		return ErrDashboardNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"testing"
+
+	dashboardv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
+
+	"github.com/orlangure/gnomock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestUserDashboardsFilter(t *testing.T) {
+	t.Run("should use empty array for nil teams", func(t *testing.T) {
+		data, err := bson.Marshal(userDashboardsFilter("user1@kobs.io", nil))
+		require.NoError(t, err)
+
+		teams, err := bson.Raw(data).LookupErr("$or", "2", "teams", "$in")
+		require.NoError(t, err)
+		require.Equal(t, bson.TypeArray, teams.Type)
+	})
+
+	t.Run("should use provided teams", func(t *testing.T) {
+		filter := userDashboardsFilter("user1@kobs.io", []string{"team1"})
+		require.Equal(t, bson.M{"$in": []string{"team1"}}, filter["$or"].(bson.A)[2].(bson.M)["teams"])
+	})
+}
+
+func TestUserDashboards(t *testing.T) {
+	uri, container := setupDatabase(t)
+	defer func(cs *gnomock.Container) {
+		err := gnomock.Stop(cs)
+		if err != nil {
+			t.Error(err)
+		}
+	}(container)
+	c, _ := NewClient(Config{URI: uri})
+
+	newDashboard := func(name, owner, visibility string, teams []string) *UserDashboard {
+		return &UserDashboard{
+			DashboardSpec: dashboardv1.DashboardSpec{ID: "/cluster/@hub/namespace/default/name/" + name, Cluster: "@hub", Namespace: "default", Name: name},
+			Owner:         owner,
+			Visibility:    visibility,
+			Teams:         teams,
+		}
+	}
+
+	t.Run("SaveUserDashboard", func(t *testing.T) {
+		ctx := ctx(t)
+
+		err := c.SaveUserDashboard(ctx, newDashboard("private", "user1@kobs.io", VisibilityPrivate, nil))
+		require.NoError(t, err)
+
+		dashboard, err := c.GetUserDashboardByID(ctx, "/cluster/@hub/namespace/default/name/private")
+		require.NoError(t, err)
+		require.Equal(t, "user1@kobs.io", dashboard.Owner)
+		require.NotZero(t, dashboard.CreatedAt)
+
+		dashboard.Title = "Private"
+		err = c.SaveUserDashboard(ctx, dashboard)
+		require.NoError(t, err)
+
+		updatedDashboard, err := c.GetUserDashboardByID(ctx, "/cluster/@hub/namespace/default/name/private")
+		require.NoError(t, err)
+		require.Equal(t, "Private", updatedDashboard.Title)
+		require.Equal(t, dashboard.CreatedAt, updatedDashboard.CreatedAt)
+	})
+
+	t.Run("GetUserDashboards", func(t *testing.T) {
+		ctx := ctx(t)
+
+		require.NoError(t, c.SaveUserDashboard(ctx, newDashboard("private", "user1@kobs.io", VisibilityPrivate, nil)))
+		require.NoError(t, c.SaveUserDashboard(ctx, newDashboard("teams", "user1@kobs.io", VisibilityTeams, []string{"team1"})))
+		require.NoError(t, c.SaveUserDashboard(ctx, newDashboard("public", "user1@kobs.io", VisibilityPublic, nil)))
+
+		dashboards, err := c.GetUserDashboards(ctx, "user1@kobs.io", nil)
+		require.NoError(t, err)
+		require.Len(t, dashboards, 3)
+
+		dashboards, err = c.GetUserDashboards(ctx, "user2@kobs.io", []string{"team1"})
+		require.NoError(t, err)
+		require.Len(t, dashboards, 2)
+		require.Equal(t, "public", dashboards[0].Name)
+		require.Equal(t, "teams", dashboards[1].Name)
+
+		dashboards, err = c.GetUserDashboards(ctx, "user2@kobs.io", []string{"team2"})
+		require.NoError(t, err)
+		require.Len(t, dashboards, 1)
+		require.Equal(t, "public", dashboards[0].Name)
+	})
+
+	t.Run("GetUserDashboardByID", func(t *testing.T) {
+		_, err := c.GetUserDashboardByID(ctx(t), "/cluster/@hub/namespace/default/name/private")
+		require.Equal(t, ErrDashboardNotFound, err)
+	})
+
+	t.Run("DeleteUserDashboard", func(t *testing.T) {
+		ctx := ctx(t)
+
+		require.NoError(t, c.SaveUserDashboard(ctx, newDashboard("private", "user1@kobs.io", VisibilityPrivate, nil)))
+		require.NoError(t, c.DeleteUserDashboard(ctx, "/cluster/@hub/namespace/default/name/private"))
+		require.Equal(t, ErrDashboardNotFound, c.DeleteUserDashboard(ctx, "/cluster/@hub/namespace/default/name/private"))
+	})
+}
@@ -13,6 +13,7 @@ import (
 	dashboardv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
 	teamv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/team/v1"
 	userv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/user/v1"
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/inventory"
 	authContext "github.com/kobsio/kobs/pkg/hub/auth/context"
 	"github.com/kobsio/kobs/pkg/plugins/plugin"
 
@@ -59,8 +60,8 @@ type Client interface {
 	GetCRDs(ctx context.Context) ([]kubernetes.CRD, error)
 	GetCRDByID(ctx context.Context, id string) (*kubernetes.CRD, error)
 	GetApplications(ctx context.Context) ([]applicationv1.ApplicationSpec, error)
-	GetApplicationsByFilter(ctx context.Context, teams, clusters, namespaces, tags []string, searchTerm string, limit, offset int) ([]applicationv1.ApplicationSpec, error)
-	GetApplicationsByFilterCount(ctx context.Context, teams, clusters, namespaces, tags []string, searchTerm string) (int, error)
+	GetApplicationsByFilter(ctx context.Context, teams, clusters, namespaces, tags, ids []string, searchTerm string, limit, offset int) ([]applicationv1.ApplicationSpec, error)
+	GetApplicationsByFilterCount(ctx context.Context, teams, clusters, namespaces, tags, ids []string, searchTerm string) (int, error)
 	GetApplicationsByGroup(ctx context.Context, teams, groups []string) ([]ApplicationGroup, error)
 	GetApplicationByID(ctx context.Context, id string) (*applicationv1.ApplicationSpec, error)
 	GetDashboards(ctx context.Context, clusters, namespaces []string) ([]dashboardv1.DashboardSpec, error)
@@ -77,6 +78,34 @@ type Client interface {
 	GetSession(ctx context.Context, sessionID primitive.ObjectID) (*Session, error)
 	GetAndUpdateSession(ctx context.Context, sessionID primitive.ObjectID) (*Session, error)
 	DeleteSession(ctx context.Context, sessionID primitive.ObjectID) error
+
+	SaveCluster(ctx context.Context, cluster *Cluster) error
+	GetClusters(ctx context.Context) ([]Cluster, error)
+	GetClusterByName(ctx context.Context, name string) (*Cluster, error)
+	DeleteCluster(ctx context.Context, name string) error
+
+	SaveInventory(ctx context.Context, cluster string, inventory *inventory.Inventory) error
+	GetInventories(ctx context.Context, clusters []string) ([]inventory.Inventory, error)
+
+	SaveUserDashboard(ctx context.Context, dashboard *UserDashboard) error
+	GetUserDashboards(ctx context.Context, user string, teams []string) ([]UserDashboard, error)
+	GetUserDashboardByID(ctx context.Context, id string) (*UserDashboard, error)
+	DeleteUserDashboard(ctx context.Context, id string) error
+
+	GetPreferences(ctx context.Context, user string) (*Preferences, error)
+	AddFavorite(ctx context.Context, user, itemType, id string) error
+	RemoveFavorite(ctx context.Context, user, itemType, id string) error
+	AddRecentlyViewed(ctx context.Context, user, itemType, id string) error
+	DeleteRecentlyViewed(ctx context.Context, user string) error
+
+	SaveMembership(ctx context.Context, user, name string, teams []string) error
+	GetMembershipByUser(ctx context.Context, user string) (*Membership, error)
+	GetMembershipsByTeam(ctx context.Context, team string) ([]Membership, error)
+	GetUsersByTeam(ctx context.Context, team string) ([]userv1.UserSpec, error)
+
+	SaveScorecards(ctx context.Context, scorecards []Scorecard) error
+	GetLatestScorecards(ctx context.Context, teams, applications []string) ([]Scorecard, error)
+	GetScorecardHistory(ctx context.Context, teams, applications []string, timeStart, timeEnd time.Time) ([]ScorecardHistory, error)
 }
 
 type client struct {
@@ -161,6 +190,21 @@ func (c *client) CreateIndexes(ctx context.Context) error {
 		return err
 	}
 
+	// Create TTL index for scorecards collection, which will delete all scorecards which are older than 90 days
+	// (2160h), so that we keep the history of the scores for the last 90 days.
+	_, err = c.coll(ctx, "scorecards").Indexes().CreateOne(
+		ctx,
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "createdAt", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(time.Duration(2160 * time.Hour).Seconds())),
+		})
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
 	return nil
 }
 
@@ -645,12 +689,13 @@ func (c *client) GetApplications(ctx context.Context) ([]applicationv1.Applicati
 	return applications, nil
 }
 
-func (c *client) GetApplicationsByFilter(ctx context.Context, teams, clusters, namespaces, tags []string, searchTerm string, limit, offset int) ([]applicationv1.ApplicationSpec, error) {
+func (c *client) GetApplicationsByFilter(ctx context.Context, teams, clusters, namespaces, tags, ids []string, searchTerm string, limit, offset int) ([]applicationv1.ApplicationSpec, error) {
 	_, span := c.tracer.Start(ctx, "db.GetApplicationsByFilter")
 	span.SetAttributes(attribute.Key("teams").StringSlice(teams))
 	span.SetAttributes(attribute.Key("clusters").StringSlice(clusters))
 	span.SetAttributes(attribute.Key("namespaces").StringSlice(namespaces))
 	span.SetAttributes(attribute.Key("tags").StringSlice(tags))
+	span.SetAttributes(attribute.Key("ids").StringSlice(ids))
 	span.SetAttributes(attribute.Key("searchTerm").String(searchTerm))
 	span.SetAttributes(attribute.Key("limit").Int(limit))
 	span.SetAttributes(attribute.Key("offset").Int(offset))
@@ -678,6 +723,12 @@ func (c *client) GetApplicationsByFilter(ctx context.Context, teams, clusters, n
 		filter["tags"] = bson.M{"$in": tags}
 	}
 
+	// The ids are used to filter the applications by the favorites of a user. In contrast to the other filters, an
+	// empty list is not ignored, so that no applications are returned when a user doesn't have any favorites.
+	if ids != nil {
+		filter["_id"] = bson.M{"$in": ids}
+	}
+
 	var applications []applicationv1.ApplicationSpec
 
 	cursor, err := c.coll(ctx, "applications").Find(ctx, filter, options.Find().SetSort(bson.M{"name": 1}).SetLimit(int64(limit)).SetSkip(int64(offset)))
@@ -697,12 +748,13 @@ func (c *client) GetApplicationsByFilter(ctx context.Context, teams, clusters, n
 	return applications, nil
 }
 
-func (c *client) GetApplicationsByFilterCount(ctx context.Context, teams, clusters, namespaces, tags []string, searchTerm string) (int, error) {
+func (c *client) GetApplicationsByFilterCount(ctx context.Context, teams, clusters, namespaces, tags, ids []string, searchTerm string) (int, error) {
 	_, span := c.tracer.Start(ctx, "db.GetApplicationsByFilterCount")
 	span.SetAttributes(attribute.Key("teams").StringSlice(teams))
 	span.SetAttributes(attribute.Key("clusters").StringSlice(clusters))
 	span.SetAttributes(attribute.Key("namespaces").StringSlice(namespaces))
 	span.SetAttributes(attribute.Key("tags").StringSlice(tags))
+	span.SetAttributes(attribute.Key("ids").StringSlice(ids))
 	span.SetAttributes(attribute.Key("searchTerm").String(searchTerm))
 	defer span.End()
 
@@ -728,6 +780,12 @@ func (c *client) GetApplicationsByFilterCount(ctx context.Context, teams, cluste
 		filter["tags"] = bson.M{"$in": tags}
 	}
 
+	// The ids are used to filter the applications by the favorites of a user. In contrast to the other filters, an
+	// empty list is not ignored, so that no applications are returned when a user doesn't have any favorites.
+	if ids != nil {
+		filter["_id"] = bson.M{"$in": ids}
+	}
+
 	count, err := c.coll(ctx, "applications").CountDocuments(ctx, filter)
 	if err != nil {
 		span.RecordError(err)
@@ -7,6 +7,7 @@ package db
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	kubernetes "github.com/kobsio/kobs/pkg/cluster/kubernetes"
@@ -14,6 +15,7 @@ import (
 	v10 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
 	v11 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/team/v1"
 	v12 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/user/v1"
+	inventory "github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/inventory"
 	context0 "github.com/kobsio/kobs/pkg/hub/auth/context"
 	plugin "github.com/kobsio/kobs/pkg/plugins/plugin"
 	primitive "go.mongodb.org/mongo-driver/bson/primitive"
@@ -43,6 +45,34 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// AddFavorite mocks base method.
+func (m *MockClient) AddFavorite(ctx context.Context, user, itemType, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFavorite", ctx, user, itemType, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddFavorite indicates an expected call of AddFavorite.
+func (mr *MockClientMockRecorder) AddFavorite(ctx, user, itemType, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFavorite", reflect.TypeOf((*MockClient)(nil).AddFavorite), ctx, user, itemType, id)
+}
+
+// AddRecentlyViewed mocks base method.
+func (m *MockClient) AddRecentlyViewed(ctx context.Context, user, itemType, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRecentlyViewed", ctx, user, itemType, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRecentlyViewed indicates an expected call of AddRecentlyViewed.
+func (mr *MockClientMockRecorder) AddRecentlyViewed(ctx, user, itemType, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRecentlyViewed", reflect.TypeOf((*MockClient)(nil).AddRecentlyViewed), ctx, user, itemType, id)
+}
+
 // CreateIndexes mocks base method.
 func (m *MockClient) CreateIndexes(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -86,6 +116,34 @@ func (mr *MockClientMockRecorder) DB() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DB", reflect.TypeOf((*MockClient)(nil).DB))
 }
 
+// DeleteCluster mocks base method.
+func (m *MockClient) DeleteCluster(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCluster", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCluster indicates an expected call of DeleteCluster.
+func (mr *MockClientMockRecorder) DeleteCluster(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCluster", reflect.TypeOf((*MockClient)(nil).DeleteCluster), ctx, name)
+}
+
+// DeleteRecentlyViewed mocks base method.
+func (m *MockClient) DeleteRecentlyViewed(ctx context.Context, user string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecentlyViewed", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecentlyViewed indicates an expected call of DeleteRecentlyViewed.
+func (mr *MockClientMockRecorder) DeleteRecentlyViewed(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecentlyViewed", reflect.TypeOf((*MockClient)(nil).DeleteRecentlyViewed), ctx, user)
+}
+
 // DeleteSession mocks base method.
 func (m *MockClient) DeleteSession(ctx context.Context, sessionID primitive.ObjectID) error {
 	m.ctrl.T.Helper()
@@ -100,6 +158,20 @@ func (mr *MockClientMockRecorder) DeleteSession(ctx, sessionID interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockClient)(nil).DeleteSession), ctx, sessionID)
 }
 
+// DeleteUserDashboard mocks base method.
+func (m *MockClient) DeleteUserDashboard(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserDashboard", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserDashboard indicates an expected call of DeleteUserDashboard.
+func (mr *MockClientMockRecorder) DeleteUserDashboard(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserDashboard", reflect.TypeOf((*MockClient)(nil).DeleteUserDashboard), ctx, id)
+}
+
 // GetAndUpdateSession mocks base method.
 func (m *MockClient) GetAndUpdateSession(ctx context.Context, sessionID primitive.ObjectID) (*Session, error) {
 	m.ctrl.T.Helper()
@@ -146,33 +218,33 @@ func (mr *MockClientMockRecorder) GetApplications(ctx interface{}) *gomock.Call
 }
 
 // GetApplicationsByFilter mocks base method.
-func (m *MockClient) GetApplicationsByFilter(ctx context.Context, teams, clusters, namespaces, tags []string, searchTerm string, limit, offset int) ([]v1.ApplicationSpec, error) {
+func (m *MockClient) GetApplicationsByFilter(ctx context.Context, teams, clusters, namespaces, tags, ids []string, searchTerm string, limit, offset int) ([]v1.ApplicationSpec, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetApplicationsByFilter", ctx, teams, clusters, namespaces, tags, searchTerm, limit, offset)
+	ret := m.ctrl.Call(m, "GetApplicationsByFilter", ctx, teams, clusters, namespaces, tags, ids, searchTerm, limit, offset)
 	ret0, _ := ret[0].([]v1.ApplicationSpec)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetApplicationsByFilter indicates an expected call of GetApplicationsByFilter.
-func (mr *MockClientMockRecorder) GetApplicationsByFilter(ctx, teams, clusters, namespaces, tags, searchTerm, limit, offset interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) GetApplicationsByFilter(ctx, teams, clusters, namespaces, tags, ids, searchTerm, limit, offset interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicationsByFilter", reflect.TypeOf((*MockClient)(nil).GetApplicationsByFilter), ctx, teams, clusters, namespaces, tags, searchTerm, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicationsByFilter", reflect.TypeOf((*MockClient)(nil).GetApplicationsByFilter), ctx, teams, clusters, namespaces, tags, ids, searchTerm, limit, offset)
 }
 
 // GetApplicationsByFilterCount mocks base method.
-func (m *MockClient) GetApplicationsByFilterCount(ctx context.Context, teams, clusters, namespaces, tags []string, searchTerm string) (int, error) {
+func (m *MockClient) GetApplicationsByFilterCount(ctx context.Context, teams, clusters, namespaces, tags, ids []string, searchTerm string) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetApplicationsByFilterCount", ctx, teams, clusters, namespaces, tags, searchTerm)
+	ret := m.ctrl.Call(m, "GetApplicationsByFilterCount", ctx, teams, clusters, namespaces, tags, ids, searchTerm)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetApplicationsByFilterCount indicates an expected call of GetApplicationsByFilterCount.
-func (mr *MockClientMockRecorder) GetApplicationsByFilterCount(ctx, teams, clusters, namespaces, tags, searchTerm interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) GetApplicationsByFilterCount(ctx, teams, clusters, namespaces, tags, ids, searchTerm interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicationsByFilterCount", reflect.TypeOf((*MockClient)(nil).GetApplicationsByFilterCount), ctx, teams, clusters, namespaces, tags, searchTerm)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicationsByFilterCount", reflect.TypeOf((*MockClient)(nil).GetApplicationsByFilterCount), ctx, teams, clusters, namespaces, tags, ids, searchTerm)
 }
 
 // GetApplicationsByGroup mocks base method.
@@ -220,6 +292,36 @@ func (mr *MockClientMockRecorder) GetCRDs(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCRDs", reflect.TypeOf((*MockClient)(nil).GetCRDs), ctx)
 }
 
+// GetClusterByName mocks base method.
+func (m *MockClient) GetClusterByName(ctx context.Context, name string) (*Cluster, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClusterByName", ctx, name)
+	ret0, _ := ret[0].(*Cluster)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClusterByName indicates an expected call of GetClusterByName.
+func (mr *MockClientMockRecorder) GetClusterByName(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusterByName", reflect.TypeOf((*MockClient)(nil).GetClusterByName), ctx, name)
+}
+
+// GetClusters mocks base method.
+func (m *MockClient) GetClusters(ctx context.Context) ([]Cluster, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClusters", ctx)
+	ret0, _ := ret[0].([]Cluster)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClusters indicates an expected call of GetClusters.
+func (mr *MockClientMockRecorder) GetClusters(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusters", reflect.TypeOf((*MockClient)(nil).GetClusters), ctx)
+}
+
 // GetDashboardByID mocks base method.
 func (m *MockClient) GetDashboardByID(ctx context.Context, id string) (*v10.DashboardSpec, error) {
 	m.ctrl.T.Helper()
@@ -250,6 +352,66 @@ func (mr *MockClientMockRecorder) GetDashboards(ctx, clusters, namespaces interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDashboards", reflect.TypeOf((*MockClient)(nil).GetDashboards), ctx, clusters, namespaces)
 }
 
+// GetInventories mocks base method.
+func (m *MockClient) GetInventories(ctx context.Context, clusters []string) ([]inventory.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventories", ctx, clusters)
+	ret0, _ := ret[0].([]inventory.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInventories indicates an expected call of GetInventories.
+func (mr *MockClientMockRecorder) GetInventories(ctx, clusters interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventories", reflect.TypeOf((*MockClient)(nil).GetInventories), ctx, clusters)
+}
+
+// GetLatestScorecards mocks base method.
+func (m *MockClient) GetLatestScorecards(ctx context.Context, teams, applications []string) ([]Scorecard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestScorecards", ctx, teams, applications)
+	ret0, _ := ret[0].([]Scorecard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestScorecards indicates an expected call of GetLatestScorecards.
+func (mr *MockClientMockRecorder) GetLatestScorecards(ctx, teams, applications interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestScorecards", reflect.TypeOf((*MockClient)(nil).GetLatestScorecards), ctx, teams, applications)
+}
+
+// GetMembershipByUser mocks base method.
+func (m *MockClient) GetMembershipByUser(ctx context.Context, user string) (*Membership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembershipByUser", ctx, user)
+	ret0, _ := ret[0].(*Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembershipByUser indicates an expected call of GetMembershipByUser.
+func (mr *MockClientMockRecorder) GetMembershipByUser(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembershipByUser", reflect.TypeOf((*MockClient)(nil).GetMembershipByUser), ctx, user)
+}
+
+// GetMembershipsByTeam mocks base method.
+func (m *MockClient) GetMembershipsByTeam(ctx context.Context, team string) ([]Membership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembershipsByTeam", ctx, team)
+	ret0, _ := ret[0].([]Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembershipsByTeam indicates an expected call of GetMembershipsByTeam.
+func (mr *MockClientMockRecorder) GetMembershipsByTeam(ctx, team interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembershipsByTeam", reflect.TypeOf((*MockClient)(nil).GetMembershipsByTeam), ctx, team)
+}
+
 // GetNamespaces mocks base method.
 func (m *MockClient) GetNamespaces(ctx context.Context) ([]Namespace, error) {
 	m.ctrl.T.Helper()
@@ -295,6 +457,36 @@ func (mr *MockClientMockRecorder) GetPlugins(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlugins", reflect.TypeOf((*MockClient)(nil).GetPlugins), ctx)
 }
 
+// GetPreferences mocks base method.
+func (m *MockClient) GetPreferences(ctx context.Context, user string) (*Preferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", ctx, user)
+	ret0, _ := ret[0].(*Preferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockClientMockRecorder) GetPreferences(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockClient)(nil).GetPreferences), ctx, user)
+}
+
+// GetScorecardHistory mocks base method.
+func (m *MockClient) GetScorecardHistory(ctx context.Context, teams, applications []string, timeStart, timeEnd time.Time) ([]ScorecardHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScorecardHistory", ctx, teams, applications, timeStart, timeEnd)
+	ret0, _ := ret[0].([]ScorecardHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScorecardHistory indicates an expected call of GetScorecardHistory.
+func (mr *MockClientMockRecorder) GetScorecardHistory(ctx, teams, applications, timeStart, timeEnd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScorecardHistory", reflect.TypeOf((*MockClient)(nil).GetScorecardHistory), ctx, teams, applications, timeStart, timeEnd)
+}
+
 // GetSession mocks base method.
 func (m *MockClient) GetSession(ctx context.Context, sessionID primitive.ObjectID) (*Session, error) {
 	m.ctrl.T.Helper()
@@ -400,6 +592,36 @@ func (mr *MockClientMockRecorder) GetUserByID(ctx, id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockClient)(nil).GetUserByID), ctx, id)
 }
 
+// GetUserDashboardByID mocks base method.
+func (m *MockClient) GetUserDashboardByID(ctx context.Context, id string) (*UserDashboard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserDashboardByID", ctx, id)
+	ret0, _ := ret[0].(*UserDashboard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserDashboardByID indicates an expected call of GetUserDashboardByID.
+func (mr *MockClientMockRecorder) GetUserDashboardByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserDashboardByID", reflect.TypeOf((*MockClient)(nil).GetUserDashboardByID), ctx, id)
+}
+
+// GetUserDashboards mocks base method.
+func (m *MockClient) GetUserDashboards(ctx context.Context, user string, teams []string) ([]UserDashboard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserDashboards", ctx, user, teams)
+	ret0, _ := ret[0].([]UserDashboard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserDashboards indicates an expected call of GetUserDashboards.
+func (mr *MockClientMockRecorder) GetUserDashboards(ctx, user, teams interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserDashboards", reflect.TypeOf((*MockClient)(nil).GetUserDashboards), ctx, user, teams)
+}
+
 // GetUsers mocks base method.
 func (m *MockClient) GetUsers(ctx context.Context) ([]v12.UserSpec, error) {
 	m.ctrl.T.Helper()
@@ -415,6 +637,35 @@ func (mr *MockClientMockRecorder) GetUsers(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockClient)(nil).GetUsers), ctx)
 }
 
+// GetUsersByTeam mocks base method.
+func (m *MockClient) GetUsersByTeam(ctx context.Context, team string) ([]v12.UserSpec, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByTeam", ctx, team)
+	ret0, _ := ret[0].([]v12.UserSpec)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByTeam indicates an expected call of GetUsersByTeam.
+func (mr *MockClientMockRecorder) GetUsersByTeam(ctx, team interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByTeam", reflect.TypeOf((*MockClient)(nil).GetUsersByTeam), ctx, team)
+}
+
+// RemoveFavorite mocks base method.
+func (m *MockClient) RemoveFavorite(ctx context.Context, user, itemType, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFavorite", ctx, user, itemType, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFavorite indicates an expected call of RemoveFavorite.
+func (mr *MockClientMockRecorder) RemoveFavorite(ctx, user, itemType, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFavorite", reflect.TypeOf((*MockClient)(nil).RemoveFavorite), ctx, user, itemType, id)
+}
+
 // SaveApplication mocks base method.
 func (m *MockClient) SaveApplication(ctx context.Context, application *v1.ApplicationSpec) error {
 	m.ctrl.T.Helper()
@@ -457,6 +708,20 @@ func (mr *MockClientMockRecorder) SaveCRDs(ctx, crds interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCRDs", reflect.TypeOf((*MockClient)(nil).SaveCRDs), ctx, crds)
 }
 
+// SaveCluster mocks base method.
+func (m *MockClient) SaveCluster(ctx context.Context, cluster *Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCluster", ctx, cluster)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCluster indicates an expected call of SaveCluster.
+func (mr *MockClientMockRecorder) SaveCluster(ctx, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCluster", reflect.TypeOf((*MockClient)(nil).SaveCluster), ctx, cluster)
+}
+
 // SaveDashboards mocks base method.
 func (m *MockClient) SaveDashboards(ctx context.Context, cluster string, dashboards []v10.DashboardSpec) error {
 	m.ctrl.T.Helper()
@@ -471,6 +736,34 @@ func (mr *MockClientMockRecorder) SaveDashboards(ctx, cluster, dashboards interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDashboards", reflect.TypeOf((*MockClient)(nil).SaveDashboards), ctx, cluster, dashboards)
 }
 
+// SaveInventory mocks base method.
+func (m *MockClient) SaveInventory(ctx context.Context, cluster string, inventory *inventory.Inventory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveInventory", ctx, cluster, inventory)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveInventory indicates an expected call of SaveInventory.
+func (mr *MockClientMockRecorder) SaveInventory(ctx, cluster, inventory interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveInventory", reflect.TypeOf((*MockClient)(nil).SaveInventory), ctx, cluster, inventory)
+}
+
+// SaveMembership mocks base method.
+func (m *MockClient) SaveMembership(ctx context.Context, user, name string, teams []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveMembership", ctx, user, name, teams)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveMembership indicates an expected call of SaveMembership.
+func (mr *MockClientMockRecorder) SaveMembership(ctx, user, name, teams interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMembership", reflect.TypeOf((*MockClient)(nil).SaveMembership), ctx, user, name, teams)
+}
+
 // SaveNamespaces mocks base method.
 func (m *MockClient) SaveNamespaces(ctx context.Context, cluster string, namespaces []string) error {
 	m.ctrl.T.Helper()
@@ -499,6 +792,20 @@ func (mr *MockClientMockRecorder) SavePlugins(ctx, cluster, plugins interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePlugins", reflect.TypeOf((*MockClient)(nil).SavePlugins), ctx, cluster, plugins)
 }
 
+// SaveScorecards mocks base method.
+func (m *MockClient) SaveScorecards(ctx context.Context, scorecards []Scorecard) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveScorecards", ctx, scorecards)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveScorecards indicates an expected call of SaveScorecards.
+func (mr *MockClientMockRecorder) SaveScorecards(ctx, scorecards interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveScorecards", reflect.TypeOf((*MockClient)(nil).SaveScorecards), ctx, scorecards)
+}
+
 // SaveTags mocks base method.
 func (m *MockClient) SaveTags(ctx context.Context, applications []v1.ApplicationSpec) error {
 	m.ctrl.T.Helper()
@@ -569,6 +876,20 @@ func (mr *MockClientMockRecorder) SaveUser(ctx, user interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUser", reflect.TypeOf((*MockClient)(nil).SaveUser), ctx, user)
 }
 
+// SaveUserDashboard mocks base method.
+func (m *MockClient) SaveUserDashboard(ctx context.Context, dashboard *UserDashboard) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveUserDashboard", ctx, dashboard)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveUserDashboard indicates an expected call of SaveUserDashboard.
+func (mr *MockClientMockRecorder) SaveUserDashboard(ctx, dashboard interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUserDashboard", reflect.TypeOf((*MockClient)(nil).SaveUserDashboard), ctx, dashboard)
+}
+
 // SaveUsers mocks base method.
 func (m *MockClient) SaveUsers(ctx context.Context, cluster string, users []v12.UserSpec) error {
 	m.ctrl.T.Helper()
@@ -462,6 +462,7 @@ func TestDB(t *testing.T) {
 			clusters             []string
 			namespaces           []string
 			tags                 []string
+			ids                  []string
 			searchTerm           string
 			limit                int
 			offset               int
@@ -478,9 +479,11 @@ func TestDB(t *testing.T) {
 			{name: "filter by teams", teams: []string{"team1", "team3"}, clusters: nil, namespaces: nil, tags: nil, searchTerm: "", limit: 100, offset: 0, expectedError: false, expectedApplications: []string{"application1", "application2", "application3", "application4", "application6", "application8", "application9"}, expectedCount: 7},
 			{name: "filter by cluster and namespace", teams: nil, clusters: []string{"test-cluster1", "test-cluster2"}, namespaces: []string{"default"}, tags: nil, searchTerm: "", limit: 100, offset: 0, expectedError: false, expectedApplications: []string{"application1", "application2", "application3", "application4", "application5"}, expectedCount: 5},
 			{name: "filter by tags", teams: nil, clusters: nil, namespaces: nil, tags: []string{"logging"}, searchTerm: "", limit: 100, offset: 0, expectedError: false, expectedApplications: []string{"application10"}, expectedCount: 1},
+			{name: "filter by ids", teams: nil, clusters: nil, namespaces: nil, tags: nil, ids: []string{"cluster/test-cluster1/namespace/default/application3", "cluster/test-cluster2/namespace/kube-system/application7"}, searchTerm: "", limit: 100, offset: 0, expectedError: false, expectedApplications: []string{"application3", "application7"}, expectedCount: 2},
+			{name: "filter by empty ids", teams: nil, clusters: nil, namespaces: nil, tags: nil, ids: []string{}, searchTerm: "", limit: 100, offset: 0, expectedError: false, expectedApplications: nil, expectedCount: 0},
 		} {
 			t.Run(tt.name, func(t *testing.T) {
-				storedApplications, err := c.GetApplicationsByFilter(ctx, tt.teams, tt.clusters, tt.namespaces, tt.tags, tt.searchTerm, tt.limit, tt.offset)
+				storedApplications, err := c.GetApplicationsByFilter(ctx, tt.teams, tt.clusters, tt.namespaces, tt.tags, tt.ids, tt.searchTerm, tt.limit, tt.offset)
 				if tt.expectedError {
 					require.Error(t, err)
 				} else {
@@ -488,7 +491,7 @@ func TestDB(t *testing.T) {
 				}
 				require.Equal(t, tt.expectedApplications, getApplicationsNames(storedApplications))
 
-				count, err := c.GetApplicationsByFilterCount(ctx, tt.teams, tt.clusters, tt.namespaces, tt.tags, tt.searchTerm)
+				count, err := c.GetApplicationsByFilterCount(ctx, tt.teams, tt.clusters, tt.namespaces, tt.tags, tt.ids, tt.searchTerm)
 				if tt.expectedError {
 					require.Error(t, err)
 				} else {
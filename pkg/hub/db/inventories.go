@@ -0,0 +1,69 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/inventory"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// SaveInventory creates or updates the inventory of the provided cluster. The name of the cluster is used as id, so
+// that we only keep the latest inventory for each cluster.
+func (c *client) SaveInventory(ctx context.Context, cluster string, inv *inventory.Inventory) error {
+	if inv == nil {
+		return nil
+	}
+
+	ctx, span := c.tracer.Start(ctx, "db.SaveInventory")
+	span.SetAttributes(attribute.Key("cluster").String(cluster))
+	defer span.End()
+
+	inv.Cluster = cluster
+	inv.UpdatedAt = time.Now().UnixMilli()
+
+	upsert := true
+	_, err := c.coll(ctx, "inventories").ReplaceOne(ctx, bson.D{{Key: "_id", Value: cluster}}, inv, &options.ReplaceOptions{Upsert: &upsert})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// GetInventories returns the inventories for the provided clusters sorted by the name of the cluster. If no cluster is
+// provided, the inventories of all clusters are returned.
+func (c *client) GetInventories(ctx context.Context, clusters []string) ([]inventory.Inventory, error) {
+	ctx, span := c.tracer.Start(ctx, "db.GetInventories")
+	span.SetAttributes(attribute.Key("clusters").StringSlice(clusters))
+	defer span.End()
+
+	filter := bson.D{}
+	if len(clusters) > 0 {
+		filter = bson.D{{Key: "_id", Value: bson.M{"$in": clusters}}}
+	}
+
+	var inventories []inventory.Inventory
+
+	cursor, err := c.coll(ctx, "inventories").Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	err = cursor.All(ctx, &inventories)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return inventories, nil
+}
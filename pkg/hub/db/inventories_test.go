@@ -0,0 +1,55 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes/cluster/inventory"
+
+	"github.com/orlangure/gnomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInventories(t *testing.T) {
+	uri, container := setupDatabase(t)
+	defer func(cs *gnomock.Container) {
+		err := gnomock.Stop(cs)
+		if err != nil {
+			t.Error(err)
+		}
+	}(container)
+	c, _ := NewClient(Config{URI: uri})
+
+	t.Run("SaveInventory", func(t *testing.T) {
+		ctx := ctx(t)
+
+		require.NoError(t, c.SaveInventory(ctx, "dev-de1", nil))
+		require.NoError(t, c.SaveInventory(ctx, "dev-de1", &inventory.Inventory{KubernetesVersion: "v1.26.6"}))
+		require.NoError(t, c.SaveInventory(ctx, "dev-de1", &inventory.Inventory{KubernetesVersion: "v1.27.3", Nodes: []inventory.Node{{Name: "node1"}}}))
+
+		inventories, err := c.GetInventories(ctx, nil)
+		require.NoError(t, err)
+		require.Len(t, inventories, 1)
+		require.Equal(t, "dev-de1", inventories[0].Cluster)
+		require.Equal(t, "v1.27.3", inventories[0].KubernetesVersion)
+		require.Equal(t, []inventory.Node{{Name: "node1"}}, inventories[0].Nodes)
+		require.NotZero(t, inventories[0].UpdatedAt)
+	})
+
+	t.Run("GetInventories", func(t *testing.T) {
+		ctx := ctx(t)
+
+		require.NoError(t, c.SaveInventory(ctx, "dev-de2", &inventory.Inventory{KubernetesVersion: "v1.27.3"}))
+		require.NoError(t, c.SaveInventory(ctx, "dev-de1", &inventory.Inventory{KubernetesVersion: "v1.26.6"}))
+
+		inventories, err := c.GetInventories(ctx, nil)
+		require.NoError(t, err)
+		require.Len(t, inventories, 2)
+		require.Equal(t, "dev-de1", inventories[0].Cluster)
+		require.Equal(t, "dev-de2", inventories[1].Cluster)
+
+		inventories, err = c.GetInventories(ctx, []string{"dev-de2"})
+		require.NoError(t, err)
+		require.Len(t, inventories, 1)
+		require.Equal(t, "dev-de2", inventories[0].Cluster)
+	})
+}
@@ -0,0 +1,125 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	userv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/user/v1"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// Membership contains the teams of a user, which were observed in the group claims of the OIDC provider when the user
+// signed in the last time. The id of the user is used as id for the membership.
+type Membership struct {
+	User      string   `json:"user" bson:"_id"`
+	Name      string   `json:"name" bson:"name"`
+	Teams     []string `json:"teams" bson:"teams"`
+	UpdatedAt int64    `json:"updatedAt" bson:"updatedAt"`
+}
+
+// SaveMembership saves the teams of the provided user. An existing membership of the user is replaced, so that teams
+// which were removed from the group claims are also removed from the membership.
+func (c *client) SaveMembership(ctx context.Context, user, name string, teams []string) error {
+	ctx, span := c.tracer.Start(ctx, "db.SaveMembership")
+	span.SetAttributes(attribute.Key("user").String(user))
+	span.SetAttributes(attribute.Key("teams").StringSlice(teams))
+	defer span.End()
+
+	if teams == nil {
+		teams = []string{}
+	}
+
+	membership := Membership{User: user, Name: name, Teams: teams, UpdatedAt: time.Now().UnixMilli()}
+
+	_, err := c.coll(ctx, "memberships").ReplaceOne(ctx, bson.D{{Key: "_id", Value: user}}, membership, options.Replace().SetUpsert(true))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// GetMembershipByUser returns the membership of the provided user. If no membership was saved for the user, nil is
+// returned.
+func (c *client) GetMembershipByUser(ctx context.Context, user string) (*Membership, error) {
+	ctx, span := c.tracer.Start(ctx, "db.GetMembershipByUser")
+	span.SetAttributes(attribute.Key("user").String(user))
+	defer span.End()
+
+	res := c.coll(ctx, "memberships").FindOne(ctx, bson.D{{Key: "_id", Value: user}})
+	if err := res.Err(); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	var membership Membership
+	if err := res.Decode(&membership); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return &membership, nil
+}
+
+// GetMembershipsByTeam returns the memberships of all users, which are a member of the provided team.
+func (c *client) GetMembershipsByTeam(ctx context.Context, team string) ([]Membership, error) {
+	ctx, span := c.tracer.Start(ctx, "db.GetMembershipsByTeam")
+	span.SetAttributes(attribute.Key("team").String(team))
+	defer span.End()
+
+	var memberships []Membership
+
+	cursor, err := c.coll(ctx, "memberships").Find(ctx, bson.D{{Key: "teams", Value: team}}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	err = cursor.All(ctx, &memberships)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return memberships, nil
+}
+
+// GetUsersByTeam returns all users from the User CRs, which have the provided team in their list of teams.
+func (c *client) GetUsersByTeam(ctx context.Context, team string) ([]userv1.UserSpec, error) {
+	ctx, span := c.tracer.Start(ctx, "db.GetUsersByTeam")
+	span.SetAttributes(attribute.Key("team").String(team))
+	defer span.End()
+
+	var users []userv1.UserSpec
+
+	cursor, err := c.coll(ctx, "users").Find(ctx, bson.D{{Key: "teams", Value: team}}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	err = cursor.All(ctx, &users)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return users, nil
+}
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"testing"
+
+	userv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/user/v1"
+
+	"github.com/orlangure/gnomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemberships(t *testing.T) {
+	uri, container := setupDatabase(t)
+	defer func(cs *gnomock.Container) {
+		err := gnomock.Stop(cs)
+		if err != nil {
+			t.Error(err)
+		}
+	}(container)
+	c, _ := NewClient(Config{URI: uri})
+
+	t.Run("SaveMembership and GetMembershipByUser", func(t *testing.T) {
+		ctx := ctx(t)
+
+		membership, err := c.GetMembershipByUser(ctx, "user1@kobs.io")
+		require.NoError(t, err)
+		require.Nil(t, membership)
+
+		require.NoError(t, c.SaveMembership(ctx, "user1@kobs.io", "User 1", []string{"team1", "team2"}))
+		require.NoError(t, c.SaveMembership(ctx, "user1@kobs.io", "User 1", []string{"team1"}))
+
+		membership, err = c.GetMembershipByUser(ctx, "user1@kobs.io")
+		require.NoError(t, err)
+		require.Equal(t, "User 1", membership.Name)
+		require.Equal(t, []string{"team1"}, membership.Teams)
+	})
+
+	t.Run("GetMembershipsByTeam", func(t *testing.T) {
+		ctx := ctx(t)
+
+		require.NoError(t, c.SaveMembership(ctx, "user2@kobs.io", "User 2", []string{"team1", "team2"}))
+		require.NoError(t, c.SaveMembership(ctx, "user3@kobs.io", "User 3", nil))
+
+		memberships, err := c.GetMembershipsByTeam(ctx, "team1")
+		require.NoError(t, err)
+		require.Len(t, memberships, 2)
+		require.Equal(t, "user1@kobs.io", memberships[0].User)
+		require.Equal(t, "user2@kobs.io", memberships[1].User)
+
+		memberships, err = c.GetMembershipsByTeam(ctx, "team2")
+		require.NoError(t, err)
+		require.Len(t, memberships, 1)
+	})
+
+	t.Run("GetUsersByTeam", func(t *testing.T) {
+		ctx := ctx(t)
+
+		require.NoError(t, c.SaveUsers(ctx, "test-cluster", []userv1.UserSpec{
+			{ID: "user1@kobs.io", Cluster: "test-cluster", Namespace: "default", Name: "user1", Teams: []string{"team1"}},
+			{ID: "user2@kobs.io", Cluster: "test-cluster", Namespace: "default", Name: "user2", Teams: []string{"team2"}},
+		}))
+
+		users, err := c.GetUsersByTeam(ctx, "team1")
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		require.Equal(t, "user1@kobs.io", users[0].ID)
+	})
+}
@@ -0,0 +1,183 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const (
+	// PreferenceApplication is the type for applications in the favorites and recently viewed items of a user.
+	PreferenceApplication = "application"
+	// PreferenceDashboard is the type for dashboards in the favorites and recently viewed items of a user.
+	PreferenceDashboard = "dashboard"
+	// PreferenceTeam is the type for teams in the favorites of a user.
+	PreferenceTeam = "team"
+
+	// MaxRecentlyViewed is the maximum number of recently viewed items, which are saved for a user.
+	MaxRecentlyViewed = 20
+)
+
+// favoriteFields maps the type of a favorite to the field in the preferences document, where the favorite is saved.
+var favoriteFields = map[string]string{
+	PreferenceApplication: "favorites.applications",
+	PreferenceDashboard:   "favorites.dashboards",
+	PreferenceTeam:        "favorites.teams",
+}
+
+// Preferences is the structure of the preferences of a single user, which are saved in the database. The id of the
+// user is used as id for the preferences.
+type Preferences struct {
+	User           string           `json:"user" bson:"_id"`
+	Favorites      Favorites        `json:"favorites" bson:"favorites"`
+	RecentlyViewed []RecentlyViewed `json:"recentlyViewed" bson:"recentlyViewed"`
+	UpdatedAt      int64            `json:"updatedAt" bson:"updatedAt"`
+}
+
+// Favorites contains the ids of the applications, dashboards and teams, which were marked as favorite by a user.
+type Favorites struct {
+	Applications []string `json:"applications" bson:"applications"`
+	Dashboards   []string `json:"dashboards" bson:"dashboards"`
+	Teams        []string `json:"teams" bson:"teams"`
+}
+
+// RecentlyViewed is an application or dashboard which was recently viewed by a user.
+type RecentlyViewed struct {
+	Type     string `json:"type" bson:"type"`
+	ID       string `json:"id" bson:"id"`
+	ViewedAt int64  `json:"viewedAt" bson:"viewedAt"`
+}
+
+// GetPreferences returns the preferences of the provided user. If the user doesn't have any preferences yet, empty
+// preferences are returned.
+func (c *client) GetPreferences(ctx context.Context, user string) (*Preferences, error) {
+	ctx, span := c.tracer.Start(ctx, "db.GetPreferences")
+	span.SetAttributes(attribute.Key("user").String(user))
+	defer span.End()
+
+	res := c.coll(ctx, "preferences").FindOne(ctx, bson.D{{Key: "_id", Value: user}})
+	if res.Err() != nil {
+		if res.Err() == mongo.ErrNoDocuments {
+			return &Preferences{User: user}, nil
+		}
+
+		span.RecordError(res.Err())
+		span.SetStatus(codes.Error, res.Err().Error())
+		return nil, res.Err()
+	}
+
+	var preferences Preferences
+	err := res.Decode(&preferences)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return &preferences, nil
+}
+
+// AddFavorite adds the item with the provided type and id to the favorites of the user. If the item is already a
+// favorite of the user, nothing is changed.
+func (c *client) AddFavorite(ctx context.Context, user, itemType, id string) error {
+	return c.updateFavorites(ctx, "db.AddFavorite", "$addToSet", user, itemType, id)
+}
+
+// RemoveFavorite removes the item with the provided type and id from the favorites of the user.
+func (c *client) RemoveFavorite(ctx context.Context, user, itemType, id string) error {
+	return c.updateFavorites(ctx, "db.RemoveFavorite", "$pull", user, itemType, id)
+}
+
+func (c *client) updateFavorites(ctx context.Context, spanName, operator, user, itemType, id string) error {
+	ctx, span := c.tracer.Start(ctx, spanName)
+	span.SetAttributes(attribute.Key("user").String(user))
+	span.SetAttributes(attribute.Key("type").String(itemType))
+	span.SetAttributes(attribute.Key("id").String(id))
+	defer span.End()
+
+	field, ok := favoriteFields[itemType]
+	if !ok {
+		err := fmt.Errorf("invalid favorite type %q", itemType)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	update := bson.D{
+		{Key: operator, Value: bson.D{{Key: field, Value: id}}},
+		{Key: "$set", Value: bson.D{{Key: "updatedAt", Value: time.Now().UnixMilli()}}},
+	}
+
+	_, err := c.coll(ctx, "preferences").UpdateOne(ctx, bson.D{{Key: "_id", Value: user}}, update, options.Update().SetUpsert(true))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// AddRecentlyViewed adds the item with the provided type and id to the top of the recently viewed items of the user.
+// If the item was already viewed before, the old entry is removed, so that each item is only contained once. We only
+// keep the last MaxRecentlyViewed items.
+func (c *client) AddRecentlyViewed(ctx context.Context, user, itemType, id string) error {
+	ctx, span := c.tracer.Start(ctx, "db.AddRecentlyViewed")
+	span.SetAttributes(attribute.Key("user").String(user))
+	span.SetAttributes(attribute.Key("type").String(itemType))
+	span.SetAttributes(attribute.Key("id").String(id))
+	defer span.End()
+
+	now := time.Now().UnixMilli()
+	filter := bson.D{{Key: "_id", Value: user}}
+
+	pull := bson.D{{Key: "$pull", Value: bson.D{{Key: "recentlyViewed", Value: bson.D{{Key: "type", Value: itemType}, {Key: "id", Value: id}}}}}}
+	if _, err := c.coll(ctx, "preferences").UpdateOne(ctx, filter, pull); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	push := bson.D{
+		{Key: "$push", Value: bson.D{{Key: "recentlyViewed", Value: bson.D{
+			{Key: "$each", Value: bson.A{RecentlyViewed{Type: itemType, ID: id, ViewedAt: now}}},
+			{Key: "$position", Value: 0},
+			{Key: "$slice", Value: MaxRecentlyViewed},
+		}}}},
+		{Key: "$set", Value: bson.D{{Key: "updatedAt", Value: now}}},
+	}
+	if _, err := c.coll(ctx, "preferences").UpdateOne(ctx, filter, push, options.Update().SetUpsert(true)); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// DeleteRecentlyViewed removes all recently viewed items of the user.
+func (c *client) DeleteRecentlyViewed(ctx context.Context, user string) error {
+	ctx, span := c.tracer.Start(ctx, "db.DeleteRecentlyViewed")
+	span.SetAttributes(attribute.Key("user").String(user))
+	defer span.End()
+
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "recentlyViewed", Value: bson.A{}},
+		{Key: "updatedAt", Value: time.Now().UnixMilli()},
+	}}}
+
+	_, err := c.coll(ctx, "preferences").UpdateOne(ctx, bson.D{{Key: "_id", Value: user}}, update)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
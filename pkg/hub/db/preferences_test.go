@@ -0,0 +1,67 @@
+package db
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/orlangure/gnomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreferences(t *testing.T) {
+	uri, container := setupDatabase(t)
+	defer func(cs *gnomock.Container) {
+		err := gnomock.Stop(cs)
+		if err != nil {
+			t.Error(err)
+		}
+	}(container)
+	c, _ := NewClient(Config{URI: uri})
+
+	t.Run("GetPreferences", func(t *testing.T) {
+		preferences, err := c.GetPreferences(ctx(t), "user1@kobs.io")
+		require.NoError(t, err)
+		require.Equal(t, &Preferences{User: "user1@kobs.io"}, preferences)
+	})
+
+	t.Run("AddFavorite and RemoveFavorite", func(t *testing.T) {
+		ctx := ctx(t)
+
+		require.NoError(t, c.AddFavorite(ctx, "user1@kobs.io", PreferenceApplication, "app1"))
+		require.NoError(t, c.AddFavorite(ctx, "user1@kobs.io", PreferenceApplication, "app1"))
+		require.NoError(t, c.AddFavorite(ctx, "user1@kobs.io", PreferenceDashboard, "dashboard1"))
+		require.NoError(t, c.AddFavorite(ctx, "user1@kobs.io", PreferenceTeam, "team1"))
+		require.Error(t, c.AddFavorite(ctx, "user1@kobs.io", "user", "user2@kobs.io"))
+
+		preferences, err := c.GetPreferences(ctx, "user1@kobs.io")
+		require.NoError(t, err)
+		require.Equal(t, Favorites{Applications: []string{"app1"}, Dashboards: []string{"dashboard1"}, Teams: []string{"team1"}}, preferences.Favorites)
+
+		require.NoError(t, c.RemoveFavorite(ctx, "user1@kobs.io", PreferenceApplication, "app1"))
+
+		preferences, err = c.GetPreferences(ctx, "user1@kobs.io")
+		require.NoError(t, err)
+		require.Empty(t, preferences.Favorites.Applications)
+	})
+
+	t.Run("AddRecentlyViewed and DeleteRecentlyViewed", func(t *testing.T) {
+		ctx := ctx(t)
+
+		for i := 0; i < MaxRecentlyViewed+5; i++ {
+			require.NoError(t, c.AddRecentlyViewed(ctx, "user1@kobs.io", PreferenceApplication, fmt.Sprintf("app%d", i)))
+		}
+		require.NoError(t, c.AddRecentlyViewed(ctx, "user1@kobs.io", PreferenceApplication, "app10"))
+
+		preferences, err := c.GetPreferences(ctx, "user1@kobs.io")
+		require.NoError(t, err)
+		require.Len(t, preferences.RecentlyViewed, MaxRecentlyViewed)
+		require.Equal(t, "app10", preferences.RecentlyViewed[0].ID)
+		require.Equal(t, fmt.Sprintf("app%d", MaxRecentlyViewed+4), preferences.RecentlyViewed[1].ID)
+
+		require.NoError(t, c.DeleteRecentlyViewed(ctx, "user1@kobs.io"))
+
+		preferences, err = c.GetPreferences(ctx, "user1@kobs.io")
+		require.NoError(t, err)
+		require.Empty(t, preferences.RecentlyViewed)
+	})
+}
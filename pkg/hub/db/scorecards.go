@@ -0,0 +1,165 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// Scorecard is the result of the evaluation of all scorecard rules for a single application. All scorecards of one
+// evaluation run have the same creation time, so that we can aggregate the scores of multiple applications (e.g. for a
+// team) over time.
+type Scorecard struct {
+	Application string            `json:"application" bson:"application"`
+	Teams       []string          `json:"teams" bson:"teams"`
+	Score       float64           `json:"score" bson:"score"`
+	Results     []ScorecardResult `json:"results" bson:"results"`
+	CreatedAt   time.Time         `json:"createdAt" bson:"createdAt"`
+}
+
+// ScorecardResult is the result of a single scorecard rule for an application. The message contains the reason why a
+// rule is not passed.
+type ScorecardResult struct {
+	Rule    string `json:"rule" bson:"rule"`
+	Title   string `json:"title" bson:"title"`
+	Weight  int    `json:"weight" bson:"weight"`
+	Passed  bool   `json:"passed" bson:"passed"`
+	Message string `json:"message,omitempty" bson:"message"`
+}
+
+// ScorecardHistory is the average score of all selected applications for a single evaluation run.
+type ScorecardHistory struct {
+	CreatedAt    time.Time `json:"createdAt" bson:"_id"`
+	Score        float64   `json:"score" bson:"score"`
+	Applications int       `json:"applications" bson:"applications"`
+}
+
+// scorecardsFilter returns the filter for the scorecards of the provided teams and applications. Empty lists are
+// ignored.
+func scorecardsFilter(teams, applications []string) bson.D {
+	filter := bson.D{}
+
+	if len(teams) > 0 {
+		filter = append(filter, bson.E{Key: "teams", Value: bson.D{{Key: "$in", Value: teams}}})
+	}
+
+	if len(applications) > 0 {
+		filter = append(filter, bson.E{Key: "application", Value: bson.D{{Key: "$in", Value: applications}}})
+	}
+
+	return filter
+}
+
+// SaveScorecards saves the scorecards of a single evaluation run. The scorecards are not updated, instead we insert
+// new documents for each run, so that we can show the history of the scores.
+func (c *client) SaveScorecards(ctx context.Context, scorecards []Scorecard) error {
+	if len(scorecards) == 0 {
+		return nil
+	}
+
+	ctx, span := c.tracer.Start(ctx, "db.SaveScorecards")
+	span.SetAttributes(attribute.Key("scorecards").Int(len(scorecards)))
+	defer span.End()
+
+	documents := make([]any, 0, len(scorecards))
+	for _, scorecard := range scorecards {
+		documents = append(documents, scorecard)
+	}
+
+	_, err := c.coll(ctx, "scorecards").InsertMany(ctx, documents)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// GetLatestScorecards returns the scorecards of the latest evaluation run for the selected applications. The
+// applications can be selected by their teams and / or their ids. We only use the latest run, because the scorecards of
+// applications which were deleted or moved to another team are kept until they expire.
+func (c *client) GetLatestScorecards(ctx context.Context, teams, applications []string) ([]Scorecard, error) {
+	ctx, span := c.tracer.Start(ctx, "db.GetLatestScorecards")
+	span.SetAttributes(attribute.Key("teams").StringSlice(teams))
+	span.SetAttributes(attribute.Key("applications").StringSlice(applications))
+	defer span.End()
+
+	var latest Scorecard
+
+	err := c.coll(ctx, "scorecards").FindOne(ctx, bson.D{}, options.FindOne().SetSort(bson.D{{Key: "createdAt", Value: -1}}).SetProjection(bson.D{{Key: "createdAt", Value: 1}})).Decode(&latest)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	filter := scorecardsFilter(teams, applications)
+	filter = append(filter, bson.E{Key: "createdAt", Value: latest.CreatedAt})
+
+	var scorecards []Scorecard
+
+	cursor, err := c.coll(ctx, "scorecards").Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "application", Value: 1}}))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	err = cursor.All(ctx, &scorecards)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return scorecards, nil
+}
+
+// GetScorecardHistory returns the average score of the selected applications for each evaluation run in the provided
+// time range.
+func (c *client) GetScorecardHistory(ctx context.Context, teams, applications []string, timeStart, timeEnd time.Time) ([]ScorecardHistory, error) {
+	ctx, span := c.tracer.Start(ctx, "db.GetScorecardHistory")
+	span.SetAttributes(attribute.Key("teams").StringSlice(teams))
+	span.SetAttributes(attribute.Key("applications").StringSlice(applications))
+	span.SetAttributes(attribute.Key("timeStart").String(timeStart.String()))
+	span.SetAttributes(attribute.Key("timeEnd").String(timeEnd.String()))
+	defer span.End()
+
+	filter := scorecardsFilter(teams, applications)
+	filter = append(filter, bson.E{Key: "createdAt", Value: bson.D{{Key: "$gte", Value: timeStart}, {Key: "$lte", Value: timeEnd}}})
+
+	var history []ScorecardHistory
+
+	cursor, err := c.coll(ctx, "scorecards").Aggregate(ctx, mongo.Pipeline{
+		bson.D{{Key: "$match", Value: filter}},
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$createdAt"},
+			{Key: "score", Value: bson.D{{Key: "$avg", Value: "$score"}}},
+			{Key: "applications", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	err = cursor.All(ctx, &history)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return history, nil
+}
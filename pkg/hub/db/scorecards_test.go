@@ -0,0 +1,75 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/orlangure/gnomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScorecards(t *testing.T) {
+	uri, container := setupDatabase(t)
+	defer func(cs *gnomock.Container) {
+		err := gnomock.Stop(cs)
+		if err != nil {
+			t.Error(err)
+		}
+	}(container)
+	c, _ := NewClient(Config{URI: uri})
+
+	run1 := time.Now().Add(-2 * time.Hour).Truncate(time.Millisecond).UTC()
+	run2 := time.Now().Add(-1 * time.Hour).Truncate(time.Millisecond).UTC()
+
+	t.Run("GetLatestScorecardsWithoutRuns", func(t *testing.T) {
+		scorecards, err := c.GetLatestScorecards(ctx(t), []string{"team1"}, nil)
+		require.NoError(t, err)
+		require.Empty(t, scorecards)
+	})
+
+	t.Run("SaveScorecards", func(t *testing.T) {
+		require.NoError(t, c.SaveScorecards(ctx(t), nil))
+		require.NoError(t, c.SaveScorecards(ctx(t), []Scorecard{
+			{Application: "app1", Teams: []string{"team1"}, Score: 50, CreatedAt: run1},
+			{Application: "app2", Teams: []string{"team1", "team2"}, Score: 100, CreatedAt: run1},
+			{Application: "app3", Teams: []string{"team3"}, Score: 100, CreatedAt: run1},
+		}))
+		require.NoError(t, c.SaveScorecards(ctx(t), []Scorecard{
+			{Application: "app1", Teams: []string{"team1"}, Score: 100, CreatedAt: run2},
+			{Application: "app2", Teams: []string{"team1", "team2"}, Score: 0, CreatedAt: run2},
+		}))
+	})
+
+	t.Run("GetLatestScorecards", func(t *testing.T) {
+		scorecards, err := c.GetLatestScorecards(ctx(t), []string{"team1"}, nil)
+		require.NoError(t, err)
+		require.Len(t, scorecards, 2)
+		require.Equal(t, "app1", scorecards[0].Application)
+		require.Equal(t, float64(100), scorecards[0].Score)
+		require.Equal(t, "app2", scorecards[1].Application)
+		require.Equal(t, float64(0), scorecards[1].Score)
+
+		scorecards, err = c.GetLatestScorecards(ctx(t), nil, []string{"app1"})
+		require.NoError(t, err)
+		require.Len(t, scorecards, 1)
+		require.Equal(t, run2, scorecards[0].CreatedAt.UTC())
+
+		scorecards, err = c.GetLatestScorecards(ctx(t), []string{"team3"}, nil)
+		require.NoError(t, err)
+		require.Empty(t, scorecards)
+	})
+
+	t.Run("GetScorecardHistory", func(t *testing.T) {
+		history, err := c.GetScorecardHistory(ctx(t), []string{"team1"}, nil, run1.Add(-time.Minute), run2.Add(time.Minute))
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		require.Equal(t, float64(75), history[0].Score)
+		require.Equal(t, 2, history[0].Applications)
+		require.Equal(t, float64(50), history[1].Score)
+
+		history, err = c.GetScorecardHistory(ctx(t), []string{"team2"}, nil, run2.Add(-time.Minute), run2.Add(time.Minute))
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		require.Equal(t, float64(0), history[0].Score)
+	})
+}
@@ -0,0 +1,250 @@
+package health
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// builtins contains the built-in rules for the core workloads. The key of the map is the id of the resource.
+var builtins = map[string]EvaluateFunc{
+	"daemonsets":             evaluateDaemonSet,
+	"deployments":            evaluateDeployment,
+	"jobs":                   evaluateJob,
+	"nodes":                  evaluateNode,
+	"persistentvolumeclaims": evaluatePersistentVolumeClaim,
+	"pods":                   evaluatePod,
+	"replicasets":            evaluateReplicaSet,
+	"statefulsets":           evaluateStatefulSet,
+}
+
+// podErrorReasons are the reasons for a waiting container, which indicate that the Pod will not become ready without
+// an intervention of the user.
+var podErrorReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"ErrImagePull":               true,
+	"ImagePullBackOff":           true,
+	"InvalidImageName":           true,
+}
+
+func evaluateDeployment(obj map[string]any) (Health, bool) {
+	var deployment appsv1.Deployment
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, &deployment); err != nil {
+		return Health{}, false
+	}
+
+	if deployment.Spec.Paused {
+		return Health{Status: StatusUnknown, Message: "Deployment is paused"}, true
+	}
+
+	if deployment.Generation > deployment.Status.ObservedGeneration {
+		return Health{Status: StatusProgressing, Message: "Waiting for rollout to be observed"}, true
+	}
+
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+			return Health{Status: StatusDegraded, Message: fmt.Sprintf("Deployment %s exceeded its progress deadline", deployment.Name)}, true
+		}
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	if deployment.Status.UpdatedReplicas < replicas {
+		return Health{Status: StatusProgressing, Message: fmt.Sprintf("%d of %d replicas have been updated", deployment.Status.UpdatedReplicas, replicas)}, true
+	}
+
+	if deployment.Status.Replicas > deployment.Status.UpdatedReplicas {
+		return Health{Status: StatusProgressing, Message: fmt.Sprintf("%d old replicas are pending termination", deployment.Status.Replicas-deployment.Status.UpdatedReplicas)}, true
+	}
+
+	if deployment.Status.AvailableReplicas < deployment.Status.UpdatedReplicas {
+		return Health{Status: StatusProgressing, Message: fmt.Sprintf("%d of %d updated replicas are available", deployment.Status.AvailableReplicas, deployment.Status.UpdatedReplicas)}, true
+	}
+
+	return Health{Status: StatusHealthy}, true
+}
+
+func evaluateStatefulSet(obj map[string]any) (Health, bool) {
+	var statefulSet appsv1.StatefulSet
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, &statefulSet); err != nil {
+		return Health{}, false
+	}
+
+	if statefulSet.Generation > statefulSet.Status.ObservedGeneration {
+		return Health{Status: StatusProgressing, Message: "Waiting for rollout to be observed"}, true
+	}
+
+	replicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+
+	if statefulSet.Status.ReadyReplicas < replicas {
+		return Health{Status: StatusProgressing, Message: fmt.Sprintf("%d of %d replicas are ready", statefulSet.Status.ReadyReplicas, replicas)}, true
+	}
+
+	if statefulSet.Spec.UpdateStrategy.Type != appsv1.OnDeleteStatefulSetStrategyType && statefulSet.Status.UpdateRevision != "" && statefulSet.Status.CurrentRevision != statefulSet.Status.UpdateRevision {
+		return Health{Status: StatusProgressing, Message: fmt.Sprintf("%d of %d replicas have been updated", statefulSet.Status.UpdatedReplicas, replicas)}, true
+	}
+
+	return Health{Status: StatusHealthy}, true
+}
+
+func evaluateDaemonSet(obj map[string]any) (Health, bool) {
+	var daemonSet appsv1.DaemonSet
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, &daemonSet); err != nil {
+		return Health{}, false
+	}
+
+	if daemonSet.Generation > daemonSet.Status.ObservedGeneration {
+		return Health{Status: StatusProgressing, Message: "Waiting for rollout to be observed"}, true
+	}
+
+	if daemonSet.Spec.UpdateStrategy.Type != appsv1.OnDeleteDaemonSetStrategyType && daemonSet.Status.UpdatedNumberScheduled < daemonSet.Status.DesiredNumberScheduled {
+		return Health{Status: StatusProgressing, Message: fmt.Sprintf("%d of %d pods have been updated", daemonSet.Status.UpdatedNumberScheduled, daemonSet.Status.DesiredNumberScheduled)}, true
+	}
+
+	if daemonSet.Status.NumberAvailable < daemonSet.Status.DesiredNumberScheduled {
+		return Health{Status: StatusProgressing, Message: fmt.Sprintf("%d of %d pods are available", daemonSet.Status.NumberAvailable, daemonSet.Status.DesiredNumberScheduled)}, true
+	}
+
+	return Health{Status: StatusHealthy}, true
+}
+
+func evaluateReplicaSet(obj map[string]any) (Health, bool) {
+	var replicaSet appsv1.ReplicaSet
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, &replicaSet); err != nil {
+		return Health{}, false
+	}
+
+	for _, condition := range replicaSet.Status.Conditions {
+		if condition.Type == appsv1.ReplicaSetReplicaFailure && condition.Status == corev1.ConditionTrue {
+			return Health{Status: StatusDegraded, Message: condition.Message}, true
+		}
+	}
+
+	replicas := int32(1)
+	if replicaSet.Spec.Replicas != nil {
+		replicas = *replicaSet.Spec.Replicas
+	}
+
+	if replicaSet.Status.AvailableReplicas < replicas {
+		return Health{Status: StatusProgressing, Message: fmt.Sprintf("%d of %d replicas are available", replicaSet.Status.AvailableReplicas, replicas)}, true
+	}
+
+	return Health{Status: StatusHealthy}, true
+}
+
+func evaluatePod(obj map[string]any) (Health, bool) {
+	var pod corev1.Pod
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, &pod); err != nil {
+		return Health{}, false
+	}
+
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		return Health{Status: StatusHealthy, Message: pod.Status.Message}, true
+	case corev1.PodFailed:
+		return Health{Status: StatusDegraded, Message: podMessage(pod)}, true
+	case corev1.PodPending, corev1.PodRunning:
+		for _, containerStatuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+			for _, containerStatus := range containerStatuses {
+				if containerStatus.State.Waiting != nil && podErrorReasons[containerStatus.State.Waiting.Reason] {
+					return Health{Status: StatusDegraded, Message: fmt.Sprintf("Container %s: %s", containerStatus.Name, containerStatus.State.Waiting.Reason)}, true
+				}
+			}
+		}
+
+		if pod.Status.Phase == corev1.PodPending {
+			return Health{Status: StatusProgressing, Message: podMessage(pod)}, true
+		}
+
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady && condition.Status != corev1.ConditionTrue {
+				return Health{Status: StatusProgressing, Message: "Pod is not ready"}, true
+			}
+		}
+
+		return Health{Status: StatusHealthy}, true
+	default:
+		return Health{Status: StatusUnknown, Message: podMessage(pod)}, true
+	}
+}
+
+// podMessage returns the message of a Pod. If the Pod doesn't contain a message we use the reason instead.
+func podMessage(pod corev1.Pod) string {
+	if pod.Status.Message != "" {
+		return pod.Status.Message
+	}
+
+	return pod.Status.Reason
+}
+
+func evaluateJob(obj map[string]any) (Health, bool) {
+	var job batchv1.Job
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, &job); err != nil {
+		return Health{}, false
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		switch condition.Type {
+		case batchv1.JobFailed:
+			return Health{Status: StatusDegraded, Message: condition.Message}, true
+		case batchv1.JobComplete:
+			return Health{Status: StatusHealthy, Message: condition.Message}, true
+		case batchv1.JobSuspended:
+			return Health{Status: StatusUnknown, Message: "Job is suspended"}, true
+		}
+	}
+
+	return Health{Status: StatusProgressing, Message: "Job is running"}, true
+}
+
+func evaluatePersistentVolumeClaim(obj map[string]any) (Health, bool) {
+	var pvc corev1.PersistentVolumeClaim
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, &pvc); err != nil {
+		return Health{}, false
+	}
+
+	switch pvc.Status.Phase {
+	case corev1.ClaimBound:
+		return Health{Status: StatusHealthy}, true
+	case corev1.ClaimPending:
+		return Health{Status: StatusProgressing, Message: "PersistentVolumeClaim is pending"}, true
+	case corev1.ClaimLost:
+		return Health{Status: StatusDegraded, Message: "PersistentVolumeClaim lost its PersistentVolume"}, true
+	default:
+		return Health{Status: StatusUnknown}, true
+	}
+}
+
+func evaluateNode(obj map[string]any) (Health, bool) {
+	var node corev1.Node
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, &node); err != nil {
+		return Health{}, false
+	}
+
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			if condition.Status == corev1.ConditionTrue {
+				return Health{Status: StatusHealthy}, true
+			}
+
+			return Health{Status: StatusDegraded, Message: condition.Message}, true
+		}
+	}
+
+	return Health{Status: StatusUnknown}, true
+}
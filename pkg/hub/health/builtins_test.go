@@ -0,0 +1,64 @@
+package health
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltins(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		resource       string
+		manifest       string
+		expectedHealth Health
+	}{
+		{name: "deployment paused", resource: "deployments", manifest: `{"spec": {"paused": true}}`, expectedHealth: Health{Status: StatusUnknown, Message: "Deployment is paused"}},
+		{name: "deployment not observed", resource: "deployments", manifest: `{"metadata": {"generation": 2}, "status": {"observedGeneration": 1}}`, expectedHealth: Health{Status: StatusProgressing, Message: "Waiting for rollout to be observed"}},
+		{name: "deployment progress deadline exceeded", resource: "deployments", manifest: `{"metadata": {"name": "nginx"}, "status": {"conditions": [{"type": "Progressing", "status": "False", "reason": "ProgressDeadlineExceeded"}]}}`, expectedHealth: Health{Status: StatusDegraded, Message: "Deployment nginx exceeded its progress deadline"}},
+		{name: "deployment updating", resource: "deployments", manifest: `{"spec": {"replicas": 3}, "status": {"replicas": 3, "updatedReplicas": 1}}`, expectedHealth: Health{Status: StatusProgressing, Message: "1 of 3 replicas have been updated"}},
+		{name: "deployment terminating old replicas", resource: "deployments", manifest: `{"spec": {"replicas": 3}, "status": {"replicas": 4, "updatedReplicas": 3}}`, expectedHealth: Health{Status: StatusProgressing, Message: "1 old replicas are pending termination"}},
+		{name: "deployment not available", resource: "deployments", manifest: `{"spec": {"replicas": 3}, "status": {"replicas": 3, "updatedReplicas": 3, "availableReplicas": 2}}`, expectedHealth: Health{Status: StatusProgressing, Message: "2 of 3 updated replicas are available"}},
+		{name: "deployment healthy", resource: "deployments", manifest: `{"spec": {"replicas": 3}, "status": {"replicas": 3, "updatedReplicas": 3, "availableReplicas": 3}}`, expectedHealth: Health{Status: StatusHealthy}},
+		{name: "deployment scaled to zero", resource: "deployments", manifest: `{"spec": {"replicas": 0}, "status": {}}`, expectedHealth: Health{Status: StatusHealthy}},
+
+		{name: "statefulset not ready", resource: "statefulsets", manifest: `{"spec": {"replicas": 3}, "status": {"readyReplicas": 2}}`, expectedHealth: Health{Status: StatusProgressing, Message: "2 of 3 replicas are ready"}},
+		{name: "statefulset updating", resource: "statefulsets", manifest: `{"spec": {"replicas": 3}, "status": {"readyReplicas": 3, "updatedReplicas": 1, "currentRevision": "v1", "updateRevision": "v2"}}`, expectedHealth: Health{Status: StatusProgressing, Message: "1 of 3 replicas have been updated"}},
+		{name: "statefulset on delete", resource: "statefulsets", manifest: `{"spec": {"replicas": 3, "updateStrategy": {"type": "OnDelete"}}, "status": {"readyReplicas": 3, "currentRevision": "v1", "updateRevision": "v2"}}`, expectedHealth: Health{Status: StatusHealthy}},
+
+		{name: "daemonset updating", resource: "daemonsets", manifest: `{"status": {"desiredNumberScheduled": 3, "updatedNumberScheduled": 2, "numberAvailable": 3}}`, expectedHealth: Health{Status: StatusProgressing, Message: "2 of 3 pods have been updated"}},
+		{name: "daemonset not available", resource: "daemonsets", manifest: `{"status": {"desiredNumberScheduled": 3, "updatedNumberScheduled": 3, "numberAvailable": 1}}`, expectedHealth: Health{Status: StatusProgressing, Message: "1 of 3 pods are available"}},
+		{name: "daemonset healthy", resource: "daemonsets", manifest: `{"status": {"desiredNumberScheduled": 3, "updatedNumberScheduled": 3, "numberAvailable": 3}}`, expectedHealth: Health{Status: StatusHealthy}},
+
+		{name: "replicaset failure", resource: "replicasets", manifest: `{"status": {"conditions": [{"type": "ReplicaFailure", "status": "True", "message": "quota exceeded"}]}}`, expectedHealth: Health{Status: StatusDegraded, Message: "quota exceeded"}},
+		{name: "replicaset healthy", resource: "replicasets", manifest: `{"spec": {"replicas": 2}, "status": {"availableReplicas": 2}}`, expectedHealth: Health{Status: StatusHealthy}},
+
+		{name: "pod succeeded", resource: "pods", manifest: `{"status": {"phase": "Succeeded"}}`, expectedHealth: Health{Status: StatusHealthy}},
+		{name: "pod crash loop", resource: "pods", manifest: `{"status": {"phase": "Running", "containerStatuses": [{"name": "app", "state": {"waiting": {"reason": "CrashLoopBackOff"}}}]}}`, expectedHealth: Health{Status: StatusDegraded, Message: "Container app: CrashLoopBackOff"}},
+		{name: "pod image pull error", resource: "pods", manifest: `{"status": {"phase": "Pending", "initContainerStatuses": [{"name": "init", "state": {"waiting": {"reason": "ImagePullBackOff"}}}]}}`, expectedHealth: Health{Status: StatusDegraded, Message: "Container init: ImagePullBackOff"}},
+		{name: "pod pending", resource: "pods", manifest: `{"status": {"phase": "Pending", "reason": "Unschedulable"}}`, expectedHealth: Health{Status: StatusProgressing, Message: "Unschedulable"}},
+		{name: "pod not ready", resource: "pods", manifest: `{"status": {"phase": "Running", "conditions": [{"type": "Ready", "status": "False"}]}}`, expectedHealth: Health{Status: StatusProgressing, Message: "Pod is not ready"}},
+		{name: "pod unknown", resource: "pods", manifest: `{"status": {"phase": "Unknown"}}`, expectedHealth: Health{Status: StatusUnknown}},
+
+		{name: "job failed", resource: "jobs", manifest: `{"status": {"conditions": [{"type": "Failed", "status": "True", "message": "BackoffLimitExceeded"}]}}`, expectedHealth: Health{Status: StatusDegraded, Message: "BackoffLimitExceeded"}},
+		{name: "job complete", resource: "jobs", manifest: `{"status": {"conditions": [{"type": "Complete", "status": "True"}]}}`, expectedHealth: Health{Status: StatusHealthy}},
+		{name: "job running", resource: "jobs", manifest: `{"status": {"active": 1}}`, expectedHealth: Health{Status: StatusProgressing, Message: "Job is running"}},
+
+		{name: "pvc bound", resource: "persistentvolumeclaims", manifest: `{"status": {"phase": "Bound"}}`, expectedHealth: Health{Status: StatusHealthy}},
+		{name: "pvc lost", resource: "persistentvolumeclaims", manifest: `{"status": {"phase": "Lost"}}`, expectedHealth: Health{Status: StatusDegraded, Message: "PersistentVolumeClaim lost its PersistentVolume"}},
+
+		{name: "node ready", resource: "nodes", manifest: `{"status": {"conditions": [{"type": "Ready", "status": "True"}]}}`, expectedHealth: Health{Status: StatusHealthy}},
+		{name: "node not ready", resource: "nodes", manifest: `{"status": {"conditions": [{"type": "Ready", "status": "Unknown", "message": "Kubelet stopped posting node status."}]}}`, expectedHealth: Health{Status: StatusDegraded, Message: "Kubelet stopped posting node status."}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			health, ok := builtins[tt.resource](decode(t, tt.manifest))
+			require.True(t, ok)
+			require.Equal(t, tt.expectedHealth, health)
+		})
+	}
+
+	t.Run("should not evaluate invalid manifest", func(t *testing.T) {
+		_, ok := evaluateDeployment(decode(t, `{"spec": {"replicas": "three"}}`))
+		require.False(t, ok)
+	})
+}
@@ -0,0 +1,121 @@
+package health
+
+// evaluateConditions is the default health evaluation for all resources without a built-in or configured rule. It
+// follows the conventions for the conditions of Custom Resources:
+//
+//   - When the resource has a "Stalled" condition with status "True", the resource is degraded.
+//   - When the resource has a "Reconciling" condition with status "True" or the controller has not observed the latest
+//     generation, the resource is progressing.
+//   - When the resource has a "Ready" or "Available" condition, the resource is healthy when the status is "True",
+//     degraded when the status is "False" and progressing when the status is "Unknown".
+//
+// If the resource doesn't have any conditions, we are not able to evaluate the health of the resource.
+func evaluateConditions(obj map[string]any) (Health, bool) {
+	status, ok := obj["status"].(map[string]any)
+	if !ok {
+		return Health{}, false
+	}
+
+	conditions, ok := status["conditions"].([]any)
+	if !ok || len(conditions) == 0 {
+		return Health{}, false
+	}
+
+	conditionsByType := make(map[string]map[string]any)
+	for _, condition := range conditions {
+		if c, ok := condition.(map[string]any); ok {
+			if conditionType, ok := c["type"].(string); ok {
+				conditionsByType[conditionType] = c
+			}
+		}
+	}
+
+	if c, ok := conditionsByType["Stalled"]; ok && c["status"] == "True" {
+		return Health{Status: StatusDegraded, Message: conditionMessage(c)}, true
+	}
+
+	if c, ok := conditionsByType["Reconciling"]; ok && c["status"] == "True" {
+		return Health{Status: StatusProgressing, Message: conditionMessage(c)}, true
+	}
+
+	if generation, ok := nestedNumber(obj, "metadata", "generation"); ok {
+		if observedGeneration, ok := nestedNumber(obj, "status", "observedGeneration"); ok && observedGeneration < generation {
+			return Health{Status: StatusProgressing, Message: "Waiting for the latest generation to be observed"}, true
+		}
+	}
+
+	for _, conditionType := range []string{"Ready", "Available"} {
+		if c, ok := conditionsByType[conditionType]; ok {
+			switch c["status"] {
+			case "True":
+				return Health{Status: StatusHealthy, Message: conditionMessage(c)}, true
+			case "False":
+				return Health{Status: StatusDegraded, Message: conditionMessage(c)}, true
+			default:
+				return Health{Status: StatusProgressing, Message: conditionMessage(c)}, true
+			}
+		}
+	}
+
+	return Health{}, false
+}
+
+// conditionMessage returns the message of a condition. If the condition doesn't contain a message we use the reason
+// instead.
+func conditionMessage(condition map[string]any) string {
+	if message, ok := condition["message"].(string); ok && message != "" {
+		return message
+	}
+
+	reason, _ := condition["reason"].(string)
+	return reason
+}
+
+// nestedString returns the string value of the nested field in the provided object.
+func nestedString(obj map[string]any, fields ...string) (string, bool) {
+	value, ok := nestedField(obj, fields...)
+	if !ok {
+		return "", false
+	}
+
+	s, ok := value.(string)
+	return s, ok
+}
+
+// nestedNumber returns the numeric value of the nested field in the provided object. Since the manifests are decoded
+// from JSON, numbers are normally float64 values, but we also handle integers for manifests created in code.
+func nestedNumber(obj map[string]any, fields ...string) (float64, bool) {
+	value, ok := nestedField(obj, fields...)
+	if !ok {
+		return 0, false
+	}
+
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case int64:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+func nestedField(obj map[string]any, fields ...string) (any, bool) {
+	var value any = obj
+
+	for _, field := range fields {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		value, ok = m[field]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return value, true
+}
@@ -0,0 +1,33 @@
+package health
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateConditions(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		manifest        string
+		expectedHealth  Health
+		expectedMatched bool
+	}{
+		{name: "no status", manifest: `{"spec": {}}`},
+		{name: "no conditions", manifest: `{"status": {"phase": "Running"}}`},
+		{name: "no known conditions", manifest: `{"status": {"conditions": [{"type": "Synced", "status": "True"}]}}`},
+		{name: "stalled", manifest: `{"status": {"conditions": [{"type": "Ready", "status": "True"}, {"type": "Stalled", "status": "True", "reason": "InvalidSpec"}]}}`, expectedHealth: Health{Status: StatusDegraded, Message: "InvalidSpec"}, expectedMatched: true},
+		{name: "reconciling", manifest: `{"status": {"conditions": [{"type": "Reconciling", "status": "True", "message": "Updating"}]}}`, expectedHealth: Health{Status: StatusProgressing, Message: "Updating"}, expectedMatched: true},
+		{name: "generation not observed", manifest: `{"metadata": {"generation": 3}, "status": {"observedGeneration": 2, "conditions": [{"type": "Ready", "status": "True"}]}}`, expectedHealth: Health{Status: StatusProgressing, Message: "Waiting for the latest generation to be observed"}, expectedMatched: true},
+		{name: "ready", manifest: `{"metadata": {"generation": 3}, "status": {"observedGeneration": 3, "conditions": [{"type": "Ready", "status": "True", "message": "Applied"}]}}`, expectedHealth: Health{Status: StatusHealthy, Message: "Applied"}, expectedMatched: true},
+		{name: "not ready", manifest: `{"status": {"conditions": [{"type": "Ready", "status": "False", "reason": "Failed"}]}}`, expectedHealth: Health{Status: StatusDegraded, Message: "Failed"}, expectedMatched: true},
+		{name: "ready unknown", manifest: `{"status": {"conditions": [{"type": "Ready", "status": "Unknown"}]}}`, expectedHealth: Health{Status: StatusProgressing}, expectedMatched: true},
+		{name: "available", manifest: `{"status": {"conditions": [{"type": "Available", "status": "True"}]}}`, expectedHealth: Health{Status: StatusHealthy}, expectedMatched: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			health, matched := evaluateConditions(decode(t, tt.manifest))
+			require.Equal(t, tt.expectedMatched, matched)
+			require.Equal(t, tt.expectedHealth, health)
+		})
+	}
+}
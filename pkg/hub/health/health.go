@@ -0,0 +1,184 @@
+package health
+
+import (
+	"fmt"
+)
+
+// Status is the health status of a resource. The statuses are ordered by their severity, so that we can use the
+// worst status of a list of resources as the status of the whole list.
+type Status string
+
+const (
+	StatusHealthy     Status = "Healthy"
+	StatusUnknown     Status = "Unknown"
+	StatusProgressing Status = "Progressing"
+	StatusDegraded    Status = "Degraded"
+)
+
+// severity returns the severity of a status. A higher value means a worse status.
+func (s Status) severity() int {
+	switch s {
+	case StatusHealthy:
+		return 0
+	case StatusUnknown:
+		return 1
+	case StatusProgressing:
+		return 2
+	case StatusDegraded:
+		return 3
+	default:
+		return 1
+	}
+}
+
+// Health is the health of a single resource. The message contains a human readable explanation for the status.
+type Health struct {
+	Status  Status `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// Item is the health of a single resource from a list of resources.
+type Item struct {
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Health    Health `json:"health"`
+}
+
+// List is the health of a list of resources. It contains the health of each resource in the same order as the items
+// in the list and a summary for all resources. Resources for which we are not able to evaluate the health (e.g.
+// ConfigMaps) are not included.
+type List struct {
+	Summary Summary `json:"summary"`
+	Items   []Item  `json:"items"`
+}
+
+// Summary is the aggregated health of multiple resources. The status is the worst status of all resources.
+type Summary struct {
+	Status      Status `json:"status"`
+	Total       int    `json:"total"`
+	Healthy     int    `json:"healthy"`
+	Progressing int    `json:"progressing"`
+	Degraded    int    `json:"degraded"`
+	Unknown     int    `json:"unknown"`
+}
+
+// Add adds the given health to the summary and updates the status of the summary, when the health is worse than the
+// current status.
+func (s *Summary) Add(health Health) {
+	s.Total++
+
+	switch health.Status {
+	case StatusHealthy:
+		s.Healthy++
+	case StatusProgressing:
+		s.Progressing++
+	case StatusDegraded:
+		s.Degraded++
+	default:
+		s.Unknown++
+	}
+
+	if s.Status == "" || health.Status.severity() > s.Status.severity() {
+		s.Status = health.Status
+	}
+}
+
+// Merge adds all resources from the other summary to the summary.
+func (s *Summary) Merge(other Summary) {
+	s.Total += other.Total
+	s.Healthy += other.Healthy
+	s.Progressing += other.Progressing
+	s.Degraded += other.Degraded
+	s.Unknown += other.Unknown
+
+	if other.Status != "" && (s.Status == "" || other.Status.severity() > s.Status.severity()) {
+		s.Status = other.Status
+	}
+}
+
+// EvaluateFunc is the signature of a function which evaluates the health of a single resource. The second return
+// value is false, when the function is not able to evaluate the health of the resource.
+type EvaluateFunc func(obj map[string]any) (Health, bool)
+
+// Evaluator is the interface which must be implemented by a health evaluator. The Evaluate method returns the health
+// of a single resource, the EvaluateList method returns the health of all resources in a list manifest as it is
+// returned by the Kubernetes API.
+type Evaluator interface {
+	Evaluate(resource string, obj map[string]any) (Health, bool)
+	EvaluateList(resource string, list map[string]any) *List
+}
+
+// evaluator implements the Evaluator interface. The health of a resource is evaluated by the configured rules for the
+// resource first, then by the built-in rules for the core workloads and when both are not able to evaluate the health
+// we check the conditions of the resource.
+type evaluator struct {
+	rules    map[string][]rule
+	builtins map[string]EvaluateFunc
+}
+
+func (e *evaluator) Evaluate(resource string, obj map[string]any) (Health, bool) {
+	for _, r := range e.rules[resource] {
+		if health, ok := r.evaluate(obj); ok {
+			return health, true
+		}
+	}
+
+	if builtin, ok := e.builtins[resource]; ok {
+		if health, ok := builtin(obj); ok {
+			return health, true
+		}
+	}
+
+	return evaluateConditions(obj)
+}
+
+func (e *evaluator) EvaluateList(resource string, list map[string]any) *List {
+	items, ok := list["items"].([]any)
+	if !ok {
+		return nil
+	}
+
+	result := &List{Items: []Item{}}
+
+	for _, item := range items {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		health, ok := e.Evaluate(resource, obj)
+		if !ok {
+			continue
+		}
+
+		namespace, _ := nestedString(obj, "metadata", "namespace")
+		name, _ := nestedString(obj, "metadata", "name")
+
+		result.Items = append(result.Items, Item{Namespace: namespace, Name: name, Health: health})
+		result.Summary.Add(health)
+	}
+
+	return result
+}
+
+// NewEvaluator returns a new health evaluator. All CEL expressions and JSONPath templates from the provided config are
+// compiled, so that an error is returned when one of the rules is invalid.
+func NewEvaluator(config Config) (Evaluator, error) {
+	rules := make(map[string][]rule)
+
+	for _, resource := range config.Rules {
+		for i, r := range resource.Rules {
+			compiledRule, err := compileRule(r)
+			if err != nil {
+				return nil, fmt.Errorf("invalid health rule %d for resource %s: %w", i, resource.Resource, err)
+			}
+
+			rules[resource.Resource] = append(rules[resource.Resource], compiledRule)
+		}
+	}
+
+	return &evaluator{
+		rules:    rules,
+		builtins: builtins,
+	}, nil
+}
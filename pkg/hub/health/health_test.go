@@ -0,0 +1,108 @@
+package health
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// decode decodes the provided JSON manifest, so that the numbers in the manifest are float64 values like in the
+// manifests we get from the clusters.
+func decode(t *testing.T, manifest string) map[string]any {
+	var obj map[string]any
+	require.NoError(t, json.Unmarshal([]byte(manifest), &obj))
+	return obj
+}
+
+func TestSummary(t *testing.T) {
+	var summary Summary
+	require.Equal(t, Status(""), summary.Status)
+
+	summary.Add(Health{Status: StatusHealthy})
+	require.Equal(t, StatusHealthy, summary.Status)
+
+	summary.Add(Health{Status: StatusProgressing})
+	summary.Add(Health{Status: StatusUnknown})
+	require.Equal(t, StatusProgressing, summary.Status)
+
+	other := Summary{}
+	other.Add(Health{Status: StatusDegraded})
+	summary.Merge(other)
+	summary.Merge(Summary{})
+
+	require.Equal(t, Summary{Status: StatusDegraded, Total: 4, Healthy: 1, Progressing: 1, Degraded: 1, Unknown: 1}, summary)
+}
+
+func TestNewEvaluator(t *testing.T) {
+	t.Run("should return error for invalid rule", func(t *testing.T) {
+		_, err := NewEvaluator(Config{Rules: []ResourceRules{{Resource: "certificates.cert-manager.io", Rules: []Rule{{Status: StatusHealthy, Expression: "object.status.("}}}}})
+		require.Error(t, err)
+	})
+
+	t.Run("should return evaluator", func(t *testing.T) {
+		e, err := NewEvaluator(Config{Rules: []ResourceRules{{Resource: "certificates.cert-manager.io", Rules: []Rule{{Status: StatusHealthy, JSONPath: ".status.phase", Value: "Issued"}}}}})
+		require.NoError(t, err)
+		require.NotNil(t, e)
+	})
+}
+
+func TestEvaluate(t *testing.T) {
+	e, err := NewEvaluator(Config{Rules: []ResourceRules{
+		{Resource: "deployments", Rules: []Rule{{Status: StatusDegraded, Message: "Deployment is disabled", Expression: `has(object.metadata.annotations) && object.metadata.annotations["example.com/disabled"] == "true"`}}},
+		{Resource: "certificates.cert-manager.io", Rules: []Rule{{Status: StatusHealthy, JSONPath: "{.status.phase}", Value: "Issued"}}},
+	}})
+	require.NoError(t, err)
+
+	t.Run("should use configured rule before built-in rule", func(t *testing.T) {
+		health, ok := e.Evaluate("deployments", decode(t, `{"metadata": {"annotations": {"example.com/disabled": "true"}}, "spec": {"replicas": 1}, "status": {"replicas": 1, "updatedReplicas": 1, "availableReplicas": 1}}`))
+		require.True(t, ok)
+		require.Equal(t, Health{Status: StatusDegraded, Message: "Deployment is disabled"}, health)
+	})
+
+	t.Run("should fall back to built-in rule", func(t *testing.T) {
+		health, ok := e.Evaluate("deployments", decode(t, `{"metadata": {}, "spec": {"replicas": 1}, "status": {"replicas": 1, "updatedReplicas": 1, "availableReplicas": 1}}`))
+		require.True(t, ok)
+		require.Equal(t, Health{Status: StatusHealthy}, health)
+	})
+
+	t.Run("should fall back to conditions", func(t *testing.T) {
+		health, ok := e.Evaluate("certificates.cert-manager.io", decode(t, `{"status": {"phase": "Pending", "conditions": [{"type": "Ready", "status": "False", "message": "Issuing certificate"}]}}`))
+		require.True(t, ok)
+		require.Equal(t, Health{Status: StatusDegraded, Message: "Issuing certificate"}, health)
+	})
+
+	t.Run("should not evaluate resource without rules and conditions", func(t *testing.T) {
+		_, ok := e.Evaluate("configmaps", decode(t, `{"data": {"key": "value"}}`))
+		require.False(t, ok)
+	})
+}
+
+func TestEvaluateList(t *testing.T) {
+	e, err := NewEvaluator(Config{})
+	require.NoError(t, err)
+
+	t.Run("should return nil for invalid list", func(t *testing.T) {
+		require.Nil(t, e.EvaluateList("pods", decode(t, `{"metadata": {"name": "nginx"}}`)))
+	})
+
+	t.Run("should return health of items", func(t *testing.T) {
+		list := e.EvaluateList("pods", decode(t, `{"items": [
+			{"metadata": {"namespace": "default", "name": "nginx-1"}, "status": {"phase": "Running", "conditions": [{"type": "Ready", "status": "True"}]}},
+			{"metadata": {"namespace": "default", "name": "nginx-2"}, "status": {"phase": "Failed", "reason": "Evicted"}}
+		]}`))
+
+		require.Equal(t, &List{
+			Summary: Summary{Status: StatusDegraded, Total: 2, Healthy: 1, Degraded: 1},
+			Items: []Item{
+				{Namespace: "default", Name: "nginx-1", Health: Health{Status: StatusHealthy}},
+				{Namespace: "default", Name: "nginx-2", Health: Health{Status: StatusDegraded, Message: "Evicted"}},
+			},
+		}, list)
+	})
+
+	t.Run("should skip items without health", func(t *testing.T) {
+		list := e.EvaluateList("configmaps", decode(t, `{"items": [{"metadata": {"name": "config"}}]}`))
+		require.Equal(t, &List{Items: []Item{}}, list)
+	})
+}
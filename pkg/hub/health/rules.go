@@ -0,0 +1,134 @@
+package health
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// Config is the configuration for the health evaluator. It contains a list of rules for Custom Resource Definitions,
+// which can be used to evaluate the health of a resource, when the built-in rules and the conditions of the resource
+// are not sufficient.
+type Config struct {
+	Rules []ResourceRules `json:"rules"`
+}
+
+// ResourceRules are the rules for a single resource. The resource must be the id of the resource, e.g.
+// "deployments" or "vaultsecrets.ricoberger.de" for a Custom Resource Definition.
+type ResourceRules struct {
+	Resource string `json:"resource"`
+	Rules    []Rule `json:"rules"`
+}
+
+// Rule is a single health rule. The rules of a resource are evaluated in the configured order and the status and
+// message of the first matching rule is used as the health of the resource. A rule matches when the CEL expression
+// returns true or when the result of the JSONPath template is equal to the configured value. If no value is
+// configured, the rule matches when the JSONPath template returns a non empty result.
+type Rule struct {
+	Status     Status `json:"status"`
+	Message    string `json:"message"`
+	Expression string `json:"expression"`
+	JSONPath   string `json:"jsonPath"`
+	Value      string `json:"value"`
+}
+
+// rule is a compiled Rule. The JSONPath template is guarded by a mutex, because it keeps an internal state during the
+// execution and the same rule can be evaluated concurrently.
+type rule struct {
+	status     Status
+	message    string
+	program    cel.Program
+	jsonPath   *jsonpath.JSONPath
+	jsonPathMu *sync.Mutex
+	value      string
+}
+
+// evaluate returns the health of the rule, when the rule matches the provided object. If the evaluation of the CEL
+// expression or JSONPath template fails, e.g. because a field is missing, the rule does not match.
+func (r rule) evaluate(obj map[string]any) (Health, bool) {
+	if r.program != nil {
+		out, _, err := r.program.Eval(map[string]any{"object": obj})
+		if err != nil {
+			return Health{}, false
+		}
+
+		if matched, ok := out.Value().(bool); !ok || !matched {
+			return Health{}, false
+		}
+
+		return Health{Status: r.status, Message: r.message}, true
+	}
+
+	var buf bytes.Buffer
+	r.jsonPathMu.Lock()
+	err := r.jsonPath.Execute(&buf, obj)
+	r.jsonPathMu.Unlock()
+	if err != nil {
+		return Health{}, false
+	}
+
+	if (r.value == "" && buf.Len() == 0) || (r.value != "" && buf.String() != r.value) {
+		return Health{}, false
+	}
+
+	return Health{Status: r.status, Message: r.message}, true
+}
+
+// compileRule compiles the CEL expression or the JSONPath template of the provided rule. The resource is available
+// via the "object" variable in CEL expressions. JSONPath templates can be written with or without the surrounding
+// curly braces, e.g. "{.status.phase}" and ".status.phase" are both valid.
+func compileRule(r Rule) (rule, error) {
+	switch r.Status {
+	case StatusHealthy, StatusProgressing, StatusDegraded, StatusUnknown:
+	default:
+		return rule{}, fmt.Errorf("invalid status %q", r.Status)
+	}
+
+	if (r.Expression == "") == (r.JSONPath == "") {
+		return rule{}, fmt.Errorf("exactly one of expression or jsonPath must be set")
+	}
+
+	compiledRule := rule{status: r.Status, message: r.Message, value: r.Value}
+
+	if r.Expression != "" {
+		env, err := cel.NewEnv(cel.Variable("object", cel.DynType))
+		if err != nil {
+			return rule{}, err
+		}
+
+		ast, issues := env.Compile(r.Expression)
+		if issues != nil && issues.Err() != nil {
+			return rule{}, issues.Err()
+		}
+
+		if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+			return rule{}, fmt.Errorf("expression must return a bool, got %s", ast.OutputType())
+		}
+
+		program, err := env.Program(ast)
+		if err != nil {
+			return rule{}, err
+		}
+
+		compiledRule.program = program
+		return compiledRule, nil
+	}
+
+	template := r.JSONPath
+	if !strings.HasPrefix(template, "{") {
+		template = fmt.Sprintf("{%s}", template)
+	}
+
+	jp := jsonpath.New("health").AllowMissingKeys(true)
+	if err := jp.Parse(template); err != nil {
+		return rule{}, err
+	}
+
+	compiledRule.jsonPath = jp
+	compiledRule.jsonPathMu = &sync.Mutex{}
+	return compiledRule, nil
+}
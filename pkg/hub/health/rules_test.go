@@ -0,0 +1,62 @@
+package health
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileRule(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		rule          Rule
+		expectedError bool
+	}{
+		{name: "should return error for invalid status", rule: Rule{Status: "Broken", Expression: "true"}, expectedError: true},
+		{name: "should return error when expression and jsonPath are missing", rule: Rule{Status: StatusHealthy}, expectedError: true},
+		{name: "should return error when expression and jsonPath are set", rule: Rule{Status: StatusHealthy, Expression: "true", JSONPath: ".status"}, expectedError: true},
+		{name: "should return error for invalid expression", rule: Rule{Status: StatusHealthy, Expression: "object.status.("}, expectedError: true},
+		{name: "should return error for non bool expression", rule: Rule{Status: StatusHealthy, Expression: `"healthy"`}, expectedError: true},
+		{name: "should return error for invalid jsonPath", rule: Rule{Status: StatusHealthy, JSONPath: "{.status[}"}, expectedError: true},
+		{name: "should compile expression", rule: Rule{Status: StatusHealthy, Expression: "object.status.ready"}},
+		{name: "should compile jsonPath", rule: Rule{Status: StatusHealthy, JSONPath: ".status.phase"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := compileRule(tt.rule)
+			if tt.expectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRuleEvaluate(t *testing.T) {
+	obj := decode(t, `{"status": {"phase": "Running", "replicas": 3, "conditions": [{"type": "Synced", "status": "True"}]}}`)
+
+	for _, tt := range []struct {
+		name            string
+		rule            Rule
+		expectedHealth  Health
+		expectedMatched bool
+	}{
+		{name: "should match expression", rule: Rule{Status: StatusHealthy, Message: "Running", Expression: `object.status.phase == "Running" && object.status.replicas == 3`}, expectedHealth: Health{Status: StatusHealthy, Message: "Running"}, expectedMatched: true},
+		{name: "should match expression with macros", rule: Rule{Status: StatusHealthy, Expression: `object.status.conditions.exists(c, c.type == "Synced" && c.status == "True")`}, expectedHealth: Health{Status: StatusHealthy}, expectedMatched: true},
+		{name: "should not match expression", rule: Rule{Status: StatusDegraded, Expression: `object.status.phase == "Failed"`}},
+		{name: "should not match expression with missing field", rule: Rule{Status: StatusDegraded, Expression: `object.spec.paused`}},
+		{name: "should match jsonPath with value", rule: Rule{Status: StatusHealthy, JSONPath: "{.status.phase}", Value: "Running"}, expectedHealth: Health{Status: StatusHealthy}, expectedMatched: true},
+		{name: "should match jsonPath without value", rule: Rule{Status: StatusProgressing, JSONPath: `.status.conditions[?(@.type=="Synced")].status`}, expectedHealth: Health{Status: StatusProgressing}, expectedMatched: true},
+		{name: "should not match jsonPath with different value", rule: Rule{Status: StatusHealthy, JSONPath: ".status.phase", Value: "Succeeded"}},
+		{name: "should not match jsonPath with missing field", rule: Rule{Status: StatusHealthy, JSONPath: ".status.message"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := compileRule(tt.rule)
+			require.NoError(t, err)
+
+			health, matched := r.evaluate(obj)
+			require.Equal(t, tt.expectedMatched, matched)
+			require.Equal(t, tt.expectedHealth, health)
+		})
+	}
+}
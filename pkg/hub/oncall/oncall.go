@@ -0,0 +1,113 @@
+package oncall
+
+//go:generate mockgen -source=oncall.go -destination=./oncall_mock.go -package=oncall Client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	teamv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/team/v1"
+	"github.com/kobsio/kobs/pkg/hub/plugins"
+	"github.com/kobsio/kobs/pkg/instrument/log"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// OnCall is the resolved on-call information of a team. It contains the static information from the team and the
+// participants which are currently on-call. If we are not able to get the participants from Opsgenie, the error is
+// returned in the error field, so that the static information can still be shown.
+type OnCall struct {
+	Team         string              `json:"team"`
+	SlackChannel string              `json:"slackChannel,omitempty"`
+	Escalation   []teamv1.Escalation `json:"escalation,omitempty"`
+	Participants []string            `json:"participants"`
+	Error        string              `json:"error,omitempty"`
+}
+
+// Client is the interface to get the on-call information of a team. GetOnCall returns nil when no on-call information
+// is configured for the team.
+type Client interface {
+	GetOnCall(ctx context.Context, team teamv1.TeamSpec) *OnCall
+}
+
+type client struct {
+	requester *plugins.Requester
+	tracer    trace.Tracer
+}
+
+// GetOnCall returns the on-call information of the provided team. The participants are resolved via the Opsgenie
+// plugin instance referenced in the team. The request is made with the user from the provided context, so that the
+// participants are only returned when the user has access to the plugin instance.
+func (c *client) GetOnCall(ctx context.Context, team teamv1.TeamSpec) *OnCall {
+	if team.OnCall == nil {
+		return nil
+	}
+
+	ctx, span := c.tracer.Start(ctx, "oncall.GetOnCall")
+	span.SetAttributes(attribute.Key("team").String(team.ID))
+	defer span.End()
+
+	onCall := &OnCall{
+		Team:         team.ID,
+		SlackChannel: team.OnCall.SlackChannel,
+		Escalation:   team.OnCall.Escalation,
+		Participants: []string{},
+	}
+
+	if team.OnCall.Opsgenie == nil {
+		return onCall
+	}
+
+	participants, err := c.getParticipants(ctx, *team.OnCall.Opsgenie)
+	if err != nil {
+		log.Warn(ctx, "Failed to get on-call participants", zap.Error(err), zap.String("team", team.ID))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		onCall.Error = err.Error()
+		return onCall
+	}
+
+	onCall.Participants = participants
+	return onCall
+}
+
+// getParticipants calls the on-call endpoint of the referenced Opsgenie plugin instance via the plugins router.
+func (c *client) getParticipants(ctx context.Context, opsgenie teamv1.Opsgenie) ([]string, error) {
+	params := url.Values{}
+	params.Set("schedule", opsgenie.Schedule)
+	if opsgenie.Rotation != "" {
+		params.Set("rotation", opsgenie.Rotation)
+	}
+
+	var participants []string
+	if err := c.requester.Do(ctx, plugins.Request{
+		Method:  http.MethodGet,
+		Cluster: opsgenie.Cluster,
+		Type:    "opsgenie",
+		Name:    opsgenie.Name,
+		Path:    "/oncall",
+		Params:  params,
+	}, &participants); err != nil {
+		return nil, err
+	}
+
+	if participants == nil {
+		participants = []string{}
+	}
+
+	return participants, nil
+}
+
+// NewClient returns a new client to get the on-call information of teams. The participants are retrieved via the
+// provided plugins router, which must be the router of the hub plugins client.
+func NewClient(pluginsRouter http.Handler) Client {
+	return &client{
+		requester: plugins.NewRequester(pluginsRouter),
+		tracer:    otel.Tracer("oncall"),
+	}
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: oncall.go
+
+// Package oncall is a generated GoMock package.
+package oncall
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	v1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/team/v1"
+)
+
+// MockClient is a mock of Client interface.
+type MockClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientMockRecorder
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient.
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// NewMockClient creates a new mock instance.
+func NewMockClient(ctrl *gomock.Controller) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// GetOnCall mocks base method.
+func (m *MockClient) GetOnCall(ctx context.Context, team v1.TeamSpec) *OnCall {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOnCall", ctx, team)
+	ret0, _ := ret[0].(*OnCall)
+	return ret0
+}
+
+// GetOnCall indicates an expected call of GetOnCall.
+func (mr *MockClientMockRecorder) GetOnCall(ctx, team interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOnCall", reflect.TypeOf((*MockClient)(nil).GetOnCall), ctx, team)
+}
@@ -0,0 +1,62 @@
+package oncall
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	teamv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/team/v1"
+	"github.com/kobsio/kobs/pkg/utils/middleware/errresponse"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/stretchr/testify/require"
+)
+
+func newPluginsRouter() chi.Router {
+	router := chi.NewRouter()
+	router.Get("/opsgenie/oncall", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-kobs-cluster") != "hub" || r.Header.Get("x-kobs-plugin") != "opsgenie" {
+			errresponse.Render(w, r, http.StatusBadRequest, "Invalid plugin instance")
+			return
+		}
+
+		if r.URL.Query().Get("rotation") == "rotation1" {
+			render.JSON(w, r, []string{"user2@kobs.io"})
+			return
+		}
+
+		render.JSON(w, r, []string{"user1@kobs.io"})
+	})
+	return router
+}
+
+func TestGetOnCall(t *testing.T) {
+	c := NewClient(newPluginsRouter())
+
+	t.Run("should return nil without on-call information", func(t *testing.T) {
+		require.Nil(t, c.GetOnCall(context.Background(), teamv1.TeamSpec{ID: "team1"}))
+	})
+
+	t.Run("should return static information without opsgenie", func(t *testing.T) {
+		onCall := c.GetOnCall(context.Background(), teamv1.TeamSpec{ID: "team1", OnCall: &teamv1.OnCall{SlackChannel: "#team1", Escalation: []teamv1.Escalation{{Name: "Lead"}}}})
+		require.Equal(t, &OnCall{Team: "team1", SlackChannel: "#team1", Escalation: []teamv1.Escalation{{Name: "Lead"}}, Participants: []string{}}, onCall)
+	})
+
+	t.Run("should return participants of schedule", func(t *testing.T) {
+		onCall := c.GetOnCall(context.Background(), teamv1.TeamSpec{ID: "team1", OnCall: &teamv1.OnCall{Opsgenie: &teamv1.Opsgenie{Name: "opsgenie", Schedule: "schedule1"}}})
+		require.Equal(t, []string{"user1@kobs.io"}, onCall.Participants)
+		require.Empty(t, onCall.Error)
+	})
+
+	t.Run("should return participants of rotation", func(t *testing.T) {
+		onCall := c.GetOnCall(context.Background(), teamv1.TeamSpec{ID: "team1", OnCall: &teamv1.OnCall{Opsgenie: &teamv1.Opsgenie{Cluster: "hub", Name: "opsgenie", Schedule: "schedule1", Rotation: "rotation1"}}})
+		require.Equal(t, []string{"user2@kobs.io"}, onCall.Participants)
+	})
+
+	t.Run("should return error from plugin", func(t *testing.T) {
+		onCall := c.GetOnCall(context.Background(), teamv1.TeamSpec{ID: "team1", OnCall: &teamv1.OnCall{Opsgenie: &teamv1.Opsgenie{Name: "invalid", Schedule: "schedule1"}}})
+		require.Equal(t, []string{}, onCall.Participants)
+		require.Equal(t, "Invalid plugin instance", onCall.Error)
+	})
+}
@@ -5,33 +5,45 @@ package plugins
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"sync/atomic"
 
 	authContext "github.com/kobsio/kobs/pkg/hub/auth/context"
 	"github.com/kobsio/kobs/pkg/hub/clusters"
 	"github.com/kobsio/kobs/pkg/hub/db"
+	"github.com/kobsio/kobs/pkg/instrument/log"
 	"github.com/kobsio/kobs/pkg/plugins"
 	"github.com/kobsio/kobs/pkg/plugins/plugin"
 	"github.com/kobsio/kobs/pkg/utils/middleware/errresponse"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
+	"go.uber.org/zap"
 )
 
 // HubClusterName is the name which represents the hub as cluster.
 const HubClusterName = "hub"
 
 // Client is the interface which must be implemented by a plugins client. The plugins client must only be export a
-// router with all plugin routes mounted.
+// router with all plugin routes mounted. Reload can be used to replace the plugin instances without restarting the hub.
 type Client interface {
 	Mount() chi.Router
+	Reload(ctx context.Context, instances []plugin.Instance) (func(), error)
 }
 
-// client implements the plugins Client interface. it contains a router and a list of plugin instances, which are
-// provided by the user.
+// client implements the plugins Client interface. it contains a router and the plugins, which are used to mount
+// the routes for the plugin instances provided by the user. The router forwards all requests to the current router,
+// which contains the routes for the current plugin instances and which is replaced when the plugin instances are
+// reloaded. The closers are the plugin routers of the current router, which must be closed when they are replaced, so
+// that the connections of the plugin instances (e.g. to a database) are not leaked.
 type client struct {
-	router    *chi.Mux
-	instances []plugin.Instance
+	router         *chi.Mux
+	current        atomic.Pointer[chi.Mux]
+	closers        []io.Closer
+	plugins        []plugins.Plugin
+	clustersClient clusters.Client
+	dbClient       db.Client
 }
 
 // Mount returns the router of the plugins client, so it can be mounted into an existing chi router.
@@ -39,11 +51,19 @@ func (c *client) Mount() chi.Router {
 	return c.router
 }
 
-// NewClient creates a new plugins client. The client contains all the user provided plugin instances and a router. The
-// router contains all the routes for all plugins.
-func NewClient(plugins []plugins.Plugin, instances []plugin.Instance, clustersClient clusters.Client, dbClient db.Client) (Client, error) {
-	// To not pass some confidential data to the frontend we are converting the provided plugin instances to so called
-	// "frontendInstances". These instances only containing the "frontendOptions" from the configuration.
+// Reload creates a new router for the provided plugin instances and saves the frontend instances in the database. The
+// router is not used until the returned function is called, which replaces the current router and closes the plugin
+// routers of the old router. The returned function can not fail, so that the caller can prepare all other changes first
+// and apply them together. If the router can not be created or the instances can not be saved, the current router is
+// kept, so that the hub can continue to serve the old plugin instances.
+func (c *client) Reload(ctx context.Context, instances []plugin.Instance) (func(), error) {
+	router, closers, err := c.newRouter(instances)
+	if err != nil {
+		return nil, err
+	}
+
+	// We are converting the provided plugin instances to so called "frontendInstances", to not pass some confidential
+	// data to the frontend. These instances only containing the "frontendOptions" from the configuration.
 	var frontendInstances []plugin.Instance
 	for _, instance := range instances {
 		frontendInstances = append(frontendInstances, plugin.Instance{
@@ -53,11 +73,24 @@ func NewClient(plugins []plugins.Plugin, instances []plugin.Instance, clustersCl
 			Options:     instance.FrontendOptions,
 		})
 	}
-	err := dbClient.SavePlugins(context.Background(), HubClusterName, frontendInstances)
-	if err != nil {
+
+	if err := c.dbClient.SavePlugins(ctx, HubClusterName, frontendInstances); err != nil {
+		closeRouters(ctx, closers)
 		return nil, err
 	}
 
+	return func() {
+		c.current.Store(router)
+
+		oldClosers := c.closers
+		c.closers = closers
+		closeRouters(ctx, oldClosers)
+	}, nil
+}
+
+// newRouter creates a new router for the provided plugin instances. Besides the router it returns all plugin routers,
+// which must be closed when the router is not used anymore.
+func (c *client) newRouter(instances []plugin.Instance) (*chi.Mux, []io.Closer, error) {
 	// Create a new router and serve all the configured plugin instances at "/". Here we are just returning the
 	// converted "frontendInstances" to not leak confidential data (like passwords and access tokens) to the user.
 	router := chi.NewRouter()
@@ -66,7 +99,7 @@ func NewClient(plugins []plugins.Plugin, instances []plugin.Instance, clustersCl
 	router.Get("/", func(w http.ResponseWriter, r *http.Request) {
 		user := authContext.MustGetUser(r.Context())
 
-		plugins, err := dbClient.GetPlugins(r.Context())
+		plugins, err := c.dbClient.GetPlugins(r.Context())
 		if err != nil {
 			errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get plugins")
 			return
@@ -82,23 +115,60 @@ func NewClient(plugins []plugins.Plugin, instances []plugin.Instance, clustersCl
 		render.JSON(w, r, filteredPlugins)
 	})
 
-	// In the last step we are using the user defined `plugins` to mount the plugin routes at the formerly created
-	// router. For that we are looping over the defined mount functions, call them and mount the returned router. If an
-	// error is returned from one of the calls we return this error, to stop the starting process of the client.
-	for _, plugin := range plugins {
+	// Use the user defined `plugins` to mount the plugin routes at the formerly created router. For that we are looping
+	// over the defined mount functions, call them and mount the returned router. If an error is returned from one of
+	// the calls we close the already created plugin routers and return this error, to stop the starting process of the
+	// client.
+	var closers []io.Closer
+
+	for _, plugin := range c.plugins {
 		filteredInstances := filterInstances(plugin.Type(), instances)
 
-		pluginRouter, err := plugin.MountHub(filteredInstances, clustersClient, dbClient)
+		pluginRouter, err := plugin.MountHub(filteredInstances, c.clustersClient, c.dbClient)
 		if err != nil {
-			return nil, err
+			closeRouters(context.Background(), closers)
+			return nil, nil, err
 		}
 		if pluginRouter != nil {
 			router.Mount(fmt.Sprintf("/%s", plugin.Type()), pluginRouter)
+
+			if closer, ok := pluginRouter.(io.Closer); ok {
+				closers = append(closers, closer)
+			}
 		}
 	}
 
-	return &client{
-		router:    router,
-		instances: instances,
-	}, nil
+	return router, closers, nil
+}
+
+// closeRouters closes the provided plugin routers. Errors are only logged, because the routers are not used anymore.
+func closeRouters(ctx context.Context, closers []io.Closer) {
+	for _, closer := range closers {
+		if err := closer.Close(); err != nil {
+			log.Warn(ctx, "Failed to close plugin router", zap.Error(err))
+		}
+	}
+}
+
+// NewClient creates a new plugins client. The client contains all the user provided plugin instances and a router. The
+// router contains all the routes for all plugins.
+func NewClient(plugins []plugins.Plugin, instances []plugin.Instance, clustersClient clusters.Client, dbClient db.Client) (Client, error) {
+	c := &client{
+		router:         chi.NewRouter(),
+		plugins:        plugins,
+		clustersClient: clustersClient,
+		dbClient:       dbClient,
+	}
+
+	commit, err := c.Reload(context.Background(), instances)
+	if err != nil {
+		return nil, err
+	}
+	commit()
+
+	c.router.Mount("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.current.Load().ServeHTTP(w, r)
+	}))
+
+	return c, nil
 }
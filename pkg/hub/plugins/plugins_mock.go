@@ -5,10 +5,12 @@
 package plugins
 
 import (
+	context "context"
 	reflect "reflect"
 
 	chi "github.com/go-chi/chi/v5"
 	gomock "github.com/golang/mock/gomock"
+	plugin "github.com/kobsio/kobs/pkg/plugins/plugin"
 )
 
 // MockClient is a mock of Client interface.
@@ -47,3 +49,18 @@ func (mr *MockClientMockRecorder) Mount() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Mount", reflect.TypeOf((*MockClient)(nil).Mount))
 }
+
+// Reload mocks base method.
+func (m *MockClient) Reload(ctx context.Context, instances []plugin.Instance) (func(), error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reload", ctx, instances)
+	ret0, _ := ret[0].(func())
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reload indicates an expected call of Reload.
+func (mr *MockClientMockRecorder) Reload(ctx, instances interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reload", reflect.TypeOf((*MockClient)(nil).Reload), ctx, instances)
+}
@@ -0,0 +1,141 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kobsio/kobs/pkg/cluster/kubernetes"
+	"github.com/kobsio/kobs/pkg/hub/clusters"
+	"github.com/kobsio/kobs/pkg/hub/db"
+	"github.com/kobsio/kobs/pkg/plugins"
+	"github.com/kobsio/kobs/pkg/plugins/plugin"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+type closingRouter struct {
+	*chi.Mux
+	closed bool
+}
+
+func (r *closingRouter) Close() error {
+	r.closed = true
+	return nil
+}
+
+type closingPlugin struct {
+	routers []*closingRouter
+}
+
+func (p *closingPlugin) Type() string {
+	return "closing"
+}
+
+func (p *closingPlugin) MountCluster(instances []plugin.Instance, kubernetesClient kubernetes.Client) (chi.Router, error) {
+	return nil, nil
+}
+
+func (p *closingPlugin) MountHub(instances []plugin.Instance, clustersClient clusters.Client, dbClient db.Client) (chi.Router, error) {
+	router := &closingRouter{Mux: chi.NewRouter()}
+	p.routers = append(p.routers, router)
+	return router, nil
+}
+
+type failingPlugin struct{}
+
+func (p *failingPlugin) Type() string {
+	return "failing"
+}
+
+func (p *failingPlugin) MountCluster(instances []plugin.Instance, kubernetesClient kubernetes.Client) (chi.Router, error) {
+	return nil, nil
+}
+
+func (p *failingPlugin) MountHub(instances []plugin.Instance, clustersClient clusters.Client, dbClient db.Client) (chi.Router, error) {
+	if len(instances) > 0 {
+		return nil, fmt.Errorf("invalid instance")
+	}
+	return nil, nil
+}
+
+func TestReload(t *testing.T) {
+	t.Run("should save plugins when they are reloaded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().SavePlugins(gomock.Any(), HubClusterName, nil).Return(nil)
+
+		client, err := NewClient(nil, nil, nil, dbClient)
+		require.NoError(t, err)
+
+		dbClient.EXPECT().SavePlugins(gomock.Any(), HubClusterName, []plugin.Instance{{Name: "prometheus", Type: "prometheus"}}).Return(nil)
+		commit, err := client.Reload(context.Background(), []plugin.Instance{{Name: "prometheus", Type: "prometheus", Options: map[string]any{"address": "http://localhost:9090"}}})
+		require.NoError(t, err)
+		commit()
+	})
+
+	t.Run("should keep the current plugin routers when plugins can not be saved", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().SavePlugins(gomock.Any(), HubClusterName, nil).Return(nil)
+
+		closingPlugin := &closingPlugin{}
+		client, err := NewClient([]plugins.Plugin{closingPlugin}, nil, nil, dbClient)
+		require.NoError(t, err)
+
+		dbClient.EXPECT().SavePlugins(gomock.Any(), HubClusterName, nil).Return(fmt.Errorf("could not save plugins"))
+		_, err = client.Reload(context.Background(), nil)
+		require.EqualError(t, err, "could not save plugins")
+		require.Len(t, closingPlugin.routers, 2)
+		require.False(t, closingPlugin.routers[0].closed)
+		require.True(t, closingPlugin.routers[1].closed)
+	})
+
+	t.Run("should close plugin routers of the old instances when changes are applied", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().SavePlugins(gomock.Any(), HubClusterName, nil).Return(nil).Times(2)
+
+		closingPlugin := &closingPlugin{}
+		client, err := NewClient([]plugins.Plugin{closingPlugin}, nil, nil, dbClient)
+		require.NoError(t, err)
+		require.Len(t, closingPlugin.routers, 1)
+
+		commit, err := client.Reload(context.Background(), nil)
+		require.NoError(t, err)
+		require.Len(t, closingPlugin.routers, 2)
+		require.False(t, closingPlugin.routers[0].closed)
+
+		commit()
+		require.True(t, closingPlugin.routers[0].closed)
+		require.False(t, closingPlugin.routers[1].closed)
+	})
+
+	t.Run("should close created plugin routers when a plugin can not be mounted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().SavePlugins(gomock.Any(), HubClusterName, nil).Return(nil)
+
+		closingPlugin := &closingPlugin{}
+		client, err := NewClient([]plugins.Plugin{closingPlugin, &failingPlugin{}}, nil, nil, dbClient)
+		require.NoError(t, err)
+
+		_, err = client.Reload(context.Background(), []plugin.Instance{{Name: "failing", Type: "failing"}})
+		require.EqualError(t, err, "invalid instance")
+		require.Len(t, closingPlugin.routers, 2)
+		require.False(t, closingPlugin.routers[0].closed)
+		require.True(t, closingPlugin.routers[1].closed)
+	})
+
+	t.Run("should return error when plugins can not be saved", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().SavePlugins(gomock.Any(), HubClusterName, nil).Return(fmt.Errorf("could not save plugins"))
+
+		client, err := NewClient(nil, nil, nil, dbClient)
+		require.EqualError(t, err, "could not save plugins")
+		require.Nil(t, client)
+	})
+}
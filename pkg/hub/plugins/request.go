@@ -0,0 +1,112 @@
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	userv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/user/v1"
+	authContext "github.com/kobsio/kobs/pkg/hub/auth/context"
+	"github.com/kobsio/kobs/pkg/utils/middleware/errresponse"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ServiceUser is the user, which is used by the hub for requests against the plugins, which are not made on behalf of a
+// user, e.g. to get the data for reports and scorecards. These features can only be configured by the administrators
+// of kobs, so that the user has access to all plugins.
+var ServiceUser = authContext.User{
+	ID:   "kobs-hub",
+	Name: "kobs-hub",
+	Permissions: userv1.Permissions{
+		Plugins: []userv1.Plugin{{Cluster: "*", Type: "*", Name: "*"}},
+	},
+}
+
+// Request is a request against the API of a plugin instance. If no cluster is set, the plugin instance from the hub is
+// used. The path is relative to the API of the plugin type and the params are added as query parameters. When a body
+// is set, it is sent as JSON.
+type Request struct {
+	Method  string
+	Cluster string
+	Type    string
+	Name    string
+	Path    string
+	Params  url.Values
+	Body    any
+}
+
+// Requester can be used to call the API of the plugin instances from within the hub. The requests are not sent over
+// the network, instead they are directly passed to the router of the hub plugins client.
+type Requester struct {
+	router *chi.Mux
+}
+
+// Do sends the provided request to the plugin instance and decodes the response into the result. The user for the
+// request must be set in the provided context. If the plugin returns an error, the error messages from the response
+// are returned.
+func (r *Requester) Do(ctx context.Context, request Request, result any) error {
+	cluster := request.Cluster
+	if cluster == "" {
+		cluster = HubClusterName
+	}
+
+	path := fmt.Sprintf("/api/plugins/%s%s", request.Type, request.Path)
+	if len(request.Params) > 0 {
+		path = fmt.Sprintf("%s?%s", path, request.Params.Encode())
+	}
+
+	var body io.Reader
+	if request.Body != nil {
+		data, err := json.Marshal(request.Body)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	// The route context must be removed from the provided context, because the context is often the context of an API
+	// request. Otherwise chi would route the request via the route path of the API request instead of the path of the
+	// plugin request.
+	req, err := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, nil), request.Method, path, body)
+	if err != nil {
+		return err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("x-kobs-cluster", cluster)
+	req.Header.Set("x-kobs-plugin", request.Name)
+
+	w := httptest.NewRecorder()
+	r.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		var res errresponse.ErrResponse
+		if err := json.NewDecoder(w.Body).Decode(&res); err != nil || len(res.Errors) == 0 {
+			return fmt.Errorf("request failed with status code %d", w.Code)
+		}
+
+		return fmt.Errorf("%s", strings.Join(res.Errors, ", "))
+	}
+
+	return json.NewDecoder(w.Body).Decode(result)
+}
+
+// NewRequester returns a new requester for the provided plugins router, which must be the router of the hub plugins
+// client.
+func NewRequester(pluginsRouter http.Handler) *Requester {
+	router := chi.NewRouter()
+	router.Mount("/api/plugins", pluginsRouter)
+
+	return &Requester{
+		router: router,
+	}
+}
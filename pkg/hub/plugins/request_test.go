@@ -0,0 +1,90 @@
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	authContext "github.com/kobsio/kobs/pkg/hub/auth/context"
+	"github.com/kobsio/kobs/pkg/utils/middleware/errresponse"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequesterDo(t *testing.T) {
+	pluginsRouter := chi.NewRouter()
+	pluginsRouter.Get("/test/query", func(w http.ResponseWriter, r *http.Request) {
+		render.JSON(w, r, map[string]string{
+			"cluster": r.Header.Get("x-kobs-cluster"),
+			"name":    r.Header.Get("x-kobs-plugin"),
+			"query":   r.URL.Query().Get("query"),
+			"user":    authContext.MustGetUser(r.Context()).ID,
+		})
+	})
+	pluginsRouter.Post("/test/body", func(w http.ResponseWriter, r *http.Request) {
+		var data map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+			errresponse.Render(w, r, http.StatusBadRequest, "Invalid body")
+			return
+		}
+		render.JSON(w, r, data)
+	})
+	pluginsRouter.Get("/test/error", func(w http.ResponseWriter, r *http.Request) {
+		errresponse.Render(w, r, http.StatusBadRequest, "Invalid plugin instance")
+	})
+	pluginsRouter.Get("/test/status", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	requester := NewRequester(pluginsRouter)
+	ctx := context.WithValue(context.Background(), authContext.UserKey, ServiceUser)
+
+	t.Run("should return result", func(t *testing.T) {
+		var result map[string]string
+		err := requester.Do(ctx, Request{Method: http.MethodGet, Type: "test", Name: "test1", Path: "/query", Params: url.Values{"query": {"up"}}}, &result)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"cluster": "hub", "name": "test1", "query": "up", "user": "kobs-hub"}, result)
+	})
+
+	t.Run("should send body", func(t *testing.T) {
+		var result map[string]string
+		err := requester.Do(ctx, Request{Method: http.MethodPost, Cluster: "dev-de1", Type: "test", Name: "test1", Path: "/body", Body: map[string]string{"query": "up"}}, &result)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"query": "up"}, result)
+	})
+
+	t.Run("should return error from response", func(t *testing.T) {
+		err := requester.Do(ctx, Request{Method: http.MethodGet, Type: "test", Name: "test1", Path: "/error"}, nil)
+		require.EqualError(t, err, "Invalid plugin instance")
+	})
+
+	t.Run("should return error for status code", func(t *testing.T) {
+		err := requester.Do(ctx, Request{Method: http.MethodGet, Type: "test", Name: "test1", Path: "/status"}, nil)
+		require.EqualError(t, err, "request failed with status code 500")
+	})
+
+	t.Run("should ignore route context of api request", func(t *testing.T) {
+		router := chi.NewRouter()
+		router.Route("/api", func(r chi.Router) {
+			r.Get("/teams/oncall", func(w http.ResponseWriter, r *http.Request) {
+				var result map[string]string
+				if err := requester.Do(r.Context(), Request{Method: http.MethodGet, Type: "test", Name: "test1", Path: "/query"}, &result); err != nil {
+					errresponse.Render(w, r, http.StatusInternalServerError, err.Error())
+					return
+				}
+				render.JSON(w, r, result)
+			})
+		})
+
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/api/teams/oncall", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -0,0 +1,154 @@
+package reload
+
+import (
+	"context"
+	"crypto/sha256"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/kobsio/kobs/pkg/instrument/log"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+// reloadTimeout is the maximum duration for applying a changed configuration.
+const reloadTimeout = 30 * time.Second
+
+var (
+	reloadsTotalMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kobs",
+		Name:      "config_reloads_total",
+		Help:      "Number of configuration reloads, partitioned by status.",
+	}, []string{"status"})
+
+	lastReloadSuccessfulMetric = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "kobs",
+		Name:      "config_last_reload_successful",
+		Help:      "Whether the last configuration reload was successful.",
+	})
+
+	lastReloadSuccessTimestampMetric = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "kobs",
+		Name:      "config_last_reload_success_timestamp_seconds",
+		Help:      "Timestamp of the last successful configuration reload.",
+	})
+)
+
+// Config is the configuration for the reload client. The interval defines how often the configuration file is checked
+// for changes. If the interval is 0, the configuration is never reloaded.
+type Config struct {
+	Interval time.Duration `json:"interval" env:"INTERVAL" default:"10s" help:"The interval to check the configuration file for changes. Set the interval to 0 to disable the reload of the configuration."`
+}
+
+// Func is the function which is called when the content of the configuration file was changed. The function must load
+// and validate the changed configuration and only apply it when it is valid.
+type Func func(ctx context.Context) error
+
+// Client is the interface to watch the configuration file for changes. Start starts watching the file in a new
+// goroutine and Stop waits until the watching and a running reload is finished.
+type Client interface {
+	Start()
+	Stop()
+}
+
+// client implements the Client interface. It keeps the hash of the last seen content of the configuration file, so
+// that the reload function is only called when the content was changed. We are polling the file instead of using file
+// system events, because the configuration is often mounted from a Kubernetes ConfigMap, where the file is replaced via
+// a symlink swap.
+type client struct {
+	interval time.Duration
+	file     string
+	hash     [sha256.Size]byte
+	reload   Func
+	done     chan struct{}
+	wg       sync.WaitGroup
+}
+
+func (c *client) Start() {
+	if c.interval <= 0 {
+		log.Info(context.Background(), "Configuration reload is disabled")
+		return
+	}
+
+	log.Info(context.Background(), "Configuration reload started", zap.String("file", c.file), zap.Duration("interval", c.interval))
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-c.done:
+				return
+			case <-ticker.C:
+				c.check()
+			}
+		}
+	}()
+}
+
+func (c *client) Stop() {
+	log.Debug(context.Background(), "Start shutdown of the configuration reload")
+	close(c.done)
+	c.wg.Wait()
+}
+
+// check reads the configuration file and calls the reload function, when the content of the file was changed since the
+// last check. The hash is also updated when the reload fails, so that an invalid configuration is only reported once
+// and not on every check.
+func (c *client) check() {
+	ctx, cancel := context.WithTimeout(context.Background(), reloadTimeout)
+	defer cancel()
+
+	content, err := os.ReadFile(c.file)
+	if err != nil {
+		log.Warn(ctx, "Could not read configuration file", zap.Error(err), zap.String("file", c.file))
+		return
+	}
+
+	hash := sha256.Sum256(content)
+	if hash == c.hash {
+		return
+	}
+	c.hash = hash
+
+	log.Info(ctx, "Configuration file was changed", zap.String("file", c.file))
+
+	if err := c.reload(ctx); err != nil {
+		reloadsTotalMetric.WithLabelValues("error").Inc()
+		lastReloadSuccessfulMetric.Set(0)
+		log.Error(ctx, "Failed to reload configuration", zap.Error(err), zap.String("file", c.file))
+		return
+	}
+
+	reloadsTotalMetric.WithLabelValues("success").Inc()
+	lastReloadSuccessfulMetric.Set(1)
+	lastReloadSuccessTimestampMetric.SetToCurrentTime()
+	log.Info(ctx, "Configuration was reloaded", zap.String("file", c.file))
+}
+
+// NewClient returns a new client to watch the provided configuration file for changes. The current content of the file
+// is used as initial state, because it was already loaded when the hub was started.
+func NewClient(config Config, file string, reload Func) (Client, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	lastReloadSuccessfulMetric.Set(1)
+	lastReloadSuccessTimestampMetric.SetToCurrentTime()
+
+	return &client{
+		interval: config.Interval,
+		file:     file,
+		hash:     sha256.Sum256(content),
+		reload:   reload,
+		done:     make(chan struct{}),
+	}, nil
+}
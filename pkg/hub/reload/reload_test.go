@@ -0,0 +1,116 @@
+package reload
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	newClient := func(t *testing.T, reload Func) (*client, string) {
+		file := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(file, []byte("hub: {}"), 0644))
+
+		c, err := NewClient(Config{}, file, reload)
+		require.NoError(t, err)
+		return c.(*client), file
+	}
+
+	t.Run("should not reload when file was not changed", func(t *testing.T) {
+		var calls int
+		c, _ := newClient(t, func(ctx context.Context) error {
+			calls++
+			return nil
+		})
+
+		c.check()
+		require.Equal(t, 0, calls)
+	})
+
+	t.Run("should reload when file was changed", func(t *testing.T) {
+		var calls int
+		c, file := newClient(t, func(ctx context.Context) error {
+			calls++
+			return nil
+		})
+
+		require.NoError(t, os.WriteFile(file, []byte("hub:\n  plugins: []"), 0644))
+		c.check()
+		c.check()
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("should not reload again after failed reload", func(t *testing.T) {
+		var calls int
+		c, file := newClient(t, func(ctx context.Context) error {
+			calls++
+			return fmt.Errorf("invalid configuration")
+		})
+
+		require.NoError(t, os.WriteFile(file, []byte("hub:\n  plugins: []"), 0644))
+		c.check()
+		c.check()
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("should not reload when file can not be read", func(t *testing.T) {
+		var calls int
+		c, file := newClient(t, func(ctx context.Context) error {
+			calls++
+			return nil
+		})
+
+		require.NoError(t, os.Remove(file))
+		c.check()
+		require.Equal(t, 0, calls)
+	})
+}
+
+func TestStartAndStop(t *testing.T) {
+	t.Run("should reload changed file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(file, []byte("hub: {}"), 0644))
+
+		reloaded := make(chan struct{}, 1)
+		c, err := NewClient(Config{Interval: 10 * time.Millisecond}, file, func(ctx context.Context) error {
+			reloaded <- struct{}{}
+			return nil
+		})
+		require.NoError(t, err)
+
+		c.Start()
+		require.NoError(t, os.WriteFile(file, []byte("hub:\n  plugins: []"), 0644))
+
+		select {
+		case <-reloaded:
+		case <-time.After(5 * time.Second):
+			t.Fatal("configuration was not reloaded")
+		}
+
+		c.Stop()
+	})
+
+	t.Run("should not start when interval is 0", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(file, []byte("hub: {}"), 0644))
+
+		c, err := NewClient(Config{}, file, nil)
+		require.NoError(t, err)
+
+		c.Start()
+		c.Stop()
+	})
+}
+
+func TestNewClient(t *testing.T) {
+	t.Run("should return error when file does not exist", func(t *testing.T) {
+		c, err := NewClient(Config{}, filepath.Join(t.TempDir(), "config.yaml"), nil)
+		require.Error(t, err)
+		require.Nil(t, c)
+	})
+}
@@ -0,0 +1,279 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	dashboardv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
+	authContext "github.com/kobsio/kobs/pkg/hub/auth/context"
+	"github.com/kobsio/kobs/pkg/hub/dashboards"
+	hubPlugins "github.com/kobsio/kobs/pkg/hub/plugins"
+	"github.com/kobsio/kobs/pkg/instrument/log"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"sigs.k8s.io/yaml"
+)
+
+// variable is a dashboard variable with its current value. The type is only set for placeholders and is used to
+// decide how the value is interpolated.
+type variable struct {
+	name      string
+	value     string
+	valueType string
+}
+
+// render gets the dashboard for the provided reference and renders all panels of the dashboard for the given time
+// range. Errors for single panels are not returned, instead the error is rendered as message in the panel, so that a
+// single failing panel doesn't break the whole report.
+func (c *client) render(ctx context.Context, reference dashboardv1.Reference, timeStart, timeEnd int64) (*Document, error) {
+	ctx, span := c.tracer.Start(ctx, "reports.render")
+	defer span.End()
+
+	dashboard, err := c.getDashboard(ctx, reference)
+	if err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Key("dashboard").String(dashboard.Title))
+
+	variables := c.getVariables(ctx, dashboard, reference.Placeholders, timeStart, timeEnd)
+
+	// Rows which should not be shown for the current values of the variables are skipped. Since reports have all
+	// values, rows which still have a condition after the evaluation (e.g. because it is invalid) are also skipped and
+	// only logged.
+	values := map[string]string{"__timeStart": fmt.Sprintf("%d", timeStart), "__timeEnd": fmt.Sprintf("%d", timeEnd)}
+	for _, v := range variables {
+		values[v.name] = v.value
+	}
+
+	evaluatedRows, problems := dashboards.EvaluateRows(dashboard.Rows, values)
+	if len(problems) > 0 {
+		log.Warn(ctx, "Failed to evaluate row conditions", zap.Strings("problems", problems))
+	}
+
+	var dashboardRows []dashboardv1.Row
+	for _, row := range evaluatedRows {
+		if row.If == "" {
+			dashboardRows = append(dashboardRows, row)
+		}
+	}
+
+	rowsData, err := json.Marshal(dashboardRows)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []dashboardv1.Row
+	if err := json.Unmarshal([]byte(interpolate(string(rowsData), variables, timeStart, timeEnd)), &rows); err != nil {
+		return nil, fmt.Errorf("failed to interpolate variables: %w", err)
+	}
+
+	document := &Document{
+		Title:       dashboard.Title,
+		Description: dashboard.Description,
+		TimeStart:   timeStart,
+		TimeEnd:     timeEnd,
+	}
+
+	for _, row := range rows {
+		documentRow := DocumentRow{Title: row.Title}
+
+		// The panels are rendered below each other, so that we sort them by their position in the grid of the
+		// dashboard, to keep the order of the panels like it is shown in the frontend.
+		panels := row.Panels
+		sort.SliceStable(panels, func(i, j int) bool {
+			if panels[i].Y != panels[j].Y {
+				return panels[i].Y < panels[j].Y
+			}
+			return panels[i].X < panels[j].X
+		})
+
+		for _, panel := range panels {
+			documentRow.Panels = append(documentRow.Panels, c.fonts.renderPanel(panel.Title, c.getContent(ctx, panel.Plugin, timeStart, timeEnd)))
+		}
+
+		document.Rows = append(document.Rows, documentRow)
+	}
+
+	return document, nil
+}
+
+// getContent returns the content for a single panel via the source of the plugin type.
+func (c *client) getContent(ctx context.Context, plugin dashboardv1.Plugin, timeStart, timeEnd int64) Content {
+	source, ok := sources[plugin.Type]
+	if !ok {
+		return Content{Message: fmt.Sprintf("Panels of the type %q are not supported in reports", plugin.Type)}
+	}
+
+	content, err := source(ctx, c.fetch, plugin, timeStart, timeEnd)
+	if err != nil {
+		log.Warn(ctx, "Failed to get panel data", zap.Error(err), zap.String("type", plugin.Type), zap.String("name", plugin.Name))
+		return Content{Message: fmt.Sprintf("Failed to get data: %s", err.Error())}
+	}
+
+	return content
+}
+
+// getDashboard returns the dashboard for a reference. For inline dashboards the dashboard is created from the
+// reference, all other dashboards are loaded from the database. Since reports are configured by an administrator, the
+// visibility of user dashboards is not checked.
+func (c *client) getDashboard(ctx context.Context, reference dashboardv1.Reference) (*dashboardv1.DashboardSpec, error) {
+	if reference.Inline != nil {
+		return &dashboardv1.DashboardSpec{
+			Title:       reference.Title,
+			Description: reference.Description,
+			Variables:   reference.Inline.Variables,
+			Rows:        reference.Inline.Rows,
+		}, nil
+	}
+
+	dashboard, err := dashboards.GetByID(ctx, c.dbClient, fmt.Sprintf("/cluster/%s/namespace/%s/name/%s", reference.Cluster, reference.Namespace, reference.Name), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if dashboard == nil {
+		return nil, fmt.Errorf("dashboard not found")
+	}
+
+	if reference.Title != "" {
+		dashboard.Title = reference.Title
+	}
+
+	return dashboard, nil
+}
+
+// getVariables returns the values for the placeholders and variables of a dashboard. Like in the frontend we always
+// use the first value of a variable, because there is no user who can select another value.
+func (c *client) getVariables(ctx context.Context, dashboard *dashboardv1.DashboardSpec, placeholderValues map[string]string, timeStart, timeEnd int64) []variable {
+	var variables []variable
+
+	for _, placeholder := range dashboard.Placeholders {
+		value := placeholder.Default
+		if v, ok := placeholderValues[placeholder.Name]; ok {
+			value = v
+		}
+
+		variables = append(variables, variable{name: placeholder.Name, value: value, valueType: placeholder.Type})
+	}
+
+	for _, v := range dashboard.Variables {
+		if v.Plugin.Type == "core" {
+			variables = append(variables, variable{name: v.Name, value: getCoreVariableValue(v)})
+			continue
+		}
+
+		data, err := json.Marshal(v.Plugin)
+		if err != nil {
+			variables = append(variables, variable{name: v.Name})
+			continue
+		}
+
+		var plugin dashboardv1.Plugin
+		if err := json.Unmarshal([]byte(interpolate(string(data), variables, timeStart, timeEnd)), &plugin); err != nil {
+			variables = append(variables, variable{name: v.Name})
+			continue
+		}
+
+		request := hubPlugins.Request{
+			Method: http.MethodPost,
+			Path:   "/variable",
+			Params: url.Values{"timeStart": {strconv.FormatInt(timeStart, 10)}, "timeEnd": {strconv.FormatInt(timeEnd, 10)}},
+			Body:   plugin.Options,
+		}
+
+		var values []string
+		if err := c.fetch(ctx, plugin, request, &values); err != nil {
+			log.Warn(ctx, "Failed to get variable values", zap.Error(err), zap.String("variable", v.Name))
+		}
+
+		value := ""
+		if len(values) > 1 && v.IncludeAllOption {
+			value = strings.Join(values, "|")
+		} else if len(values) > 0 {
+			value = values[0]
+		}
+
+		variables = append(variables, variable{name: v.Name, value: value})
+	}
+
+	return variables
+}
+
+// getCoreVariableValue returns the value for a variable of the core plugin. The "static" plugin contains a list of
+// values as options and the "placeholder" plugin contains the value in the options.
+func getCoreVariableValue(v dashboardv1.Variable) string {
+	if v.Plugin.Options == nil {
+		return ""
+	}
+
+	switch v.Plugin.Name {
+	case "static":
+		var values []string
+		if err := json.Unmarshal(v.Plugin.Options.Raw, &values); err == nil && len(values) > 0 {
+			return values[0]
+		}
+	case "placeholder":
+		var options struct {
+			Value string `json:"value"`
+		}
+		if err := json.Unmarshal(v.Plugin.Options.Raw, &options); err == nil {
+			return options.Value
+		}
+	}
+
+	return ""
+}
+
+// interpolate replaces the variables in the provided JSON document with their values. It works in the same way as the
+// "interpolate" function in the frontend: Variables must have the form "{% .name %}" and the special variables
+// "{% .__timeStart %}" and "{% .__timeEnd %}" are replaced with the time range of the report. For placeholders with the
+// type "number" or "object" the surrounding quotes are also replaced.
+func interpolate(str string, variables []variable, timeStart, timeEnd int64) string {
+	for _, v := range variables {
+		placeholder := fmt.Sprintf("{%% .%s %%}", v.name)
+
+		switch v.valueType {
+		case "number":
+			str = strings.ReplaceAll(str, fmt.Sprintf("%q", placeholder), v.value)
+		case "object":
+			if data, err := yaml.YAMLToJSON([]byte(v.value)); err == nil && v.value != "" {
+				str = strings.ReplaceAll(str, fmt.Sprintf("%q", placeholder), string(data))
+			}
+		}
+
+		str = strings.ReplaceAll(str, placeholder, escape(v.value))
+	}
+
+	str = strings.ReplaceAll(str, "{% .__timeStart %}", fmt.Sprintf("%d", timeStart))
+	str = strings.ReplaceAll(str, "{% .__timeEnd %}", fmt.Sprintf("%d", timeEnd))
+
+	return str
+}
+
+// escape escapes the provided value, so that it can be used within a JSON string.
+func escape(value string) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+
+	return string(data[1 : len(data)-1])
+}
+
+// fetch sends a request to the plugin API, via the router of the hub plugins client. The request is handled in-process
+// with the service user, so that it doesn't matter if the plugin instance belongs to the hub or to a cluster.
+func (c *client) fetch(ctx context.Context, plugin dashboardv1.Plugin, request hubPlugins.Request, result any) error {
+	request.Cluster = plugin.Cluster
+	request.Type = plugin.Type
+	request.Name = plugin.Name
+
+	return c.requester.Do(context.WithValue(ctx, authContext.UserKey, hubPlugins.ServiceUser), request, result)
+}
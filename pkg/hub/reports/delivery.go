@@ -0,0 +1,151 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SMTPConfig is the configuration of the SMTP server, which is used to send reports via email.
+type SMTPConfig struct {
+	Host     string `json:"host" env:"HOST" help:"The host of the SMTP server, which is used to send reports via email."`
+	Port     int    `json:"port" env:"PORT" default:"587" help:"The port of the SMTP server."`
+	Username string `json:"username" env:"USERNAME" help:"The username to authenticate against the SMTP server."`
+	Password string `json:"password" env:"PASSWORD" help:"The password to authenticate against the SMTP server."`
+	From     string `json:"from" env:"FROM" help:"The sender address for all reports."`
+}
+
+// Email is the delivery of a report via email. The rendered dashboard is added as attachment to the email.
+type Email struct {
+	To      []string `json:"to"`
+	Subject string   `json:"subject"`
+}
+
+// Webhook is the delivery of a report via a webhook. The rendered dashboard is sent as body of a POST request to the
+// configured url.
+type Webhook struct {
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+}
+
+// attachment is a rendered report, which should be delivered.
+type attachment struct {
+	filename    string
+	contentType string
+	data        []byte
+}
+
+// sendMailFunc is the function to send an email. It is the same as smtp.SendMail, so that we can replace it in our
+// tests.
+type sendMailFunc func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+
+// sendEmail sends the provided attachment via email to all recipients. When no username is configured for the SMTP
+// server, the email is sent without authentication.
+func sendEmail(sendMail sendMailFunc, config SMTPConfig, email *Email, subject string, a attachment) error {
+	if config.Host == "" {
+		return fmt.Errorf("smtp host is missing")
+	}
+
+	if len(email.To) == 0 {
+		return fmt.Errorf("email recipients are missing")
+	}
+
+	if email.Subject != "" {
+		subject = email.Subject
+	}
+
+	msg, err := buildMessage(config.From, email.To, subject, a)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if config.Username != "" {
+		auth = smtp.PlainAuth("", config.Username, config.Password, config.Host)
+	}
+
+	return sendMail(net.JoinHostPort(config.Host, strconv.Itoa(config.Port)), auth, config.From, email.To, msg)
+}
+
+// buildMessage creates a multipart MIME message with a short text and the rendered dashboard as attachment.
+func buildMessage(from string, to []string, subject string, a attachment) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", writer.Boundary())
+
+	text, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {"text/plain; charset=utf-8"}})
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(text, "The report %q is attached to this email.\r\n", subject)
+
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {a.contentType},
+		"Content-Transfer-Encoding": {"base64"},
+		"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": a.filename})},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The base64 encoded attachment must be split into lines with a maximum length of 76 characters (RFC 2045).
+	encoded := base64.StdEncoding.EncodeToString(a.data)
+	for len(encoded) > 76 {
+		fmt.Fprintf(part, "%s\r\n", encoded[:76])
+		encoded = encoded[76:]
+	}
+	fmt.Fprintf(part, "%s\r\n", encoded)
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// sendWebhook sends the provided attachment as body of a POST request to the url of the webhook. The name of the file
+// is set in the "Content-Disposition" header. All status codes outside of the 2xx range are returned as error.
+func sendWebhook(ctx context.Context, httpClient *http.Client, webhook *Webhook, a attachment) error {
+	if webhook.URL == "" {
+		return fmt.Errorf("webhook url is missing")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(a.data))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", a.contentType)
+	req.Header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": a.filename}))
+	for key, value := range webhook.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendEmail(t *testing.T) {
+	a := attachment{filename: "report.pdf", contentType: "application/pdf", data: []byte(strings.Repeat("a", 100))}
+
+	t.Run("should fail for missing host", func(t *testing.T) {
+		err := sendEmail(nil, SMTPConfig{}, &Email{To: []string{"admin@kobs.io"}}, "Report", a)
+		require.EqualError(t, err, "smtp host is missing")
+	})
+
+	t.Run("should fail for missing recipients", func(t *testing.T) {
+		err := sendEmail(nil, SMTPConfig{Host: "localhost"}, &Email{}, "Report", a)
+		require.EqualError(t, err, "email recipients are missing")
+	})
+
+	t.Run("should send email with authentication", func(t *testing.T) {
+		var message string
+		sendMail := func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+			require.NotNil(t, auth)
+			message = string(msg)
+			return nil
+		}
+
+		err := sendEmail(sendMail, SMTPConfig{Host: "localhost", Port: 587, Username: "user", Password: "password", From: "kobs@kobs.io"}, &Email{To: []string{"admin@kobs.io", "dev@kobs.io"}, Subject: "Weekly"}, "Report", a)
+		require.NoError(t, err)
+		require.Contains(t, message, "To: admin@kobs.io, dev@kobs.io\r\n")
+		require.Contains(t, message, "Subject: Weekly\r\n")
+		require.Contains(t, message, `Content-Disposition: attachment; filename=report.pdf`)
+	})
+
+	t.Run("should return send error", func(t *testing.T) {
+		sendMail := func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+			return fmt.Errorf("unexpected error")
+		}
+
+		err := sendEmail(sendMail, SMTPConfig{Host: "localhost", Port: 25}, &Email{To: []string{"admin@kobs.io"}}, "Report", a)
+		require.EqualError(t, err, "unexpected error")
+	})
+}
+
+func TestSendWebhook(t *testing.T) {
+	a := attachment{filename: "report.png", contentType: "image/png", data: []byte("png")}
+
+	t.Run("should fail for missing url", func(t *testing.T) {
+		err := sendWebhook(context.Background(), http.DefaultClient, &Webhook{}, a)
+		require.EqualError(t, err, "webhook url is missing")
+	})
+
+	t.Run("should send report", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			require.Equal(t, "png", string(body))
+			require.Equal(t, "image/png", r.Header.Get("Content-Type"))
+			require.Equal(t, "attachment; filename=report.png", r.Header.Get("Content-Disposition"))
+			require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		}))
+		defer ts.Close()
+
+		err := sendWebhook(context.Background(), http.DefaultClient, &Webhook{URL: ts.URL, Headers: map[string]string{"Authorization": "Bearer token"}}, a)
+		require.NoError(t, err)
+	})
+
+	t.Run("should fail for invalid status code", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer ts.Close()
+
+		err := sendWebhook(context.Background(), http.DefaultClient, &Webhook{URL: ts.URL}, a)
+		require.EqualError(t, err, "webhook returned status code 400")
+	})
+}
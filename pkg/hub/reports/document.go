@@ -0,0 +1,165 @@
+package reports
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+)
+
+const (
+	FormatPDF = "pdf"
+	FormatPNG = "png"
+)
+
+// Document is a rendered dashboard. It contains the title of the dashboard, the time range for which the panels were
+// rendered and the rendered panels grouped by the rows of the dashboard.
+type Document struct {
+	Title       string
+	Description string
+	TimeStart   int64
+	TimeEnd     int64
+	Rows        []DocumentRow
+}
+
+// DocumentRow is a single row of a dashboard with the images of all panels in the row.
+type DocumentRow struct {
+	Title  string
+	Panels []*image.RGBA
+}
+
+// subtitle returns the time range of the document, which is shown below the title.
+func (d *Document) subtitle() string {
+	return fmt.Sprintf("%s - %s", time.Unix(d.TimeStart, 0).UTC().Format(time.RFC1123), time.Unix(d.TimeEnd, 0).UTC().Format(time.RFC1123))
+}
+
+// encode encodes the document in the provided format. It returns the encoded document and the content type.
+func (d *Document) encode(f *fonts, format string) ([]byte, string, error) {
+	switch format {
+	case FormatPNG:
+		data, err := d.encodePNG(f)
+		return data, "image/png", err
+	case FormatPDF, "":
+		data, err := d.encodePDF()
+		return data, "application/pdf", err
+	default:
+		return nil, "", fmt.Errorf("invalid format %q", format)
+	}
+}
+
+// encodePNG renders all panels below each other into a single image. The title of the dashboard and the titles of the
+// rows are rendered above the panels.
+func (d *Document) encodePNG(f *fonts) ([]byte, error) {
+	height := 2*titleHeight + padding
+	for _, row := range d.Rows {
+		if row.Title != "" {
+			height += titleHeight
+		}
+		for _, panel := range row.Panels {
+			height += panel.Bounds().Dy() + padding
+		}
+	}
+
+	img := newImage(panelWidth, height)
+	f.drawText(img, f.title, colorText, padding, padding+18, d.Title)
+	f.drawText(img, f.regular, colorSecondary, padding, titleHeight+padding+8, d.subtitle())
+
+	y := 2*titleHeight + padding
+	for _, row := range d.Rows {
+		if row.Title != "" {
+			f.drawText(img, f.title, colorText, padding, y+24, row.Title)
+			y += titleHeight
+		}
+
+		for _, panel := range row.Panels {
+			draw.Draw(img, panel.Bounds().Add(image.Point{X: 0, Y: y}), panel, image.Point{}, draw.Src)
+			y += panel.Bounds().Dy()
+			fillRect(img, padding, y+padding/2, panelWidth-padding, y+padding/2+1, colorGrid)
+			y += padding
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// encodePDF creates a PDF document in the A4 landscape format. Each row of the dashboard starts on a new page and the
+// panels are placed below each other, so that a new page is added when a panel doesn't fit on the current page.
+func (d *Document) encodePDF() ([]byte, error) {
+	pdf := fpdf.New("L", "mm", "A4", "")
+	pdf.SetTitle(d.Title, true)
+	pdf.SetCreator("kobs", true)
+	pdf.SetAutoPageBreak(false, 0)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	const margin = 10.0
+	pageWidth, pageHeight := pdf.GetPageSize()
+	contentWidth := pageWidth - 2*margin
+
+	pdf.AddPage()
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.SetXY(margin, margin)
+	pdf.MultiCell(contentWidth, 9, tr(d.Title), "", "L", false)
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.SetTextColor(0x75, 0x75, 0x75)
+	pdf.MultiCell(contentWidth, 5, tr(d.subtitle()), "", "L", false)
+	if d.Description != "" {
+		pdf.MultiCell(contentWidth, 5, tr(d.Description), "", "L", false)
+	}
+	pdf.SetTextColor(0x21, 0x21, 0x21)
+	pdf.Ln(4)
+
+	for rowIndex, row := range d.Rows {
+		if rowIndex > 0 {
+			pdf.AddPage()
+			pdf.SetY(margin)
+		}
+
+		if row.Title != "" {
+			pdf.SetFont("Helvetica", "B", 14)
+			pdf.SetX(margin)
+			pdf.MultiCell(contentWidth, 8, tr(row.Title), "", "L", false)
+			pdf.Ln(2)
+		}
+
+		for panelIndex, panel := range row.Panels {
+			var buf bytes.Buffer
+			if err := png.Encode(&buf, panel); err != nil {
+				return nil, err
+			}
+
+			name := fmt.Sprintf("row-%d-panel-%d", rowIndex, panelIndex)
+			options := fpdf.ImageOptions{ImageType: "PNG"}
+			pdf.RegisterImageOptionsReader(name, options, &buf)
+
+			height := contentWidth * float64(panel.Bounds().Dy()) / float64(panel.Bounds().Dx())
+			if height > pageHeight-2*margin {
+				height = pageHeight - 2*margin
+			}
+
+			y := pdf.GetY()
+			if y+height > pageHeight-margin {
+				pdf.AddPage()
+				y = margin
+			}
+
+			pdf.ImageOptions(name, margin, y, 0, height, false, options, 0, "")
+			pdf.SetY(y + height + 4)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
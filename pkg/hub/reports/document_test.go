@@ -0,0 +1,57 @@
+package reports
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newDocument(t *testing.T) (*Document, *fonts) {
+	f, err := newFonts()
+	require.NoError(t, err)
+
+	value := 1.0
+
+	return &Document{
+		Title:     "Weekly Report",
+		TimeStart: 0,
+		TimeEnd:   3600,
+		Rows: []DocumentRow{
+			{Title: "Metrics", Panels: []*image.RGBA{
+				f.renderPanel("Chart", Content{Chart: &Chart{Series: []Series{{Name: "test", Points: []Point{{X: 0, Y: &value}, {X: 1000, Y: nil}, {X: 2000, Y: &value}}}}}}),
+				f.renderPanel("Table", Content{Table: &Table{Columns: []string{"Name", "Value"}, Rows: [][]string{{"a", "1"}}}}),
+			}},
+			{Panels: []*image.RGBA{f.renderPanel("Message", Content{Message: "Not supported"})}},
+		},
+	}, f
+}
+
+func TestEncode(t *testing.T) {
+	t.Run("should encode pdf", func(t *testing.T) {
+		document, f := newDocument(t)
+		data, contentType, err := document.encode(f, FormatPDF)
+		require.NoError(t, err)
+		require.Equal(t, "application/pdf", contentType)
+		require.Equal(t, "%PDF", string(data[:4]))
+	})
+
+	t.Run("should encode png", func(t *testing.T) {
+		document, f := newDocument(t)
+		data, contentType, err := document.encode(f, FormatPNG)
+		require.NoError(t, err)
+		require.Equal(t, "image/png", contentType)
+
+		img, err := png.Decode(bytes.NewReader(data))
+		require.NoError(t, err)
+		require.Equal(t, panelWidth, img.Bounds().Dx())
+	})
+
+	t.Run("should fail for invalid format", func(t *testing.T) {
+		document, f := newDocument(t)
+		_, _, err := document.encode(f, "svg")
+		require.EqualError(t, err, `invalid format "svg"`)
+	})
+}
@@ -0,0 +1,351 @@
+package reports
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"time"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	// panelWidth is the width of the image for a single panel in pixels. All panels are rendered with the same width,
+	// so that they can be placed below each other in the document.
+	panelWidth = 1200
+	// chartHeight is the height of the image for a chart, without the legend.
+	chartHeight = 400
+	// tableRowHeight is the height of a single row in a table.
+	tableRowHeight = 28
+	// maxTableRows is the maximum number of rows, which are rendered for a table. All other rows are skipped, to keep
+	// the size of the reports reasonable.
+	maxTableRows = 50
+
+	padding     = 16
+	titleHeight = 36
+	legendLine  = 20
+)
+
+var (
+	colorBackground = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	colorText       = color.RGBA{0x21, 0x21, 0x21, 0xff}
+	colorSecondary  = color.RGBA{0x75, 0x75, 0x75, 0xff}
+	colorGrid       = color.RGBA{0xe0, 0xe0, 0xe0, 0xff}
+	colorStripe     = color.RGBA{0xf5, 0xf5, 0xf5, 0xff}
+
+	// colorSeries are the colors for the series in a chart. They are the same as the chart colors in the frontend.
+	colorSeries = []color.RGBA{
+		{0x19, 0x76, 0xd2, 0xff},
+		{0x38, 0x8e, 0x3c, 0xff},
+		{0xf5, 0x7c, 0x00, 0xff},
+		{0xd3, 0x2f, 0x2f, 0xff},
+		{0x7b, 0x1f, 0xa2, 0xff},
+		{0x00, 0x97, 0xa7, 0xff},
+		{0xaf, 0xb4, 0x2b, 0xff},
+		{0x5d, 0x40, 0x37, 0xff},
+	}
+)
+
+// fonts contains the font faces, which are used to render the text in the images. We are using the Go fonts, which
+// are embedded in the binary, so that we do not depend on the fonts installed on the system.
+type fonts struct {
+	regular font.Face
+	bold    font.Face
+	title   font.Face
+}
+
+func newFonts() (*fonts, error) {
+	regular, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		return nil, err
+	}
+
+	bold, err := opentype.Parse(gobold.TTF)
+	if err != nil {
+		return nil, err
+	}
+
+	regularFace, err := opentype.NewFace(regular, &opentype.FaceOptions{Size: 13, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, err
+	}
+
+	boldFace, err := opentype.NewFace(bold, &opentype.FaceOptions{Size: 13, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, err
+	}
+
+	titleFace, err := opentype.NewFace(bold, &opentype.FaceOptions{Size: 18, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, err
+	}
+
+	return &fonts{regular: regularFace, bold: boldFace, title: titleFace}, nil
+}
+
+// renderPanel renders the content of a panel with the provided title into an image.
+func (f *fonts) renderPanel(title string, content Content) *image.RGBA {
+	switch {
+	case content.Chart != nil:
+		return f.renderChart(title, content.Chart)
+	case content.Table != nil:
+		return f.renderTable(title, content.Table)
+	default:
+		return f.renderMessage(title, content.Message)
+	}
+}
+
+// renderMessage renders a panel, which only contains a message. This is used for panels of plugins, which are not
+// supported in reports and for panels where we failed to get the data.
+func (f *fonts) renderMessage(title, message string) *image.RGBA {
+	img := newImage(panelWidth, titleHeight+2*padding+legendLine)
+	f.drawText(img, f.title, colorText, padding, padding+18, title)
+	f.drawText(img, f.regular, colorSecondary, padding, titleHeight+padding+13, message)
+	return img
+}
+
+// renderTable renders a table with a header row. Each column gets the same width and the text of a cell is truncated
+// when it doesn't fit into the column.
+func (f *fonts) renderTable(title string, table *Table) *image.RGBA {
+	rows := table.Rows
+	truncated := len(rows) > maxTableRows
+	if truncated {
+		rows = rows[:maxTableRows]
+	}
+
+	height := titleHeight + padding + (len(rows)+1)*tableRowHeight + padding
+	if truncated {
+		height += tableRowHeight
+	}
+
+	img := newImage(panelWidth, height)
+	f.drawText(img, f.title, colorText, padding, padding+18, title)
+
+	if len(table.Columns) == 0 {
+		return img
+	}
+
+	columnWidth := (panelWidth - 2*padding) / len(table.Columns)
+	y := titleHeight + padding
+
+	for i, column := range table.Columns {
+		f.drawText(img, f.bold, colorText, padding+i*columnWidth+4, y+18, truncate(f.bold, column, columnWidth-8))
+	}
+	fillRect(img, padding, y+tableRowHeight-1, panelWidth-padding, y+tableRowHeight, colorSecondary)
+
+	for rowIndex, row := range rows {
+		y += tableRowHeight
+		if rowIndex%2 == 1 {
+			fillRect(img, padding, y, panelWidth-padding, y+tableRowHeight-1, colorStripe)
+		}
+
+		for i, cell := range row {
+			if i >= len(table.Columns) {
+				break
+			}
+			f.drawText(img, f.regular, colorText, padding+i*columnWidth+4, y+18, truncate(f.regular, cell, columnWidth-8))
+		}
+		fillRect(img, padding, y+tableRowHeight-1, panelWidth-padding, y+tableRowHeight, colorGrid)
+	}
+
+	if truncated {
+		f.drawText(img, f.regular, colorSecondary, padding+4, y+tableRowHeight+18, fmt.Sprintf("%d more rows are not shown", len(table.Rows)-maxTableRows))
+	}
+
+	return img
+}
+
+// renderChart renders a line chart for all series. The y axis always starts at zero for positive values, the x axis
+// uses the time range of all data points. The legend with the names of the series is rendered below the chart.
+func (f *fonts) renderChart(title string, chart *Chart) *image.RGBA {
+	legendHeight := len(chart.Series) * legendLine
+	img := newImage(panelWidth, titleHeight+chartHeight+legendHeight+padding)
+	f.drawText(img, f.title, colorText, padding, padding+18, title)
+
+	minX, maxX := int64(math.MaxInt64), int64(math.MinInt64)
+	minY, maxY := 0.0, 0.0
+	hasData := false
+
+	for _, series := range chart.Series {
+		for _, point := range series.Points {
+			if point.X < minX {
+				minX = point.X
+			}
+			if point.X > maxX {
+				maxX = point.X
+			}
+			if point.Y != nil {
+				minY = math.Min(minY, *point.Y)
+				maxY = math.Max(maxY, *point.Y)
+				hasData = true
+			}
+		}
+	}
+
+	if !hasData {
+		f.drawText(img, f.regular, colorSecondary, padding, titleHeight+padding+13, "No data found")
+		return img
+	}
+
+	if maxY == minY {
+		maxY = minY + 1
+	}
+	if maxX == minX {
+		maxX = minX + 1
+	}
+
+	// The area for the chart, the space on the left side is used for the labels of the y axis and the space at the
+	// bottom for the labels of the x axis.
+	left, top := padding+80, titleHeight+padding
+	right, bottom := panelWidth-padding, titleHeight+chartHeight-legendLine
+
+	toX := func(x int64) int {
+		return left + int(float64(x-minX)/float64(maxX-minX)*float64(right-left))
+	}
+	toY := func(y float64) int {
+		return bottom - int((y-minY)/(maxY-minY)*float64(bottom-top))
+	}
+
+	for i := 0; i <= 4; i++ {
+		value := minY + (maxY-minY)*float64(i)/4
+		y := toY(value)
+		fillRect(img, left, y, right, y+1, colorGrid)
+
+		label := truncate(f.regular, fmt.Sprintf("%s %s", formatNumber(value), chart.Unit), left-padding-8)
+		f.drawText(img, f.regular, colorSecondary, left-8-textWidth(f.regular, label), y+4, label)
+	}
+
+	layout := "15:04"
+	if time.Duration(maxX-minX)*time.Millisecond > 24*time.Hour {
+		layout = "01-02 15:04"
+	}
+
+	for i := 0; i <= 5; i++ {
+		value := minX + (maxX-minX)*int64(i)/5
+		x := toX(value)
+		label := time.UnixMilli(value).UTC().Format(layout)
+		labelX := x - textWidth(f.regular, label)/2
+		if i == 5 {
+			labelX = x - textWidth(f.regular, label)
+		}
+		fillRect(img, x, bottom, x+1, bottom+4, colorSecondary)
+		f.drawText(img, f.regular, colorSecondary, labelX, bottom+18, label)
+	}
+
+	fillRect(img, left, bottom, right, bottom+1, colorSecondary)
+
+	for i, series := range chart.Series {
+		c := colorSeries[i%len(colorSeries)]
+
+		var last *image.Point
+		for _, point := range series.Points {
+			if point.Y == nil {
+				last = nil
+				continue
+			}
+
+			current := image.Point{X: toX(point.X), Y: toY(*point.Y)}
+			if last != nil {
+				drawLine(img, *last, current, c)
+			} else {
+				fillRect(img, current.X, current.Y, current.X+1, current.Y+1, c)
+			}
+			last = &current
+		}
+
+		y := titleHeight + chartHeight + i*legendLine
+		fillRect(img, padding, y+4, padding+12, y+16, c)
+		f.drawText(img, f.regular, colorText, padding+20, y+14, truncate(f.regular, series.Name, panelWidth-3*padding))
+	}
+
+	return img
+}
+
+// drawText draws the provided text at the given position. The y coordinate is the baseline of the text.
+func (f *fonts) drawText(img draw.Image, face font.Face, c color.Color, x, y int, text string) {
+	d := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(c),
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(text)
+}
+
+// textWidth returns the width of the provided text in pixels.
+func textWidth(face font.Face, text string) int {
+	return font.MeasureString(face, text).Ceil()
+}
+
+// truncate shortens the provided text, so that it fits into the given width. When the text was shortened we append
+// "..." to the text.
+func truncate(face font.Face, text string, width int) string {
+	if textWidth(face, text) <= width {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 && textWidth(face, string(runes)+"...") > width {
+		runes = runes[:len(runes)-1]
+	}
+
+	return string(runes) + "..."
+}
+
+func newImage(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(colorBackground), image.Point{}, draw.Src)
+	return img
+}
+
+func fillRect(img *image.RGBA, x0, y0, x1, y1 int, c color.Color) {
+	draw.Draw(img, image.Rect(x0, y0, x1, y1), image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+// drawLine draws a line with a width of two pixels between the provided points, using Bresenham's line algorithm.
+func drawLine(img *image.RGBA, from, to image.Point, c color.RGBA) {
+	dx := abs(to.X - from.X)
+	dy := -abs(to.Y - from.Y)
+	sx, sy := 1, 1
+	if from.X > to.X {
+		sx = -1
+	}
+	if from.Y > to.Y {
+		sy = -1
+	}
+
+	err := dx + dy
+	x, y := from.X, from.Y
+
+	for {
+		img.SetRGBA(x, y, c)
+		img.SetRGBA(x, y+1, c)
+
+		if x == to.X && y == to.Y {
+			return
+		}
+
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y += sy
+		}
+	}
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
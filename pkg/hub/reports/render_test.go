@@ -0,0 +1,51 @@
+package reports
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderPanel(t *testing.T) {
+	f, err := newFonts()
+	require.NoError(t, err)
+
+	value := 1.0
+
+	t.Run("should render message", func(t *testing.T) {
+		img := f.renderPanel("Panel", Content{Message: "Not supported"})
+		require.Equal(t, panelWidth, img.Bounds().Dx())
+	})
+
+	t.Run("should render chart without data", func(t *testing.T) {
+		img := f.renderPanel("Panel", Content{Chart: &Chart{Series: []Series{{Name: "test", Points: []Point{{X: 0}}}}}})
+		require.Equal(t, titleHeight+chartHeight+legendLine+padding, img.Bounds().Dy())
+	})
+
+	t.Run("should render chart", func(t *testing.T) {
+		img := f.renderPanel("Panel", Content{Chart: &Chart{Series: []Series{
+			{Name: "first", Points: []Point{{X: 0, Y: &value}, {X: 1000, Y: &value}}},
+			{Name: "second", Points: []Point{{X: 0, Y: &value}}},
+		}}})
+		require.Equal(t, titleHeight+chartHeight+2*legendLine+padding, img.Bounds().Dy())
+		require.Equal(t, colorSeries[0], img.RGBAAt(panelWidth-padding, titleHeight+padding))
+	})
+
+	t.Run("should render table and truncate rows", func(t *testing.T) {
+		rows := make([][]string, maxTableRows+10)
+		for i := range rows {
+			rows[i] = []string{"a", "b"}
+		}
+
+		img := f.renderPanel("Panel", Content{Table: &Table{Columns: []string{"A", "B"}, Rows: rows}})
+		require.Equal(t, titleHeight+2*padding+(maxTableRows+2)*tableRowHeight, img.Bounds().Dy())
+	})
+}
+
+func TestTruncate(t *testing.T) {
+	f, err := newFonts()
+	require.NoError(t, err)
+
+	require.Equal(t, "test", truncate(f.regular, "test", 100))
+	require.Equal(t, "te...", truncate(f.regular, "test test test", textWidth(f.regular, "te...")))
+}
@@ -0,0 +1,257 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"time"
+
+	dashboardv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
+	"github.com/kobsio/kobs/pkg/hub/db"
+	hubPlugins "github.com/kobsio/kobs/pkg/hub/plugins"
+	"github.com/kobsio/kobs/pkg/instrument/log"
+	"github.com/kobsio/kobs/pkg/utils/middleware/roundtripper"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/common/model"
+	"github.com/robfig/cron/v3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// runTimeout is the maximum duration for rendering and delivering a single report.
+const runTimeout = 5 * time.Minute
+
+var (
+	runsTotalMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kobs",
+		Name:      "reports_runs_total",
+		Help:      "Number of report runs, partitioned by report and status.",
+	}, []string{"report", "status"})
+)
+
+// Config is the configuration for the reports. The SMTP server is only required when at least one report should be
+// delivered via email.
+type Config struct {
+	SMTP    SMTPConfig `json:"smtp" embed:"" prefix:"smtp." envprefix:"SMTP_"`
+	Reports []Report   `json:"reports" kong:"-"`
+}
+
+// Report is a dashboard, which is rendered on the server and delivered on the configured schedule. The schedule must be
+// a cron expression. The time is the duration before the execution of the report, which is used as time range for all
+// panels (e.g. "7d").
+type Report struct {
+	Name      string                `json:"name"`
+	Schedule  string                `json:"schedule"`
+	Dashboard dashboardv1.Reference `json:"dashboard"`
+	Time      string                `json:"time"`
+	Format    string                `json:"format"`
+	Email     *Email                `json:"email"`
+	Webhook   *Webhook              `json:"webhook"`
+}
+
+// Client is the interface to run the configured reports. Start starts the scheduler for all reports and Stop waits
+// until all running reports are finished. Run can be used to render and deliver a single report immediately.
+type Client interface {
+	Start()
+	Stop()
+	Run(ctx context.Context, name string) error
+}
+
+type client struct {
+	config     Config
+	cron       *cron.Cron
+	requester  *hubPlugins.Requester
+	dbClient   db.Client
+	fonts      *fonts
+	httpClient *http.Client
+	sendMail   sendMailFunc
+	tracer     trace.Tracer
+}
+
+func (c *client) Start() {
+	log.Info(context.Background(), "Reports scheduler started", zap.Int("reportsCount", len(c.config.Reports)))
+	c.cron.Start()
+}
+
+func (c *client) Stop() {
+	log.Debug(context.Background(), "Start shutdown of the reports scheduler")
+	<-c.cron.Stop().Done()
+}
+
+// Run renders the report with the provided name and delivers it to all configured targets. The returned error must be
+// logged by the caller.
+func (c *client) Run(ctx context.Context, name string) error {
+	ctx, span := c.tracer.Start(ctx, "reports.Run")
+	span.SetAttributes(attribute.Key("report").String(name))
+	defer span.End()
+
+	startTime := time.Now()
+
+	err := c.run(ctx, name)
+	if err != nil {
+		runsTotalMetric.WithLabelValues(name, "error").Inc()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	runsTotalMetric.WithLabelValues(name, "success").Inc()
+	log.Info(ctx, "Report was delivered", zap.String("report", name), zap.Duration("duration", time.Since(startTime)))
+	return nil
+}
+
+func (c *client) run(ctx context.Context, name string) error {
+	var report *Report
+	for i := range c.config.Reports {
+		if c.config.Reports[i].Name == name {
+			report = &c.config.Reports[i]
+			break
+		}
+	}
+
+	if report == nil {
+		return fmt.Errorf("report not found")
+	}
+
+	duration, err := parseTime(report.Time)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	document, err := c.render(ctx, report.Dashboard, now.Add(-duration).Unix(), now.Unix())
+	if err != nil {
+		return err
+	}
+
+	data, contentType, err := document.encode(c.fonts, report.Format)
+	if err != nil {
+		return err
+	}
+
+	format := report.Format
+	if format == "" {
+		format = FormatPDF
+	}
+
+	a := attachment{
+		filename:    fmt.Sprintf("%s-%s.%s", report.Name, now.Format("2006-01-02"), format),
+		contentType: contentType,
+		data:        data,
+	}
+
+	var errs []error
+	if report.Email != nil {
+		if err := sendEmail(c.sendMail, c.config.SMTP, report.Email, document.Title, a); err != nil {
+			errs = append(errs, fmt.Errorf("failed to send email: %w", err))
+		}
+	}
+	if report.Webhook != nil {
+		if err := sendWebhook(ctx, c.httpClient, report.Webhook, a); err != nil {
+			errs = append(errs, fmt.Errorf("failed to send webhook: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// parseTime parses the time of a report. The time supports the same units as Prometheus (e.g. "1d" or "1w"). If the
+// time is not set, we use the last 24 hours.
+func parseTime(value string) (time.Duration, error) {
+	if value == "" {
+		return 24 * time.Hour, nil
+	}
+
+	duration, err := model.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(duration), nil
+}
+
+// validate checks the configuration of a report, so that configuration errors are already returned when the hub is
+// started and not when the report is executed.
+func (r *Report) validate(smtpConfig SMTPConfig) error {
+	if r.Name == "" {
+		return fmt.Errorf("name is missing")
+	}
+
+	if r.Dashboard.Inline == nil && (r.Dashboard.Cluster == "" || r.Dashboard.Namespace == "" || r.Dashboard.Name == "") {
+		return fmt.Errorf("dashboard is missing")
+	}
+
+	if r.Format != "" && r.Format != FormatPDF && r.Format != FormatPNG {
+		return fmt.Errorf("invalid format %q", r.Format)
+	}
+
+	if _, err := parseTime(r.Time); err != nil {
+		return fmt.Errorf("invalid time: %w", err)
+	}
+
+	if r.Email == nil && r.Webhook == nil {
+		return fmt.Errorf("email or webhook is required")
+	}
+
+	if r.Email != nil && smtpConfig.Host == "" {
+		return fmt.Errorf("smtp server is not configured")
+	}
+
+	return nil
+}
+
+// NewClient returns a new client to run the configured reports. The data for the panels is retrieved via the provided
+// plugins router, which must be the router of the hub plugins client, so that we can use the same API as the frontend.
+func NewClient(config Config, pluginsRouter http.Handler, dbClient db.Client) (Client, error) {
+	f, err := newFonts()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &client{
+		config:    config,
+		cron:      cron.New(),
+		requester: hubPlugins.NewRequester(pluginsRouter),
+		httpClient: &http.Client{
+			Timeout:   runTimeout,
+			Transport: roundtripper.DefaultRoundTripper,
+		},
+		dbClient: dbClient,
+		fonts:    f,
+		sendMail: smtp.SendMail,
+		tracer:   otel.Tracer("reports"),
+	}
+
+	names := make(map[string]bool)
+	for _, report := range config.Reports {
+		if err := report.validate(config.SMTP); err != nil {
+			return nil, fmt.Errorf("invalid report %q: %w", report.Name, err)
+		}
+
+		if names[report.Name] {
+			return nil, fmt.Errorf("duplicate report %q", report.Name)
+		}
+		names[report.Name] = true
+
+		name := report.Name
+		if _, err := c.cron.AddFunc(report.Schedule, func() {
+			ctx, cancel := context.WithTimeout(context.Background(), runTimeout)
+			defer cancel()
+
+			if err := c.Run(ctx, name); err != nil {
+				log.Error(ctx, "Failed to run report", zap.Error(err), zap.String("report", name))
+			}
+		}); err != nil {
+			return nil, fmt.Errorf("invalid schedule for report %q: %w", report.Name, err)
+		}
+	}
+
+	return c, nil
+}
@@ -0,0 +1,249 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"testing"
+
+	dashboardv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
+	"github.com/kobsio/kobs/pkg/hub/db"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func newPluginsRouter() chi.Router {
+	router := chi.NewRouter()
+	router.Post("/prometheus/range", func(w http.ResponseWriter, r *http.Request) {
+		value := 1.0
+		render.JSON(w, r, prometheusMetrics{Metrics: []struct {
+			Name string `json:"name"`
+			Data []struct {
+				X int64    `json:"x"`
+				Y *float64 `json:"y"`
+			} `json:"data"`
+		}{{Name: "test", Data: []struct {
+			X int64    `json:"x"`
+			Y *float64 `json:"y"`
+		}{{X: 0, Y: &value}, {X: 60000, Y: &value}}}}})
+	})
+	return router
+}
+
+func newReport(name string, webhookURL string) Report {
+	return Report{
+		Name:     name,
+		Schedule: "0 8 * * 1",
+		Time:     "7d",
+		Format:   FormatPDF,
+		Dashboard: dashboardv1.Reference{
+			Title: "Weekly Report",
+			Inline: &dashboardv1.ReferenceInline{
+				Rows: []dashboardv1.Row{{
+					Title: "Row",
+					Panels: []dashboardv1.Panel{
+						{Title: "Requests", Plugin: dashboardv1.Plugin{Type: "prometheus", Cluster: "hub", Name: "prometheus", Options: &apiextensionsv1.JSON{Raw: []byte(`{"type":"line","queries":[{"query":"up"}]}`)}}},
+						{Title: "Issues", Plugin: dashboardv1.Plugin{Type: "jira", Cluster: "hub", Name: "jira"}},
+					},
+				}},
+			},
+		},
+		Webhook: &Webhook{URL: webhookURL},
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		config      Config
+		expectedErr string
+	}{
+		{
+			name:   "should return client without reports",
+			config: Config{},
+		},
+		{
+			name:   "should return client with valid report",
+			config: Config{Reports: []Report{newReport("weekly", "http://localhost")}},
+		},
+		{
+			name:        "should fail for missing name",
+			config:      Config{Reports: []Report{newReport("", "http://localhost")}},
+			expectedErr: `invalid report "": name is missing`,
+		},
+		{
+			name:        "should fail for duplicate name",
+			config:      Config{Reports: []Report{newReport("weekly", "http://localhost"), newReport("weekly", "http://localhost")}},
+			expectedErr: `duplicate report "weekly"`,
+		},
+		{
+			name: "should fail for invalid schedule",
+			config: Config{Reports: []Report{func() Report {
+				r := newReport("weekly", "http://localhost")
+				r.Schedule = "every monday"
+				return r
+			}()}},
+			expectedErr: `invalid schedule for report "weekly"`,
+		},
+		{
+			name: "should fail for invalid format",
+			config: Config{Reports: []Report{func() Report {
+				r := newReport("weekly", "http://localhost")
+				r.Format = "svg"
+				return r
+			}()}},
+			expectedErr: `invalid report "weekly": invalid format "svg"`,
+		},
+		{
+			name: "should fail for email without smtp server",
+			config: Config{Reports: []Report{func() Report {
+				r := newReport("weekly", "")
+				r.Webhook = nil
+				r.Email = &Email{To: []string{"admin@kobs.io"}}
+				return r
+			}()}},
+			expectedErr: `invalid report "weekly": smtp server is not configured`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(tt.config, chi.NewRouter(), nil)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				require.Nil(t, client)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, client)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Run("should fail for unknown report", func(t *testing.T) {
+		client, err := NewClient(Config{}, newPluginsRouter(), nil)
+		require.NoError(t, err)
+		require.EqualError(t, client.Run(context.Background(), "weekly"), "report not found")
+	})
+
+	t.Run("should deliver report via webhook", func(t *testing.T) {
+		var contentType string
+		var body []byte
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			contentType = r.Header.Get("Content-Type")
+			body, _ = io.ReadAll(r.Body)
+		}))
+		defer ts.Close()
+
+		client, err := NewClient(Config{Reports: []Report{newReport("weekly", ts.URL)}}, newPluginsRouter(), nil)
+		require.NoError(t, err)
+		require.NoError(t, client.Run(context.Background(), "weekly"))
+		require.Equal(t, "application/pdf", contentType)
+		require.Equal(t, "%PDF", string(body[:4]))
+	})
+
+	t.Run("should deliver report via email", func(t *testing.T) {
+		var recipients []string
+		report := newReport("weekly", "")
+		report.Webhook = nil
+		report.Email = &Email{To: []string{"admin@kobs.io"}}
+		report.Format = FormatPNG
+
+		c, err := NewClient(Config{SMTP: SMTPConfig{Host: "localhost", Port: 25, From: "kobs@kobs.io"}, Reports: []Report{report}}, newPluginsRouter(), nil)
+		require.NoError(t, err)
+
+		c.(*client).sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+			require.Equal(t, "localhost:25", addr)
+			require.Nil(t, a)
+			require.Equal(t, "kobs@kobs.io", from)
+			recipients = to
+			return nil
+		}
+
+		require.NoError(t, c.Run(context.Background(), "weekly"))
+		require.Equal(t, []string{"admin@kobs.io"}, recipients)
+	})
+
+	t.Run("should return delivery error", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer ts.Close()
+
+		client, err := NewClient(Config{Reports: []Report{newReport("weekly", ts.URL)}}, newPluginsRouter(), nil)
+		require.NoError(t, err)
+		require.EqualError(t, client.Run(context.Background(), "weekly"), "failed to send webhook: webhook returned status code 500")
+	})
+
+	t.Run("should return dashboard error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetDashboardByID(gomock.Any(), "/cluster/test/namespace/default/name/weekly").Return(nil, fmt.Errorf("unexpected error"))
+
+		report := newReport("weekly", "http://localhost")
+		report.Dashboard = dashboardv1.Reference{Cluster: "test", Namespace: "default", Name: "weekly"}
+
+		client, err := NewClient(Config{Reports: []Report{report}}, newPluginsRouter(), dbClient)
+		require.NoError(t, err)
+		require.EqualError(t, client.Run(context.Background(), "weekly"), "unexpected error")
+	})
+}
+
+func TestRender(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	dbClient := db.NewMockClient(ctrl)
+	dbClient.EXPECT().GetDashboardByID(gomock.Any(), "/cluster/test/namespace/default/name/weekly").Return(&dashboardv1.DashboardSpec{
+		Title:        "Dashboard",
+		Placeholders: []dashboardv1.Placeholder{{Name: "namespace", Default: "kube-system"}},
+		Rows: []dashboardv1.Row{{Panels: []dashboardv1.Panel{
+			{Title: "Second", Y: 1, Plugin: dashboardv1.Plugin{Type: "jira"}},
+			{Title: "First", Plugin: dashboardv1.Plugin{Type: "prometheus", Cluster: "hub", Name: "prometheus", Options: &apiextensionsv1.JSON{Raw: []byte(`{"queries":[{"query":"up{namespace=\"{% .namespace %}\"}"}]}`)}}},
+		}}, {If: `"{% .namespace %}" === "kube-system"`, Panels: []dashboardv1.Panel{
+			{Title: "Hidden", Plugin: dashboardv1.Plugin{Type: "jira"}},
+		}}, {If: `"{% .namespace %}".includes("default")`, Panels: []dashboardv1.Panel{
+			{Title: "Invalid", Plugin: dashboardv1.Plugin{Type: "jira"}},
+		}}},
+	}, nil)
+
+	var query string
+	router := chi.NewRouter()
+	router.Post("/prometheus/range", func(w http.ResponseWriter, r *http.Request) {
+		var data prometheusRequest
+		render.DecodeJSON(r.Body, &data)
+		query = data.Queries[0].Query
+		render.JSON(w, r, prometheusMetrics{})
+	})
+
+	c, err := NewClient(Config{}, router, dbClient)
+	require.NoError(t, err)
+
+	document, err := c.(*client).render(context.Background(), dashboardv1.Reference{Cluster: "test", Namespace: "default", Name: "weekly", Title: "Weekly", Placeholders: map[string]string{"namespace": "default"}}, 0, 3600)
+	require.NoError(t, err)
+	require.Equal(t, "Weekly", document.Title)
+	require.Len(t, document.Rows, 1)
+	require.Len(t, document.Rows[0].Panels, 2)
+	require.Equal(t, `up{namespace="default"}`, query)
+}
+
+func TestInterpolate(t *testing.T) {
+	variables := []variable{
+		{name: "name", value: `my-"app"`},
+		{name: "replicas", value: "3", valueType: "number"},
+		{name: "labels", value: "app: test", valueType: "object"},
+	}
+
+	actual := interpolate(`{"name":"{% .name %}","replicas":"{% .replicas %}","labels":"{% .labels %}","time":"{% .__timeStart %}-{% .__timeEnd %}"}`, variables, 1, 2)
+	require.Equal(t, `{"name":"my-\"app\"","replicas":3,"labels":{"app":"test"},"time":"1-2"}`, actual)
+}
+
+func TestGetCoreVariableValue(t *testing.T) {
+	require.Equal(t, "", getCoreVariableValue(dashboardv1.Variable{Plugin: dashboardv1.Plugin{Type: "core", Name: "static"}}))
+	require.Equal(t, "a", getCoreVariableValue(dashboardv1.Variable{Plugin: dashboardv1.Plugin{Type: "core", Name: "static", Options: &apiextensionsv1.JSON{Raw: []byte(`["a","b"]`)}}}))
+	require.Equal(t, "b", getCoreVariableValue(dashboardv1.Variable{Plugin: dashboardv1.Plugin{Type: "core", Name: "placeholder", Options: &apiextensionsv1.JSON{Raw: []byte(`{"value":"b"}`)}}}))
+}
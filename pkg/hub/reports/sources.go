@@ -0,0 +1,301 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	dashboardv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
+	hubPlugins "github.com/kobsio/kobs/pkg/hub/plugins"
+)
+
+// Content is the data of a single panel, which can be rendered on the server. Only one of the fields should be set.
+// When no field is set, the panel is rendered with the message.
+type Content struct {
+	Chart   *Chart
+	Table   *Table
+	Message string
+}
+
+// Chart is a time series chart. All values of a series are drawn as a line, where null values are skipped.
+type Chart struct {
+	Unit   string
+	Series []Series
+}
+
+// Series is a single line in a chart.
+type Series struct {
+	Name   string
+	Points []Point
+}
+
+// Point is a single data point of a series. The x value is the time in milliseconds, like it is returned by the
+// plugins.
+type Point struct {
+	X int64
+	Y *float64
+}
+
+// Table is a table with a header and a list of rows. Each row must have the same number of cells as the header.
+type Table struct {
+	Columns []string
+	Rows    [][]string
+}
+
+// fetchFunc is used by the sources to call the API of a plugin instance. The cluster, type and name of the request are
+// set from the provided plugin and the response is decoded into the result.
+type fetchFunc func(ctx context.Context, plugin dashboardv1.Plugin, request hubPlugins.Request, result any) error
+
+// Source returns the content for a panel of a plugin. The options of the panel are already interpolated with the values
+// of the dashboard variables. The start and end time are provided in seconds.
+type Source func(ctx context.Context, fetch fetchFunc, plugin dashboardv1.Plugin, timeStart, timeEnd int64) (Content, error)
+
+// sources contains all plugins, for which we can render the panels on the server. The key is the type of the plugin.
+// Panels of all other plugins are rendered with a message, that they are not supported in reports.
+var sources = map[string]Source{
+	"prometheus": prometheusSource,
+	"sql":        sqlSource,
+}
+
+type prometheusOptions struct {
+	Type    string             `json:"type"`
+	Unit    string             `json:"unit"`
+	Queries []prometheusQuery  `json:"queries"`
+	Columns []prometheusColumn `json:"columns"`
+}
+
+type prometheusQuery struct {
+	Query string `json:"query"`
+	Label string `json:"label"`
+}
+
+type prometheusColumn struct {
+	Name     string            `json:"name"`
+	Title    string            `json:"title"`
+	Unit     string            `json:"unit"`
+	Mappings map[string]string `json:"mappings"`
+}
+
+type prometheusRequest struct {
+	Queries   []prometheusQuery `json:"queries"`
+	TimeStart int64             `json:"timeStart"`
+	TimeEnd   int64             `json:"timeEnd"`
+}
+
+type prometheusMetrics struct {
+	Metrics []struct {
+		Name string `json:"name"`
+		Data []struct {
+			X int64    `json:"x"`
+			Y *float64 `json:"y"`
+		} `json:"data"`
+	} `json:"metrics"`
+}
+
+// prometheusSource returns the content for a Prometheus panel. When the type of the panel is "table" we use the
+// instant endpoint to get the rows of the table. For all other types we get the metrics from the range endpoint and
+// render them as chart.
+func prometheusSource(ctx context.Context, fetch fetchFunc, plugin dashboardv1.Plugin, timeStart, timeEnd int64) (Content, error) {
+	var options prometheusOptions
+	if plugin.Options != nil {
+		if err := json.Unmarshal(plugin.Options.Raw, &options); err != nil {
+			return Content{}, fmt.Errorf("invalid options: %w", err)
+		}
+	}
+
+	if len(options.Queries) == 0 {
+		return Content{}, fmt.Errorf("queries are missing")
+	}
+
+	request := prometheusRequest{Queries: options.Queries, TimeStart: timeStart, TimeEnd: timeEnd}
+
+	if options.Type == "table" {
+		var rows map[string]map[string]string
+		if err := fetch(ctx, plugin, hubPlugins.Request{Method: http.MethodPost, Path: "/instant", Body: request}, &rows); err != nil {
+			return Content{}, err
+		}
+
+		return Content{Table: prometheusTable(options.Columns, rows)}, nil
+	}
+
+	var metrics prometheusMetrics
+	if err := fetch(ctx, plugin, hubPlugins.Request{Method: http.MethodPost, Path: "/range", Body: request}, &metrics); err != nil {
+		return Content{}, err
+	}
+
+	chart := &Chart{Unit: options.Unit}
+	for _, metric := range metrics.Metrics {
+		series := Series{Name: metric.Name}
+		for _, datum := range metric.Data {
+			series.Points = append(series.Points, Point{X: datum.X, Y: datum.Y})
+		}
+		chart.Series = append(chart.Series, series)
+	}
+
+	return Content{Chart: chart}, nil
+}
+
+// prometheusTable converts the rows returned by the instant endpoint into a table. If the panel doesn't define any
+// columns, we use all labels of the returned rows as columns.
+func prometheusTable(columns []prometheusColumn, rows map[string]map[string]string) *Table {
+	if len(columns) == 0 {
+		names := make(map[string]bool)
+		for _, row := range rows {
+			for name := range row {
+				names[name] = true
+			}
+		}
+
+		for name := range names {
+			columns = append(columns, prometheusColumn{Name: name})
+		}
+
+		sort.Slice(columns, func(i, j int) bool { return columns[i].Name < columns[j].Name })
+	}
+
+	table := &Table{}
+	for _, column := range columns {
+		if column.Title != "" {
+			table.Columns = append(table.Columns, column.Title)
+		} else {
+			table.Columns = append(table.Columns, column.Name)
+		}
+	}
+
+	keys := make([]string, 0, len(rows))
+	for key := range rows {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		var cells []string
+		for _, column := range columns {
+			cells = append(cells, formatCellValue(column, rows[key][column.Name]))
+		}
+		table.Rows = append(table.Rows, cells)
+	}
+
+	return table
+}
+
+// formatCellValue formats a cell like it is done in the frontend: If the column contains mappings the mapped value is
+// returned. Values of a query (columns starting with "value-") are rounded and the unit is appended.
+func formatCellValue(column prometheusColumn, value string) string {
+	if column.Mappings != nil {
+		return column.Mappings[value]
+	}
+
+	if strings.HasPrefix(column.Name, "value-") && value != "" {
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return strings.TrimSpace(fmt.Sprintf("%s %s", formatNumber(f), column.Unit))
+		}
+	}
+
+	return value
+}
+
+// formatNumber rounds the provided number to at most four decimal places.
+func formatNumber(value float64) string {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	return strconv.FormatFloat(math.Round(value*10000)/10000, 'f', -1, 64)
+}
+
+type sqlOptions struct {
+	Type    string     `json:"type"`
+	Queries []sqlQuery `json:"queries"`
+}
+
+type sqlQuery struct {
+	Name    string               `json:"name"`
+	Query   string               `json:"query"`
+	Columns map[string]sqlColumn `json:"columns"`
+}
+
+type sqlColumn struct {
+	Title string `json:"title"`
+	Unit  string `json:"unit"`
+}
+
+type sqlResult struct {
+	Columns []string         `json:"columns"`
+	Rows    []map[string]any `json:"rows"`
+}
+
+// sqlSource returns the content for a SQL panel. Only panels with the type "table" are supported, where the result of
+// the first query is rendered, like it is done in the frontend when the panel is loaded. The columns of the query are
+// only used to set the title and the unit of the returned columns.
+func sqlSource(ctx context.Context, fetch fetchFunc, plugin dashboardv1.Plugin, timeStart, timeEnd int64) (Content, error) {
+	var options sqlOptions
+	if plugin.Options != nil {
+		if err := json.Unmarshal(plugin.Options.Raw, &options); err != nil {
+			return Content{}, fmt.Errorf("invalid options: %w", err)
+		}
+	}
+
+	if options.Type != "table" {
+		return Content{Message: "Only SQL panels with the type \"table\" are supported in reports"}, nil
+	}
+
+	if len(options.Queries) == 0 {
+		return Content{}, fmt.Errorf("queries are missing")
+	}
+
+	query := options.Queries[0]
+
+	var result sqlResult
+	if err := fetch(ctx, plugin, hubPlugins.Request{Method: http.MethodGet, Path: "/query", Params: url.Values{"query": {query.Query}}}, &result); err != nil {
+		return Content{}, err
+	}
+
+	table := &Table{}
+	for _, column := range result.Columns {
+		if title := query.Columns[column].Title; title != "" {
+			table.Columns = append(table.Columns, title)
+		} else {
+			table.Columns = append(table.Columns, column)
+		}
+	}
+
+	for _, row := range result.Rows {
+		var cells []string
+		for _, column := range result.Columns {
+			cells = append(cells, formatSQLValue(row[column], query.Columns[column].Unit))
+		}
+		table.Rows = append(table.Rows, cells)
+	}
+
+	return Content{Table: table}, nil
+}
+
+// formatSQLValue formats a value of a SQL result like it is done in the frontend: Lists are joined with a comma, objects
+// are encoded as JSON and the unit is appended to all other values.
+func formatSQLValue(value any, unit string) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []any:
+		var values []string
+		for _, item := range v {
+			values = append(values, fmt.Sprintf("%v", item))
+		}
+		return fmt.Sprintf("[%s]", strings.Join(values, ", "))
+	case map[string]any:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	default:
+		return strings.TrimSpace(fmt.Sprintf("%v %s", v, unit))
+	}
+}
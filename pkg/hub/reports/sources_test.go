@@ -0,0 +1,110 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	dashboardv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
+	hubPlugins "github.com/kobsio/kobs/pkg/hub/plugins"
+
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// newFetch returns a fetch function, which returns the provided response as result for all requests.
+func newFetch(response string, err error) (fetchFunc, *hubPlugins.Request) {
+	var request hubPlugins.Request
+	return func(ctx context.Context, plugin dashboardv1.Plugin, r hubPlugins.Request, result any) error {
+		request = r
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal([]byte(response), result)
+	}, &request
+}
+
+func TestPrometheusSource(t *testing.T) {
+	t.Run("should fail for invalid options", func(t *testing.T) {
+		fetch, _ := newFetch("", nil)
+		_, err := prometheusSource(context.Background(), fetch, dashboardv1.Plugin{Options: &apiextensionsv1.JSON{Raw: []byte(`[]`)}}, 0, 0)
+		require.ErrorContains(t, err, "invalid options")
+	})
+
+	t.Run("should fail for missing queries", func(t *testing.T) {
+		fetch, _ := newFetch("", nil)
+		_, err := prometheusSource(context.Background(), fetch, dashboardv1.Plugin{}, 0, 0)
+		require.EqualError(t, err, "queries are missing")
+	})
+
+	t.Run("should return fetch error", func(t *testing.T) {
+		fetch, _ := newFetch("", fmt.Errorf("unexpected error"))
+		_, err := prometheusSource(context.Background(), fetch, dashboardv1.Plugin{Options: &apiextensionsv1.JSON{Raw: []byte(`{"queries":[{"query":"up"}]}`)}}, 0, 0)
+		require.EqualError(t, err, "unexpected error")
+	})
+
+	t.Run("should return chart", func(t *testing.T) {
+		fetch, request := newFetch(`{"metrics":[{"name":"up","data":[{"x":1000,"y":1},{"x":2000,"y":null}]}]}`, nil)
+		content, err := prometheusSource(context.Background(), fetch, dashboardv1.Plugin{Options: &apiextensionsv1.JSON{Raw: []byte(`{"type":"area","unit":"req/s","queries":[{"query":"up"}]}`)}}, 0, 0)
+		require.NoError(t, err)
+		require.Equal(t, "/range", request.Path)
+		require.Equal(t, "req/s", content.Chart.Unit)
+		require.Len(t, content.Chart.Series, 1)
+		require.Equal(t, "up", content.Chart.Series[0].Name)
+		require.Equal(t, 1.0, *content.Chart.Series[0].Points[0].Y)
+		require.Nil(t, content.Chart.Series[0].Points[1].Y)
+	})
+
+	t.Run("should return table", func(t *testing.T) {
+		fetch, request := newFetch(`{"b":{"pod":"b","value-1":"0.123456"},"a":{"pod":"a","value-1":"2"}}`, nil)
+		content, err := prometheusSource(context.Background(), fetch, dashboardv1.Plugin{Options: &apiextensionsv1.JSON{Raw: []byte(`{"type":"table","queries":[{"query":"up","label":"{% .pod %}"}],"columns":[{"name":"pod","title":"Pod"},{"name":"value-1","title":"Value","unit":"%"}]}`)}}, 0, 0)
+		require.NoError(t, err)
+		require.Equal(t, "/instant", request.Path)
+		require.Equal(t, &Table{Columns: []string{"Pod", "Value"}, Rows: [][]string{{"a", "2 %"}, {"b", "0.1235 %"}}}, content.Table)
+	})
+}
+
+func TestSQLSource(t *testing.T) {
+	t.Run("should return message for charts", func(t *testing.T) {
+		fetch, _ := newFetch("", nil)
+		content, err := sqlSource(context.Background(), fetch, dashboardv1.Plugin{Options: &apiextensionsv1.JSON{Raw: []byte(`{"type":"chart","chart":{"type":"line"}}`)}}, 0, 0)
+		require.NoError(t, err)
+		require.Equal(t, `Only SQL panels with the type "table" are supported in reports`, content.Message)
+	})
+
+	t.Run("should fail for missing queries", func(t *testing.T) {
+		fetch, _ := newFetch("", nil)
+		_, err := sqlSource(context.Background(), fetch, dashboardv1.Plugin{Options: &apiextensionsv1.JSON{Raw: []byte(`{"type":"table"}`)}}, 0, 0)
+		require.EqualError(t, err, "queries are missing")
+	})
+
+	t.Run("should return fetch error", func(t *testing.T) {
+		fetch, _ := newFetch("", fmt.Errorf("unexpected error"))
+		_, err := sqlSource(context.Background(), fetch, dashboardv1.Plugin{Options: &apiextensionsv1.JSON{Raw: []byte(`{"type":"table","queries":[{"query":"SELECT 1"}]}`)}}, 0, 0)
+		require.EqualError(t, err, "unexpected error")
+	})
+
+	t.Run("should return table", func(t *testing.T) {
+		fetch, request := newFetch(`{"columns":["name","count","tags"],"rows":[{"name":"a","count":3,"tags":["x","y"]},{"name":"b"}]}`, nil)
+		content, err := sqlSource(context.Background(), fetch, dashboardv1.Plugin{Options: &apiextensionsv1.JSON{Raw: []byte(`{"type":"table","queries":[{"query":"SELECT * FROM t","columns":{"name":{"title":"Name"},"count":{"unit":"req"}}},{"query":"SELECT 2"}]}`)}}, 0, 0)
+		require.NoError(t, err)
+		require.Equal(t, http.MethodGet, request.Method)
+		require.Equal(t, "/query", request.Path)
+		require.Equal(t, "SELECT * FROM t", request.Params.Get("query"))
+		require.Equal(t, &Table{Columns: []string{"Name", "count", "tags"}, Rows: [][]string{{"a", "3 req", "[x, y]"}, {"b", "", ""}}}, content.Table)
+	})
+}
+
+func TestPrometheusTable(t *testing.T) {
+	t.Run("should use all labels as columns", func(t *testing.T) {
+		table := prometheusTable(nil, map[string]map[string]string{"a": {"pod": "a", "value-1": "1"}})
+		require.Equal(t, &Table{Columns: []string{"pod", "value-1"}, Rows: [][]string{{"a", "1"}}}, table)
+	})
+
+	t.Run("should use mappings", func(t *testing.T) {
+		table := prometheusTable([]prometheusColumn{{Name: "value-1", Mappings: map[string]string{"1": "Up"}}}, map[string]map[string]string{"a": {"value-1": "1"}})
+		require.Equal(t, &Table{Columns: []string{"value-1"}, Rows: [][]string{{"Up"}}}, table)
+	})
+}
@@ -0,0 +1,287 @@
+package scorecards
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	applicationv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/application/v1"
+	authContext "github.com/kobsio/kobs/pkg/hub/auth/context"
+	hubPlugins "github.com/kobsio/kobs/pkg/hub/plugins"
+
+	"github.com/google/cel-go/cel"
+)
+
+const (
+	RuleTypeOwner      = "owner"
+	RuleTypeDashboards = "dashboards"
+	RuleTypeRunbooks   = "runbooks"
+	RuleTypeTechDocs   = "techdocs"
+	RuleTypeSonarQube  = "sonarqube"
+	RuleTypeHarbor     = "harbor"
+	RuleTypeExpression = "expression"
+)
+
+// optionVariable matches a variable in the options of a rule. Variables must have the same form as in dashboards, e.g.
+// "{% .name %}".
+var optionVariable = regexp.MustCompile(`\{%\s*\.([a-zA-Z]+)\s*%\}`)
+
+// Rule is a single scorecard rule. The type defines how the rule is evaluated:
+//
+//   - "owner": The application must have at least one team.
+//   - "dashboards": The application must have at least one dashboard.
+//   - "runbooks": The Runbooks plugin must return at least one runbook for the "query" option.
+//   - "techdocs": The TechDocs plugin must return an index for the "service" option.
+//   - "sonarqube": The quality gate of the SonarQube project from the "project" option must be passed.
+//   - "harbor": The artifact from the "projectName", "repositoryName" and "artifactReference" options must not have
+//     vulnerabilities with the severity from the "severity" option.
+//   - "expression": The CEL expression must return true. The application is available via the "application" variable.
+//
+// The options can contain the "{% .id %}", "{% .cluster %}", "{% .namespace %}" and "{% .name %}" variables, which are
+// replaced with the values of the application. The weight is used to calculate the score of an application.
+type Rule struct {
+	ID          string            `json:"id"`
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Weight      int               `json:"weight"`
+	Type        string            `json:"type"`
+	Expression  string            `json:"expression"`
+	Plugin      *Plugin           `json:"plugin"`
+	Options     map[string]string `json:"options"`
+}
+
+// Plugin is the plugin instance, which is used to evaluate a rule. If no cluster is set, the "hub" cluster is used.
+type Plugin struct {
+	Cluster string `json:"cluster"`
+	Name    string `json:"name"`
+}
+
+// rule is a compiled Rule.
+type rule struct {
+	Rule
+	program cel.Program
+}
+
+// defaultOptions are the default values for the options of the rules, which are using a plugin.
+var defaultOptions = map[string]map[string]string{
+	RuleTypeRunbooks:  {"query": "{% .name %}"},
+	RuleTypeTechDocs:  {"service": "{% .name %}"},
+	RuleTypeSonarQube: {"project": "{% .name %}"},
+	RuleTypeHarbor:    {"repositoryName": "{% .name %}", "artifactReference": "latest", "severity": "Critical"},
+}
+
+// compileRule validates the provided rule and sets the default values for the weight, the title and the options. The
+// CEL expression of an expression rule is compiled, so that an invalid expression is already returned when the hub is
+// started.
+func compileRule(r Rule) (rule, error) {
+	if r.ID == "" {
+		return rule{}, fmt.Errorf("id is missing")
+	}
+
+	if r.Title == "" {
+		r.Title = r.ID
+	}
+
+	if r.Weight < 0 {
+		return rule{}, fmt.Errorf("weight must not be negative")
+	}
+
+	if r.Weight == 0 {
+		r.Weight = 1
+	}
+
+	switch r.Type {
+	case RuleTypeOwner, RuleTypeDashboards:
+		return rule{Rule: r}, nil
+
+	case RuleTypeRunbooks, RuleTypeTechDocs, RuleTypeSonarQube, RuleTypeHarbor:
+		if r.Plugin == nil || r.Plugin.Name == "" {
+			return rule{}, fmt.Errorf("plugin is missing")
+		}
+
+		options := make(map[string]string)
+		for key, value := range defaultOptions[r.Type] {
+			options[key] = value
+		}
+		for key, value := range r.Options {
+			options[key] = value
+		}
+		r.Options = options
+
+		if r.Type == RuleTypeHarbor && r.Options["projectName"] == "" {
+			return rule{}, fmt.Errorf("projectName option is missing")
+		}
+
+		return rule{Rule: r}, nil
+
+	case RuleTypeExpression:
+		env, err := cel.NewEnv(cel.Variable("application", cel.DynType))
+		if err != nil {
+			return rule{}, err
+		}
+
+		ast, issues := env.Compile(r.Expression)
+		if issues != nil && issues.Err() != nil {
+			return rule{}, issues.Err()
+		}
+
+		if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+			return rule{}, fmt.Errorf("expression must return a bool, got %s", ast.OutputType())
+		}
+
+		program, err := env.Program(ast)
+		if err != nil {
+			return rule{}, err
+		}
+
+		return rule{Rule: r, program: program}, nil
+
+	default:
+		return rule{}, fmt.Errorf("invalid type %q", r.Type)
+	}
+}
+
+// evaluate evaluates the rule for the provided application. It returns if the rule is passed and a message with the
+// reason why the rule is not passed.
+func (r rule) evaluate(ctx context.Context, c *client, application applicationv1.ApplicationSpec) (bool, string, error) {
+	switch r.Type {
+	case RuleTypeOwner:
+		if len(application.Teams) == 0 {
+			return false, "Application has no owner team", nil
+		}
+		return true, "", nil
+
+	case RuleTypeDashboards:
+		if len(application.Dashboards) == 0 {
+			return false, "Application has no dashboards", nil
+		}
+		return true, "", nil
+
+	case RuleTypeRunbooks:
+		var runbooks []json.RawMessage
+		if err := c.get(ctx, r.Plugin, "runbooks", "/runbooks", url.Values{"query": {r.option("query", application)}}, &runbooks); err != nil {
+			return false, "", err
+		}
+
+		if len(runbooks) == 0 {
+			return false, "Application has no runbooks", nil
+		}
+		return true, "", nil
+
+	case RuleTypeTechDocs:
+		var index json.RawMessage
+		if err := c.get(ctx, r.Plugin, "techdocs", "/index", url.Values{"service": {r.option("service", application)}}, &index); err != nil {
+			return false, "", err
+		}
+		return true, "", nil
+
+	case RuleTypeSonarQube:
+		var projectMeasures struct {
+			Component struct {
+				Measures []struct {
+					Metric string `json:"metric"`
+					Value  string `json:"value"`
+				} `json:"measures"`
+			} `json:"component"`
+		}
+		if err := c.get(ctx, r.Plugin, "sonarqube", "/projectmeasures", url.Values{"project": {r.option("project", application)}, "metricKey": {"alert_status"}}, &projectMeasures); err != nil {
+			return false, "", err
+		}
+
+		for _, measure := range projectMeasures.Component.Measures {
+			if measure.Metric == "alert_status" {
+				if measure.Value != "OK" {
+					return false, fmt.Sprintf("Quality gate status is %s", measure.Value), nil
+				}
+				return true, "", nil
+			}
+		}
+
+		return false, "Quality gate status is missing", nil
+
+	case RuleTypeHarbor:
+		var vulnerabilities map[string]struct {
+			Vulnerabilities []struct {
+				Severity string `json:"severity"`
+			} `json:"vulnerabilities"`
+		}
+		if err := c.get(ctx, r.Plugin, "harbor", "/vulnerabilities", url.Values{
+			"projectName":       {r.option("projectName", application)},
+			"repositoryName":    {r.option("repositoryName", application)},
+			"artifactReference": {r.option("artifactReference", application)},
+		}, &vulnerabilities); err != nil {
+			return false, "", err
+		}
+
+		severity := r.option("severity", application)
+		count := 0
+		for _, report := range vulnerabilities {
+			for _, vulnerability := range report.Vulnerabilities {
+				if strings.EqualFold(vulnerability.Severity, severity) {
+					count++
+				}
+			}
+		}
+
+		if count > 0 {
+			return false, fmt.Sprintf("Artifact has %d vulnerabilities with severity %s", count, severity), nil
+		}
+		return true, "", nil
+
+	case RuleTypeExpression:
+		data, err := json.Marshal(application)
+		if err != nil {
+			return false, "", err
+		}
+
+		var obj map[string]any
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return false, "", err
+		}
+
+		out, _, err := r.program.Eval(map[string]any{"application": obj})
+		if err != nil {
+			return false, "", err
+		}
+
+		if passed, ok := out.Value().(bool); !ok || !passed {
+			return false, "Expression returned false", nil
+		}
+		return true, "", nil
+
+	default:
+		return false, "", fmt.Errorf("invalid type %q", r.Type)
+	}
+}
+
+// option returns the value of the option with the provided key, where all variables are replaced with the values of
+// the provided application.
+func (r rule) option(key string, application applicationv1.ApplicationSpec) string {
+	values := map[string]string{
+		"id":        application.ID,
+		"cluster":   application.Cluster,
+		"namespace": application.Namespace,
+		"name":      application.Name,
+	}
+
+	return optionVariable.ReplaceAllStringFunc(r.Options[key], func(variable string) string {
+		return values[optionVariable.FindStringSubmatch(variable)[1]]
+	})
+}
+
+// get calls the API of a plugin instance via the plugins router and decodes the response into the result. The request
+// is made with the service user, which has access to all plugins.
+func (c *client) get(ctx context.Context, plugin *Plugin, pluginType, path string, params url.Values, result any) error {
+	return c.requester.Do(context.WithValue(ctx, authContext.UserKey, hubPlugins.ServiceUser), hubPlugins.Request{
+		Method:  http.MethodGet,
+		Cluster: plugin.Cluster,
+		Type:    pluginType,
+		Name:    plugin.Name,
+		Path:    path,
+		Params:  params,
+	}, result)
+}
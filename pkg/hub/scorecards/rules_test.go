@@ -0,0 +1,139 @@
+package scorecards
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	applicationv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/application/v1"
+	dashboardv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
+	"github.com/kobsio/kobs/pkg/utils/middleware/errresponse"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/stretchr/testify/require"
+)
+
+func newPluginsRouter() chi.Router {
+	router := chi.NewRouter()
+	router.Get("/runbooks/runbooks", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("query") == "app1" {
+			render.JSON(w, r, []map[string]string{{"alert": "alert1"}})
+			return
+		}
+		render.JSON(w, r, nil)
+	})
+	router.Get("/techdocs/index", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("service") != "app1" {
+			errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get index")
+			return
+		}
+		render.JSON(w, r, map[string]string{"key": "app1"})
+	})
+	router.Get("/sonarqube/projectmeasures", func(w http.ResponseWriter, r *http.Request) {
+		status := "ERROR"
+		if r.URL.Query().Get("project") == "default-app1" {
+			status = "OK"
+		}
+		render.JSON(w, r, map[string]any{"component": map[string]any{"measures": []map[string]string{{"metric": "alert_status", "value": status}}}})
+	})
+	router.Get("/harbor/vulnerabilities", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-kobs-cluster") != "hub" || r.Header.Get("x-kobs-plugin") != "harbor" {
+			errresponse.Render(w, r, http.StatusBadRequest, "Invalid instance name")
+			return
+		}
+
+		severity := "Critical"
+		if r.URL.Query().Get("artifactReference") == "latest" {
+			severity = "Low"
+		}
+		render.JSON(w, r, map[string]any{"report": map[string]any{"vulnerabilities": []map[string]string{{"severity": severity}}}})
+	})
+	return router
+}
+
+func TestCompileRule(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		rule        Rule
+		expectErr   bool
+		expectedErr string
+	}{
+		{name: "should return error for missing id", rule: Rule{Type: RuleTypeOwner}, expectErr: true, expectedErr: "id is missing"},
+		{name: "should return error for negative weight", rule: Rule{ID: "rule1", Type: RuleTypeOwner, Weight: -1}, expectErr: true, expectedErr: "weight must not be negative"},
+		{name: "should return error for invalid type", rule: Rule{ID: "rule1", Type: "invalid"}, expectErr: true, expectedErr: "invalid type \"invalid\""},
+		{name: "should return error for missing plugin", rule: Rule{ID: "rule1", Type: RuleTypeSonarQube}, expectErr: true, expectedErr: "plugin is missing"},
+		{name: "should return error for missing harbor project", rule: Rule{ID: "rule1", Type: RuleTypeHarbor, Plugin: &Plugin{Name: "harbor"}}, expectErr: true, expectedErr: "projectName option is missing"},
+		{name: "should return error for invalid expression", rule: Rule{ID: "rule1", Type: RuleTypeExpression, Expression: "application.name =="}, expectErr: true},
+		{name: "should return error for non bool expression", rule: Rule{ID: "rule1", Type: RuleTypeExpression, Expression: "'foo'"}, expectErr: true, expectedErr: "expression must return a bool, got string"},
+		{name: "should compile owner rule", rule: Rule{ID: "rule1", Type: RuleTypeOwner}},
+		{name: "should compile expression rule", rule: Rule{ID: "rule1", Type: RuleTypeExpression, Expression: "has(application.links)"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := compileRule(tt.rule)
+			if tt.expectErr {
+				require.Error(t, err)
+				if tt.expectedErr != "" {
+					require.EqualError(t, err, tt.expectedErr)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("should set default values", func(t *testing.T) {
+		r, err := compileRule(Rule{ID: "rule1", Type: RuleTypeHarbor, Plugin: &Plugin{Name: "harbor"}, Options: map[string]string{"projectName": "project1", "artifactReference": "main"}})
+		require.NoError(t, err)
+		require.Equal(t, "rule1", r.Title)
+		require.Equal(t, 1, r.Weight)
+		require.Equal(t, map[string]string{"projectName": "project1", "repositoryName": "{% .name %}", "artifactReference": "main", "severity": "Critical"}, r.Options)
+	})
+}
+
+func TestEvaluateRule(t *testing.T) {
+	c, err := NewClient(Config{}, newPluginsRouter(), nil)
+	require.NoError(t, err)
+	app1 := applicationv1.ApplicationSpec{ID: "/cluster/cluster1/namespace/default/name/app1", Cluster: "cluster1", Namespace: "default", Name: "app1", Teams: []string{"team1"}, Dashboards: []dashboardv1.Reference{{Title: "Dashboard"}}}
+	app2 := applicationv1.ApplicationSpec{ID: "/cluster/cluster1/namespace/default/name/app2", Cluster: "cluster1", Namespace: "default", Name: "app2"}
+
+	for _, tt := range []struct {
+		name            string
+		rule            Rule
+		application     applicationv1.ApplicationSpec
+		expectedPassed  bool
+		expectedMessage string
+		expectedErr     string
+	}{
+		{name: "owner passed", rule: Rule{ID: "rule", Type: RuleTypeOwner}, application: app1, expectedPassed: true},
+		{name: "owner failed", rule: Rule{ID: "rule", Type: RuleTypeOwner}, application: app2, expectedMessage: "Application has no owner team"},
+		{name: "dashboards passed", rule: Rule{ID: "rule", Type: RuleTypeDashboards}, application: app1, expectedPassed: true},
+		{name: "dashboards failed", rule: Rule{ID: "rule", Type: RuleTypeDashboards}, application: app2, expectedMessage: "Application has no dashboards"},
+		{name: "runbooks passed", rule: Rule{ID: "rule", Type: RuleTypeRunbooks, Plugin: &Plugin{Name: "runbooks"}}, application: app1, expectedPassed: true},
+		{name: "runbooks failed", rule: Rule{ID: "rule", Type: RuleTypeRunbooks, Plugin: &Plugin{Name: "runbooks"}}, application: app2, expectedMessage: "Application has no runbooks"},
+		{name: "techdocs passed", rule: Rule{ID: "rule", Type: RuleTypeTechDocs, Plugin: &Plugin{Name: "techdocs"}}, application: app1, expectedPassed: true},
+		{name: "techdocs failed", rule: Rule{ID: "rule", Type: RuleTypeTechDocs, Plugin: &Plugin{Name: "techdocs"}}, application: app2, expectedErr: "Failed to get index"},
+		{name: "sonarqube passed", rule: Rule{ID: "rule", Type: RuleTypeSonarQube, Plugin: &Plugin{Name: "sonarqube"}, Options: map[string]string{"project": "{% .namespace %}-{%.name%}"}}, application: app1, expectedPassed: true},
+		{name: "sonarqube failed", rule: Rule{ID: "rule", Type: RuleTypeSonarQube, Plugin: &Plugin{Name: "sonarqube"}}, application: app1, expectedMessage: "Quality gate status is ERROR"},
+		{name: "harbor passed", rule: Rule{ID: "rule", Type: RuleTypeHarbor, Plugin: &Plugin{Name: "harbor"}, Options: map[string]string{"projectName": "project1"}}, application: app1, expectedPassed: true},
+		{name: "harbor failed", rule: Rule{ID: "rule", Type: RuleTypeHarbor, Plugin: &Plugin{Name: "harbor"}, Options: map[string]string{"projectName": "project1", "artifactReference": "main"}}, application: app1, expectedMessage: "Artifact has 1 vulnerabilities with severity Critical"},
+		{name: "harbor invalid plugin", rule: Rule{ID: "rule", Type: RuleTypeHarbor, Plugin: &Plugin{Cluster: "cluster1", Name: "harbor"}, Options: map[string]string{"projectName": "project1"}}, application: app1, expectedErr: "Invalid instance name"},
+		{name: "expression passed", rule: Rule{ID: "rule", Type: RuleTypeExpression, Expression: "application.name.startsWith('app') && size(application.teams) > 0"}, application: app1, expectedPassed: true},
+		{name: "expression failed", rule: Rule{ID: "rule", Type: RuleTypeExpression, Expression: "has(application.teams)"}, application: app2, expectedMessage: "Expression returned false"},
+		{name: "expression error", rule: Rule{ID: "rule", Type: RuleTypeExpression, Expression: "size(application.teams) > 0"}, application: app2, expectedErr: "no such key: teams"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := compileRule(tt.rule)
+			require.NoError(t, err)
+
+			passed, message, err := r.evaluate(context.Background(), c.(*client), tt.application)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.expectedPassed, passed)
+			require.Equal(t, tt.expectedMessage, message)
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package scorecards
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	applicationv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/application/v1"
+	"github.com/kobsio/kobs/pkg/hub/db"
+	hubPlugins "github.com/kobsio/kobs/pkg/hub/plugins"
+	"github.com/kobsio/kobs/pkg/instrument/log"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/robfig/cron/v3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultSchedule is used, when no schedule is configured. The scorecards are evaluated at the start of every hour.
+	defaultSchedule = "0 * * * *"
+
+	// runTimeout is the maximum duration for the evaluation of the scorecards of all applications.
+	runTimeout = 30 * time.Minute
+)
+
+var (
+	runsTotalMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kobs",
+		Name:      "scorecards_runs_total",
+		Help:      "Number of scorecard runs, partitioned by status.",
+	}, []string{"status"})
+)
+
+// Config is the configuration for the scorecards. The schedule must be a cron expression and defines when the
+// scorecards of all applications are evaluated. If no rules are configured, the scorecards are not evaluated.
+type Config struct {
+	Schedule string `json:"schedule"`
+	Rules    []Rule `json:"rules"`
+}
+
+// Client is the interface to evaluate the scorecards of all applications. Start evaluates the scorecards once and
+// starts the scheduler and Stop waits until a running evaluation is finished. Run can be used to evaluate the
+// scorecards immediately.
+type Client interface {
+	Start()
+	Stop()
+	Run(ctx context.Context) error
+}
+
+type client struct {
+	rules     []rule
+	cron      *cron.Cron
+	wg        sync.WaitGroup
+	requester *hubPlugins.Requester
+	dbClient  db.Client
+	tracer    trace.Tracer
+}
+
+// Start starts the scheduler. The scorecards are also evaluated once when the scheduler is started, so that we do not
+// have to wait for the first scheduled run until the scorecards of the applications are available.
+func (c *client) Start() {
+	log.Info(context.Background(), "Scorecards scheduler started", zap.Int("rulesCount", len(c.rules)))
+	c.cron.Start()
+
+	if len(c.rules) > 0 {
+		c.wg.Add(1)
+		go func() {
+			defer c.wg.Done()
+			c.run()
+		}()
+	}
+}
+
+func (c *client) Stop() {
+	log.Debug(context.Background(), "Start shutdown of the scorecards scheduler")
+	<-c.cron.Stop().Done()
+	c.wg.Wait()
+}
+
+// run evaluates the scorecards of all applications with the run timeout. It is used for the scheduled runs and for the
+// run when the scheduler is started.
+func (c *client) run() {
+	ctx, cancel := context.WithTimeout(context.Background(), runTimeout)
+	defer cancel()
+	c.Run(ctx)
+}
+
+// Run evaluates the scorecards of all applications and saves them in the database. All scorecards of one run get the
+// same creation time, so that the scores can be aggregated over time.
+func (c *client) Run(ctx context.Context) error {
+	ctx, span := c.tracer.Start(ctx, "scorecards.Run")
+	defer span.End()
+
+	startTime := time.Now()
+
+	applications, err := c.dbClient.GetApplicationsByFilter(ctx, nil, nil, nil, nil, nil, "", 0, 0)
+	if err != nil {
+		runsTotalMetric.WithLabelValues("error").Inc()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		log.Error(ctx, "Failed to get applications", zap.Error(err))
+		return err
+	}
+
+	span.SetAttributes(attribute.Key("applications").Int(len(applications)))
+
+	var scorecards []db.Scorecard
+	for _, application := range applications {
+		scorecards = append(scorecards, c.evaluate(ctx, application, startTime))
+	}
+
+	if err := c.dbClient.SaveScorecards(ctx, scorecards); err != nil {
+		runsTotalMetric.WithLabelValues("error").Inc()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		log.Error(ctx, "Failed to save scorecards", zap.Error(err))
+		return err
+	}
+
+	runsTotalMetric.WithLabelValues("success").Inc()
+	log.Info(ctx, "Scorecards were evaluated", zap.Int("applicationsCount", len(applications)), zap.Duration("duration", time.Since(startTime)))
+	return nil
+}
+
+// evaluate evaluates all rules for the provided application and returns the scorecard. The score is the weighted
+// percentage of the passed rules. If a rule returns an error, the rule is not passed and the error is used as message.
+func (c *client) evaluate(ctx context.Context, application applicationv1.ApplicationSpec, createdAt time.Time) db.Scorecard {
+	ctx, span := c.tracer.Start(ctx, "scorecards.evaluate")
+	span.SetAttributes(attribute.Key("application").String(application.ID))
+	defer span.End()
+
+	scorecard := db.Scorecard{
+		Application: application.ID,
+		Teams:       application.Teams,
+		Results:     make([]db.ScorecardResult, 0, len(c.rules)),
+		CreatedAt:   createdAt,
+	}
+
+	var passedWeight, totalWeight int
+
+	for _, r := range c.rules {
+		passed, message, err := r.evaluate(ctx, c, application)
+		if err != nil {
+			log.Debug(ctx, "Failed to evaluate scorecard rule", zap.Error(err), zap.String("application", application.ID), zap.String("rule", r.ID))
+			passed = false
+			message = err.Error()
+		}
+
+		scorecard.Results = append(scorecard.Results, db.ScorecardResult{
+			Rule:    r.ID,
+			Title:   r.Title,
+			Weight:  r.Weight,
+			Passed:  passed,
+			Message: message,
+		})
+
+		totalWeight = totalWeight + r.Weight
+		if passed {
+			passedWeight = passedWeight + r.Weight
+		}
+	}
+
+	if totalWeight > 0 {
+		scorecard.Score = math.Round(float64(passedWeight)/float64(totalWeight)*10000) / 100
+	}
+
+	return scorecard
+}
+
+// Validate checks the configured rules and the schedule, so that configuration errors are already returned when the
+// configuration is loaded and not when the scorecards are evaluated.
+func (c Config) Validate() error {
+	if _, err := compileRules(c.Rules); err != nil {
+		return err
+	}
+
+	if c.Schedule != "" {
+		if _, err := cron.ParseStandard(c.Schedule); err != nil {
+			return fmt.Errorf("invalid schedule for scorecards: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// compileRules compiles all provided rules and checks that the ids of the rules are unique.
+func compileRules(rules []Rule) ([]rule, error) {
+	var compiledRules []rule
+
+	ids := make(map[string]bool)
+	for _, r := range rules {
+		compiledRule, err := compileRule(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid scorecard rule %q: %w", r.ID, err)
+		}
+
+		if ids[r.ID] {
+			return nil, fmt.Errorf("duplicate scorecard rule %q", r.ID)
+		}
+		ids[r.ID] = true
+
+		compiledRules = append(compiledRules, compiledRule)
+	}
+
+	return compiledRules, nil
+}
+
+// NewClient returns a new client to evaluate the scorecards of all applications. The data for the rules which are
+// using a plugin is retrieved via the provided plugins router, which must be the router of the hub plugins client.
+func NewClient(config Config, pluginsRouter http.Handler, dbClient db.Client) (Client, error) {
+	rules, err := compileRules(config.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &client{
+		rules:     rules,
+		cron:      cron.New(),
+		requester: hubPlugins.NewRequester(pluginsRouter),
+		dbClient:  dbClient,
+		tracer:    otel.Tracer("scorecards"),
+	}
+
+	if len(c.rules) == 0 {
+		return c, nil
+	}
+
+	schedule := config.Schedule
+	if schedule == "" {
+		schedule = defaultSchedule
+	}
+
+	if _, err := c.cron.AddFunc(schedule, c.run); err != nil {
+		return nil, fmt.Errorf("invalid schedule for scorecards: %w", err)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,124 @@
+package scorecards
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	applicationv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/application/v1"
+	"github.com/kobsio/kobs/pkg/hub/db"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	config := Config{Rules: []Rule{
+		{ID: "owner", Type: RuleTypeOwner, Weight: 3},
+		{ID: "runbooks", Type: RuleTypeRunbooks, Plugin: &Plugin{Name: "runbooks"}},
+	}}
+
+	t.Run("should return error when applications can not be loaded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetApplicationsByFilter(gomock.Any(), nil, nil, nil, nil, nil, "", 0, 0).Return(nil, fmt.Errorf("could not get applications"))
+
+		c, err := NewClient(config, newPluginsRouter(), dbClient)
+		require.NoError(t, err)
+		require.EqualError(t, c.Run(context.Background()), "could not get applications")
+	})
+
+	t.Run("should return error when scorecards can not be saved", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetApplicationsByFilter(gomock.Any(), nil, nil, nil, nil, nil, "", 0, 0).Return([]applicationv1.ApplicationSpec{{ID: "app1", Name: "app1"}}, nil)
+		dbClient.EXPECT().SaveScorecards(gomock.Any(), gomock.Any()).Return(fmt.Errorf("could not save scorecards"))
+
+		c, err := NewClient(config, newPluginsRouter(), dbClient)
+		require.NoError(t, err)
+		require.EqualError(t, c.Run(context.Background()), "could not save scorecards")
+	})
+
+	t.Run("should save scorecards", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetApplicationsByFilter(gomock.Any(), nil, nil, nil, nil, nil, "", 0, 0).Return([]applicationv1.ApplicationSpec{
+			{ID: "app1", Name: "app1", Teams: []string{"team1"}},
+			{ID: "app2", Name: "app2", Teams: []string{"team1"}},
+			{ID: "app3", Name: "app3"},
+		}, nil)
+		dbClient.EXPECT().SaveScorecards(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, scorecards []db.Scorecard) error {
+			require.Len(t, scorecards, 3)
+
+			require.Equal(t, "app1", scorecards[0].Application)
+			require.Equal(t, []string{"team1"}, scorecards[0].Teams)
+			require.Equal(t, float64(100), scorecards[0].Score)
+			require.Equal(t, []db.ScorecardResult{{Rule: "owner", Title: "owner", Weight: 3, Passed: true}, {Rule: "runbooks", Title: "runbooks", Weight: 1, Passed: true}}, scorecards[0].Results)
+
+			require.Equal(t, float64(75), scorecards[1].Score)
+			require.Equal(t, "Application has no runbooks", scorecards[1].Results[1].Message)
+
+			require.Equal(t, float64(0), scorecards[2].Score)
+
+			require.Equal(t, scorecards[0].CreatedAt, scorecards[1].CreatedAt)
+			require.Equal(t, scorecards[0].CreatedAt, scorecards[2].CreatedAt)
+			return nil
+		})
+
+		c, err := NewClient(config, newPluginsRouter(), dbClient)
+		require.NoError(t, err)
+		require.NoError(t, c.Run(context.Background()))
+	})
+}
+
+func TestNewClient(t *testing.T) {
+	t.Run("should return error for invalid rule", func(t *testing.T) {
+		_, err := NewClient(Config{Rules: []Rule{{ID: "rule1", Type: "invalid"}}}, newPluginsRouter(), nil)
+		require.EqualError(t, err, "invalid scorecard rule \"rule1\": invalid type \"invalid\"")
+	})
+
+	t.Run("should return error for duplicate rule", func(t *testing.T) {
+		_, err := NewClient(Config{Rules: []Rule{{ID: "rule1", Type: RuleTypeOwner}, {ID: "rule1", Type: RuleTypeDashboards}}}, newPluginsRouter(), nil)
+		require.EqualError(t, err, "duplicate scorecard rule \"rule1\"")
+	})
+
+	t.Run("should return error for invalid schedule", func(t *testing.T) {
+		_, err := NewClient(Config{Schedule: "invalid", Rules: []Rule{{ID: "rule1", Type: RuleTypeOwner}}}, newPluginsRouter(), nil)
+		require.Error(t, err)
+	})
+
+	t.Run("should not schedule evaluation without rules", func(t *testing.T) {
+		c, err := NewClient(Config{Schedule: "invalid"}, newPluginsRouter(), nil)
+		require.NoError(t, err)
+		require.Empty(t, c.(*client).cron.Entries())
+	})
+
+	t.Run("should start and stop scheduler", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		dbClient.EXPECT().GetApplicationsByFilter(gomock.Any(), nil, nil, nil, nil, nil, "", 0, 0).Return([]applicationv1.ApplicationSpec{{ID: "app1", Teams: []string{"team1"}}}, nil)
+		dbClient.EXPECT().SaveScorecards(gomock.Any(), gomock.Any()).Return(nil)
+
+		c, err := NewClient(Config{Rules: []Rule{{ID: "rule1", Type: RuleTypeOwner}}}, newPluginsRouter(), dbClient)
+		require.NoError(t, err)
+		require.Len(t, c.(*client).cron.Entries(), 1)
+
+		c.Start()
+		c.Stop()
+	})
+
+	t.Run("should not evaluate scorecards on start without rules", func(t *testing.T) {
+		c, err := NewClient(Config{}, newPluginsRouter(), nil)
+		require.NoError(t, err)
+
+		c.Start()
+		c.Stop()
+	})
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, Config{}.Validate())
+	require.NoError(t, Config{Schedule: "0 0 * * *", Rules: []Rule{{ID: "rule1", Type: RuleTypeOwner}}}.Validate())
+	require.EqualError(t, Config{Rules: []Rule{{ID: "rule1", Type: "invalid"}}}.Validate(), "invalid scorecard rule \"rule1\": invalid type \"invalid\"")
+	require.Error(t, Config{Schedule: "invalid"}.Validate())
+}
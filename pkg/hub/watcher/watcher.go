@@ -4,10 +4,12 @@ package watcher
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/kobsio/kobs/pkg/hub/clusters"
 	"github.com/kobsio/kobs/pkg/hub/clusters/cluster"
+	hubDashboards "github.com/kobsio/kobs/pkg/hub/dashboards"
 	"github.com/kobsio/kobs/pkg/hub/db"
 	"github.com/kobsio/kobs/pkg/hub/watcher/worker"
 	"github.com/kobsio/kobs/pkg/instrument/log"
@@ -52,9 +54,16 @@ func (c *client) Watch() {
 	ticker := time.NewTicker(c.interval)
 	defer ticker.Stop()
 
+	refreshTicker := time.NewTicker(clusters.RefreshInterval)
+	defer refreshTicker.Stop()
+
 	for {
-		<-ticker.C
-		c.watch()
+		select {
+		case <-ticker.C:
+			c.watch()
+		case <-refreshTicker.C:
+			c.refresh()
+		}
 	}
 }
 
@@ -63,201 +72,297 @@ func (c *client) Stop() error {
 	return c.workerPool.Stop()
 }
 
-// watch is the internal watch method of the watcher. It loops through all configured clusters and adds a task for
-// each resource (plugins, applications, dashboards, teams and users) to the worker pool.
+// watch is the internal watch method of the watcher. It loops through all clusters and adds the tasks to sync the
+// resources of each cluster to the worker pool.
 func (c *client) watch() {
 	startTime := time.Now()
 	ctx, span := c.tracer.Start(context.Background(), "watcher")
 	defer span.End()
 
-	for _, cl := range c.clustersClient.GetClusters() {
-		go func(cl cluster.Client) {
-			c.workerPool.RunTask(task(func() {
-				ctx, span := c.tracer.Start(ctx, "watcher.plugins")
-				span.SetAttributes(attribute.Key("cluster").String(cl.GetName()))
-				defer span.End()
-
-				ctx, cancel := context.WithTimeout(log.ContextWithValue(ctx, zap.Time("startTime", startTime)), 30*time.Second)
-				defer cancel()
-
-				plugins, err := cl.GetPlugins(ctx)
-				if err != nil {
-					instrument(ctx, span, cl.GetName(), "plugins", err, len(plugins), startTime)
-					return
-				}
-
-				err = c.dbClient.SavePlugins(ctx, cl.GetName(), plugins)
-				if err != nil {
-					instrument(ctx, span, cl.GetName(), "plugins", err, len(plugins), startTime)
-					return
-				}
-
-				instrument(ctx, span, cl.GetName(), "plugins", nil, len(plugins), startTime)
-			}))
-		}(cl)
-
-		go func(cl cluster.Client) {
-			c.workerPool.RunTask(task(func() {
-				ctx, span := c.tracer.Start(ctx, "watcher.namespaces")
-				span.SetAttributes(attribute.Key("cluster").String(cl.GetName()))
-				defer span.End()
-
-				ctx, cancel := context.WithTimeout(log.ContextWithValue(ctx, zap.Time("startTime", startTime)), 30*time.Second)
-				defer cancel()
-
-				namespaces, err := cl.GetNamespaces(ctx)
-				if err != nil {
-					instrument(ctx, span, cl.GetName(), "namespaces", err, len(namespaces), startTime)
-					return
-				}
-
-				err = c.dbClient.SaveNamespaces(ctx, cl.GetName(), namespaces)
-				if err != nil {
-					instrument(ctx, span, cl.GetName(), "namespaces", err, len(namespaces), startTime)
-					return
-				}
-
-				instrument(ctx, span, cl.GetName(), "namespaces", nil, len(namespaces), startTime)
-			}))
-		}(cl)
-
-		go func(cl cluster.Client) {
-			c.workerPool.RunTask(task(func() {
-				ctx, span := c.tracer.Start(ctx, "watcher.crds")
-				span.SetAttributes(attribute.Key("cluster").String(cl.GetName()))
-				defer span.End()
+	clusterClients := c.clustersClient.GetClusters()
 
-				ctx, cancel := context.WithTimeout(log.ContextWithValue(ctx, zap.Time("startTime", startTime)), 30*time.Second)
-				defer cancel()
-
-				crds, err := cl.GetCRDs(ctx)
-				if err != nil {
-					instrument(ctx, span, cl.GetName(), "crds", err, len(crds), startTime)
-					return
-				}
-
-				err = c.dbClient.SaveCRDs(ctx, crds)
-				if err != nil {
-					instrument(ctx, span, cl.GetName(), "crds", err, len(crds), startTime)
-					return
-				}
-
-				instrument(ctx, span, cl.GetName(), "crds", nil, len(crds), startTime)
-			}))
-		}(cl)
-
-		go func(cl cluster.Client) {
-			c.workerPool.RunTask(task(func() {
-				ctx, span := c.tracer.Start(ctx, "watcher.applications")
-				span.SetAttributes(attribute.Key("cluster").String(cl.GetName()))
-				defer span.End()
-
-				ctx, cancel := context.WithTimeout(log.ContextWithValue(ctx, zap.Time("startTime", startTime)), 30*time.Second)
-				defer cancel()
-
-				applications, err := cl.GetApplications(ctx)
-				if err != nil {
-					instrument(ctx, span, cl.GetName(), "applications", err, len(applications), startTime)
-					return
-				}
-
-				err = c.dbClient.SaveApplications(ctx, cl.GetName(), applications)
-				if err != nil {
-					instrument(ctx, span, cl.GetName(), "applications", err, len(applications), startTime)
-					return
-				}
-
-				err = c.dbClient.SaveTags(ctx, applications)
-				if err != nil {
-					instrument(ctx, span, cl.GetName(), "tags", err, len(applications), startTime)
-					return
-				}
+	// The dashboards are validated against the plugins of all clusters, so that the plugins of all clusters must be
+	// saved, before the dashboards are synced.
+	var pluginsSynced sync.WaitGroup
+	pluginsSynced.Add(len(clusterClients))
 
-				err = c.dbClient.SaveTopology(ctx, cl.GetName(), applications)
-				if err != nil {
-					instrument(ctx, span, cl.GetName(), "tags", err, len(applications), startTime)
-					return
-				}
-
-				instrument(ctx, span, cl.GetName(), "applications", nil, len(applications), startTime)
-			}))
-		}(cl)
-
-		go func(cl cluster.Client) {
-			c.workerPool.RunTask(task(func() {
-				ctx, span := c.tracer.Start(ctx, "watcher.dashboards")
-				span.SetAttributes(attribute.Key("cluster").String(cl.GetName()))
-				defer span.End()
-
-				ctx, cancel := context.WithTimeout(log.ContextWithValue(ctx, zap.Time("startTime", startTime)), 30*time.Second)
-				defer cancel()
-
-				dashboards, err := cl.GetDashboards(ctx)
-				if err != nil {
-					instrument(ctx, span, cl.GetName(), "dashboards", err, len(dashboards), startTime)
-					return
-				}
-
-				err = c.dbClient.SaveDashboards(ctx, cl.GetName(), dashboards)
-				if err != nil {
-					instrument(ctx, span, cl.GetName(), "dashboards", err, len(dashboards), startTime)
-					return
-				}
-
-				instrument(ctx, span, cl.GetName(), "dashboards", nil, len(dashboards), startTime)
-			}))
-		}(cl)
-
-		go func(cl cluster.Client) {
-			c.workerPool.RunTask(task(func() {
-				ctx, span := c.tracer.Start(ctx, "watcher.teams")
-				span.SetAttributes(attribute.Key("cluster").String(cl.GetName()))
-				defer span.End()
+	for _, cl := range clusterClients {
+		c.watchCluster(ctx, startTime, cl, &pluginsSynced)
+	}
+}
 
-				ctx, cancel := context.WithTimeout(log.ContextWithValue(ctx, zap.Time("startTime", startTime)), 30*time.Second)
-				defer cancel()
+// refresh reloads the clusters, which were registered via the API. For all clusters which were added since the last
+// refresh, the resources are synced immediately, so that we do not have to wait for the next interval. Clusters which
+// were removed are not synced anymore.
+func (c *client) refresh() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
 
-				teams, err := cl.GetTeams(ctx)
-				if err != nil {
-					instrument(ctx, span, cl.GetName(), "teams", err, len(teams), startTime)
-					return
-				}
+	existingClusters := make(map[string]bool)
+	for _, cl := range c.clustersClient.GetClusters() {
+		existingClusters[cl.GetName()] = true
+	}
 
-				err = c.dbClient.SaveTeams(ctx, cl.GetName(), teams)
-				if err != nil {
-					instrument(ctx, span, cl.GetName(), "teams", err, len(teams), startTime)
-					return
-				}
+	if err := c.clustersClient.Refresh(ctx); err != nil {
+		return
+	}
 
-				instrument(ctx, span, cl.GetName(), "teams", nil, len(teams), startTime)
-			}))
-		}(cl)
+	startTime := time.Now()
+	ctx, span := c.tracer.Start(context.Background(), "watcher.refresh")
+	defer span.End()
 
-		go func(cl cluster.Client) {
-			c.workerPool.RunTask(task(func() {
-				ctx, span := c.tracer.Start(ctx, "watcher.users")
-				span.SetAttributes(attribute.Key("cluster").String(cl.GetName()))
-				defer span.End()
+	var newClusters []cluster.Client
+	for _, cl := range c.clustersClient.GetClusters() {
+		if !existingClusters[cl.GetName()] {
+			newClusters = append(newClusters, cl)
+		}
+	}
 
-				ctx, cancel := context.WithTimeout(log.ContextWithValue(ctx, zap.Time("startTime", startTime)), 30*time.Second)
-				defer cancel()
+	var pluginsSynced sync.WaitGroup
+	pluginsSynced.Add(len(newClusters))
 
-				users, err := cl.GetUsers(ctx)
-				if err != nil {
-					instrument(ctx, span, cl.GetName(), "users", err, len(users), startTime)
-					return
-				}
+	for _, cl := range newClusters {
+		log.Info(ctx, "Sync resources for new cluster", zap.String("cluster", cl.GetName()))
+		c.watchCluster(ctx, startTime, cl, &pluginsSynced)
+	}
+}
 
-				err = c.dbClient.SaveUsers(ctx, cl.GetName(), users)
-				if err != nil {
-					instrument(ctx, span, cl.GetName(), "users", err, len(users), startTime)
-					return
+// watchCluster adds a task for each resource (plugins, namespaces, crds, inventory, applications, dashboards, teams and
+// users) of the given cluster to the worker pool. When the plugins task is finished pluginsSynced is marked as done.
+// The dashboards task is only added to the worker pool, when the plugins of all clusters in pluginsSynced are saved,
+// because the dashboards are validated against the saved plugins. We are waiting outside of the worker pool, so that a
+// waiting task can not block a worker, which is required to run the plugins tasks.
+func (c *client) watchCluster(ctx context.Context, startTime time.Time, cl cluster.Client, pluginsSynced *sync.WaitGroup) {
+	go func(cl cluster.Client) {
+		c.workerPool.RunTask(task(func() {
+			defer pluginsSynced.Done()
+
+			ctx, span := c.tracer.Start(ctx, "watcher.plugins")
+			span.SetAttributes(attribute.Key("cluster").String(cl.GetName()))
+			defer span.End()
+
+			ctx, cancel := context.WithTimeout(log.ContextWithValue(ctx, zap.Time("startTime", startTime)), 30*time.Second)
+			defer cancel()
+
+			plugins, err := cl.GetPlugins(ctx)
+			if err != nil {
+				instrument(ctx, span, cl.GetName(), "plugins", err, len(plugins), startTime)
+				return
+			}
+
+			err = c.dbClient.SavePlugins(ctx, cl.GetName(), plugins)
+			if err != nil {
+				instrument(ctx, span, cl.GetName(), "plugins", err, len(plugins), startTime)
+				return
+			}
+
+			instrument(ctx, span, cl.GetName(), "plugins", nil, len(plugins), startTime)
+		}))
+	}(cl)
+
+	go func(cl cluster.Client) {
+		c.workerPool.RunTask(task(func() {
+			ctx, span := c.tracer.Start(ctx, "watcher.namespaces")
+			span.SetAttributes(attribute.Key("cluster").String(cl.GetName()))
+			defer span.End()
+
+			ctx, cancel := context.WithTimeout(log.ContextWithValue(ctx, zap.Time("startTime", startTime)), 30*time.Second)
+			defer cancel()
+
+			namespaces, err := cl.GetNamespaces(ctx)
+			if err != nil {
+				instrument(ctx, span, cl.GetName(), "namespaces", err, len(namespaces), startTime)
+				return
+			}
+
+			err = c.dbClient.SaveNamespaces(ctx, cl.GetName(), namespaces)
+			if err != nil {
+				instrument(ctx, span, cl.GetName(), "namespaces", err, len(namespaces), startTime)
+				return
+			}
+
+			instrument(ctx, span, cl.GetName(), "namespaces", nil, len(namespaces), startTime)
+		}))
+	}(cl)
+
+	go func(cl cluster.Client) {
+		c.workerPool.RunTask(task(func() {
+			ctx, span := c.tracer.Start(ctx, "watcher.crds")
+			span.SetAttributes(attribute.Key("cluster").String(cl.GetName()))
+			defer span.End()
+
+			ctx, cancel := context.WithTimeout(log.ContextWithValue(ctx, zap.Time("startTime", startTime)), 30*time.Second)
+			defer cancel()
+
+			crds, err := cl.GetCRDs(ctx)
+			if err != nil {
+				instrument(ctx, span, cl.GetName(), "crds", err, len(crds), startTime)
+				return
+			}
+
+			err = c.dbClient.SaveCRDs(ctx, crds)
+			if err != nil {
+				instrument(ctx, span, cl.GetName(), "crds", err, len(crds), startTime)
+				return
+			}
+
+			instrument(ctx, span, cl.GetName(), "crds", nil, len(crds), startTime)
+		}))
+	}(cl)
+
+	go func(cl cluster.Client) {
+		c.workerPool.RunTask(task(func() {
+			ctx, span := c.tracer.Start(ctx, "watcher.inventory")
+			span.SetAttributes(attribute.Key("cluster").String(cl.GetName()))
+			defer span.End()
+
+			ctx, cancel := context.WithTimeout(log.ContextWithValue(ctx, zap.Time("startTime", startTime)), 30*time.Second)
+			defer cancel()
+
+			inventory, err := cl.GetInventory(ctx)
+			if err != nil {
+				instrument(ctx, span, cl.GetName(), "inventory", err, 0, startTime)
+				return
+			}
+
+			err = c.dbClient.SaveInventory(ctx, cl.GetName(), inventory)
+			if err != nil {
+				instrument(ctx, span, cl.GetName(), "inventory", err, 1, startTime)
+				return
+			}
+
+			instrument(ctx, span, cl.GetName(), "inventory", nil, 1, startTime)
+		}))
+	}(cl)
+
+	go func(cl cluster.Client) {
+		c.workerPool.RunTask(task(func() {
+			ctx, span := c.tracer.Start(ctx, "watcher.applications")
+			span.SetAttributes(attribute.Key("cluster").String(cl.GetName()))
+			defer span.End()
+
+			ctx, cancel := context.WithTimeout(log.ContextWithValue(ctx, zap.Time("startTime", startTime)), 30*time.Second)
+			defer cancel()
+
+			applications, err := cl.GetApplications(ctx)
+			if err != nil {
+				instrument(ctx, span, cl.GetName(), "applications", err, len(applications), startTime)
+				return
+			}
+
+			err = c.dbClient.SaveApplications(ctx, cl.GetName(), applications)
+			if err != nil {
+				instrument(ctx, span, cl.GetName(), "applications", err, len(applications), startTime)
+				return
+			}
+
+			err = c.dbClient.SaveTags(ctx, applications)
+			if err != nil {
+				instrument(ctx, span, cl.GetName(), "tags", err, len(applications), startTime)
+				return
+			}
+
+			err = c.dbClient.SaveTopology(ctx, cl.GetName(), applications)
+			if err != nil {
+				instrument(ctx, span, cl.GetName(), "tags", err, len(applications), startTime)
+				return
+			}
+
+			instrument(ctx, span, cl.GetName(), "applications", nil, len(applications), startTime)
+		}))
+	}(cl)
+
+	go func(cl cluster.Client) {
+		pluginsSynced.Wait()
+
+		c.workerPool.RunTask(task(func() {
+			ctx, span := c.tracer.Start(ctx, "watcher.dashboards")
+			span.SetAttributes(attribute.Key("cluster").String(cl.GetName()))
+			defer span.End()
+
+			ctx, cancel := context.WithTimeout(log.ContextWithValue(ctx, zap.Time("startTime", startTime)), 30*time.Second)
+			defer cancel()
+
+			dashboards, err := cl.GetDashboards(ctx)
+			if err != nil {
+				instrument(ctx, span, cl.GetName(), "dashboards", err, len(dashboards), startTime)
+				return
+			}
+
+			// Validate all dashboards against the plugins known by the hub, so that problems with a dashboard (e.g.
+			// variables which are using a non-existing plugin instance) can be shown to the users. If we are not able to
+			// get the plugins, the plugin instances are not validated.
+			plugins, err := c.dbClient.GetPlugins(ctx)
+			if err != nil {
+				log.Warn(ctx, "Could not get plugins to validate dashboards", zap.Error(err), zap.String("cluster", cl.GetName()))
+			}
+
+			for i := range dashboards {
+				dashboards[i].Problems = hubDashboards.Validate(&dashboards[i], plugins)
+				if len(dashboards[i].Problems) > 0 {
+					log.Warn(ctx, "Dashboard is invalid", zap.String("cluster", cl.GetName()), zap.String("namespace", dashboards[i].Namespace), zap.String("name", dashboards[i].Name), zap.Strings("problems", dashboards[i].Problems))
 				}
-
-				instrument(ctx, span, cl.GetName(), "users", nil, len(users), startTime)
-			}))
-		}(cl)
-	}
+			}
+
+			err = c.dbClient.SaveDashboards(ctx, cl.GetName(), dashboards)
+			if err != nil {
+				instrument(ctx, span, cl.GetName(), "dashboards", err, len(dashboards), startTime)
+				return
+			}
+
+			instrument(ctx, span, cl.GetName(), "dashboards", nil, len(dashboards), startTime)
+		}))
+	}(cl)
+
+	go func(cl cluster.Client) {
+		c.workerPool.RunTask(task(func() {
+			ctx, span := c.tracer.Start(ctx, "watcher.teams")
+			span.SetAttributes(attribute.Key("cluster").String(cl.GetName()))
+			defer span.End()
+
+			ctx, cancel := context.WithTimeout(log.ContextWithValue(ctx, zap.Time("startTime", startTime)), 30*time.Second)
+			defer cancel()
+
+			teams, err := cl.GetTeams(ctx)
+			if err != nil {
+				instrument(ctx, span, cl.GetName(), "teams", err, len(teams), startTime)
+				return
+			}
+
+			err = c.dbClient.SaveTeams(ctx, cl.GetName(), teams)
+			if err != nil {
+				instrument(ctx, span, cl.GetName(), "teams", err, len(teams), startTime)
+				return
+			}
+
+			instrument(ctx, span, cl.GetName(), "teams", nil, len(teams), startTime)
+		}))
+	}(cl)
+
+	go func(cl cluster.Client) {
+		c.workerPool.RunTask(task(func() {
+			ctx, span := c.tracer.Start(ctx, "watcher.users")
+			span.SetAttributes(attribute.Key("cluster").String(cl.GetName()))
+			defer span.End()
+
+			ctx, cancel := context.WithTimeout(log.ContextWithValue(ctx, zap.Time("startTime", startTime)), 30*time.Second)
+			defer cancel()
+
+			users, err := cl.GetUsers(ctx)
+			if err != nil {
+				instrument(ctx, span, cl.GetName(), "users", err, len(users), startTime)
+				return
+			}
+
+			err = c.dbClient.SaveUsers(ctx, cl.GetName(), users)
+			if err != nil {
+				instrument(ctx, span, cl.GetName(), "users", err, len(users), startTime)
+				return
+			}
+
+			instrument(ctx, span, cl.GetName(), "users", nil, len(users), startTime)
+		}))
+	}(cl)
 }
 
 // NewClient returns a new watcher. To create the watcher a interval, the number of workers in the worker pool, the
@@ -0,0 +1,87 @@
+package watcher
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	dashboardv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
+	"github.com/kobsio/kobs/pkg/hub/clusters"
+	"github.com/kobsio/kobs/pkg/hub/clusters/cluster"
+	"github.com/kobsio/kobs/pkg/hub/db"
+	"github.com/kobsio/kobs/pkg/hub/watcher/worker"
+	"github.com/kobsio/kobs/pkg/plugins/plugin"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestWatch(t *testing.T) {
+	t.Run("should validate dashboards after plugins of all clusters are saved", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		dbClient := db.NewMockClient(ctrl)
+		clustersClient := clusters.NewMockClient(ctrl)
+
+		var savedPlugins atomic.Int64
+		var validatedPlugins []int64
+		var mu sync.Mutex
+		savedDashboards := make(chan string, 2)
+
+		var clusterClients []cluster.Client
+		for _, name := range []string{"dev-de1", "dev-de2"} {
+			clusterClient := cluster.NewMockClient(ctrl)
+			clusterClient.EXPECT().GetName().Return(name).AnyTimes()
+			clusterClient.EXPECT().GetPlugins(gomock.Any()).DoAndReturn(func(_ any) ([]plugin.Instance, error) {
+				time.Sleep(10 * time.Millisecond)
+				return []plugin.Instance{{Cluster: name, Type: "prometheus", Name: "prometheus"}}, nil
+			})
+			clusterClient.EXPECT().GetNamespaces(gomock.Any()).Return(nil, fmt.Errorf("unexpected error"))
+			clusterClient.EXPECT().GetCRDs(gomock.Any()).Return(nil, fmt.Errorf("unexpected error"))
+			clusterClient.EXPECT().GetInventory(gomock.Any()).Return(nil, fmt.Errorf("unexpected error"))
+			clusterClient.EXPECT().GetApplications(gomock.Any()).Return(nil, fmt.Errorf("unexpected error"))
+			clusterClient.EXPECT().GetDashboards(gomock.Any()).Return([]dashboardv1.DashboardSpec{{Cluster: name, Namespace: "default", Name: "dashboard"}}, nil)
+			clusterClient.EXPECT().GetTeams(gomock.Any()).Return(nil, fmt.Errorf("unexpected error"))
+			clusterClient.EXPECT().GetUsers(gomock.Any()).Return(nil, fmt.Errorf("unexpected error"))
+
+			clusterClients = append(clusterClients, clusterClient)
+		}
+
+		clustersClient.EXPECT().GetClusters().Return(clusterClients)
+		dbClient.EXPECT().SavePlugins(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ any, _ string, _ []plugin.Instance) error {
+			savedPlugins.Add(1)
+			return nil
+		}).Times(2)
+		dbClient.EXPECT().GetPlugins(gomock.Any()).DoAndReturn(func(_ any) ([]plugin.Instance, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			validatedPlugins = append(validatedPlugins, savedPlugins.Load())
+			return nil, nil
+		}).Times(2)
+		dbClient.EXPECT().SaveDashboards(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ any, cluster string, _ []dashboardv1.DashboardSpec) error {
+			savedDashboards <- cluster
+			return nil
+		}).Times(2)
+
+		workerPool, err := worker.NewPool(1)
+		require.NoError(t, err)
+		defer workerPool.Stop()
+
+		c := &client{workerPool: workerPool, clustersClient: clustersClient, dbClient: dbClient, tracer: otel.Tracer("watcher")}
+		c.watch()
+
+		for i := 0; i < 2; i++ {
+			select {
+			case <-savedDashboards:
+			case <-time.After(5 * time.Second):
+				t.Fatal("dashboards were not saved")
+			}
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Equal(t, []int64{2, 2}, validatedPlugins)
+	})
+}
@@ -0,0 +1,639 @@
+// Package converter implements a converter for Grafana dashboards. The converter takes the JSON model of a Grafana
+// dashboard and returns a kobs dashboard, where the Prometheus and Elasticsearch panels are replaced with panels of the
+// corresponding kobs plugins. Everything which can not be converted is reported, so that the user can decide how to
+// handle these parts of the dashboard.
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	dashboardv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+const (
+	// KindPanel is the kind for a panel which could not be converted.
+	KindPanel = "panel"
+	// KindVariable is the kind for a templating variable which could not be converted.
+	KindVariable = "variable"
+)
+
+var (
+	// legendFormatRegexp matches the labels in a Grafana legend format, e.g. "{{pod}}".
+	legendFormatRegexp = regexp.MustCompile(`\{\{\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*\}\}`)
+	// variableRegexp matches all supported formats for variables in Grafana: "$var", "${var}", "${var:format}" and
+	// "[[var]]".
+	variableRegexp = regexp.MustCompile(`\$\{([a-zA-Z0-9_]+)(?::[^}]*)?\}|\[\[([a-zA-Z0-9_]+)(?::[^\]]*)?\]\]|\$([a-zA-Z0-9_]+)`)
+	// labelValuesRegexp matches the "label_values" function of a Prometheus variable query.
+	labelValuesRegexp = regexp.MustCompile(`^\s*label_values\((.*)\)\s*$`)
+)
+
+// intervals are the values for the built-in Grafana variables which are not supported by kobs. Since kobs calculates
+// the step of a query based on the selected time range, we are using a fixed interval, which should work for most
+// queries.
+var intervals = map[string]string{
+	"__interval":      "5m",
+	"__rate_interval": "5m",
+}
+
+// units maps the Grafana units to the units which are displayed in the kobs panels. Units which are not in the map are
+// used as they are.
+var units = map[string]string{
+	"none":        "",
+	"short":       "",
+	"percent":     "%",
+	"percentunit": "",
+	"reqps":       "req/s",
+	"ops":         "ops/s",
+	"bytes":       "bytes",
+	"decbytes":    "bytes",
+	"Bps":         "bytes/s",
+	"s":           "s",
+	"ms":          "ms",
+	"µs":          "µs",
+	"ns":          "ns",
+}
+
+// Options are the options for the converter. The options define the kobs plugin instances which should be used for
+// the converted Prometheus and Elasticsearch panels and variables.
+type Options struct {
+	Cluster       string
+	Prometheus    string
+	Elasticsearch string
+	DataView      string
+}
+
+// Result is the result of a conversion. It contains the converted dashboard and a list of all panels and variables,
+// which could not be converted.
+type Result struct {
+	Dashboard   dashboardv1.DashboardSpec `json:"dashboard"`
+	Unconverted []Unconverted             `json:"unconverted"`
+}
+
+// Unconverted is a panel or variable of the Grafana dashboard, which could not be converted. The reason contains a
+// human readable explanation why it could not be converted.
+type Unconverted struct {
+	Kind   string `json:"kind"`
+	Title  string `json:"title"`
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+type converter struct {
+	options     Options
+	inputs      map[string]string
+	datasources map[string]string
+	variables   map[string]bool
+	result      Result
+}
+
+// Convert converts the provided Grafana dashboard JSON into a kobs dashboard. The JSON can be the dashboard model
+// itself or the response of the Grafana API, where the model is wrapped in a "dashboard" field.
+func Convert(data []byte, options Options) (*Result, error) {
+	var wrapper struct {
+		Dashboard *grafanaDashboard `json:"dashboard"`
+	}
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return nil, fmt.Errorf("invalid dashboard: %w", err)
+	}
+
+	dashboard := wrapper.Dashboard
+	if dashboard == nil {
+		dashboard = &grafanaDashboard{}
+		if err := json.Unmarshal(data, dashboard); err != nil {
+			return nil, fmt.Errorf("invalid dashboard: %w", err)
+		}
+	}
+
+	if len(dashboard.Panels) == 0 && len(dashboard.Rows) > 0 {
+		return nil, fmt.Errorf("dashboards with the legacy rows format are not supported")
+	}
+
+	if options.Cluster == "" {
+		options.Cluster = "hub"
+	}
+
+	c := &converter{
+		options:     options,
+		inputs:      make(map[string]string),
+		datasources: make(map[string]string),
+		variables:   make(map[string]bool),
+		result: Result{
+			Dashboard: dashboardv1.DashboardSpec{
+				Title:       dashboard.Title,
+				Description: dashboard.Description,
+			},
+			Unconverted: []Unconverted{},
+		},
+	}
+
+	for _, input := range dashboard.Inputs {
+		if input.Type == "datasource" {
+			c.inputs[input.Name] = input.PluginID
+		}
+	}
+
+	for _, variable := range dashboard.Templating.List {
+		if variable.Type == "datasource" {
+			c.datasources[variable.Name] = queryString(variable.Query)
+		}
+	}
+
+	c.convertVariables(dashboard.Templating.List)
+	c.convertPanels(dashboard.Panels)
+
+	return &c.result, nil
+}
+
+// convertVariables converts the templating variables of the Grafana dashboard. Since the variables are used in the
+// queries of the panels, the variables must be converted before the panels, so that we know which variables can be
+// replaced in the queries.
+func (c *converter) convertVariables(variables []grafanaVariable) {
+	for _, variable := range variables {
+		query := queryString(variable.Query)
+
+		switch variable.Type {
+		case "query":
+			if datasourceType := c.datasourceType(variable.Datasource); datasourceType != "" && datasourceType != "prometheus" {
+				c.unconverted(KindVariable, variable.Name, variable.Type, fmt.Sprintf("data source %q is not supported", datasourceType))
+				continue
+			}
+
+			label, seriesQuery, ok := parseLabelValues(query)
+			if !ok {
+				c.unconverted(KindVariable, variable.Name, variable.Type, fmt.Sprintf("query %q is not supported, only label_values can be converted", query))
+				continue
+			}
+
+			c.addVariable(variable, dashboardv1.Plugin{
+				Type:    "prometheus",
+				Cluster: c.options.Cluster,
+				Name:    c.options.Prometheus,
+				Options: toJSON(map[string]any{
+					"type":  "labelValues",
+					"label": label,
+					"query": c.replaceVariables(seriesQuery),
+				}),
+			})
+
+		case "custom", "interval":
+			var values []string
+			for _, value := range strings.Split(query, ",") {
+				if parts := strings.SplitN(value, " : ", 2); len(parts) == 2 {
+					value = parts[1]
+				}
+				if value = strings.TrimSpace(value); value != "" {
+					values = append(values, value)
+				}
+			}
+
+			c.addVariable(variable, dashboardv1.Plugin{Type: "core", Name: "static", Options: toJSON(values)})
+
+		case "constant":
+			variable.Hide = 2
+			c.addVariable(variable, dashboardv1.Plugin{Type: "core", Name: "static", Options: toJSON([]string{query})})
+
+		case "textbox":
+			c.variables[variable.Name] = true
+			c.result.Dashboard.Placeholders = append(c.result.Dashboard.Placeholders, dashboardv1.Placeholder{
+				Name:        variable.Name,
+				Description: variable.Label,
+				Default:     query,
+			})
+
+		case "datasource":
+			c.unconverted(KindVariable, variable.Name, variable.Type, "data source variables are not supported, the plugin instances are set via the converter options")
+
+		default:
+			c.unconverted(KindVariable, variable.Name, variable.Type, fmt.Sprintf("variables of type %q are not supported", variable.Type))
+		}
+	}
+}
+
+func (c *converter) addVariable(variable grafanaVariable, plugin dashboardv1.Plugin) {
+	c.variables[variable.Name] = true
+	c.result.Dashboard.Variables = append(c.result.Dashboard.Variables, dashboardv1.Variable{
+		Name:             variable.Name,
+		Label:            variable.Label,
+		Hide:             variable.Hide == 2,
+		IncludeAllOption: variable.IncludeAll,
+		Plugin:           plugin,
+	})
+}
+
+// convertPanels converts the panels of the Grafana dashboard into rows and panels of the kobs dashboard. Each Grafana
+// panel with the type "row" starts a new row. Panels before the first row are added to a row without a title.
+//
+// The Grafana grid has 24 columns, while the kobs grid has 12 columns, so that the x position and the width of a panel
+// are divided by two. The y position of a panel is relative to the start of the row, because each row in kobs has its
+// own grid. The height can be used as it is, because the row height in both grids is nearly the same.
+func (c *converter) convertPanels(panels []grafanaPanel) {
+	var row *dashboardv1.Row
+	var rowOffset int64
+
+	for _, panel := range panels {
+		if panel.Type == "row" {
+			if row != nil {
+				c.result.Dashboard.Rows = append(c.result.Dashboard.Rows, *row)
+			}
+
+			row = &dashboardv1.Row{Title: c.replaceVariables(panel.Title), Panels: []dashboardv1.Panel{}}
+			rowOffset = panel.GridPos.Y + 1
+
+			for _, nestedPanel := range panel.Panels {
+				c.addPanel(row, nestedPanel, rowOffset)
+			}
+
+			continue
+		}
+
+		if row == nil {
+			row = &dashboardv1.Row{Panels: []dashboardv1.Panel{}}
+		}
+
+		c.addPanel(row, panel, rowOffset)
+	}
+
+	if row != nil {
+		c.result.Dashboard.Rows = append(c.result.Dashboard.Rows, *row)
+	}
+}
+
+func (c *converter) addPanel(row *dashboardv1.Row, panel grafanaPanel, rowOffset int64) {
+	plugin, err := c.convertPanel(panel)
+	if err != nil {
+		c.unconverted(KindPanel, panel.Title, panel.Type, err.Error())
+		return
+	}
+
+	y := panel.GridPos.Y - rowOffset
+	if y < 0 {
+		y = 0
+	}
+
+	w := (panel.GridPos.W + 1) / 2
+	if w < 1 {
+		w = 1
+	}
+
+	row.Panels = append(row.Panels, dashboardv1.Panel{
+		Title:       c.replaceVariables(panel.Title),
+		Description: panel.Description,
+		X:           panel.GridPos.X / 2,
+		Y:           y,
+		W:           w,
+		H:           panel.GridPos.H,
+		Plugin:      *plugin,
+	})
+}
+
+// convertPanel returns the kobs plugin for a Grafana panel. The plugin is selected by the data source of the panel
+// targets. All targets of a panel must use the same data source, because a kobs panel can only use one plugin.
+func (c *converter) convertPanel(panel grafanaPanel) (*dashboardv1.Plugin, error) {
+	var targets []grafanaTarget
+	datasourceType := ""
+
+	for _, target := range panel.Targets {
+		if target.Hide {
+			continue
+		}
+
+		targetType := c.targetType(panel, target)
+		if datasourceType != "" && targetType != datasourceType {
+			return nil, fmt.Errorf("panels with multiple data sources are not supported")
+		}
+
+		datasourceType = targetType
+		targets = append(targets, target)
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("panels of type %q without queries are not supported", panel.Type)
+	}
+
+	switch datasourceType {
+	case "prometheus":
+		return c.convertPrometheusPanel(panel, targets)
+	case "elasticsearch":
+		return c.convertElasticsearchPanel(panel, targets)
+	case "":
+		return nil, fmt.Errorf("data source could not be detected")
+	default:
+		return nil, fmt.Errorf("data source %q is not supported", datasourceType)
+	}
+}
+
+// convertPrometheusPanel converts a Grafana panel with Prometheus targets into a panel for the Prometheus plugin.
+func (c *converter) convertPrometheusPanel(panel grafanaPanel, targets []grafanaTarget) (*dashboardv1.Plugin, error) {
+	options := map[string]any{}
+
+	unit := panel.FieldConfig.Defaults.Unit
+	if unit == "" && len(panel.Yaxes) > 0 {
+		unit = panel.Yaxes[0].Format
+	}
+	if mappedUnit, ok := units[unit]; ok {
+		unit = mappedUnit
+	}
+	if unit != "" {
+		options["unit"] = unit
+	}
+
+	switch panel.Type {
+	case "graph":
+		options["type"] = "line"
+		if panel.Bars {
+			options["type"] = "bar"
+		} else if panel.Fill > 0 {
+			options["type"] = "area"
+		}
+		if panel.Stack {
+			options["stacked"] = true
+		}
+		if panel.Legend.AlignAsTable {
+			options["legend"] = "table"
+		}
+
+	case "timeseries":
+		custom := panel.FieldConfig.Defaults.Custom
+		options["type"] = "line"
+		if custom.DrawStyle == "bars" {
+			options["type"] = "bar"
+		} else if custom.FillOpacity > 0 {
+			options["type"] = "area"
+		}
+		if custom.Stacking.Mode != "" && custom.Stacking.Mode != "none" {
+			options["stacked"] = true
+		}
+		if panel.Options.Legend.DisplayMode == "table" {
+			options["legend"] = "table"
+		}
+
+	case "barchart":
+		options["type"] = "bar"
+
+	case "stat", "singlestat", "gauge", "bargauge":
+		options["type"] = "sparkline"
+
+	case "table", "table-old":
+		options["type"] = "table"
+
+	default:
+		return nil, fmt.Errorf("panels of type %q are not supported", panel.Type)
+	}
+
+	var queries []map[string]string
+	var labels []string
+
+	for _, target := range targets {
+		query := map[string]string{"query": c.replaceVariables(target.Expr)}
+
+		// Sparklines are only showing a single value, so that they do not support a label for the query.
+		if options["type"] != "sparkline" && target.LegendFormat != "" {
+			query["label"] = c.replaceVariables(legendFormatRegexp.ReplaceAllString(target.LegendFormat, "{% .$1 %}"))
+		}
+
+		for _, match := range legendFormatRegexp.FindAllStringSubmatch(target.LegendFormat, -1) {
+			labels = appendIfMissing(labels, match[1])
+		}
+
+		queries = append(queries, query)
+
+		// Sparklines can only show the result of a single query, so that we ignore all other queries.
+		if options["type"] == "sparkline" {
+			break
+		}
+	}
+
+	options["queries"] = queries
+
+	// For tables we have to define the columns. The columns are the labels from the legend formats, followed by one
+	// column for the value of each query, which are named "value-1", "value-2", etc. by the Prometheus plugin.
+	if options["type"] == "table" {
+		var columns []map[string]string
+		for _, label := range labels {
+			columns = append(columns, map[string]string{"name": label, "title": label})
+		}
+
+		for i, target := range targets {
+			column := map[string]string{"name": fmt.Sprintf("value-%d", i+1), "title": "Value"}
+			if len(targets) > 1 {
+				column["title"] = target.RefID
+			}
+			if unit != "" {
+				column["unit"] = unit
+			}
+			columns = append(columns, column)
+		}
+
+		options["columns"] = columns
+	}
+
+	return &dashboardv1.Plugin{
+		Type:    "prometheus",
+		Cluster: c.options.Cluster,
+		Name:    c.options.Prometheus,
+		Options: toJSON(options),
+	}, nil
+}
+
+// convertElasticsearchPanel converts a Grafana panel with Elasticsearch targets into a logs panel for the
+// Elasticsearch plugin. Each target is added as a query, so that the user can select the query in the panel. Since the
+// Elasticsearch plugin requires a data view for each query, the panel can only be converted when a data view is set in
+// the converter options.
+func (c *converter) convertElasticsearchPanel(panel grafanaPanel, targets []grafanaTarget) (*dashboardv1.Plugin, error) {
+	if c.options.DataView == "" {
+		return nil, fmt.Errorf("data view for Elasticsearch panels is missing")
+	}
+
+	var queries []map[string]string
+	for _, target := range targets {
+		query := c.replaceVariables(target.Query)
+		if query == "" {
+			query = "*"
+		}
+
+		name := target.RefID
+		if name == "" {
+			name = panel.Title
+		}
+
+		queries = append(queries, map[string]string{
+			"name":     name,
+			"dataView": c.options.DataView,
+			"query":    query,
+		})
+	}
+
+	return &dashboardv1.Plugin{
+		Type:    "elasticsearch",
+		Cluster: c.options.Cluster,
+		Name:    c.options.Elasticsearch,
+		Options: toJSON(map[string]any{
+			"type":      "logs",
+			"showChart": panel.Type != "logs",
+			"queries":   queries,
+		}),
+	}, nil
+}
+
+// targetType returns the data source type of a target. If the target doesn't define its own data source the data
+// source of the panel is used. If we can not get the type from the data source, we try to detect it from the fields
+// of the target.
+func (c *converter) targetType(panel grafanaPanel, target grafanaTarget) string {
+	if datasourceType := c.datasourceType(target.Datasource); datasourceType != "" {
+		return datasourceType
+	}
+
+	if datasourceType := c.datasourceType(panel.Datasource); datasourceType != "" {
+		return datasourceType
+	}
+
+	if target.Expr != "" {
+		return "prometheus"
+	}
+
+	if len(target.Metrics) > 0 || len(target.BucketAggs) > 0 {
+		return "elasticsearch"
+	}
+
+	return ""
+}
+
+// datasourceType returns the type of a data source. In newer Grafana versions the data source is an object with a
+// "type" and "uid" field, in older versions it is only the name of the data source. The name or uid can also reference
+// an input of an exported dashboard or a data source variable, in this case the type of the input or variable is
+// returned.
+func (c *converter) datasourceType(data json.RawMessage) string {
+	if len(data) == 0 || string(data) == "null" {
+		return ""
+	}
+
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		var datasource struct {
+			Type string `json:"type"`
+			UID  string `json:"uid"`
+		}
+		if err := json.Unmarshal(data, &datasource); err != nil {
+			return ""
+		}
+
+		if datasource.Type != "" && datasource.Type != "datasource" {
+			return datasource.Type
+		}
+
+		name = datasource.UID
+	}
+
+	if matches := variableRegexp.FindStringSubmatch(name); matches != nil {
+		variable := matches[1] + matches[2] + matches[3]
+		if pluginID, ok := c.inputs[variable]; ok {
+			return pluginID
+		}
+		if pluginID, ok := c.datasources[variable]; ok {
+			return pluginID
+		}
+	}
+
+	lowerName := strings.ToLower(name)
+	if strings.Contains(lowerName, "prometheus") {
+		return "prometheus"
+	}
+	if strings.Contains(lowerName, "elasticsearch") {
+		return "elasticsearch"
+	}
+
+	return ""
+}
+
+// replaceVariables replaces all Grafana variables in the provided string with the kobs syntax for variables. Only the
+// variables which could be converted are replaced, so that other usages of the "$" sign, e.g. in the replacement of a
+// "label_replace" function, are not changed. The built-in interval variables of Grafana are replaced with a fixed
+// value.
+func (c *converter) replaceVariables(str string) string {
+	return variableRegexp.ReplaceAllStringFunc(str, func(match string) string {
+		matches := variableRegexp.FindStringSubmatch(match)
+		name := matches[1] + matches[2] + matches[3]
+
+		if interval, ok := intervals[name]; ok {
+			return interval
+		}
+
+		if c.variables[name] {
+			return fmt.Sprintf("{%% .%s %%}", name)
+		}
+
+		return match
+	})
+}
+
+func (c *converter) unconverted(kind, title, unconvertedType, reason string) {
+	c.result.Unconverted = append(c.result.Unconverted, Unconverted{
+		Kind:   kind,
+		Title:  title,
+		Type:   unconvertedType,
+		Reason: reason,
+	})
+}
+
+// parseLabelValues parses the "label_values" function of a Prometheus variable query and returns the label and the
+// series query, which can be used for the "labelValues" variable of the Prometheus plugin. If the function doesn't
+// contain a metric, we use a query which matches all series with the label.
+func parseLabelValues(query string) (string, string, bool) {
+	matches := labelValuesRegexp.FindStringSubmatch(query)
+	if matches == nil {
+		return "", "", false
+	}
+
+	args := matches[1]
+	index := strings.LastIndex(args, ",")
+	if index == -1 {
+		label := strings.TrimSpace(args)
+		return label, fmt.Sprintf(`{%s!=""}`, label), label != ""
+	}
+
+	label := strings.TrimSpace(args[index+1:])
+	seriesQuery := strings.TrimSpace(args[:index])
+
+	return label, seriesQuery, label != "" && seriesQuery != ""
+}
+
+// queryString returns the query of a variable. The query can be a string or an object with a "query" field.
+func queryString(data json.RawMessage) string {
+	var query string
+	if err := json.Unmarshal(data, &query); err == nil {
+		return query
+	}
+
+	var queryObject struct {
+		Query string `json:"query"`
+	}
+	if err := json.Unmarshal(data, &queryObject); err == nil {
+		return queryObject.Query
+	}
+
+	return ""
+}
+
+func toJSON(value any) *apiextensionsv1.JSON {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+
+	return &apiextensionsv1.JSON{Raw: data}
+}
+
+func appendIfMissing(items []string, item string) []string {
+	for _, i := range items {
+		if i == item {
+			return items
+		}
+	}
+
+	return append(items, item)
+}
@@ -0,0 +1,118 @@
+package converter
+
+import (
+	"encoding/json"
+	"testing"
+
+	dashboardv1 "github.com/kobsio/kobs/pkg/cluster/kubernetes/apis/dashboard/v1"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvert(t *testing.T) {
+	options := Options{Prometheus: "prometheus", Elasticsearch: "elasticsearch", DataView: "logs"}
+
+	t.Run("should fail for invalid json", func(t *testing.T) {
+		_, err := Convert([]byte(`[`), options)
+		require.ErrorContains(t, err, "invalid dashboard")
+	})
+
+	t.Run("should fail for legacy rows", func(t *testing.T) {
+		_, err := Convert([]byte(`{"rows":[{"panels":[]}]}`), options)
+		require.EqualError(t, err, "dashboards with the legacy rows format are not supported")
+	})
+
+	t.Run("should convert dashboard from api response", func(t *testing.T) {
+		result, err := Convert([]byte(`{"dashboard":{"title":"Test","description":"Test Dashboard"},"meta":{}}`), options)
+		require.NoError(t, err)
+		require.Equal(t, dashboardv1.DashboardSpec{Title: "Test", Description: "Test Dashboard"}, result.Dashboard)
+		require.Empty(t, result.Unconverted)
+	})
+
+	t.Run("should convert dashboard", func(t *testing.T) {
+		result, err := Convert([]byte(`{
+			"title": "Test",
+			"__inputs": [{"name": "DS_PROMETHEUS", "type": "datasource", "pluginId": "prometheus"}],
+			"templating": {"list": [
+				{"name": "namespace", "type": "query", "datasource": "${DS_PROMETHEUS}", "query": {"query": "label_values(kube_pod_info, namespace)"}, "includeAll": true},
+				{"name": "pod", "type": "query", "datasource": {"type": "prometheus", "uid": "abc"}, "query": "label_values(kube_pod_info{namespace=\"$namespace\"}, pod)"},
+				{"name": "job", "type": "query", "query": "query_result(up)"},
+				{"name": "interval", "type": "interval", "query": "1m,5m"},
+				{"name": "env", "type": "custom", "query": "Production : prod, dev"},
+				{"name": "cluster", "type": "constant", "query": "kobs"},
+				{"name": "filter", "type": "textbox", "label": "Filter", "query": ".*"},
+				{"name": "ds", "type": "datasource", "query": "prometheus"}
+			]},
+			"panels": [
+				{"title": "Text", "type": "text", "gridPos": {"x": 0, "y": 0, "w": 24, "h": 2}},
+				{"title": "Pods", "type": "stat", "datasource": "${DS_PROMETHEUS}", "gridPos": {"x": 0, "y": 2, "w": 6, "h": 4}, "targets": [{"expr": "count(kube_pod_info{namespace=~\"$namespace\"})", "legendFormat": "Pods"}]},
+				{"title": "Row $namespace", "type": "row", "gridPos": {"x": 0, "y": 6, "w": 24, "h": 1}, "collapsed": true, "panels": [
+					{"title": "CPU", "type": "timeseries", "datasource": {"type": "prometheus", "uid": "abc"}, "gridPos": {"x": 12, "y": 7, "w": 12, "h": 8}, "fieldConfig": {"defaults": {"unit": "percent", "custom": {"fillOpacity": 10, "stacking": {"mode": "normal"}}}}, "options": {"legend": {"displayMode": "table"}}, "targets": [{"expr": "sum(rate(cpu{pod=\"${pod}\"}[$__rate_interval])) by (pod)", "legendFormat": "{{pod}}"}]}
+				]},
+				{"title": "Logs", "type": "logs", "datasource": "Elasticsearch", "gridPos": {"x": 0, "y": 15, "w": 24, "h": 10}, "targets": [{"refId": "A", "query": "namespace: [[namespace]]"}]},
+				{"title": "Table", "type": "table", "gridPos": {"x": 0, "y": 25, "w": 23, "h": 10}, "targets": [{"refId": "A", "expr": "up", "legendFormat": "{{job}} {{instance}}"}, {"refId": "B", "expr": "up", "hide": true}]},
+				{"title": "Mixed", "type": "timeseries", "datasource": {"type": "datasource", "uid": "-- Mixed --"}, "gridPos": {"x": 0, "y": 35, "w": 24, "h": 10}, "targets": [{"datasource": {"type": "prometheus"}, "expr": "up"}, {"datasource": {"type": "loki"}, "expr": "{app=\"kobs\"}"}]}
+			]
+		}`), options)
+		require.NoError(t, err)
+
+		actual, err := json.Marshal(result)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"dashboard": {
+				"title": "Test",
+				"placeholders": [{"name": "filter", "description": "Filter", "default": ".*"}],
+				"variables": [
+					{"name": "namespace", "includeAllOption": true, "plugin": {"type": "prometheus", "cluster": "hub", "name": "prometheus", "options": {"label": "namespace", "query": "kube_pod_info", "type": "labelValues"}}},
+					{"name": "pod", "plugin": {"type": "prometheus", "cluster": "hub", "name": "prometheus", "options": {"label": "pod", "query": "kube_pod_info{namespace=\"{% .namespace %}\"}", "type": "labelValues"}}},
+					{"name": "interval", "plugin": {"type": "core", "name": "static", "options": ["1m", "5m"]}},
+					{"name": "env", "plugin": {"type": "core", "name": "static", "options": ["prod", "dev"]}},
+					{"name": "cluster", "hide": true, "plugin": {"type": "core", "name": "static", "options": ["kobs"]}}
+				],
+				"rows": [
+					{"panels": [
+						{"title": "Pods", "y": 2, "w": 3, "h": 4, "plugin": {"type": "prometheus", "cluster": "hub", "name": "prometheus", "options": {"type": "sparkline", "queries": [{"query": "count(kube_pod_info{namespace=~\"{% .namespace %}\"})"}]}}}
+					]},
+					{"title": "Row {% .namespace %}", "panels": [
+						{"title": "CPU", "x": 6, "w": 6, "h": 8, "plugin": {"type": "prometheus", "cluster": "hub", "name": "prometheus", "options": {"type": "area", "unit": "%", "stacked": true, "legend": "table", "queries": [{"query": "sum(rate(cpu{pod=\"{% .pod %}\"}[5m])) by (pod)", "label": "{% .pod %}"}]}}},
+						{"title": "Logs", "y": 8, "w": 12, "h": 10, "plugin": {"type": "elasticsearch", "cluster": "hub", "name": "elasticsearch", "options": {"type": "logs", "showChart": false, "queries": [{"name": "A", "dataView": "logs", "query": "namespace: {% .namespace %}"}]}}},
+						{"title": "Table", "y": 18, "w": 12, "h": 10, "plugin": {"type": "prometheus", "cluster": "hub", "name": "prometheus", "options": {"type": "table", "queries": [{"query": "up", "label": "{% .job %} {% .instance %}"}], "columns": [{"name": "job", "title": "job"}, {"name": "instance", "title": "instance"}, {"name": "value-1", "title": "Value"}]}}}
+					]}
+				]
+			},
+			"unconverted": [
+				{"kind": "variable", "title": "job", "type": "query", "reason": "query \"query_result(up)\" is not supported, only label_values can be converted"},
+				{"kind": "variable", "title": "ds", "type": "datasource", "reason": "data source variables are not supported, the plugin instances are set via the converter options"},
+				{"kind": "panel", "title": "Text", "type": "text", "reason": "panels of type \"text\" without queries are not supported"},
+				{"kind": "panel", "title": "Mixed", "type": "timeseries", "reason": "panels with multiple data sources are not supported"}
+			]
+		}`, string(actual))
+	})
+
+	t.Run("should report elasticsearch panels without data view", func(t *testing.T) {
+		result, err := Convert([]byte(`{"panels":[{"title":"Logs","type":"logs","datasource":{"type":"elasticsearch"},"targets":[{"query":"*"}]}]}`), Options{})
+		require.NoError(t, err)
+		require.Equal(t, []Unconverted{{Kind: KindPanel, Title: "Logs", Type: "logs", Reason: "data view for Elasticsearch panels is missing"}}, result.Unconverted)
+	})
+}
+
+func TestParseLabelValues(t *testing.T) {
+	for _, tt := range []struct {
+		query         string
+		expectedLabel string
+		expectedQuery string
+		expectedOk    bool
+	}{
+		{query: "label_values(up, job)", expectedLabel: "job", expectedQuery: "up", expectedOk: true},
+		{query: `label_values(up{job="a,b"}, instance)`, expectedLabel: "instance", expectedQuery: `up{job="a,b"}`, expectedOk: true},
+		{query: "label_values(job)", expectedLabel: "job", expectedQuery: `{job!=""}`, expectedOk: true},
+		{query: "label_names()", expectedOk: false},
+	} {
+		t.Run(tt.query, func(t *testing.T) {
+			label, query, ok := parseLabelValues(tt.query)
+			require.Equal(t, tt.expectedLabel, label)
+			require.Equal(t, tt.expectedQuery, query)
+			require.Equal(t, tt.expectedOk, ok)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package converter
+
+import (
+	"encoding/json"
+)
+
+// grafanaDashboard is the structure of a Grafana dashboard as it is returned by the Grafana API or exported via the
+// Grafana UI. We only define the fields which are needed to convert the dashboard into a kobs dashboard.
+type grafanaDashboard struct {
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Panels      []grafanaPanel    `json:"panels"`
+	Rows        []json.RawMessage `json:"rows"`
+	Templating  struct {
+		List []grafanaVariable `json:"list"`
+	} `json:"templating"`
+	Inputs []grafanaInput `json:"__inputs"`
+}
+
+// grafanaInput is an input of an exported Grafana dashboard. Inputs are used to replace the datasources of a dashboard
+// during the import, e.g. "${DS_PROMETHEUS}".
+type grafanaInput struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	PluginID string `json:"pluginId"`
+}
+
+// grafanaPanel is the structure of a single panel in a Grafana dashboard. Panels with the type "row" are used to group
+// the following panels. When a row is collapsed the panels of the row are nested in the "panels" field of the row.
+type grafanaPanel struct {
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	Type        string             `json:"type"`
+	Datasource  json.RawMessage    `json:"datasource"`
+	GridPos     grafanaGridPos     `json:"gridPos"`
+	Collapsed   bool               `json:"collapsed"`
+	Panels      []grafanaPanel     `json:"panels"`
+	Targets     []grafanaTarget    `json:"targets"`
+	FieldConfig grafanaFieldConfig `json:"fieldConfig"`
+	Options     struct {
+		Legend struct {
+			DisplayMode string `json:"displayMode"`
+		} `json:"legend"`
+	} `json:"options"`
+	Bars  bool `json:"bars"`
+	Fill  int  `json:"fill"`
+	Stack bool `json:"stack"`
+	Yaxes []struct {
+		Format string `json:"format"`
+	} `json:"yaxes"`
+	Legend struct {
+		AlignAsTable bool `json:"alignAsTable"`
+	} `json:"legend"`
+}
+
+// grafanaGridPos is the position of a panel in the Grafana grid. The Grafana grid has 24 columns.
+type grafanaGridPos struct {
+	X int64 `json:"x"`
+	Y int64 `json:"y"`
+	W int64 `json:"w"`
+	H int64 `json:"h"`
+}
+
+// grafanaFieldConfig contains the default field configuration of a panel, which is used for the unit and the style of
+// the "timeseries" panel.
+type grafanaFieldConfig struct {
+	Defaults struct {
+		Unit   string `json:"unit"`
+		Custom struct {
+			FillOpacity float64 `json:"fillOpacity"`
+			DrawStyle   string  `json:"drawStyle"`
+			Stacking    struct {
+				Mode string `json:"mode"`
+			} `json:"stacking"`
+		} `json:"custom"`
+	} `json:"defaults"`
+}
+
+// grafanaTarget is a single query of a panel. Prometheus targets are using the "expr" field for the query, while
+// Elasticsearch targets are using the "query" field.
+type grafanaTarget struct {
+	RefID        string            `json:"refId"`
+	Datasource   json.RawMessage   `json:"datasource"`
+	Hide         bool              `json:"hide"`
+	Expr         string            `json:"expr"`
+	LegendFormat string            `json:"legendFormat"`
+	Query        string            `json:"query"`
+	Metrics      []json.RawMessage `json:"metrics"`
+	BucketAggs   []json.RawMessage `json:"bucketAggs"`
+}
+
+// grafanaVariable is a templating variable of a Grafana dashboard. The query of a variable can be a string or an
+// object with a "query" field, depending on the Grafana version which was used to create the dashboard.
+type grafanaVariable struct {
+	Name       string          `json:"name"`
+	Label      string          `json:"label"`
+	Type       string          `json:"type"`
+	Datasource json.RawMessage `json:"datasource"`
+	Query      json.RawMessage `json:"query"`
+	IncludeAll bool            `json:"includeAll"`
+	Hide       int             `json:"hide"`
+}
@@ -2,6 +2,7 @@ package instance
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -24,6 +25,7 @@ type Instance interface {
 	GetName() string
 	GetDashboards(ctx context.Context, query string) ([]Dashboard, error)
 	GetDashboard(ctx context.Context, uid string) (*Dashboard, error)
+	GetDashboardModel(ctx context.Context, uid string) (json.RawMessage, error)
 }
 
 type instance struct {
@@ -62,6 +64,19 @@ func (i *instance) GetDashboard(ctx context.Context, uid string) (*Dashboard, er
 	}, nil
 }
 
+// GetDashboardModel returns the complete JSON model of a dashboard by it's uid. In contrast to the GetDashboard method
+// the model is not decoded, so that it can be passed to the converter.
+func (i *instance) GetDashboardModel(ctx context.Context, uid string) (json.RawMessage, error) {
+	dashboard, err := doRequest[struct {
+		Dashboard json.RawMessage `json:"dashboard"`
+	}](ctx, i.client, fmt.Sprintf("%s/api/dashboards/uid/%s", i.address, uid))
+	if err != nil {
+		return nil, err
+	}
+
+	return dashboard.Dashboard, nil
+}
+
 // New returns a new Grafana instance for the given configuration.
 func New(name string, options map[string]any) (Instance, error) {
 	var config Config
@@ -6,6 +6,7 @@ package instance
 
 import (
 	context "context"
+	json "encoding/json"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -49,6 +50,21 @@ func (mr *MockInstanceMockRecorder) GetDashboard(ctx, uid interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDashboard", reflect.TypeOf((*MockInstance)(nil).GetDashboard), ctx, uid)
 }
 
+// GetDashboardModel mocks base method.
+func (m *MockInstance) GetDashboardModel(ctx context.Context, uid string) (json.RawMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDashboardModel", ctx, uid)
+	ret0, _ := ret[0].(json.RawMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDashboardModel indicates an expected call of GetDashboardModel.
+func (mr *MockInstanceMockRecorder) GetDashboardModel(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDashboardModel", reflect.TypeOf((*MockInstance)(nil).GetDashboardModel), ctx, uid)
+}
+
 // GetDashboards mocks base method.
 func (m *MockInstance) GetDashboards(ctx context.Context, query string) ([]Dashboard, error) {
 	m.ctrl.T.Helper()
@@ -146,6 +146,47 @@ func TestGetDashboard(t *testing.T) {
 	})
 }
 
+func TestGetDashboardModel(t *testing.T) {
+	t.Run("request error", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/dashboards/uid/6Lk9wMHik" {
+				w.WriteHeader(http.StatusBadRequest)
+			}
+		}))
+		defer ts.Close()
+
+		instance := &instance{
+			name:    "grafana",
+			address: ts.URL,
+			client:  ts.Client(),
+		}
+
+		_, err := instance.GetDashboardModel(context.Background(), "6Lk9wMHik")
+		require.Error(t, err)
+	})
+
+	t.Run("get dashboard model", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/dashboards/uid/6Lk9wMHik" {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"meta":{"type":"db","slug":"mongodb-overview"},"dashboard":{"id":19,"title":"MongoDB Overview","panels":[]}}`))
+			}
+		}))
+		defer ts.Close()
+
+		instance := &instance{
+			name:    "grafana",
+			address: ts.URL,
+			client:  ts.Client(),
+		}
+
+		model, err := instance.GetDashboardModel(context.Background(), "6Lk9wMHik")
+		require.NoError(t, err)
+		require.JSONEq(t, `{"id":19,"title":"MongoDB Overview","panels":[]}`, string(model))
+	})
+}
+
 func TestNew(t *testing.T) {
 	for _, tt := range []struct {
 		name    string
@@ -1,10 +1,12 @@
 package grafana
 
 import (
+	"io"
 	"net/http"
 
 	"github.com/kobsio/kobs/pkg/hub/clusters"
 	"github.com/kobsio/kobs/pkg/instrument/log"
+	"github.com/kobsio/kobs/pkg/plugins/grafana/converter"
 	"github.com/kobsio/kobs/pkg/plugins/grafana/instance"
 	"github.com/kobsio/kobs/pkg/plugins/plugin"
 	"github.com/kobsio/kobs/pkg/utils/middleware/errresponse"
@@ -83,6 +85,62 @@ func (router *Router) getDashboards(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, dashboards)
 }
 
+// convertDashboard converts a Grafana dashboard into a kobs dashboard. If the request contains a "uid" parameter, the
+// dashboard is loaded from the Grafana instance, otherwise the Grafana dashboard JSON must be provided in the request
+// body. The "cluster", "prometheus", "elasticsearch" and "dataView" parameters are used to set the plugin instances for
+// the converted panels and variables. The response contains the converted dashboard and all panels and variables,
+// which could not be converted.
+func (router *Router) convertDashboard(w http.ResponseWriter, r *http.Request) {
+	name := r.Header.Get("x-kobs-plugin")
+	uid := r.URL.Query().Get("uid")
+	options := converter.Options{
+		Cluster:       r.URL.Query().Get("cluster"),
+		Prometheus:    r.URL.Query().Get("prometheus"),
+		Elasticsearch: r.URL.Query().Get("elasticsearch"),
+		DataView:      r.URL.Query().Get("dataView"),
+	}
+
+	log.Debug(r.Context(), "convertDashboard", zap.String("name", name), zap.String("uid", uid), zap.String("cluster", options.Cluster), zap.String("prometheus", options.Prometheus), zap.String("elasticsearch", options.Elasticsearch), zap.String("dataView", options.DataView))
+
+	var data []byte
+
+	if uid != "" {
+		i := router.getInstance(name)
+		if i == nil {
+			log.Error(r.Context(), "Invalid plugin instance", zap.String("name", name))
+			errresponse.Render(w, r, http.StatusBadRequest, "Invalid plugin instance")
+			return
+		}
+
+		model, err := i.GetDashboardModel(r.Context(), uid)
+		if err != nil {
+			log.Error(r.Context(), "Failed to get dashboard", zap.Error(err))
+			errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get dashboard")
+			return
+		}
+
+		data = model
+	} else {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			log.Error(r.Context(), "Failed to read request body", zap.Error(err))
+			errresponse.Render(w, r, http.StatusBadRequest, "Failed to read request body")
+			return
+		}
+
+		data = body
+	}
+
+	result, err := converter.Convert(data, options)
+	if err != nil {
+		log.Error(r.Context(), "Failed to convert dashboard", zap.Error(err))
+		errresponse.Render(w, r, http.StatusBadRequest, "Failed to convert dashboard")
+		return
+	}
+
+	render.JSON(w, r, result)
+}
+
 func Mount(instances []plugin.Instance, clustersClient clusters.Client) (chi.Router, error) {
 	var grafanaInstances []instance.Instance
 
@@ -102,6 +160,7 @@ func Mount(instances []plugin.Instance, clustersClient clusters.Client) (chi.Rou
 	proxy := pluginproxy.New(clustersClient)
 
 	router.With(proxy).Get("/dashboards", router.getDashboards)
+	router.With(proxy).Post("/convert", router.convertDashboard)
 
 	return router, nil
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/kobsio/kobs/pkg/plugins/grafana/instance"
@@ -132,6 +133,84 @@ func TestGetDashboards(t *testing.T) {
 	})
 }
 
+func TestConvertDashboard(t *testing.T) {
+	var newRouter = func(t *testing.T) (*instance.MockInstance, Router) {
+		ctrl := gomock.NewController(t)
+		mockInstance := instance.NewMockInstance(ctrl)
+		router := Router{chi.NewRouter(), []instance.Instance{mockInstance}}
+
+		return mockInstance, router
+	}
+
+	t.Run("should fail for invalid instance name", func(t *testing.T) {
+		i, router := newRouter(t)
+		i.EXPECT().GetName().Return("grafana")
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/convert?uid=test", nil)
+		req.Header.Set("x-kobs-plugin", "invalidname")
+		w := httptest.NewRecorder()
+
+		router.convertDashboard(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusBadRequest)
+		utils.AssertJSONEq(t, w, `{"errors": ["Invalid plugin instance"]}`)
+	})
+
+	t.Run("should return error on GetDashboardModel error", func(t *testing.T) {
+		i, router := newRouter(t)
+		i.EXPECT().GetName().Return("grafana")
+		i.EXPECT().GetDashboardModel(gomock.Any(), "test").Return(nil, fmt.Errorf("unexpected error"))
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/convert?uid=test", nil)
+		req.Header.Set("x-kobs-plugin", "grafana")
+		w := httptest.NewRecorder()
+
+		router.convertDashboard(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get dashboard"]}`)
+	})
+
+	t.Run("should return error for invalid dashboard", func(t *testing.T) {
+		_, router := newRouter(t)
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/convert", strings.NewReader(`[`))
+		w := httptest.NewRecorder()
+
+		router.convertDashboard(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusBadRequest)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to convert dashboard"]}`)
+	})
+
+	t.Run("should convert dashboard from instance", func(t *testing.T) {
+		i, router := newRouter(t)
+		i.EXPECT().GetName().Return("grafana")
+		i.EXPECT().GetDashboardModel(gomock.Any(), "test").Return([]byte(`{"title":"Test","panels":[{"title":"Text","type":"text"}]}`), nil)
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/convert?uid=test", nil)
+		req.Header.Set("x-kobs-plugin", "grafana")
+		w := httptest.NewRecorder()
+
+		router.convertDashboard(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `{"dashboard":{"title":"Test","rows":[{"panels":[]}]},"unconverted":[{"kind":"panel","title":"Text","type":"text","reason":"panels of type \"text\" without queries are not supported"}]}`)
+	})
+
+	t.Run("should convert dashboard from request body", func(t *testing.T) {
+		_, router := newRouter(t)
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/convert?cluster=dev&prometheus=prom", strings.NewReader(`{"title":"Test","panels":[{"title":"Up","type":"stat","datasource":"Prometheus","gridPos":{"w":24,"h":4},"targets":[{"expr":"up"}]}]}`))
+		w := httptest.NewRecorder()
+
+		router.convertDashboard(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `{"dashboard":{"title":"Test","rows":[{"panels":[{"title":"Up","w":12,"h":4,"plugin":{"type":"prometheus","cluster":"dev","name":"prom","options":{"type":"sparkline","queries":[{"query":"up"}]}}}]}]},"unconverted":[]}`)
+	})
+}
+
 func TestMount(t *testing.T) {
 	t.Run("should return error for invalid options", func(t *testing.T) {
 		router, err := Mount([]plugin.Instance{{Name: "grafana", Options: map[string]any{"address": []string{"localhost"}}}}, nil)
@@ -30,7 +30,7 @@ type client struct {
 }
 
 func (c *client) List(ctx context.Context, namespace string) ([]*Release, error) {
-	secretsData, err := c.kubernetesClient.GetResources(ctx, namespace, "", "/api/v1", "secrets", "labelSelector", "owner=helm")
+	secretsData, err := c.kubernetesClient.GetResources(ctx, namespace, "", "/api/v1", "secrets", "labelSelector", "owner=helm", kubernetes.ListOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +72,7 @@ func (c *client) List(ctx context.Context, namespace string) ([]*Release, error)
 }
 
 func (c *client) Get(ctx context.Context, namespace, name string, version int) (*Release, error) {
-	secretsData, err := c.kubernetesClient.GetResources(ctx, namespace, "", "/api/v1", "secrets", "labelSelector", fmt.Sprintf("name=%s,owner=helm,version=%d", name, version))
+	secretsData, err := c.kubernetesClient.GetResources(ctx, namespace, "", "/api/v1", "secrets", "labelSelector", fmt.Sprintf("name=%s,owner=helm,version=%d", name, version), kubernetes.ListOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -91,7 +91,7 @@ func (c *client) Get(ctx context.Context, namespace, name string, version int) (
 }
 
 func (c *client) History(ctx context.Context, namespace, name string) ([]*Release, error) {
-	secretsData, err := c.kubernetesClient.GetResources(ctx, namespace, "", "/api/v1", "secrets", "labelSelector", fmt.Sprintf("name=%s,owner=helm", name))
+	secretsData, err := c.kubernetesClient.GetResources(ctx, namespace, "", "/api/v1", "secrets", "labelSelector", fmt.Sprintf("name=%s,owner=helm", name), kubernetes.ListOptions{})
 	if err != nil {
 		return nil, err
 	}
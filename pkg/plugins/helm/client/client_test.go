@@ -15,10 +15,10 @@ func TestList(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	kubernetesClient := kubernetes.NewMockClient(ctrl)
 
-	kubernetesClient.EXPECT().GetResources(gomock.Any(), "namespace1", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("could not get secrets"))
-	kubernetesClient.EXPECT().GetResources(gomock.Any(), "namespace2", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte(`foobar`), nil)
-	kubernetesClient.EXPECT().GetResources(gomock.Any(), "namespace3", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte(`{"items":[{"data":{"release":"H4sIACQY1mEAA6tWykvMTVWyUsrOTypW0gHzigsSk5GEylKLijPz85SsDGsBlraqGy4AAAA="},"metadata":{"labels":{"name":"kobs","owner":"helm","version":"1"},"name":"sh.helm.release.v1.kobs.v124","namespace":"kobs"}},{"data":{"release":"H4sIAC0Y1mEAA6tWykvMTVWyUsrOTypW0gHzigsSk5GEylKLijPz85SsjGoBVeWHMC4AAAA="},"metadata":{"labels":{"name":"kobs","owner":"helm","version":"2"},"name":"sh.helm.release.v1.kobs.v125","namespace":"kobs"}}]}`), nil)
-	kubernetesClient.EXPECT().GetResources(gomock.Any(), "namespace4", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte(`{"items":[{"data":{"release":"SDRzSUFLY2IxbUVBQTZ0V3lrdk1UVld5VXNyT1R5cFcwZ0h6aWdzU2s1R0V5bEtMaWpQejg1U3NER3NCbHJhcUd5NEFBQUE9Cg=="},"metadata":{"labels":{"name":"kobs","owner":"helm","version":"1"},"name":"sh.helm.release.v1.kobs.v124","namespace":"kobs"}},{"data":{"release":"SDRzSUFKRWIxbUVBQTZ0V3lrdk1UVld5VXNyT1R5cFcwZ0h6aWdzU2s1R0V5bEtMaWpQejg1U3NqR29CVmVXSE1DNEFBQUE9Cg=="},"metadata":{"labels":{"name":"kobs","owner":"helm","version":"2"},"name":"sh.helm.release.v1.kobs.v125","namespace":"kobs"}}]}`), nil)
+	kubernetesClient.EXPECT().GetResources(gomock.Any(), "namespace1", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("could not get secrets"))
+	kubernetesClient.EXPECT().GetResources(gomock.Any(), "namespace2", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte(`foobar`), nil)
+	kubernetesClient.EXPECT().GetResources(gomock.Any(), "namespace3", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte(`{"items":[{"data":{"release":"H4sIACQY1mEAA6tWykvMTVWyUsrOTypW0gHzigsSk5GEylKLijPz85SsDGsBlraqGy4AAAA="},"metadata":{"labels":{"name":"kobs","owner":"helm","version":"1"},"name":"sh.helm.release.v1.kobs.v124","namespace":"kobs"}},{"data":{"release":"H4sIAC0Y1mEAA6tWykvMTVWyUsrOTypW0gHzigsSk5GEylKLijPz85SsjGoBVeWHMC4AAAA="},"metadata":{"labels":{"name":"kobs","owner":"helm","version":"2"},"name":"sh.helm.release.v1.kobs.v125","namespace":"kobs"}}]}`), nil)
+	kubernetesClient.EXPECT().GetResources(gomock.Any(), "namespace4", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte(`{"items":[{"data":{"release":"SDRzSUFLY2IxbUVBQTZ0V3lrdk1UVld5VXNyT1R5cFcwZ0h6aWdzU2s1R0V5bEtMaWpQejg1U3NER3NCbHJhcUd5NEFBQUE9Cg=="},"metadata":{"labels":{"name":"kobs","owner":"helm","version":"1"},"name":"sh.helm.release.v1.kobs.v124","namespace":"kobs"}},{"data":{"release":"SDRzSUFKRWIxbUVBQTZ0V3lrdk1UVld5VXNyT1R5cFcwZ0h6aWdzU2s1R0V5bEtMaWpQejg1U3NqR29CVmVXSE1DNEFBQUE9Cg=="},"metadata":{"labels":{"name":"kobs","owner":"helm","version":"2"},"name":"sh.helm.release.v1.kobs.v125","namespace":"kobs"}}]}`), nil)
 
 	helmClient := client{
 		kubernetesClient: kubernetesClient,
@@ -54,11 +54,11 @@ func TestGet(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	kubernetesClient := kubernetes.NewMockClient(ctrl)
 
-	kubernetesClient.EXPECT().GetResources(gomock.Any(), "namespace1", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("could not get secrets"))
-	kubernetesClient.EXPECT().GetResources(gomock.Any(), "namespace2", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte(`foobar`), nil)
-	kubernetesClient.EXPECT().GetResources(gomock.Any(), "namespace3", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte(`{"items":[{"data":{"release":"H4sIACQY1mEAA6tWykvMTVWyUsrOTypW0gHzigsSk5GEylKLijPz85SsDGsBlraqGy4AAAA="},"metadata":{"labels":{"name":"kobs","owner":"helm","version":"1"},"name":"sh.helm.release.v1.kobs.v124","namespace":"kobs"}},{"data":{"release":"H4sIAC0Y1mEAA6tWykvMTVWyUsrOTypW0gHzigsSk5GEylKLijPz85SsjGoBVeWHMC4AAAA="},"metadata":{"labels":{"name":"kobs","owner":"helm","version":"2"},"name":"sh.helm.release.v1.kobs.v125","namespace":"kobs"}}]}`), nil)
-	kubernetesClient.EXPECT().GetResources(gomock.Any(), "namespace4", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte(`{"items":[{"data":{"release":"H4sIACQY1mEAA6tWykvMTVWyUsrOTypW0gHzigsSk5GEylKLijPz85SsDGsBlraqGy4AAAA="},"metadata":{"labels":{"name":"kobs","owner":"helm","version":"1"},"name":"sh.helm.release.v1.kobs.v124","namespace":"kobs"}}]}`), nil)
-	kubernetesClient.EXPECT().GetResources(gomock.Any(), "namespace5", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte(`{"items":[{"data":{"release":"SDRzSUFLY2IxbUVBQTZ0V3lrdk1UVld5VXNyT1R5cFcwZ0h6aWdzU2s1R0V5bEtMaWpQejg1U3NER3NCbHJhcUd5NEFBQUE9Cg=="},"metadata":{"labels":{"name":"kobs","owner":"helm","version":"1"},"name":"sh.helm.release.v1.kobs.v124","namespace":"kobs"}}]}`), nil)
+	kubernetesClient.EXPECT().GetResources(gomock.Any(), "namespace1", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("could not get secrets"))
+	kubernetesClient.EXPECT().GetResources(gomock.Any(), "namespace2", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte(`foobar`), nil)
+	kubernetesClient.EXPECT().GetResources(gomock.Any(), "namespace3", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte(`{"items":[{"data":{"release":"H4sIACQY1mEAA6tWykvMTVWyUsrOTypW0gHzigsSk5GEylKLijPz85SsDGsBlraqGy4AAAA="},"metadata":{"labels":{"name":"kobs","owner":"helm","version":"1"},"name":"sh.helm.release.v1.kobs.v124","namespace":"kobs"}},{"data":{"release":"H4sIAC0Y1mEAA6tWykvMTVWyUsrOTypW0gHzigsSk5GEylKLijPz85SsjGoBVeWHMC4AAAA="},"metadata":{"labels":{"name":"kobs","owner":"helm","version":"2"},"name":"sh.helm.release.v1.kobs.v125","namespace":"kobs"}}]}`), nil)
+	kubernetesClient.EXPECT().GetResources(gomock.Any(), "namespace4", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte(`{"items":[{"data":{"release":"H4sIACQY1mEAA6tWykvMTVWyUsrOTypW0gHzigsSk5GEylKLijPz85SsDGsBlraqGy4AAAA="},"metadata":{"labels":{"name":"kobs","owner":"helm","version":"1"},"name":"sh.helm.release.v1.kobs.v124","namespace":"kobs"}}]}`), nil)
+	kubernetesClient.EXPECT().GetResources(gomock.Any(), "namespace5", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte(`{"items":[{"data":{"release":"SDRzSUFLY2IxbUVBQTZ0V3lrdk1UVld5VXNyT1R5cFcwZ0h6aWdzU2s1R0V5bEtMaWpQejg1U3NER3NCbHJhcUd5NEFBQUE9Cg=="},"metadata":{"labels":{"name":"kobs","owner":"helm","version":"1"},"name":"sh.helm.release.v1.kobs.v124","namespace":"kobs"}}]}`), nil)
 
 	helmClient := client{
 		kubernetesClient: kubernetesClient,
@@ -100,10 +100,10 @@ func TestHistory(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	kubernetesClient := kubernetes.NewMockClient(ctrl)
 
-	kubernetesClient.EXPECT().GetResources(gomock.Any(), "namespace1", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("could not get secrets"))
-	kubernetesClient.EXPECT().GetResources(gomock.Any(), "namespace2", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte(`foobar`), nil)
-	kubernetesClient.EXPECT().GetResources(gomock.Any(), "namespace3", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte(`{"items":[{"data":{"release":"H4sIACQY1mEAA6tWykvMTVWyUsrOTypW0gHzigsSk5GEylKLijPz85SsDGsBlraqGy4AAAA="},"metadata":{"labels":{"name":"kobs","owner":"helm","version":"1"},"name":"sh.helm.release.v1.kobs.v124","namespace":"kobs"}},{"data":{"release":"H4sIAC0Y1mEAA6tWykvMTVWyUsrOTypW0gHzigsSk5GEylKLijPz85SsjGoBVeWHMC4AAAA="},"metadata":{"labels":{"name":"kobs","owner":"helm","version":"2"},"name":"sh.helm.release.v1.kobs.v125","namespace":"kobs"}}]}`), nil)
-	kubernetesClient.EXPECT().GetResources(gomock.Any(), "namespace4", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte(`{"items":[{"data":{"release":"SDRzSUFLY2IxbUVBQTZ0V3lrdk1UVld5VXNyT1R5cFcwZ0h6aWdzU2s1R0V5bEtMaWpQejg1U3NER3NCbHJhcUd5NEFBQUE9Cg=="},"metadata":{"labels":{"name":"kobs","owner":"helm","version":"1"},"name":"sh.helm.release.v1.kobs.v124","namespace":"kobs"}},{"data":{"release":"SDRzSUFKRWIxbUVBQTZ0V3lrdk1UVld5VXNyT1R5cFcwZ0h6aWdzU2s1R0V5bEtMaWpQejg1U3NqR29CVmVXSE1DNEFBQUE9Cg=="},"metadata":{"labels":{"name":"kobs","owner":"helm","version":"2"},"name":"sh.helm.release.v1.kobs.v125","namespace":"kobs"}}]}`), nil)
+	kubernetesClient.EXPECT().GetResources(gomock.Any(), "namespace1", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("could not get secrets"))
+	kubernetesClient.EXPECT().GetResources(gomock.Any(), "namespace2", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte(`foobar`), nil)
+	kubernetesClient.EXPECT().GetResources(gomock.Any(), "namespace3", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte(`{"items":[{"data":{"release":"H4sIACQY1mEAA6tWykvMTVWyUsrOTypW0gHzigsSk5GEylKLijPz85SsDGsBlraqGy4AAAA="},"metadata":{"labels":{"name":"kobs","owner":"helm","version":"1"},"name":"sh.helm.release.v1.kobs.v124","namespace":"kobs"}},{"data":{"release":"H4sIAC0Y1mEAA6tWykvMTVWyUsrOTypW0gHzigsSk5GEylKLijPz85SsjGoBVeWHMC4AAAA="},"metadata":{"labels":{"name":"kobs","owner":"helm","version":"2"},"name":"sh.helm.release.v1.kobs.v125","namespace":"kobs"}}]}`), nil)
+	kubernetesClient.EXPECT().GetResources(gomock.Any(), "namespace4", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte(`{"items":[{"data":{"release":"SDRzSUFLY2IxbUVBQTZ0V3lrdk1UVld5VXNyT1R5cFcwZ0h6aWdzU2s1R0V5bEtMaWpQejg1U3NER3NCbHJhcUd5NEFBQUE9Cg=="},"metadata":{"labels":{"name":"kobs","owner":"helm","version":"1"},"name":"sh.helm.release.v1.kobs.v124","namespace":"kobs"}},{"data":{"release":"SDRzSUFKRWIxbUVBQTZ0V3lrdk1UVld5VXNyT1R5cFcwZ0h6aWdzU2s1R0V5bEtMaWpQejg1U3NqR29CVmVXSE1DNEFBQUE9Cg=="},"metadata":{"labels":{"name":"kobs","owner":"helm","version":"2"},"name":"sh.helm.release.v1.kobs.v125","namespace":"kobs"}}]}`), nil)
 
 	helmClient := client{
 		kubernetesClient: kubernetesClient,
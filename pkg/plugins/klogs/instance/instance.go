@@ -42,6 +42,7 @@ type Instance interface {
 	GetFields(filter string, fieldType string) []string
 	GetLogs(ctx context.Context, query, order, orderBy string, limit, timeStart, timeEnd int64) ([]map[string]any, []Field, int64, int64, []Bucket, error)
 	GetAggregation(ctx context.Context, aggregation Aggregation) ([]map[string]any, []string, error)
+	Close() error
 }
 
 // Rows is an interface closely related to the sql.Rows interface
@@ -58,6 +59,7 @@ type Rows interface {
 // Defining this interface here allows us to replace the database implementation with a mock in the tests.
 type Querier interface {
 	QueryContext(ctx context.Context, query string, args ...any) (Rows, error)
+	Close() error
 }
 
 // Instance represents a single klogs instance, which can be added via the configuration file.
@@ -69,12 +71,19 @@ type instance struct {
 	cachedFields        Fields
 	defaultFields       []string
 	sqlParser           parser.SQLParser
+	done                chan struct{}
 }
 
 func (i *instance) GetName() string {
 	return i.name
 }
 
+// Close stops the refresh of the cached fields and closes the connections to ClickHouse.
+func (i *instance) Close() error {
+	close(i.done)
+	return i.querier.Close()
+}
+
 func (i *instance) getFields() (Fields, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
 	defer cancel()
@@ -112,7 +121,8 @@ func (i *instance) getFields() (Fields, error) {
 }
 
 // refreshCachedFields retrieves all fields for the last 24 hours and merges them with the already cached fields. To get
-// the initial list of cached fields we are running the query before starting the ticker.
+// the initial list of cached fields we are running the query before starting the ticker. The fields are refreshed until
+// the instance is closed.
 func (i *instance) refreshCachedFields() []string {
 	ctx := context.Background()
 
@@ -128,7 +138,11 @@ func (i *instance) refreshCachedFields() []string {
 	defer ticker.Stop()
 
 	for {
-		<-ticker.C
+		select {
+		case <-i.done:
+			return nil
+		case <-ticker.C:
+		}
 
 		fields, err := i.getFields()
 		if err != nil {
@@ -187,6 +201,11 @@ func (s *sqlQuerier) QueryContext(ctx context.Context, query string, args ...any
 	return Rows(rows), nil
 }
 
+// Close closes the connections to ClickHouse.
+func (s *sqlQuerier) Close() error {
+	return s.client.Close()
+}
+
 func newQuerierFromConfig(config Config) Querier {
 	parsedDialTimeout := 10 * time.Second
 	if config.DialTimeout != "" {
@@ -244,6 +263,7 @@ func New(name string, options map[string]any) (Instance, error) {
 		defaultFields:       defaultFields,
 		materializedColumns: config.MaterializedColumns,
 		sqlParser:           parser.NewSQLParser(defaultFields, nil),
+		done:                make(chan struct{}),
 	}
 
 	go instance.refreshCachedFields()
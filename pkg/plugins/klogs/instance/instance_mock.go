@@ -34,6 +34,20 @@ func (m *MockInstance) EXPECT() *MockInstanceMockRecorder {
 	return m.recorder
 }
 
+// Close mocks base method.
+func (m *MockInstance) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockInstanceMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockInstance)(nil).Close))
+}
+
 // GetAggregation mocks base method.
 func (m *MockInstance) GetAggregation(ctx context.Context, aggregation Aggregation) ([]map[string]any, []string, error) {
 	m.ctrl.T.Helper()
@@ -247,6 +261,20 @@ func (m *MockQuerier) EXPECT() *MockQuerierMockRecorder {
 	return m.recorder
 }
 
+// Close mocks base method.
+func (m *MockQuerier) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockQuerierMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockQuerier)(nil).Close))
+}
+
 // QueryContext mocks base method.
 func (m *MockQuerier) QueryContext(ctx context.Context, query string, args ...any) (Rows, error) {
 	m.ctrl.T.Helper()
@@ -127,3 +127,15 @@ func Test_GetName(t *testing.T) {
 	i := &instance{name: "instance_name"}
 	require.Equal(t, "instance_name", i.GetName())
 }
+
+func TestClose(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	querier := NewMockQuerier(ctrl)
+	querier.EXPECT().Close().Return(nil)
+
+	i := &instance{querier: querier, done: make(chan struct{})}
+	require.NoError(t, i.Close())
+
+	_, ok := <-i.done
+	require.False(t, ok)
+}
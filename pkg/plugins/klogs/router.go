@@ -2,6 +2,7 @@ package klogs
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -222,18 +223,38 @@ func (router *Router) getAggregation(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, data)
 }
 
+// Close closes all klogs instances of the router. It should be called when the router is not used anymore, e.g. when
+// the plugin instances are reloaded, so that the connections to the databases are closed.
+func (router *Router) Close() error {
+	return closeInstances(router.instances)
+}
+
+// closeInstances closes all provided klogs instances and returns the errors of all instances, which could not be
+// closed.
+func closeInstances(instances []instance.Instance) error {
+	var errs []error
+	for _, i := range instances {
+		if err := i.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 func (p *Plugin) Mount(instances []plugin.Instance, clustersClient clusters.Client) (chi.Router, error) {
 	var klogsInstances []instance.Instance
 
 	for _, i := range instances {
 		klogsInstance, err := instance.New(i.Name, i.Options)
 		if err != nil {
+			closeInstances(klogsInstances)
 			return nil, err
 		}
 		klogsInstances = append(klogsInstances, klogsInstance)
 	}
 
-	router := Router{
+	router := &Router{
 		chi.NewRouter(),
 		klogsInstances,
 	}
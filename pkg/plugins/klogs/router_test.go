@@ -263,3 +263,27 @@ func TestGetAggregation(t *testing.T) {
 		utils.AssertJSONEq(t, w, `{"errors":["Failed to get aggragation result"]}`)
 	})
 }
+
+func TestClose(t *testing.T) {
+	t.Run("should close all instances", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockInstance1 := instance.NewMockInstance(ctrl)
+		mockInstance1.EXPECT().Close().Return(nil)
+		mockInstance2 := instance.NewMockInstance(ctrl)
+		mockInstance2.EXPECT().Close().Return(nil)
+
+		router := Router{instances: []instance.Instance{mockInstance1, mockInstance2}}
+		require.NoError(t, router.Close())
+	})
+
+	t.Run("should close all instances when an instance returns an error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockInstance1 := instance.NewMockInstance(ctrl)
+		mockInstance1.EXPECT().Close().Return(fmt.Errorf("unexpected error"))
+		mockInstance2 := instance.NewMockInstance(ctrl)
+		mockInstance2.EXPECT().Close().Return(nil)
+
+		router := Router{instances: []instance.Instance{mockInstance1, mockInstance2}}
+		require.EqualError(t, router.Close(), "unexpected error")
+	})
+}
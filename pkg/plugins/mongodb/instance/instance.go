@@ -34,6 +34,7 @@ type Instance interface {
 	UpdateMany(ctx context.Context, collectionName, filter, update string) (int64, int64, error)
 	DeleteMany(ctx context.Context, collectionName, filter string) (int64, error)
 	Aggregate(ctx context.Context, collectionName, pipeline string) ([]bson.D, error)
+	Close() error
 }
 
 type instance struct {
@@ -314,6 +315,14 @@ func (i *instance) Aggregate(ctx context.Context, collectionName, pipeline strin
 	return results, nil
 }
 
+// Close closes the connections to the MongoDB instance.
+func (i *instance) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	return i.mongoClient.Disconnect(ctx)
+}
+
 func New(name string, options map[string]any) (Instance, error) {
 	var config Config
 	err := mapstructure.Decode(options, &config)
@@ -50,6 +50,20 @@ func (mr *MockInstanceMockRecorder) Aggregate(ctx, collectionName, pipeline inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Aggregate", reflect.TypeOf((*MockInstance)(nil).Aggregate), ctx, collectionName, pipeline)
 }
 
+// Close mocks base method.
+func (m *MockInstance) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockInstanceMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockInstance)(nil).Close))
+}
+
 // Count mocks base method.
 func (m *MockInstance) Count(ctx context.Context, collectionName, filter string) (int64, error) {
 	m.ctrl.T.Helper()
@@ -2,6 +2,7 @@ package mongodb
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/kobsio/kobs/pkg/hub/clusters"
@@ -463,18 +464,38 @@ func (router *Router) aggregate(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, docs)
 }
 
+// Close closes all MongoDB instances of the router. It should be called when the router is not used anymore, e.g. when
+// the plugin instances are reloaded, so that the connections to the databases are closed.
+func (router *Router) Close() error {
+	return closeInstances(router.instances)
+}
+
+// closeInstances closes all provided MongoDB instances and returns the errors of all instances, which could not be
+// closed.
+func closeInstances(instances []instance.Instance) error {
+	var errs []error
+	for _, i := range instances {
+		if err := i.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 func Mount(instances []plugin.Instance, clustersClient clusters.Client) (chi.Router, error) {
 	var mongodbInstances []instance.Instance
 
 	for _, i := range instances {
 		mongodbInstance, err := instance.New(i.Name, i.Options)
 		if err != nil {
+			closeInstances(mongodbInstances)
 			return nil, err
 		}
 		mongodbInstances = append(mongodbInstances, mongodbInstance)
 	}
 
-	router := Router{
+	router := &Router{
 		chi.NewRouter(),
 		mongodbInstances,
 	}
@@ -15,6 +15,7 @@ import (
 	"github.com/opsgenie/opsgenie-go-sdk-v2/alert"
 	"github.com/opsgenie/opsgenie-go-sdk-v2/client"
 	"github.com/opsgenie/opsgenie-go-sdk-v2/incident"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/schedule"
 )
 
 // Config is the structure of the configuration for a single Opsgenie instance. The user can set some general
@@ -43,6 +44,7 @@ type Instance interface {
 	CloseAlert(ctx context.Context, id, user string) error
 	ResolveIncident(ctx context.Context, id, user string) error
 	CloseIncident(ctx context.Context, id, user string) error
+	GetOnCalls(ctx context.Context, scheduleName, rotationName string) ([]string, error)
 }
 
 type instance struct {
@@ -50,6 +52,7 @@ type instance struct {
 	alertClient            *alert.Client
 	incidentClient         *incident.Client
 	extendedIncidentClient *extendedIncident.Client
+	scheduleClient         *schedule.Client
 }
 
 // GetName returns the name of the current Opsgenie instance.
@@ -177,6 +180,39 @@ func (i *instance) CloseIncident(ctx context.Context, id, user string) error {
 	return err
 }
 
+// GetOnCalls returns the current on-call participants for the schedule with the provided name. If a rotation is
+// provided, we get the timeline of the schedule and only return the participants of the current period of the
+// rotation. The rotation can be referenced by its name or id.
+func (i *instance) GetOnCalls(ctx context.Context, scheduleName, rotationName string) ([]string, error) {
+	if rotationName == "" {
+		flat := true
+		res, err := i.scheduleClient.GetOnCalls(ctx, &schedule.GetOnCallsRequest{
+			Flat:                   &flat,
+			ScheduleIdentifierType: schedule.Name,
+			ScheduleIdentifier:     scheduleName,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return res.OnCallRecipients, nil
+	}
+
+	now := time.Now()
+	res, err := i.scheduleClient.GetTimeline(ctx, &schedule.GetTimelineRequest{
+		IdentifierType:  schedule.Name,
+		IdentifierValue: scheduleName,
+		Interval:        1,
+		IntervalUnit:    schedule.Days,
+		Date:            &now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return getRotationParticipants(res.FinalTimeline, rotationName, now)
+}
+
 // New returns a new Elasticsearch instance for the given configuration.
 func New(name string, options map[string]any) (Instance, error) {
 	var config Config
@@ -209,10 +245,37 @@ func New(name string, options map[string]any) (Instance, error) {
 		return nil, err
 	}
 
+	scheduleClient, err := schedule.NewClient(opsgenieConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	return &instance{
 		name:                   name,
 		alertClient:            alertClient,
 		incidentClient:         incidentClient,
 		extendedIncidentClient: extendedIncidentClient,
+		scheduleClient:         scheduleClient,
 	}, nil
 }
+
+// getRotationParticipants returns the participants of the provided rotation, which are on-call at the provided time.
+// If the timeline doesn't contain the rotation an error is returned.
+func getRotationParticipants(timeline schedule.Timeline, rotationName string, now time.Time) ([]string, error) {
+	for _, rotation := range timeline.Rotations {
+		if rotation.Name != rotationName && rotation.Id != rotationName {
+			continue
+		}
+
+		var participants []string
+		for _, period := range rotation.Periods {
+			if !period.StartDate.After(now) && period.EndDate.After(now) {
+				participants = append(participants, period.Recipient.Name)
+			}
+		}
+
+		return participants, nil
+	}
+
+	return nil, fmt.Errorf("rotation %q not found", rotationName)
+}
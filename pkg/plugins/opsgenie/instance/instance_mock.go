@@ -214,6 +214,21 @@ func (mr *MockInstanceMockRecorder) GetName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetName", reflect.TypeOf((*MockInstance)(nil).GetName))
 }
 
+// GetOnCalls mocks base method.
+func (m *MockInstance) GetOnCalls(ctx context.Context, scheduleName, rotationName string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOnCalls", ctx, scheduleName, rotationName)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOnCalls indicates an expected call of GetOnCalls.
+func (mr *MockInstanceMockRecorder) GetOnCalls(ctx, scheduleName, rotationName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOnCalls", reflect.TypeOf((*MockInstance)(nil).GetOnCalls), ctx, scheduleName, rotationName)
+}
+
 // ResolveIncident mocks base method.
 func (m *MockInstance) ResolveIncident(ctx context.Context, id, user string) error {
 	m.ctrl.T.Helper()
@@ -2,7 +2,10 @@ package instance
 
 import (
 	"testing"
+	"time"
 
+	"github.com/opsgenie/opsgenie-go-sdk-v2/og"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/schedule"
 	"github.com/stretchr/testify/require"
 )
 
@@ -14,6 +17,36 @@ func TestGetName(t *testing.T) {
 	require.Equal(t, "opsgenie", instance.GetName())
 }
 
+func TestGetRotationParticipants(t *testing.T) {
+	now := time.Now()
+	timeline := schedule.Timeline{Rotations: []schedule.TimelineRotation{
+		{Id: "id1", Name: "rotation1", Periods: []schedule.Period{
+			{StartDate: now.Add(-2 * time.Hour), EndDate: now.Add(-1 * time.Hour), Recipient: og.Participant{Name: "user1@kobs.io"}},
+			{StartDate: now.Add(-1 * time.Hour), EndDate: now.Add(1 * time.Hour), Recipient: og.Participant{Name: "user2@kobs.io"}},
+		}},
+		{Id: "id2", Name: "rotation2", Periods: []schedule.Period{
+			{StartDate: now.Add(-1 * time.Hour), EndDate: now.Add(1 * time.Hour), Recipient: og.Participant{Name: "user3@kobs.io"}},
+		}},
+	}}
+
+	t.Run("should return participants for rotation name", func(t *testing.T) {
+		participants, err := getRotationParticipants(timeline, "rotation1", now)
+		require.NoError(t, err)
+		require.Equal(t, []string{"user2@kobs.io"}, participants)
+	})
+
+	t.Run("should return participants for rotation id", func(t *testing.T) {
+		participants, err := getRotationParticipants(timeline, "id2", now)
+		require.NoError(t, err)
+		require.Equal(t, []string{"user3@kobs.io"}, participants)
+	})
+
+	t.Run("should return error for unknown rotation", func(t *testing.T) {
+		_, err := getRotationParticipants(timeline, "rotation3", now)
+		require.EqualError(t, err, `rotation "rotation3" not found`)
+	})
+}
+
 func TestNew(t *testing.T) {
 	t.Run("should return error for invalid options", func(t *testing.T) {
 		instance, err := New("opsgenie", map[string]any{"apiUrl": []string{"localhost"}})
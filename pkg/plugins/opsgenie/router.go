@@ -348,6 +348,38 @@ func (router *Router) closeIncident(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, nil)
 }
 
+// getOnCalls returns the current on-call participants for a schedule. If the rotation parameter is set, only the
+// participants of this rotation are returned.
+func (router *Router) getOnCalls(w http.ResponseWriter, r *http.Request) {
+	name := r.Header.Get("x-kobs-plugin")
+	schedule := r.URL.Query().Get("schedule")
+	rotation := r.URL.Query().Get("rotation")
+
+	log.Debug(r.Context(), "getOnCalls", zap.String("name", name), zap.String("schedule", schedule), zap.String("rotation", rotation))
+
+	i := router.getInstance(name)
+	if i == nil {
+		log.Error(r.Context(), "Invalid plugin instance", zap.String("name", name))
+		errresponse.Render(w, r, http.StatusBadRequest, "Invalid plugin instance")
+		return
+	}
+
+	if schedule == "" {
+		log.Error(r.Context(), "Schedule is missing")
+		errresponse.Render(w, r, http.StatusBadRequest, "Schedule is missing")
+		return
+	}
+
+	participants, err := i.GetOnCalls(r.Context(), schedule, rotation)
+	if err != nil {
+		log.Error(r.Context(), "Failed to get on-call participants", zap.Error(err))
+		errresponse.Render(w, r, http.StatusInternalServerError, "Failed to get on-call participants")
+		return
+	}
+
+	render.JSON(w, r, participants)
+}
+
 func Mount(instances []plugin.Instance) (chi.Router, error) {
 	var opsgenieInstances []instance.Instance
 
@@ -377,6 +409,7 @@ func Mount(instances []plugin.Instance) (chi.Router, error) {
 	router.Get("/incident/timeline", router.getIncidentTimeline)
 	router.Get("/incident/resolve", router.resolveIncident)
 	router.Get("/incident/close", router.closeIncident)
+	router.Get("/oncall", router.getOnCalls)
 
 	return router, nil
 }
@@ -799,6 +799,74 @@ func TestCloseIncident(t *testing.T) {
 	})
 }
 
+func TestGetOnCalls(t *testing.T) {
+	var newRouter = func(t *testing.T) (*instance.MockInstance, Router) {
+		ctrl := gomock.NewController(t)
+		mockInstance := instance.NewMockInstance(ctrl)
+		router := Router{chi.NewRouter(), []instance.Instance{mockInstance}}
+
+		return mockInstance, router
+	}
+
+	t.Run("should fail for invalid instance name", func(t *testing.T) {
+		i, router := newRouter(t)
+		i.EXPECT().GetName().Return("opsgenie")
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/oncall?schedule=schedule1", nil)
+		req.Header.Set("x-kobs-plugin", "invalidname")
+		w := httptest.NewRecorder()
+
+		router.getOnCalls(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusBadRequest)
+		utils.AssertJSONEq(t, w, `{"errors": ["Invalid plugin instance"]}`)
+	})
+
+	t.Run("should fail for missing schedule", func(t *testing.T) {
+		i, router := newRouter(t)
+		i.EXPECT().GetName().Return("opsgenie")
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/oncall", nil)
+		req.Header.Set("x-kobs-plugin", "opsgenie")
+		w := httptest.NewRecorder()
+
+		router.getOnCalls(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusBadRequest)
+		utils.AssertJSONEq(t, w, `{"errors": ["Schedule is missing"]}`)
+	})
+
+	t.Run("should fail when instance returns an error", func(t *testing.T) {
+		i, router := newRouter(t)
+		i.EXPECT().GetName().Return("opsgenie")
+		i.EXPECT().GetOnCalls(gomock.Any(), "schedule1", "rotation1").Return(nil, fmt.Errorf("unexpected error"))
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/oncall?schedule=schedule1&rotation=rotation1", nil)
+		req.Header.Set("x-kobs-plugin", "opsgenie")
+		w := httptest.NewRecorder()
+
+		router.getOnCalls(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusInternalServerError)
+		utils.AssertJSONEq(t, w, `{"errors": ["Failed to get on-call participants"]}`)
+	})
+
+	t.Run("should return on-call participants", func(t *testing.T) {
+		i, router := newRouter(t)
+		i.EXPECT().GetName().Return("opsgenie")
+		i.EXPECT().GetOnCalls(gomock.Any(), "schedule1", "").Return([]string{"user1@kobs.io"}, nil)
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/oncall?schedule=schedule1", nil)
+		req.Header.Set("x-kobs-plugin", "opsgenie")
+		w := httptest.NewRecorder()
+
+		router.getOnCalls(w, req)
+
+		utils.AssertStatusEq(t, w, http.StatusOK)
+		utils.AssertJSONEq(t, w, `["user1@kobs.io"]`)
+	})
+}
+
 func TestMount(t *testing.T) {
 	t.Run("should return error for invalid instance", func(t *testing.T) {
 		router, err := Mount([]plugin.Instance{{Name: "opsgenie"}})
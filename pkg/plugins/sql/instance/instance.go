@@ -28,6 +28,7 @@ type Instance interface {
 	GetName() string
 	GetCompletions() map[string][]string
 	GetQueryResults(ctx context.Context, query string) ([]map[string]any, []string, error)
+	Close() error
 }
 
 type instance struct {
@@ -36,6 +37,7 @@ type instance struct {
 	name               string
 	completions        map[string][]string
 	completionProvider CompletionProvider
+	done               chan struct{}
 }
 
 func (i *instance) GetName() string {
@@ -54,6 +56,12 @@ func (i *instance) GetQueryResults(ctx context.Context, query string) ([]map[str
 	return i.querier.GetQueryResults(ctx, query)
 }
 
+// Close stops the refresh of the completions and closes the connections to the database.
+func (i *instance) Close() error {
+	close(i.done)
+	return i.querier.Close()
+}
+
 func dialectFromDriver(driver string) string {
 	if driver == "postgres" {
 		return "postgres"
@@ -84,12 +92,16 @@ func (i *instance) updateCompletions() {
 	}
 }
 
-// refreshCompletions fetches the completions for this instance in a regular interval
+// refreshCompletions fetches the completions for this instance in a regular interval until the instance is closed
 func (i *instance) refreshCompletions() {
 	i.updateCompletions()
 	for {
-		<-time.After(1 * time.Hour)
-		i.updateCompletions()
+		select {
+		case <-i.done:
+			return
+		case <-time.After(1 * time.Hour):
+			i.updateCompletions()
+		}
 	}
 }
 
@@ -129,6 +141,7 @@ func New(name string, options map[string]any) (*instance, error) {
 				bigqueryDatasetName: config.Database,
 			}),
 			dialect: dialectFromDriver(config.Driver),
+			done:    make(chan struct{}),
 		}
 		go instance.refreshCompletions()
 
@@ -144,6 +157,7 @@ func New(name string, options map[string]any) (*instance, error) {
 		name:               name,
 		completionProvider: newCompletionProvider(querier, completionConfig{driver: config.Driver, databaseName: config.Database}),
 		dialect:            dialectFromDriver(config.Driver),
+		done:               make(chan struct{}),
 	}
 
 	go instance.refreshCompletions()
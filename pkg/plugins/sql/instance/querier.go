@@ -20,6 +20,8 @@ import (
 type Querier interface {
 	// GetQueryResults returns all rows for the user provided SQL query.
 	GetQueryResults(ctx context.Context, query string) ([]map[string]any, []string, error)
+	// Close closes the connections to the database.
+	Close() error
 }
 
 // NewStandardQuerier returns a Querier for databases that support database/sql interface
@@ -44,6 +46,10 @@ type querier struct {
 	client *sql.DB
 }
 
+func (q *querier) Close() error {
+	return q.client.Close()
+}
+
 func (q *querier) GetQueryResults(ctx context.Context, query string) ([]map[string]any, []string, error) {
 	rows, err := q.client.QueryContext(ctx, query)
 	if err != nil {
@@ -101,6 +107,10 @@ type bigqueryQuerier struct {
 	defaultDataset string
 }
 
+func (bq *bigqueryQuerier) Close() error {
+	return bq.client.Close()
+}
+
 func (bq *bigqueryQuerier) GetQueryResults(ctx context.Context, query string) ([]map[string]any, []string, error) {
 	q := bq.client.Query(query)
 	q.DefaultDatasetID = bq.defaultDataset
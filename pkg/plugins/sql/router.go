@@ -1,6 +1,7 @@
 package sql
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/kobsio/kobs/pkg/hub/clusters"
@@ -90,19 +91,39 @@ func (router *Router) getMetaInfo(w http.ResponseWriter, r *http.Request) {
 }
 
 // Mount mounts the SQL plugin routes in the plugins router of a kobs satellite instance.
+// Close closes all SQL instances of the router. It should be called when the router is not used anymore, e.g. when
+// the plugin instances are reloaded, so that the connections to the databases are closed.
+func (router *Router) Close() error {
+	return closeInstances(router.instances)
+}
+
+// closeInstances closes all provided SQL instances and returns the errors of all instances, which could not be
+// closed.
+func closeInstances(instances []instance.Instance) error {
+	var errs []error
+	for _, i := range instances {
+		if err := i.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 func Mount(instances []plugin.Instance, clustersClient clusters.Client) (chi.Router, error) {
 	var sqlInstances []instance.Instance
 
 	for _, i := range instances {
 		sqlInstance, err := instance.New(i.Name, i.Options)
 		if err != nil {
+			closeInstances(sqlInstances)
 			return nil, err
 		}
 
 		sqlInstances = append(sqlInstances, sqlInstance)
 	}
 
-	router := Router{
+	router := &Router{
 		chi.NewRouter(),
 		sqlInstances,
 	}
@@ -115,8 +115,33 @@ func TestMount(t *testing.T) {
 	router1, err := Mount([]plugin.Instance{{Name: "sql", Options: map[string]any{"driver": "mysql", "database": "mydb"}}}, nil)
 	require.NoError(t, err)
 	require.NotNil(t, router1)
+	require.NoError(t, router1.(*Router).Close())
 
 	router2, err := Mount([]plugin.Instance{{Name: "sql", Options: map[string]any{"driver": "unknown"}}}, nil)
 	require.Error(t, err)
 	require.Nil(t, router2)
 }
+
+func TestClose(t *testing.T) {
+	t.Run("should close all instances", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockInstance1 := instance.NewMockInstance(ctrl)
+		mockInstance1.EXPECT().Close().Return(nil)
+		mockInstance2 := instance.NewMockInstance(ctrl)
+		mockInstance2.EXPECT().Close().Return(nil)
+
+		router := Router{instances: []instance.Instance{mockInstance1, mockInstance2}}
+		require.NoError(t, router.Close())
+	})
+
+	t.Run("should close all instances when an instance returns an error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockInstance1 := instance.NewMockInstance(ctrl)
+		mockInstance1.EXPECT().Close().Return(fmt.Errorf("unexpected error"))
+		mockInstance2 := instance.NewMockInstance(ctrl)
+		mockInstance2.EXPECT().Close().Return(nil)
+
+		router := Router{instances: []instance.Instance{mockInstance1, mockInstance2}}
+		require.EqualError(t, router.Close(), "unexpected error")
+	})
+}
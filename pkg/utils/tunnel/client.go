@@ -0,0 +1,152 @@
+package tunnel
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kobsio/kobs/pkg/instrument/log"
+
+	"github.com/gorilla/websocket"
+	"github.com/hashicorp/yamux"
+	"go.uber.org/zap"
+)
+
+type Config struct {
+	Addresses []string `json:"addresses" env:"ADDRESSES" help:"The addresses of the hubs and watchers, to which the cluster should open a tunnel. This can be used for clusters which are not reachable by the hub."`
+	Name      string   `json:"name" env:"NAME" default:"" help:"The name of the cluster, which is used to open the tunnel. The name must match the name of the cluster in the hub configuration."`
+}
+
+// Client is the interface for the cluster side of the reverse tunnel. When the client is started, it opens a tunnel
+// to each configured address and serves the given handler on all streams, which are opened by the hub. If the tunnel
+// is closed, the client tries to reopen it.
+type Client interface {
+	Start()
+	Stop()
+}
+
+type client struct {
+	config  Config
+	token   string
+	handler http.Handler
+	dialer  *websocket.Dialer
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// Start opens a tunnel to each configured address. The method blocks until the client is stopped.
+func (c *client) Start() {
+	for _, address := range c.config.Addresses {
+		c.wg.Add(1)
+		go func(address string) {
+			defer c.wg.Done()
+			c.run(address)
+		}(address)
+	}
+
+	c.wg.Wait()
+}
+
+// Stop closes all tunnels of the client.
+func (c *client) Stop() {
+	c.cancel()
+	c.wg.Wait()
+}
+
+// run opens the tunnel to the given address and serves the cluster API on the tunnel. When the tunnel is closed we wait
+// some time before we try to reopen it. The time is doubled after each failed attempt up to a maximum of 30 seconds.
+func (c *client) run(address string) {
+	backoff := time.Second
+
+	for {
+		connected, err := c.serve(address)
+		if c.ctx.Err() != nil {
+			return
+		}
+
+		if connected {
+			backoff = time.Second
+		}
+
+		log.Warn(c.ctx, "Tunnel was closed", zap.Error(err), zap.String("address", address), zap.Duration("reconnect", backoff))
+
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, 30*time.Second)
+	}
+}
+
+// serve opens the tunnel to the given address and serves the handler on it, until the tunnel is closed. The returned
+// boolean is true when the tunnel was opened successfully.
+func (c *client) serve(address string) (bool, error) {
+	header := http.Header{}
+	header.Set(ClusterHeader, c.config.Name)
+	header.Set("Authorization", "Bearer "+c.token)
+
+	ws, _, err := c.dialer.DialContext(c.ctx, websocketURL(address)+Path, header)
+	if err != nil {
+		return false, err
+	}
+
+	// The cluster is the server of the multiplexed connection, because it accepts the streams opened by the hub. A
+	// yamux session implements the net.Listener interface, so that we can use it to serve the cluster API.
+	session, err := yamux.Server(newConn(ws), newMuxConfig())
+	if err != nil {
+		ws.Close()
+		return false, err
+	}
+
+	log.Info(c.ctx, "Tunnel opened", zap.String("address", address))
+
+	go func() {
+		select {
+		case <-c.ctx.Done():
+		case <-session.CloseChan():
+		}
+		session.Close()
+	}()
+
+	server := &http.Server{
+		Handler:           c.handler,
+		ReadHeaderTimeout: 3 * time.Second,
+	}
+
+	return true, server.Serve(session)
+}
+
+// websocketURL converts the given http(s) address to a ws(s) address.
+func websocketURL(address string) string {
+	address = strings.TrimSuffix(address, "/")
+
+	if strings.HasPrefix(address, "https://") {
+		return "wss://" + strings.TrimPrefix(address, "https://")
+	}
+
+	if strings.HasPrefix(address, "http://") {
+		return "ws://" + strings.TrimPrefix(address, "http://")
+	}
+
+	return address
+}
+
+// NewClient returns a new tunnel client. The token is the same token, which is used to protect the cluster API, so that
+// the hub can use the configured token for the cluster to verify the tunnel.
+func NewClient(config Config, token string, handler http.Handler) Client {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &client{
+		config:  config,
+		token:   token,
+		handler: handler,
+		dialer:  websocket.DefaultDialer,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
@@ -0,0 +1,64 @@
+package tunnel
+
+import (
+	"io"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// conn wraps a WebSocket connection, so that it can be used as io.ReadWriteCloser for the multiplexed tunnel. All data
+// is sent as binary messages. Since a message can be larger than the buffer passed to the Read method, we keep the
+// reader for the current message until all data is read.
+type conn struct {
+	ws      *websocket.Conn
+	reader  io.Reader
+	writeMu sync.Mutex
+}
+
+func (c *conn) Read(p []byte) (int, error) {
+	for {
+		if c.reader == nil {
+			messageType, reader, err := c.ws.NextReader()
+			if err != nil {
+				return 0, err
+			}
+
+			if messageType != websocket.BinaryMessage {
+				continue
+			}
+
+			c.reader = reader
+		}
+
+		n, err := c.reader.Read(p)
+		if err == io.EOF {
+			c.reader = nil
+			if n > 0 {
+				return n, nil
+			}
+			continue
+		}
+
+		return n, err
+	}
+}
+
+func (c *conn) Write(p []byte) (int, error) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if err := c.ws.WriteMessage(websocket.BinaryMessage, p); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func (c *conn) Close() error {
+	return c.ws.Close()
+}
+
+func newConn(ws *websocket.Conn) *conn {
+	return &conn{ws: ws}
+}
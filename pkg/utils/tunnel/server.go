@@ -0,0 +1,132 @@
+package tunnel
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/kobsio/kobs/pkg/instrument/log"
+	"github.com/kobsio/kobs/pkg/utils/middleware/errresponse"
+
+	"github.com/gorilla/websocket"
+	"github.com/hashicorp/yamux"
+	"go.uber.org/zap"
+)
+
+// ErrNotConnected is returned when a connection to a cluster should be opened, but the cluster has not opened a tunnel.
+var ErrNotConnected = fmt.Errorf("cluster is not connected")
+
+// Server is the interface for the hub side of the reverse tunnel. The ServeHTTP method must be mounted in the hub API
+// so that clusters can open a tunnel. The Dial method can then be used to open a new connection to a cluster through
+// its tunnel.
+type Server interface {
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+	Dial(ctx context.Context, name string) (net.Conn, error)
+	SetTokens(tokens map[string]string)
+}
+
+type server struct {
+	tokens   map[string]string
+	upgrader websocket.Upgrader
+	mu       sync.RWMutex
+	sessions map[string]*yamux.Session
+}
+
+// ServeHTTP accepts a new tunnel from a cluster. The cluster must send its name in the "x-kobs-cluster" header and the
+// configured token in the "Authorization" header. When a cluster opens a new tunnel, while an old tunnel is still open,
+// the old tunnel is closed.
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := r.Header.Get(ClusterHeader)
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	s.mu.RLock()
+	expectedToken, ok := s.tokens[name]
+	s.mu.RUnlock()
+
+	// A cluster without a token is never allowed to open a tunnel, because an empty token would match a request without
+	// an "Authorization" header.
+	if !ok || expectedToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(expectedToken)) != 1 {
+		log.Warn(r.Context(), "Cluster is not allowed to open a tunnel", zap.String("cluster", name))
+		errresponse.Render(w, r, http.StatusUnauthorized)
+		return
+	}
+
+	ws, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Error(r.Context(), "Failed to upgrade connection", zap.Error(err), zap.String("cluster", name))
+		return
+	}
+
+	// The hub is the client of the multiplexed connection, because the hub opens the streams to the cluster, while
+	// the cluster accepts the streams and serves the cluster API on them.
+	session, err := yamux.Client(newConn(ws), newMuxConfig())
+	if err != nil {
+		log.Error(r.Context(), "Failed to create tunnel session", zap.Error(err), zap.String("cluster", name))
+		ws.Close()
+		return
+	}
+
+	s.mu.Lock()
+	if oldSession, ok := s.sessions[name]; ok {
+		oldSession.Close()
+	}
+	s.sessions[name] = session
+	s.mu.Unlock()
+
+	log.Info(r.Context(), "Tunnel opened", zap.String("cluster", name))
+
+	go func() {
+		<-session.CloseChan()
+
+		s.mu.Lock()
+		if s.sessions[name] == session {
+			delete(s.sessions, name)
+		}
+		s.mu.Unlock()
+
+		log.Info(context.Background(), "Tunnel closed", zap.String("cluster", name))
+	}()
+}
+
+// Dial opens a new connection to the cluster with the given name through the tunnel of the cluster. If the cluster has
+// not opened a tunnel, ErrNotConnected is returned.
+func (s *server) Dial(ctx context.Context, name string) (net.Conn, error) {
+	s.mu.RLock()
+	session, ok := s.sessions[name]
+	s.mu.RUnlock()
+
+	if !ok {
+		return nil, ErrNotConnected
+	}
+
+	return session.Open()
+}
+
+// SetTokens replaces the names and tokens of the clusters, which are allowed to open a tunnel. The tunnels of all
+// clusters, which were removed or where the token was changed, are closed.
+func (s *server) SetTokens(tokens map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for name, session := range s.sessions {
+		if token, ok := tokens[name]; !ok || token != s.tokens[name] {
+			session.Close()
+			delete(s.sessions, name)
+		}
+	}
+
+	s.tokens = tokens
+}
+
+// NewServer returns a new tunnel server. The tokens map contains the names of all clusters, which are allowed to open a
+// tunnel, together with the token, which must be used by the cluster.
+func NewServer(tokens map[string]string) Server {
+	return &server{
+		tokens:   tokens,
+		sessions: make(map[string]*yamux.Session),
+	}
+}
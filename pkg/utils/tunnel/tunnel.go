@@ -0,0 +1,24 @@
+// Package tunnel implements a reverse tunnel between a kobs cluster and the hub. The tunnel is used for clusters, which
+// are not reachable by the hub (e.g. because they are behind a NAT or firewall). Instead of the hub connecting to the
+// cluster, the cluster connects to the hub via a WebSocket connection. The connection is multiplexed, so that the hub
+// can open a new stream for each request it wants to send to the cluster API.
+package tunnel
+
+import (
+	"io"
+
+	"github.com/hashicorp/yamux"
+)
+
+const (
+	// Path is the path of the hub API, where a cluster can open a tunnel.
+	Path = "/api/tunnel"
+	// ClusterHeader is the header, which contains the name of the cluster, which opens a tunnel.
+	ClusterHeader = "x-kobs-cluster"
+)
+
+func newMuxConfig() *yamux.Config {
+	config := yamux.DefaultConfig()
+	config.LogOutput = io.Discard
+	return config
+}
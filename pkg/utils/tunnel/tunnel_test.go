@@ -0,0 +1,144 @@
+package tunnel
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTunnel(t *testing.T) {
+	tunnelServer := NewServer(map[string]string{"dev-de1": "token"})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(Path, tunnelServer.ServeHTTP)
+	hub := httptest.NewServer(mux)
+	defer hub.Close()
+
+	upgrader := websocket.Upgrader{}
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/hello", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello world"))
+	})
+	handler.HandleFunc("/api/echo", func(w http.ResponseWriter, r *http.Request) {
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer ws.Close()
+
+		messageType, message, err := ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		ws.WriteMessage(messageType, message)
+	})
+
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return tunnelServer.Dial(ctx, "dev-de1")
+	}
+
+	t.Run("should fail to dial when cluster is not connected", func(t *testing.T) {
+		_, err := tunnelServer.Dial(context.Background(), "dev-de1")
+		require.ErrorIs(t, err, ErrNotConnected)
+	})
+
+	t.Run("should reject tunnel with invalid token", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, hub.URL+Path, nil)
+		req.Header.Set(ClusterHeader, "dev-de1")
+		req.Header.Set("Authorization", "Bearer invalid")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("should reject tunnel for cluster with empty token", func(t *testing.T) {
+		emptyTokenServer := NewServer(map[string]string{"dev-de2": ""})
+
+		req := httptest.NewRequest(http.MethodGet, Path, nil)
+		req.Header.Set(ClusterHeader, "dev-de2")
+		w := httptest.NewRecorder()
+
+		emptyTokenServer.ServeHTTP(w, req)
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	tunnelClient := NewClient(Config{Addresses: []string{hub.URL}, Name: "dev-de1"}, "token", handler)
+	go tunnelClient.Start()
+	defer tunnelClient.Stop()
+
+	require.Eventually(t, func() bool {
+		conn, err := tunnelServer.Dial(context.Background(), "dev-de1")
+		if err != nil {
+			return false
+		}
+		conn.Close()
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+
+	t.Run("should send request through tunnel", func(t *testing.T) {
+		client := &http.Client{Transport: &http.Transport{DialContext: dial}}
+
+		resp, err := client.Get("http://tunnel/api/hello")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, "hello world", string(body))
+	})
+
+	t.Run("should open websocket connection through tunnel", func(t *testing.T) {
+		dialer := websocket.Dialer{NetDialContext: dial}
+
+		ws, _, err := dialer.Dial("ws://tunnel/api/echo", nil)
+		require.NoError(t, err)
+		defer ws.Close()
+
+		require.NoError(t, ws.WriteMessage(websocket.TextMessage, []byte("ping")))
+		_, message, err := ws.ReadMessage()
+		require.NoError(t, err)
+		require.Equal(t, "ping", string(message))
+	})
+}
+
+func TestWebsocketURL(t *testing.T) {
+	require.Equal(t, "wss://kobs.io", websocketURL("https://kobs.io/"))
+	require.Equal(t, "ws://localhost:15220", websocketURL("http://localhost:15220"))
+	require.Equal(t, "ws://localhost:15220", websocketURL("ws://localhost:15220"))
+}
+
+func TestSetTokens(t *testing.T) {
+	tunnelServer := NewServer(map[string]string{"dev-de1": "token"})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(Path, tunnelServer.ServeHTTP)
+	hub := httptest.NewServer(mux)
+	defer hub.Close()
+
+	tunnelClient := NewClient(Config{Addresses: []string{hub.URL}, Name: "dev-de1"}, "token", http.NewServeMux())
+	go tunnelClient.Start()
+	defer tunnelClient.Stop()
+
+	require.Eventually(t, func() bool {
+		conn, err := tunnelServer.Dial(context.Background(), "dev-de1")
+		if err != nil {
+			return false
+		}
+		conn.Close()
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+
+	tunnelServer.SetTokens(map[string]string{"dev-de1": "newtoken"})
+
+	_, err := tunnelServer.Dial(context.Background(), "dev-de1")
+	require.ErrorIs(t, err, ErrNotConnected)
+}